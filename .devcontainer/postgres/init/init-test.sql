@@ -7,23 +7,406 @@ GRANT ALL PRIVILEGES ON DATABASE godo_test TO godo_test_user;
 \connect godo_test;
 
 CREATE TABLE users (
-    id         UUID         PRIMARY KEY,
-    username   VARCHAR(255) NOT NULL,
-    email      VARCHAR(255) UNIQUE,
-    password   VARCHAR(255) NOT NULL,
-    created_at TIMESTAMP    DEFAULT CURRENT_TIMESTAMP
+    id          UUID         PRIMARY KEY,
+    username    VARCHAR(255) NOT NULL,
+    email       VARCHAR(255) UNIQUE,
+    password    VARCHAR(255) NOT NULL,
+    role        VARCHAR(16)  NOT NULL DEFAULT 'user',
+    is_disabled BOOLEAN      NOT NULL DEFAULT false,
+    created_at  TIMESTAMP    DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE todo_items (
     id          UUID         PRIMARY KEY,
     title       VARCHAR(255) NOT NULL,
-    description VARCHAR(255) UNIQUE,
+    description VARCHAR(255),
     is_done     BOOLEAN      DEFAULT false,
     created_at  TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
     user_id     UUID         NOT NULL,
+    -- NULL if personal.
+    project_id  UUID,
+    -- NULL if unassigned.
+    assignee_id UUID,
+    -- Title, description and comments for full-text search. Maintained by triggers.
+    search_vector TSVECTOR NOT NULL DEFAULT '',
+    -- Sequence number of last change, for sync. Assigned on commit by trigger.
+    seq         BIGINT       NOT NULL DEFAULT 0,
+    -- Clocks of fields by field name, for sync. Maintained by trigger unless written by sync.
+    field_clocks JSONB       NOT NULL DEFAULT '{}',
     
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (assignee_id) REFERENCES users(id)
+);
+
+CREATE TABLE sessions (
+    token_hash VARCHAR(64)  PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    expires_at TIMESTAMPTZ   NOT NULL,
+    created_at TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE password_resets (
+    token_hash VARCHAR(64)  PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    expires_at TIMESTAMPTZ   NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE user_two_factors (
+    user_id              UUID         PRIMARY KEY,
+    secret               VARCHAR(64)  NOT NULL,
+    is_enabled           BOOLEAN      DEFAULT false,
+    recovery_code_hashes TEXT[]       NOT NULL DEFAULT '{}',
+    last_used_step       BIGINT       NOT NULL DEFAULT 0,
+    created_at           TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE login_challenges (
+    token_hash      VARCHAR(64)  PRIMARY KEY,
+    user_id         UUID         NOT NULL,
+    expires_at      TIMESTAMPTZ   NOT NULL,
+    failed_attempts INTEGER      NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE email_changes (
+    id                 UUID         PRIMARY KEY,
+    user_id            UUID         NOT NULL,
+    old_email          VARCHAR(255) NOT NULL,
+    new_email          VARCHAR(255) NOT NULL,
+    confirm_token_hash VARCHAR(64)  UNIQUE NOT NULL,
+    revert_token_hash  VARCHAR(64)  UNIQUE NOT NULL,
+    expires_at         TIMESTAMPTZ  NOT NULL,
+    revertible_until   TIMESTAMPTZ  NOT NULL,
+    confirmed_at       TIMESTAMPTZ,
+    reverted_at        TIMESTAMPTZ,
+    created_at         TIMESTAMP    DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE account_deletions (
+    user_id      UUID         PRIMARY KEY,
+    requested_at TIMESTAMPTZ  NOT NULL,
+    scheduled_at TIMESTAMPTZ  NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE access_tokens (
+    id           UUID         PRIMARY KEY,
+    user_id      UUID         NOT NULL,
+    name         VARCHAR(255) NOT NULL,
+    token_hash   VARCHAR(64)  UNIQUE NOT NULL,
+    scopes       TEXT[]       NOT NULL DEFAULT '{}',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- No foreign keys, so audit logs outlive deleted users.
+CREATE TABLE audit_logs (
+    id             UUID         PRIMARY KEY,
+    actor_id       UUID,
+    action         VARCHAR(64)  NOT NULL,
+    target_user_id UUID         NOT NULL,
+    detail         TEXT         NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE projects (
+    id         UUID         PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE project_members (
+    project_id UUID         NOT NULL,
+    user_id    UUID         NOT NULL,
+    permission VARCHAR(16)  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (project_id, user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE project_invites (
+    id          UUID         PRIMARY KEY,
+    project_id  UUID         NOT NULL,
+    email       VARCHAR(255) NOT NULL,
+    permission  VARCHAR(16)  NOT NULL,
+    token_hash  VARCHAR(64)  UNIQUE NOT NULL,
+    invited_by  UUID         NOT NULL,
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (project_id) REFERENCES projects(id),
+    FOREIGN KEY (invited_by) REFERENCES users(id)
+);
+
+ALTER TABLE todo_items ADD FOREIGN KEY (project_id) REFERENCES projects(id);
+
+-- History follows its todo item. Users are not referenced, so history outlives them.
+CREATE TABLE todo_assignments (
+    id          UUID         PRIMARY KEY,
+    todo_id     UUID         NOT NULL,
+    assignee_id UUID,
+    assigned_by UUID         NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (todo_id) REFERENCES todo_items(id) ON DELETE CASCADE
+);
+
+CREATE TABLE todo_comments (
+    id         UUID         PRIMARY KEY,
+    todo_id    UUID         NOT NULL,
+    author_id  UUID         NOT NULL,
+    body       TEXT         NOT NULL,
+    mentions   TEXT[]       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at  TIMESTAMPTZ,
+
+    FOREIGN KEY (todo_id) REFERENCES todo_items(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id)
+);
+
+CREATE TABLE todo_attachments (
+    id           UUID          PRIMARY KEY,
+    todo_id      UUID          NOT NULL,
+    uploader_id  UUID          NOT NULL,
+    file_name    VARCHAR(255)  NOT NULL,
+    content_type VARCHAR(255)  NOT NULL,
+    size         BIGINT        NOT NULL,
+    storage_key  TEXT          NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (todo_id) REFERENCES todo_items(id) ON DELETE CASCADE,
+    FOREIGN KEY (uploader_id) REFERENCES users(id)
+);
+
+-- Weights rank matches in title over description over comments.
+CREATE FUNCTION todo_search_vector(todo UUID, title TEXT, description TEXT) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('english', coalesce(title, '')), 'A')
+        || setweight(to_tsvector('english', coalesce(description, '')), 'B')
+        || setweight(to_tsvector('english', coalesce(
+            (SELECT string_agg(body, ' ' ORDER BY created_at) FROM todo_comments WHERE todo_id = todo),
+            ''
+        )), 'C')
+$$ LANGUAGE SQL STABLE;
+
+CREATE FUNCTION todo_items_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := todo_search_vector(NEW.id, NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_items_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON todo_items
+    FOR EACH ROW EXECUTE FUNCTION todo_items_search_vector_trigger();
+
+CREATE FUNCTION todo_comments_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE todo_items
+    SET search_vector = todo_search_vector(id, title, description)
+    WHERE id = CASE WHEN TG_OP = 'DELETE' THEN OLD.todo_id ELSE NEW.todo_id END;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_comments_search_vector
+    AFTER INSERT OR UPDATE OF body OR DELETE ON todo_comments
+    FOR EACH ROW EXECUTE FUNCTION todo_comments_search_vector_trigger();
+
+CREATE INDEX todo_items_search_vector_idx ON todo_items USING GIN (search_vector);
+
+CREATE TABLE todo_undos (
+    token_hash VARCHAR(64)  PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    -- States of changed todo items before and after mutation.
+    changes    JSONB        NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE todo_events (
+    id         BIGSERIAL    PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    type       VARCHAR(32)  NOT NULL,
+    -- State of todo item the event is about.
+    todo       JSONB        NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX todo_events_user_id_id_idx ON todo_events (user_id, id);
+
+-- Number and notify events of user one transaction at a time,
+-- so they commit in order of their IDs and subscribers resuming skip none.
+CREATE FUNCTION todo_events_publish_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('todo_events:' || NEW.user_id));
+    NEW.id := nextval(pg_get_serial_sequence('todo_events', 'id'));
+    PERFORM pg_notify('todo_events', json_build_object(
+        'id', NEW.id,
+        'userId', NEW.user_id,
+        'type', NEW.type,
+        'todo', NEW.todo,
+        'occurredAt', NEW.created_at
+    )::text);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_events_publish
+    BEFORE INSERT ON todo_events
+    FOR EACH ROW EXECUTE FUNCTION todo_events_publish_trigger();
+
+-- Sequence numbers of todo changes, for sync.
+CREATE SEQUENCE todo_change_seq;
+
+-- Todo items deleted, so sync tells clients about it.
+CREATE TABLE todo_tombstones (
+    todo_id    UUID         PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    -- NULL if personal.
+    project_id UUID,
+    seq        BIGINT       NOT NULL,
+    deleted_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX todo_items_seq_idx ON todo_items (seq);
+
+CREATE INDEX todo_tombstones_seq_idx ON todo_tombstones (seq);
+
+-- Outcomes of client operations of sync, so retried ones are not applied twice.
+CREATE TABLE todo_sync_operations (
+    user_id      UUID         NOT NULL,
+    operation_id VARCHAR(64)  NOT NULL,
+    -- As given by client.
+    todo_id      TEXT         NOT NULL,
+    status       VARCHAR(16)  NOT NULL,
+    message      TEXT         NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, operation_id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- Clock fields changed outside sync with now. Sync writes clocks itself.
+CREATE FUNCTION todo_items_field_clocks_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    clock JSONB := jsonb_build_object('at', now(), 'by', '');
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.field_clocks := jsonb_build_object('title', clock, 'description', clock, 'isDone', clock) || NEW.field_clocks;
+    ELSIF NEW.field_clocks = OLD.field_clocks THEN
+        IF NEW.title IS DISTINCT FROM OLD.title THEN
+            NEW.field_clocks := NEW.field_clocks || jsonb_build_object('title', clock);
+        END IF;
+        IF NEW.description IS DISTINCT FROM OLD.description THEN
+            NEW.field_clocks := NEW.field_clocks || jsonb_build_object('description', clock);
+        END IF;
+        IF NEW.is_done IS DISTINCT FROM OLD.is_done THEN
+            NEW.field_clocks := NEW.field_clocks || jsonb_build_object('isDone', clock);
+        END IF;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_items_field_clocks
+    BEFORE INSERT OR UPDATE OF title, description, is_done ON todo_items
+    FOR EACH ROW EXECUTE FUNCTION todo_items_field_clocks_trigger();
+
+-- Number changes on commit, one transaction at a time per user seeing todo item before or after,
+-- so sequence numbers a user syncs become visible in order and sync skips none.
+-- Users are locked in order of ID. Transactions locking many users in other orders may deadlock, and one is rolled back.
+CREATE FUNCTION todo_items_change_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    viewer UUID;
+BEGIN
+    FOR viewer IN
+        SELECT user_id FROM (
+            SELECT OLD.user_id WHERE OLD.project_id IS NULL
+            UNION
+            SELECT NEW.user_id WHERE NEW.project_id IS NULL
+            UNION
+            SELECT user_id FROM project_members WHERE project_id IN (OLD.project_id, NEW.project_id)
+        ) AS viewers (user_id)
+        WHERE user_id IS NOT NULL
+        ORDER BY user_id
+    LOOP
+        PERFORM pg_advisory_xact_lock(hashtext('todo_change:' || viewer));
+    END LOOP;
+    IF TG_OP = 'DELETE' THEN
+        -- Todo items of deleted users leave nothing behind.
+        IF EXISTS (SELECT 1 FROM users WHERE id = OLD.user_id) THEN
+            INSERT INTO todo_tombstones (todo_id, user_id, project_id, seq)
+            VALUES (OLD.id, OLD.user_id, OLD.project_id, nextval('todo_change_seq'))
+            ON CONFLICT (todo_id) DO UPDATE
+            SET user_id = EXCLUDED.user_id,
+                project_id = EXCLUDED.project_id,
+                seq = EXCLUDED.seq,
+                deleted_at = CURRENT_TIMESTAMP;
+        END IF;
+    ELSE
+        DELETE FROM todo_tombstones WHERE todo_id = NEW.id;
+        UPDATE todo_items SET seq = nextval('todo_change_seq') WHERE id = NEW.id;
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER todo_items_change
+    AFTER INSERT OR UPDATE OF title, description, is_done, user_id, project_id, assignee_id OR DELETE ON todo_items
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION todo_items_change_trigger();
+
+CREATE TABLE calendar_feeds (
+    user_id    UUID        PRIMARY KEY,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
     FOREIGN KEY (user_id) REFERENCES users(id)
 );
 
 ALTER TABLE users OWNER TO godo_test_user;
-ALTER TABLE todo_items OWNER TO godo_test_user;
\ No newline at end of file
+ALTER TABLE todo_items OWNER TO godo_test_user;
+ALTER TABLE sessions OWNER TO godo_test_user;
+ALTER TABLE password_resets OWNER TO godo_test_user;
+ALTER TABLE user_two_factors OWNER TO godo_test_user;
+ALTER TABLE login_challenges OWNER TO godo_test_user;
+ALTER TABLE email_changes OWNER TO godo_test_user;
+ALTER TABLE account_deletions OWNER TO godo_test_user;
+ALTER TABLE access_tokens OWNER TO godo_test_user;
+ALTER TABLE audit_logs OWNER TO godo_test_user;
+ALTER TABLE projects OWNER TO godo_test_user;
+ALTER TABLE project_members OWNER TO godo_test_user;
+ALTER TABLE project_invites OWNER TO godo_test_user;
+ALTER TABLE todo_assignments OWNER TO godo_test_user;
+ALTER TABLE todo_comments OWNER TO godo_test_user;
+ALTER TABLE todo_attachments OWNER TO godo_test_user;
+ALTER TABLE todo_undos OWNER TO godo_test_user;
+ALTER TABLE todo_events OWNER TO godo_test_user;
+ALTER TABLE todo_tombstones OWNER TO godo_test_user;
+ALTER TABLE todo_sync_operations OWNER TO godo_test_user;
+ALTER SEQUENCE todo_change_seq OWNER TO godo_test_user;
+ALTER TABLE calendar_feeds OWNER TO godo_test_user;
\ No newline at end of file
@@ -2,8 +2,13 @@ package app
 
 import (
 	"github.com/kkatou7209/godo/app/port/in/usecase"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
 	"github.com/kkatou7209/godo/app/port/out/password"
 	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/port/out/totp"
 	"github.com/kkatou7209/godo/app/service"
 )
 
@@ -17,7 +22,83 @@ type Application struct {
 	getUserPersistence persistence.GetUserPersistence
 	updateUserPersistence persistence.UpdateUserPersistence
 	createUserPersistence persistence.CreateUserPersistence
+	createSessionPersistence persistence.CreateSessionPersistence
+	deleteSessionPersistence persistence.DeleteSessionPersistence
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence
+	getPasswordResetPersistence persistence.GetPasswordResetPersistence
+	updatePasswordResetPersistence persistence.UpdatePasswordResetPersistence
+	createTwoFactorPersistence persistence.CreateTwoFactorPersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence
+	deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence
+	createLoginChallengePersistence persistence.CreateLoginChallengePersistence
+	getLoginChallengePersistence persistence.GetLoginChallengePersistence
+	updateLoginChallengePersistence persistence.UpdateLoginChallengePersistence
+	deleteLoginChallengePersistence persistence.DeleteLoginChallengePersistence
+	createEmailChangePersistence persistence.CreateEmailChangePersistence
+	getEmailChangePersistence persistence.GetEmailChangePersistence
+	updateEmailChangePersistence persistence.UpdateEmailChangePersistence
+	deleteUserPersistence persistence.DeleteUserPersistence
+	createAccountDeletionPersistence persistence.CreateAccountDeletionPersistence
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence
+	deleteAccountDeletionPersistence persistence.DeleteAccountDeletionPersistence
+	getSessionPersistence persistence.GetSessionPersistence
+	createAccessTokenPersistence persistence.CreateAccessTokenPersistence
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence
+	listAccessTokenPersistence persistence.ListAccessTokenPersistence
+	updateAccessTokenPersistence persistence.UpdateAccessTokenPersistence
+	deleteAccessTokenPersistence persistence.DeleteAccessTokenPersistence
+	listUserPersistence persistence.ListUserPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+	listAuditLogPersistence persistence.ListAuditLogPersistence
+	createProjectPersistence persistence.CreateProjectPersistence
+	getProjectPersistence persistence.GetProjectPersistence
+	listProjectPersistence persistence.ListProjectPersistence
+	updateProjectPersistence persistence.UpdateProjectPersistence
+	deleteProjectPersistence persistence.DeleteProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	saveProjectMemberPersistence persistence.SaveProjectMemberPersistence
+	deleteProjectMemberPersistence persistence.DeleteProjectMemberPersistence
+	createProjectInvitePersistence persistence.CreateProjectInvitePersistence
+	getProjectInvitePersistence persistence.GetProjectInvitePersistence
+	updateProjectInvitePersistence persistence.UpdateProjectInvitePersistence
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence
+	listTodoAssignmentPersistence persistence.ListTodoAssignmentPersistence
+	createTodoCommentPersistence persistence.CreateTodoCommentPersistence
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence
+	listTodoCommentPersistence persistence.ListTodoCommentPersistence
+	updateTodoCommentPersistence persistence.UpdateTodoCommentPersistence
+	deleteTodoCommentPersistence persistence.DeleteTodoCommentPersistence
+	createTodoAttachmentPersistence persistence.CreateTodoAttachmentPersistence
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	deleteTodoAttachmentPersistence persistence.DeleteTodoAttachmentPersistence
+	searchTodoPersistence persistence.SearchTodoPersistence
+	getTodoBatchPersistence persistence.GetTodoBatchPersistence
+	writeTodoBatchPersistence persistence.WriteTodoBatchPersistence
+	createTodoBatchPersistence persistence.CreateTodoBatchPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	getTodoUndoPersistence persistence.GetTodoUndoPersistence
+	updateTodoUndoPersistence persistence.UpdateTodoUndoPersistence
+	listTodoChangePersistence persistence.ListTodoChangePersistence
+	getTodoRevisionPersistence persistence.GetTodoRevisionPersistence
+	getTodoSyncOperationPersistence persistence.GetTodoSyncOperationPersistence
+	writeTodoSyncPersistence persistence.WriteTodoSyncPersistence
+	saveCalendarFeedPersistence persistence.SaveCalendarFeedPersistence
+	getCalendarFeedPersistence persistence.GetCalendarFeedPersistence
+	deleteCalendarFeedPersistence persistence.DeleteCalendarFeedPersistence
+	listTodoPagePersistence persistence.ListTodoPagePersistence
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listTodoCommentBatchPersistence persistence.ListTodoCommentBatchPersistence
+	getUserBatchPersistence persistence.GetUserBatchPersistence
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence
+	checkHealthPersistence persistence.CheckHealthPersistence
 	passwordHasher password.PasswordHasher
+	tokenGenerator token.TokenGenerator
+	mailer mailer.Mailer
+	totpProvider totp.TotpProvider
+	blobStorage storage.BlobStorage
+	todoEventBus event.TodoEventBus
 }
 
 func New() *Application {
@@ -30,7 +111,83 @@ func New() *Application {
 		getUserPersistence: nil,
 		updateUserPersistence: nil,
 		createUserPersistence: nil,
+		createSessionPersistence: nil,
+		deleteSessionPersistence: nil,
+		createPasswordResetPersistence: nil,
+		getPasswordResetPersistence: nil,
+		updatePasswordResetPersistence: nil,
+		createTwoFactorPersistence: nil,
+		getTwoFactorPersistence: nil,
+		updateTwoFactorPersistence: nil,
+		deleteTwoFactorPersistence: nil,
+		createLoginChallengePersistence: nil,
+		getLoginChallengePersistence: nil,
+		updateLoginChallengePersistence: nil,
+		deleteLoginChallengePersistence: nil,
+		createEmailChangePersistence: nil,
+		getEmailChangePersistence: nil,
+		updateEmailChangePersistence: nil,
+		deleteUserPersistence: nil,
+		createAccountDeletionPersistence: nil,
+		getAccountDeletionPersistence: nil,
+		deleteAccountDeletionPersistence: nil,
+		getSessionPersistence: nil,
+		createAccessTokenPersistence: nil,
+		getAccessTokenPersistence: nil,
+		listAccessTokenPersistence: nil,
+		updateAccessTokenPersistence: nil,
+		deleteAccessTokenPersistence: nil,
+		listUserPersistence: nil,
+		createAuditLogPersistence: nil,
+		listAuditLogPersistence: nil,
+		createProjectPersistence: nil,
+		getProjectPersistence: nil,
+		listProjectPersistence: nil,
+		updateProjectPersistence: nil,
+		deleteProjectPersistence: nil,
+		getProjectMemberPersistence: nil,
+		saveProjectMemberPersistence: nil,
+		deleteProjectMemberPersistence: nil,
+		createProjectInvitePersistence: nil,
+		getProjectInvitePersistence: nil,
+		updateProjectInvitePersistence: nil,
+		createTodoAssignmentPersistence: nil,
+		listTodoAssignmentPersistence: nil,
+		createTodoCommentPersistence: nil,
+		getTodoCommentPersistence: nil,
+		listTodoCommentPersistence: nil,
+		updateTodoCommentPersistence: nil,
+		deleteTodoCommentPersistence: nil,
+		createTodoAttachmentPersistence: nil,
+		getTodoAttachmentPersistence: nil,
+		listTodoAttachmentPersistence: nil,
+		deleteTodoAttachmentPersistence: nil,
+		searchTodoPersistence: nil,
+		getTodoBatchPersistence: nil,
+		writeTodoBatchPersistence: nil,
+		createTodoBatchPersistence: nil,
+		createTodoUndoPersistence: nil,
+		getTodoUndoPersistence: nil,
+		updateTodoUndoPersistence: nil,
+		listTodoChangePersistence: nil,
+		getTodoRevisionPersistence: nil,
+		getTodoSyncOperationPersistence: nil,
+		writeTodoSyncPersistence: nil,
+		saveCalendarFeedPersistence: nil,
+		getCalendarFeedPersistence: nil,
+		deleteCalendarFeedPersistence: nil,
+		listTodoPagePersistence: nil,
+		listTodoBatchPersistence: nil,
+		listTodoCommentBatchPersistence: nil,
+		getUserBatchPersistence: nil,
+		listProjectMemberBatchPersistence: nil,
+		checkHealthPersistence: nil,
 		passwordHasher: nil,
+		tokenGenerator: nil,
+		mailer: nil,
+		totpProvider: nil,
+		blobStorage: nil,
+		todoEventBus: nil,
 	}
 }
 
@@ -74,11 +231,391 @@ func (a *Application) SetCreateUserPersistence(createUserPersistence persistence
 	return a
 }
 
+func (a *Application) SetCreateSessionPersistence(createSessionPersistence persistence.CreateSessionPersistence) *Application {
+	a.createSessionPersistence = createSessionPersistence
+	return a
+}
+
+func (a *Application) SetDeleteSessionPersistence(deleteSessionPersistence persistence.DeleteSessionPersistence) *Application {
+	a.deleteSessionPersistence = deleteSessionPersistence
+	return a
+}
+
+func (a *Application) SetCreatePasswordResetPersistence(createPasswordResetPersistence persistence.CreatePasswordResetPersistence) *Application {
+	a.createPasswordResetPersistence = createPasswordResetPersistence
+	return a
+}
+
+func (a *Application) SetGetPasswordResetPersistence(getPasswordResetPersistence persistence.GetPasswordResetPersistence) *Application {
+	a.getPasswordResetPersistence = getPasswordResetPersistence
+	return a
+}
+
+func (a *Application) SetUpdatePasswordResetPersistence(updatePasswordResetPersistence persistence.UpdatePasswordResetPersistence) *Application {
+	a.updatePasswordResetPersistence = updatePasswordResetPersistence
+	return a
+}
+
+func (a *Application) SetCreateTwoFactorPersistence(createTwoFactorPersistence persistence.CreateTwoFactorPersistence) *Application {
+	a.createTwoFactorPersistence = createTwoFactorPersistence
+	return a
+}
+
+func (a *Application) SetGetTwoFactorPersistence(getTwoFactorPersistence persistence.GetTwoFactorPersistence) *Application {
+	a.getTwoFactorPersistence = getTwoFactorPersistence
+	return a
+}
+
+func (a *Application) SetUpdateTwoFactorPersistence(updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence) *Application {
+	a.updateTwoFactorPersistence = updateTwoFactorPersistence
+	return a
+}
+
+func (a *Application) SetDeleteTwoFactorPersistence(deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence) *Application {
+	a.deleteTwoFactorPersistence = deleteTwoFactorPersistence
+	return a
+}
+
+func (a *Application) SetCreateLoginChallengePersistence(createLoginChallengePersistence persistence.CreateLoginChallengePersistence) *Application {
+	a.createLoginChallengePersistence = createLoginChallengePersistence
+	return a
+}
+
+func (a *Application) SetGetLoginChallengePersistence(getLoginChallengePersistence persistence.GetLoginChallengePersistence) *Application {
+	a.getLoginChallengePersistence = getLoginChallengePersistence
+	return a
+}
+
+func (a *Application) SetUpdateLoginChallengePersistence(updateLoginChallengePersistence persistence.UpdateLoginChallengePersistence) *Application {
+	a.updateLoginChallengePersistence = updateLoginChallengePersistence
+	return a
+}
+
+func (a *Application) SetDeleteLoginChallengePersistence(deleteLoginChallengePersistence persistence.DeleteLoginChallengePersistence) *Application {
+	a.deleteLoginChallengePersistence = deleteLoginChallengePersistence
+	return a
+}
+
+func (a *Application) SetCreateEmailChangePersistence(createEmailChangePersistence persistence.CreateEmailChangePersistence) *Application {
+	a.createEmailChangePersistence = createEmailChangePersistence
+	return a
+}
+
+func (a *Application) SetGetEmailChangePersistence(getEmailChangePersistence persistence.GetEmailChangePersistence) *Application {
+	a.getEmailChangePersistence = getEmailChangePersistence
+	return a
+}
+
+func (a *Application) SetUpdateEmailChangePersistence(updateEmailChangePersistence persistence.UpdateEmailChangePersistence) *Application {
+	a.updateEmailChangePersistence = updateEmailChangePersistence
+	return a
+}
+
+func (a *Application) SetDeleteUserPersistence(deleteUserPersistence persistence.DeleteUserPersistence) *Application {
+	a.deleteUserPersistence = deleteUserPersistence
+	return a
+}
+
+func (a *Application) SetCreateAccountDeletionPersistence(createAccountDeletionPersistence persistence.CreateAccountDeletionPersistence) *Application {
+	a.createAccountDeletionPersistence = createAccountDeletionPersistence
+	return a
+}
+
+func (a *Application) SetGetAccountDeletionPersistence(getAccountDeletionPersistence persistence.GetAccountDeletionPersistence) *Application {
+	a.getAccountDeletionPersistence = getAccountDeletionPersistence
+	return a
+}
+
+func (a *Application) SetDeleteAccountDeletionPersistence(deleteAccountDeletionPersistence persistence.DeleteAccountDeletionPersistence) *Application {
+	a.deleteAccountDeletionPersistence = deleteAccountDeletionPersistence
+	return a
+}
+
+func (a *Application) SetGetSessionPersistence(getSessionPersistence persistence.GetSessionPersistence) *Application {
+	a.getSessionPersistence = getSessionPersistence
+	return a
+}
+
+func (a *Application) SetCreateAccessTokenPersistence(createAccessTokenPersistence persistence.CreateAccessTokenPersistence) *Application {
+	a.createAccessTokenPersistence = createAccessTokenPersistence
+	return a
+}
+
+func (a *Application) SetGetAccessTokenPersistence(getAccessTokenPersistence persistence.GetAccessTokenPersistence) *Application {
+	a.getAccessTokenPersistence = getAccessTokenPersistence
+	return a
+}
+
+func (a *Application) SetListAccessTokenPersistence(listAccessTokenPersistence persistence.ListAccessTokenPersistence) *Application {
+	a.listAccessTokenPersistence = listAccessTokenPersistence
+	return a
+}
+
+func (a *Application) SetUpdateAccessTokenPersistence(updateAccessTokenPersistence persistence.UpdateAccessTokenPersistence) *Application {
+	a.updateAccessTokenPersistence = updateAccessTokenPersistence
+	return a
+}
+
+func (a *Application) SetDeleteAccessTokenPersistence(deleteAccessTokenPersistence persistence.DeleteAccessTokenPersistence) *Application {
+	a.deleteAccessTokenPersistence = deleteAccessTokenPersistence
+	return a
+}
+
+func (a *Application) SetListUserPersistence(listUserPersistence persistence.ListUserPersistence) *Application {
+	a.listUserPersistence = listUserPersistence
+	return a
+}
+
+func (a *Application) SetCreateAuditLogPersistence(createAuditLogPersistence persistence.CreateAuditLogPersistence) *Application {
+	a.createAuditLogPersistence = createAuditLogPersistence
+	return a
+}
+
+func (a *Application) SetListAuditLogPersistence(listAuditLogPersistence persistence.ListAuditLogPersistence) *Application {
+	a.listAuditLogPersistence = listAuditLogPersistence
+	return a
+}
+
+func (a *Application) SetCreateProjectPersistence(createProjectPersistence persistence.CreateProjectPersistence) *Application {
+	a.createProjectPersistence = createProjectPersistence
+	return a
+}
+
+func (a *Application) SetGetProjectPersistence(getProjectPersistence persistence.GetProjectPersistence) *Application {
+	a.getProjectPersistence = getProjectPersistence
+	return a
+}
+
+func (a *Application) SetListProjectPersistence(listProjectPersistence persistence.ListProjectPersistence) *Application {
+	a.listProjectPersistence = listProjectPersistence
+	return a
+}
+
+func (a *Application) SetUpdateProjectPersistence(updateProjectPersistence persistence.UpdateProjectPersistence) *Application {
+	a.updateProjectPersistence = updateProjectPersistence
+	return a
+}
+
+func (a *Application) SetDeleteProjectPersistence(deleteProjectPersistence persistence.DeleteProjectPersistence) *Application {
+	a.deleteProjectPersistence = deleteProjectPersistence
+	return a
+}
+
+func (a *Application) SetGetProjectMemberPersistence(getProjectMemberPersistence persistence.GetProjectMemberPersistence) *Application {
+	a.getProjectMemberPersistence = getProjectMemberPersistence
+	return a
+}
+
+func (a *Application) SetSaveProjectMemberPersistence(saveProjectMemberPersistence persistence.SaveProjectMemberPersistence) *Application {
+	a.saveProjectMemberPersistence = saveProjectMemberPersistence
+	return a
+}
+
+func (a *Application) SetDeleteProjectMemberPersistence(deleteProjectMemberPersistence persistence.DeleteProjectMemberPersistence) *Application {
+	a.deleteProjectMemberPersistence = deleteProjectMemberPersistence
+	return a
+}
+
+func (a *Application) SetCreateProjectInvitePersistence(createProjectInvitePersistence persistence.CreateProjectInvitePersistence) *Application {
+	a.createProjectInvitePersistence = createProjectInvitePersistence
+	return a
+}
+
+func (a *Application) SetGetProjectInvitePersistence(getProjectInvitePersistence persistence.GetProjectInvitePersistence) *Application {
+	a.getProjectInvitePersistence = getProjectInvitePersistence
+	return a
+}
+
+func (a *Application) SetUpdateProjectInvitePersistence(updateProjectInvitePersistence persistence.UpdateProjectInvitePersistence) *Application {
+	a.updateProjectInvitePersistence = updateProjectInvitePersistence
+	return a
+}
+
+func (a *Application) SetCreateTodoAssignmentPersistence(createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence) *Application {
+	a.createTodoAssignmentPersistence = createTodoAssignmentPersistence
+	return a
+}
+
+func (a *Application) SetListTodoAssignmentPersistence(listTodoAssignmentPersistence persistence.ListTodoAssignmentPersistence) *Application {
+	a.listTodoAssignmentPersistence = listTodoAssignmentPersistence
+	return a
+}
+
+func (a *Application) SetCreateTodoCommentPersistence(createTodoCommentPersistence persistence.CreateTodoCommentPersistence) *Application {
+	a.createTodoCommentPersistence = createTodoCommentPersistence
+	return a
+}
+
+func (a *Application) SetGetTodoCommentPersistence(getTodoCommentPersistence persistence.GetTodoCommentPersistence) *Application {
+	a.getTodoCommentPersistence = getTodoCommentPersistence
+	return a
+}
+
+func (a *Application) SetListTodoCommentPersistence(listTodoCommentPersistence persistence.ListTodoCommentPersistence) *Application {
+	a.listTodoCommentPersistence = listTodoCommentPersistence
+	return a
+}
+
+func (a *Application) SetUpdateTodoCommentPersistence(updateTodoCommentPersistence persistence.UpdateTodoCommentPersistence) *Application {
+	a.updateTodoCommentPersistence = updateTodoCommentPersistence
+	return a
+}
+
+func (a *Application) SetDeleteTodoCommentPersistence(deleteTodoCommentPersistence persistence.DeleteTodoCommentPersistence) *Application {
+	a.deleteTodoCommentPersistence = deleteTodoCommentPersistence
+	return a
+}
+
+func (a *Application) SetCreateTodoAttachmentPersistence(createTodoAttachmentPersistence persistence.CreateTodoAttachmentPersistence) *Application {
+	a.createTodoAttachmentPersistence = createTodoAttachmentPersistence
+	return a
+}
+
+func (a *Application) SetGetTodoAttachmentPersistence(getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence) *Application {
+	a.getTodoAttachmentPersistence = getTodoAttachmentPersistence
+	return a
+}
+
+func (a *Application) SetListTodoAttachmentPersistence(listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence) *Application {
+	a.listTodoAttachmentPersistence = listTodoAttachmentPersistence
+	return a
+}
+
+func (a *Application) SetDeleteTodoAttachmentPersistence(deleteTodoAttachmentPersistence persistence.DeleteTodoAttachmentPersistence) *Application {
+	a.deleteTodoAttachmentPersistence = deleteTodoAttachmentPersistence
+	return a
+}
+
+func (a *Application) SetSearchTodoPersistence(searchTodoPersistence persistence.SearchTodoPersistence) *Application {
+	a.searchTodoPersistence = searchTodoPersistence
+	return a
+}
+
+func (a *Application) SetGetTodoBatchPersistence(getTodoBatchPersistence persistence.GetTodoBatchPersistence) *Application {
+	a.getTodoBatchPersistence = getTodoBatchPersistence
+	return a
+}
+
+func (a *Application) SetWriteTodoBatchPersistence(writeTodoBatchPersistence persistence.WriteTodoBatchPersistence) *Application {
+	a.writeTodoBatchPersistence = writeTodoBatchPersistence
+	return a
+}
+
+func (a *Application) SetCreateTodoBatchPersistence(createTodoBatchPersistence persistence.CreateTodoBatchPersistence) *Application {
+	a.createTodoBatchPersistence = createTodoBatchPersistence
+	return a
+}
+
+func (a *Application) SetCreateTodoUndoPersistence(createTodoUndoPersistence persistence.CreateTodoUndoPersistence) *Application {
+	a.createTodoUndoPersistence = createTodoUndoPersistence
+	return a
+}
+
+func (a *Application) SetGetTodoUndoPersistence(getTodoUndoPersistence persistence.GetTodoUndoPersistence) *Application {
+	a.getTodoUndoPersistence = getTodoUndoPersistence
+	return a
+}
+
+func (a *Application) SetUpdateTodoUndoPersistence(updateTodoUndoPersistence persistence.UpdateTodoUndoPersistence) *Application {
+	a.updateTodoUndoPersistence = updateTodoUndoPersistence
+	return a
+}
+
+func (a *Application) SetListTodoChangePersistence(listTodoChangePersistence persistence.ListTodoChangePersistence) *Application {
+	a.listTodoChangePersistence = listTodoChangePersistence
+	return a
+}
+
+func (a *Application) SetGetTodoRevisionPersistence(getTodoRevisionPersistence persistence.GetTodoRevisionPersistence) *Application {
+	a.getTodoRevisionPersistence = getTodoRevisionPersistence
+	return a
+}
+
+func (a *Application) SetGetTodoSyncOperationPersistence(getTodoSyncOperationPersistence persistence.GetTodoSyncOperationPersistence) *Application {
+	a.getTodoSyncOperationPersistence = getTodoSyncOperationPersistence
+	return a
+}
+
+func (a *Application) SetWriteTodoSyncPersistence(writeTodoSyncPersistence persistence.WriteTodoSyncPersistence) *Application {
+	a.writeTodoSyncPersistence = writeTodoSyncPersistence
+	return a
+}
+
+func (a *Application) SetSaveCalendarFeedPersistence(saveCalendarFeedPersistence persistence.SaveCalendarFeedPersistence) *Application {
+	a.saveCalendarFeedPersistence = saveCalendarFeedPersistence
+	return a
+}
+
+func (a *Application) SetGetCalendarFeedPersistence(getCalendarFeedPersistence persistence.GetCalendarFeedPersistence) *Application {
+	a.getCalendarFeedPersistence = getCalendarFeedPersistence
+	return a
+}
+
+func (a *Application) SetDeleteCalendarFeedPersistence(deleteCalendarFeedPersistence persistence.DeleteCalendarFeedPersistence) *Application {
+	a.deleteCalendarFeedPersistence = deleteCalendarFeedPersistence
+	return a
+}
+
+func (a *Application) SetListTodoPagePersistence(listTodoPagePersistence persistence.ListTodoPagePersistence) *Application {
+	a.listTodoPagePersistence = listTodoPagePersistence
+	return a
+}
+
+func (a *Application) SetListTodoBatchPersistence(listTodoBatchPersistence persistence.ListTodoBatchPersistence) *Application {
+	a.listTodoBatchPersistence = listTodoBatchPersistence
+	return a
+}
+
+func (a *Application) SetListTodoCommentBatchPersistence(listTodoCommentBatchPersistence persistence.ListTodoCommentBatchPersistence) *Application {
+	a.listTodoCommentBatchPersistence = listTodoCommentBatchPersistence
+	return a
+}
+
+func (a *Application) SetGetUserBatchPersistence(getUserBatchPersistence persistence.GetUserBatchPersistence) *Application {
+	a.getUserBatchPersistence = getUserBatchPersistence
+	return a
+}
+
+func (a *Application) SetListProjectMemberBatchPersistence(listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence) *Application {
+	a.listProjectMemberBatchPersistence = listProjectMemberBatchPersistence
+	return a
+}
+
 func (a *Application) SetPasswordHasher(passwordHasher password.PasswordHasher) *Application {
 	a.passwordHasher = passwordHasher
 	return a
 }
 
+func (a *Application) SetTokenGenerator(tokenGenerator token.TokenGenerator) *Application {
+	a.tokenGenerator = tokenGenerator
+	return a
+}
+
+func (a *Application) SetMailer(mailer mailer.Mailer) *Application {
+	a.mailer = mailer
+	return a
+}
+
+func (a *Application) SetTotpProvider(totpProvider totp.TotpProvider) *Application {
+	a.totpProvider = totpProvider
+	return a
+}
+
+func (a *Application) SetCheckHealthPersistence(checkHealthPersistence persistence.CheckHealthPersistence) *Application {
+	a.checkHealthPersistence = checkHealthPersistence
+	return a
+}
+
+func (a *Application) SetBlobStorage(blobStorage storage.BlobStorage) *Application {
+	a.blobStorage = blobStorage
+	return a
+}
+
+func (a *Application) SetTodoEventBus(todoEventBus event.TodoEventBus) *Application {
+	a.todoEventBus = todoEventBus
+	return a
+}
+
 func (a *Application) AddUserUsecase() usecase.AddUserUsecase {
 	return service.NewAddUserService(
 		a.createUserPersistence,
@@ -92,41 +629,535 @@ func (a *Application) GetUserUsecase() usecase.GetUserUsecase {
 }
 
 func (a *Application) ChangeUserInfoUsecase() usecase.ChangeUserInfoUsecase {
-	return service.NewChangeUserInfoService(a.updateUserPersistence, a.getUserPersistence)
+	return service.NewChangeUserInfoService(
+		a.updateUserPersistence,
+		a.getUserPersistence,
+		a.createEmailChangePersistence,
+		a.tokenGenerator,
+		a.mailer,
+	)
+}
+
+func (a *Application) ConfirmEmailChangeUsecase() usecase.ConfirmEmailChangeUsecase {
+	return service.NewConfirmEmailChangeService(
+		a.getEmailChangePersistence,
+		a.updateEmailChangePersistence,
+		a.getUserPersistence,
+		a.updateUserPersistence,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) RevertEmailChangeUsecase() usecase.RevertEmailChangeUsecase {
+	return service.NewRevertEmailChangeService(
+		a.getEmailChangePersistence,
+		a.updateEmailChangePersistence,
+		a.getUserPersistence,
+		a.updateUserPersistence,
+		a.deleteSessionPersistence,
+		a.tokenGenerator,
+	)
 }
 
 func (a *Application) ChangeUserPasswordUsecase() usecase.ChangeUserPasswordUsecase {
 	return service.NewChangeUserPasswordService(a.updateUserPersistence, a.getUserPersistence, a.passwordHasher)
 }
 
+func (a *Application) ExportUserDataUsecase() usecase.ExportUserDataUsecase {
+	return service.NewExportUserDataService(a.getUserPersistence, a.listTodoPersistence, a.listTodoBatchPersistence, a.listProjectPersistence)
+}
+
+func (a *Application) RequestAccountDeletionUsecase() usecase.RequestAccountDeletionUsecase {
+	return service.NewRequestAccountDeletionService(
+		a.getUserPersistence,
+		a.getAccountDeletionPersistence,
+		a.createAccountDeletionPersistence,
+		a.passwordHasher,
+		a.mailer,
+	)
+}
+
+func (a *Application) CancelAccountDeletionUsecase() usecase.CancelAccountDeletionUsecase {
+	return service.NewCancelAccountDeletionService(a.getAccountDeletionPersistence, a.deleteAccountDeletionPersistence)
+}
+
+func (a *Application) PurgeDeletedAccountsUsecase() usecase.PurgeDeletedAccountsUsecase {
+	return service.NewPurgeDeletedAccountsService(
+		a.getAccountDeletionPersistence,
+		a.deleteUserPersistence,
+		a.listTodoPersistence,
+		a.listTodoBatchPersistence,
+		a.listProjectPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+	)
+}
+
 func (a *Application) LoginUsecase() usecase.LoginUsecase {
-	return service.NewLoginService(a.getUserPersistence, a.passwordHasher)
+	return service.NewLoginService(
+		a.getUserPersistence,
+		a.createSessionPersistence,
+		a.getTwoFactorPersistence,
+		a.createLoginChallengePersistence,
+		a.passwordHasher,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) VerifyLoginUsecase() usecase.VerifyLoginUsecase {
+	return service.NewVerifyLoginService(
+		a.getLoginChallengePersistence,
+		a.updateLoginChallengePersistence,
+		a.deleteLoginChallengePersistence,
+		a.getTwoFactorPersistence,
+		a.updateTwoFactorPersistence,
+		a.getUserPersistence,
+		a.createSessionPersistence,
+		a.totpProvider,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) EnrollTwoFactorUsecase() usecase.EnrollTwoFactorUsecase {
+	return service.NewEnrollTwoFactorService(
+		a.getUserPersistence,
+		a.getTwoFactorPersistence,
+		a.createTwoFactorPersistence,
+		a.deleteTwoFactorPersistence,
+		a.totpProvider,
+	)
+}
+
+func (a *Application) ConfirmTwoFactorUsecase() usecase.ConfirmTwoFactorUsecase {
+	return service.NewConfirmTwoFactorService(
+		a.getTwoFactorPersistence,
+		a.updateTwoFactorPersistence,
+		a.totpProvider,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) DisableTwoFactorUsecase() usecase.DisableTwoFactorUsecase {
+	return service.NewDisableTwoFactorService(
+		a.getUserPersistence,
+		a.getTwoFactorPersistence,
+		a.deleteTwoFactorPersistence,
+		a.passwordHasher,
+	)
+}
+
+func (a *Application) RequestPasswordResetUsecase() usecase.RequestPasswordResetUsecase {
+	return service.NewRequestPasswordResetService(
+		a.getUserPersistence,
+		a.createPasswordResetPersistence,
+		a.tokenGenerator,
+		a.mailer,
+	)
+}
+
+func (a *Application) ResetPasswordUsecase() usecase.ResetPasswordUsecase {
+	return service.NewResetPasswordService(
+		a.getPasswordResetPersistence,
+		a.updatePasswordResetPersistence,
+		a.getUserPersistence,
+		a.updateUserPersistence,
+		a.deleteSessionPersistence,
+		a.passwordHasher,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) AuthenticateUsecase() usecase.AuthenticateUsecase {
+	return service.NewAuthenticateService(
+		a.getUserPersistence,
+		a.getSessionPersistence,
+		a.getAccessTokenPersistence,
+		a.updateAccessTokenPersistence,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) CreateAccessTokenUsecase() usecase.CreateAccessTokenUsecase {
+	return service.NewCreateAccessTokenService(
+		a.createAccessTokenPersistence,
+		a.getAccessTokenPersistence,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) ListAccessTokensUsecase() usecase.ListAccessTokensUsecase {
+	return service.NewListAccessTokensService(a.listAccessTokenPersistence)
+}
+
+func (a *Application) RevokeAccessTokenUsecase() usecase.RevokeAccessTokenUsecase {
+	return service.NewRevokeAccessTokenService(a.getAccessTokenPersistence, a.deleteAccessTokenPersistence)
+}
+
+func (a *Application) ListUsersUsecase() usecase.ListUsersUsecase {
+	return service.NewListUsersService(a.listUserPersistence)
+}
+
+func (a *Application) InspectUserUsecase() usecase.InspectUserUsecase {
+	return service.NewInspectUserService(
+		a.getUserPersistence,
+		a.listTodoPersistence,
+		a.listAccessTokenPersistence,
+		a.getTwoFactorPersistence,
+		a.createAuditLogPersistence,
+	)
+}
+
+func (a *Application) DisableUserUsecase() usecase.DisableUserUsecase {
+	return service.NewDisableUserService(
+		a.getUserPersistence,
+		a.updateUserPersistence,
+		a.deleteSessionPersistence,
+		a.createAuditLogPersistence,
+	)
+}
+
+func (a *Application) EnableUserUsecase() usecase.EnableUserUsecase {
+	return service.NewEnableUserService(a.getUserPersistence, a.updateUserPersistence, a.createAuditLogPersistence)
+}
+
+func (a *Application) ForcePasswordResetUsecase() usecase.ForcePasswordResetUsecase {
+	return service.NewForcePasswordResetService(
+		a.getUserPersistence,
+		a.createPasswordResetPersistence,
+		a.deleteSessionPersistence,
+		a.createAuditLogPersistence,
+		a.tokenGenerator,
+		a.mailer,
+	)
+}
+
+func (a *Application) ChangeUserRoleUsecase() usecase.ChangeUserRoleUsecase {
+	return service.NewChangeUserRoleService(a.getUserPersistence, a.updateUserPersistence, a.createAuditLogPersistence)
+}
+
+func (a *Application) GrantAdminUsecase() usecase.GrantAdminUsecase {
+	return service.NewGrantAdminService(a.getUserPersistence, a.updateUserPersistence, a.createAuditLogPersistence)
+}
+
+func (a *Application) ListAuditLogsUsecase() usecase.ListAuditLogsUsecase {
+	return service.NewListAuditLogsService(a.listAuditLogPersistence)
 }
 
 func (a *Application) AddTodoUsecase() usecase.AddTodoUsecase {
-	return service.NewAddTodoService(a.createTodoPersistence)
+	return service.NewAddTodoService(a.createTodoPersistence, a.getProjectMemberPersistence, a.createTodoUndoPersistence, a.tokenGenerator, a.todoEventBus)
 }
 
 func (a *Application) GetTodoUsecase() usecase.GetTodoUsecase {
-	return service.NewGetTodoService(a.getTodoPersistence)
+	return service.NewGetTodoService(a.getTodoPersistence, a.getProjectMemberPersistence)
 }
 
 func (a *Application) ListTodoUsecase() usecase.ListTodoUsecase {
-	return service.NewListTodoService(a.listTodoPersistence)
+	return service.NewListTodoService(a.listTodoPersistence, a.listTodoBatchPersistence, a.listProjectPersistence)
 }
 
 func (a *Application) UpdateTodoUsecase() usecase.UpdateTodoUsecase {
-	return service.NewUpdateTodoService(a.updateTodoPersistence, a.getTodoPersistence)
+	return service.NewUpdateTodoService(
+		a.updateTodoPersistence,
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
 }
 
 func (a *Application) CompleteTodoUsecase() usecase.CompleteTodoUsecase {
-	return service.NewCompleteTodoService(a.updateTodoPersistence, a.getTodoPersistence)
+	return service.NewCompleteTodoService(
+		a.updateTodoPersistence,
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
 }
 
 func (a *Application) UncompleteTodoUsecase() usecase.UncompleteTodoUsecase {
-	return service.NewUncompleteTodoService(a.updateTodoPersistence, a.getTodoPersistence)
+	return service.NewUncompleteTodoService(
+		a.updateTodoPersistence,
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
 }
 
 func (a *Application) DeleteTodoUsecase() usecase.DeleteTodoUsecase {
-	return service.NewDeleteTodoService(a.deleteTodoPersistence, a.getTodoPersistence)
+	return service.NewDeleteTodoService(
+		a.deleteTodoPersistence,
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) AssignTodoUsecase() usecase.AssignTodoUsecase {
+	return service.NewAssignTodoService(
+		a.getTodoPersistence,
+		a.updateTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.getUserPersistence,
+		a.createTodoAssignmentPersistence,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) UnassignTodoUsecase() usecase.UnassignTodoUsecase {
+	return service.NewUnassignTodoService(
+		a.getTodoPersistence,
+		a.updateTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoAssignmentPersistence,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) ListAssignedTodosUsecase() usecase.ListAssignedTodosUsecase {
+	return service.NewListAssignedTodosService(a.listTodoPersistence, a.getProjectMemberPersistence)
+}
+
+func (a *Application) ListTodoAssignmentsUsecase() usecase.ListTodoAssignmentsUsecase {
+	return service.NewListTodoAssignmentsService(a.getTodoPersistence, a.getProjectMemberPersistence, a.listTodoAssignmentPersistence)
+}
+
+func (a *Application) ListTodoCommentsUsecase() usecase.ListTodoCommentsUsecase {
+	return service.NewListTodoCommentsService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoCommentPersistence,
+		a.getUserPersistence,
+	)
+}
+
+func (a *Application) ListTodoCommentsBatchUsecase() usecase.ListTodoCommentsBatchUsecase {
+	return service.NewListTodoCommentsBatchService(
+		a.getTodoBatchPersistence,
+		a.listProjectMemberBatchPersistence,
+		a.listTodoCommentBatchPersistence,
+		a.getUserBatchPersistence,
+	)
+}
+
+func (a *Application) AddTodoCommentUsecase() usecase.AddTodoCommentUsecase {
+	return service.NewAddTodoCommentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoCommentPersistence,
+		a.getUserPersistence,
+	)
+}
+
+func (a *Application) EditTodoCommentUsecase() usecase.EditTodoCommentUsecase {
+	return service.NewEditTodoCommentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.getTodoCommentPersistence,
+		a.updateTodoCommentPersistence,
+		a.getUserPersistence,
+	)
+}
+
+func (a *Application) DeleteTodoCommentUsecase() usecase.DeleteTodoCommentUsecase {
+	return service.NewDeleteTodoCommentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.getTodoCommentPersistence,
+		a.deleteTodoCommentPersistence,
+	)
+}
+
+func (a *Application) CreateProjectUsecase() usecase.CreateProjectUsecase {
+	return service.NewCreateProjectService(a.createProjectPersistence)
+}
+
+func (a *Application) ListProjectsUsecase() usecase.ListProjectsUsecase {
+	return service.NewListProjectsService(a.listProjectPersistence, a.getProjectMemberPersistence)
+}
+
+func (a *Application) GetProjectUsecase() usecase.GetProjectUsecase {
+	return service.NewGetProjectService(a.getProjectPersistence, a.getProjectMemberPersistence)
+}
+
+func (a *Application) RenameProjectUsecase() usecase.RenameProjectUsecase {
+	return service.NewRenameProjectService(a.getProjectPersistence, a.updateProjectPersistence, a.getProjectMemberPersistence)
+}
+
+func (a *Application) DeleteProjectUsecase() usecase.DeleteProjectUsecase {
+	return service.NewDeleteProjectService(
+		a.deleteProjectPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+	)
+}
+
+func (a *Application) ListProjectTodosUsecase() usecase.ListProjectTodosUsecase {
+	return service.NewListProjectTodosService(a.listTodoPersistence, a.getProjectMemberPersistence)
+}
+
+func (a *Application) ListProjectTodosBatchUsecase() usecase.ListProjectTodosBatchUsecase {
+	return service.NewListProjectTodosBatchService(a.listTodoBatchPersistence, a.listProjectMemberBatchPersistence)
+}
+
+func (a *Application) ListProjectMembersUsecase() usecase.ListProjectMembersUsecase {
+	return service.NewListProjectMembersService(a.getProjectMemberPersistence, a.getUserPersistence)
+}
+
+func (a *Application) ListProjectMembersBatchUsecase() usecase.ListProjectMembersBatchUsecase {
+	return service.NewListProjectMembersBatchService(a.listProjectMemberBatchPersistence, a.getUserBatchPersistence)
+}
+
+func (a *Application) ChangeProjectMemberUsecase() usecase.ChangeProjectMemberUsecase {
+	return service.NewChangeProjectMemberService(a.getProjectMemberPersistence, a.saveProjectMemberPersistence)
+}
+
+func (a *Application) RemoveProjectMemberUsecase() usecase.RemoveProjectMemberUsecase {
+	return service.NewRemoveProjectMemberService(a.getProjectMemberPersistence, a.deleteProjectMemberPersistence)
+}
+
+func (a *Application) InviteProjectMemberUsecase() usecase.InviteProjectMemberUsecase {
+	return service.NewInviteProjectMemberService(
+		a.getProjectPersistence,
+		a.getProjectMemberPersistence,
+		a.createProjectInvitePersistence,
+		a.getUserPersistence,
+		a.tokenGenerator,
+		a.mailer,
+	)
+}
+
+func (a *Application) AcceptProjectInviteUsecase() usecase.AcceptProjectInviteUsecase {
+	return service.NewAcceptProjectInviteService(
+		a.getProjectInvitePersistence,
+		a.updateProjectInvitePersistence,
+		a.getProjectPersistence,
+		a.getProjectMemberPersistence,
+		a.saveProjectMemberPersistence,
+		a.getUserPersistence,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) ListTodoAttachmentsUsecase() usecase.ListTodoAttachmentsUsecase {
+	return service.NewListTodoAttachmentsService(a.getTodoPersistence, a.getProjectMemberPersistence, a.listTodoAttachmentPersistence)
+}
+
+func (a *Application) UploadTodoAttachmentUsecase() usecase.UploadTodoAttachmentUsecase {
+	return service.NewUploadTodoAttachmentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoAttachmentPersistence,
+		a.blobStorage,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) DownloadTodoAttachmentUsecase() usecase.DownloadTodoAttachmentUsecase {
+	return service.NewDownloadTodoAttachmentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.getTodoAttachmentPersistence,
+		a.blobStorage,
+	)
+}
+
+func (a *Application) DeleteTodoAttachmentUsecase() usecase.DeleteTodoAttachmentUsecase {
+	return service.NewDeleteTodoAttachmentService(
+		a.getTodoPersistence,
+		a.getProjectMemberPersistence,
+		a.getTodoAttachmentPersistence,
+		a.deleteTodoAttachmentPersistence,
+		a.blobStorage,
+	)
+}
+
+func (a *Application) SearchTodoUsecase() usecase.SearchTodoUsecase {
+	return service.NewSearchTodoService(a.searchTodoPersistence, a.listProjectPersistence)
+}
+
+func (a *Application) BatchTodoUsecase() usecase.BatchTodoUsecase {
+	return service.NewBatchTodoService(
+		a.getTodoBatchPersistence,
+		a.writeTodoBatchPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+		a.createTodoUndoPersistence,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) UndoTodoUsecase() usecase.UndoTodoUsecase {
+	return service.NewUndoTodoService(
+		a.getTodoUndoPersistence,
+		a.updateTodoUndoPersistence,
+		a.getTodoRevisionPersistence,
+		a.writeTodoBatchPersistence,
+		a.getProjectMemberPersistence,
+		a.createTodoAssignmentPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+		a.tokenGenerator,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) SubscribeTodoEventsUsecase() usecase.SubscribeTodoEventsUsecase {
+	return service.NewSubscribeTodoEventsService(a.todoEventBus)
+}
+
+func (a *Application) SyncTodoUsecase() usecase.SyncTodoUsecase {
+	return service.NewSyncTodoService(
+		a.getTodoRevisionPersistence,
+		a.writeTodoSyncPersistence,
+		a.getTodoSyncOperationPersistence,
+		a.listTodoChangePersistence,
+		a.listProjectPersistence,
+		a.getProjectMemberPersistence,
+		a.listTodoAttachmentPersistence,
+		a.blobStorage,
+		a.todoEventBus,
+	)
+}
+
+func (a *Application) CalendarFeedUsecase() usecase.CalendarFeedUsecase {
+	return service.NewCalendarFeedService(
+		a.saveCalendarFeedPersistence,
+		a.getCalendarFeedPersistence,
+		a.deleteCalendarFeedPersistence,
+		a.getUserPersistence,
+		a.listTodoPersistence,
+		a.listTodoBatchPersistence,
+		a.listProjectPersistence,
+		a.tokenGenerator,
+	)
+}
+
+func (a *Application) ImportTodosUsecase() usecase.ImportTodosUsecase {
+	return service.NewImportTodosService(a.createTodoBatchPersistence, a.getProjectMemberPersistence, a.createTodoUndoPersistence, a.tokenGenerator, a.todoEventBus)
+}
+
+func (a *Application) ExportTodosUsecase() usecase.ExportTodosUsecase {
+	return service.NewExportTodosService(a.listTodoPagePersistence, a.listProjectPersistence)
+}
+
+func (a *Application) CheckReadinessUsecase() usecase.CheckReadinessUsecase {
+	return service.NewCheckReadinessService(a.checkHealthPersistence)
 }
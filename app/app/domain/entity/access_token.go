@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Scopes personal access token can be granted.
+const (
+	ScopeTodosRead = "todos:read"
+	ScopeTodosWrite = "todos:write"
+	ScopeProfileRead = "profile:read"
+	ScopeProfileWrite = "profile:write"
+)
+
+// All scopes known to application.
+var Scopes = []string{
+	ScopeTodosRead,
+	ScopeTodosWrite,
+	ScopeProfileRead,
+	ScopeProfileWrite,
+}
+
+// Check if scope is known to application.
+func IsScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// Personal access token of user for scripts and integrations.
+type AccessToken struct {
+	// ID of access token.
+	id string
+	// Owner of access token.
+	userId value.UserId
+	// Name given by user.
+	name string
+	// Hash of access token.
+	tokenHash string
+	// Scopes granted to access token.
+	scopes []string
+	// Time access token was created.
+	createdAt time.Time
+	// Expiration of access token. nil if it never expires.
+	expiresAt *time.Time
+	// Time access token was last used. nil if never used.
+	lastUsedAt *time.Time
+}
+
+// Create new access token.
+func NewAccessToken(
+	id string,
+	userId value.UserId,
+	name string,
+	tokenHash string,
+	scopes []string,
+	createdAt time.Time,
+	expiresAt *time.Time,
+	lastUsedAt *time.Time,
+) *AccessToken {
+	return &AccessToken{id, userId, name, tokenHash, scopes, createdAt, expiresAt, lastUsedAt}
+}
+
+// Get ID of access token.
+func (t *AccessToken) Id() string {
+	return t.id
+}
+
+// Get owner of access token.
+func (t *AccessToken) UserId() value.UserId {
+	return t.userId
+}
+
+// Get name of access token.
+func (t *AccessToken) Name() string {
+	return t.name
+}
+
+// Get hash of access token.
+func (t *AccessToken) TokenHash() string {
+	return t.tokenHash
+}
+
+// Get scopes granted to access token.
+func (t *AccessToken) Scopes() []string {
+	return t.scopes
+}
+
+// Get time access token was created.
+func (t *AccessToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Get expiration of access token.
+func (t *AccessToken) ExpiresAt() *time.Time {
+	return t.expiresAt
+}
+
+// Get time access token was last used.
+func (t *AccessToken) LastUsedAt() *time.Time {
+	return t.lastUsedAt
+}
+
+// Check if access token is expired at given time.
+func (t *AccessToken) IsExpired(now time.Time) bool {
+	return t.expiresAt != nil && !now.Before(*t.expiresAt)
+}
+
+// Check if scope is granted to access token.
+func (t *AccessToken) HasScope(scope string) bool {
+	return slices.Contains(t.scopes, scope)
+}
+
+// Record use of access token.
+func (t *AccessToken) Use(at time.Time) {
+	t.lastUsedAt = &at
+}
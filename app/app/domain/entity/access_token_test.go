@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("AccessToken test", func() {
+
+	ginkgo.It("should never expire without expiration", func() {
+		token := entity.NewAccessToken("1", value.NewUserId("1"), "ci", "hash", nil, time.Now(), nil, nil)
+		gomega.Expect(token.IsExpired(time.Now().Add(100 * 365 * 24 * time.Hour))).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should expire at expiration", func() {
+		now := time.Now()
+		expiresAt := now.Add(time.Hour)
+		token := entity.NewAccessToken("1", value.NewUserId("1"), "ci", "hash", nil, now, &expiresAt, nil)
+		gomega.Expect(token.IsExpired(now)).To(gomega.BeFalse())
+		gomega.Expect(token.IsExpired(expiresAt)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should have only granted scopes", func() {
+		token := entity.NewAccessToken("1", value.NewUserId("1"), "ci", "hash", []string{entity.ScopeTodosRead}, time.Now(), nil, nil)
+		gomega.Expect(token.HasScope(entity.ScopeTodosRead)).To(gomega.BeTrue())
+		gomega.Expect(token.HasScope(entity.ScopeTodosWrite)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should record last use", func() {
+		now := time.Now()
+		token := entity.NewAccessToken("1", value.NewUserId("1"), "ci", "hash", nil, now, nil, nil)
+		gomega.Expect(token.LastUsedAt()).To(gomega.BeNil())
+		token.Use(now)
+		gomega.Expect(*token.LastUsedAt()).To(gomega.Equal(now))
+	})
+
+	ginkgo.It("should know scopes of application", func() {
+		gomega.Expect(entity.IsScope("todos:write")).To(gomega.BeTrue())
+		gomega.Expect(entity.IsScope("admin")).To(gomega.BeFalse())
+	})
+})
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Scheduled deletion of user account.
+type AccountDeletion struct {
+	// User to be deleted.
+	userId value.UserId
+	// Time deletion was requested.
+	requestedAt time.Time
+	// Account is deleted permanently after this time.
+	scheduledAt time.Time
+}
+
+// Create new account deletion.
+func NewAccountDeletion(userId value.UserId, requestedAt time.Time, scheduledAt time.Time) *AccountDeletion {
+	return &AccountDeletion{userId, requestedAt, scheduledAt}
+}
+
+// Get user to be deleted.
+func (a *AccountDeletion) UserId() value.UserId {
+	return a.userId
+}
+
+// Get time deletion was requested.
+func (a *AccountDeletion) RequestedAt() time.Time {
+	return a.requestedAt
+}
+
+// Get time account is deleted permanently.
+func (a *AccountDeletion) ScheduledAt() time.Time {
+	return a.scheduledAt
+}
+
+// Check if grace period is over at given time.
+func (a *AccountDeletion) IsDue(now time.Time) bool {
+	return !now.Before(a.scheduledAt)
+}
@@ -0,0 +1,25 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("AccountDeletion test", func() {
+
+	ginkgo.It("should not be due during grace period", func() {
+		now := time.Now()
+		deletion := entity.NewAccountDeletion(value.NewUserId("1"), now, now.Add(time.Hour))
+		gomega.Expect(deletion.IsDue(now)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should be due after grace period", func() {
+		now := time.Now()
+		deletion := entity.NewAccountDeletion(value.NewUserId("1"), now, now.Add(time.Hour))
+		gomega.Expect(deletion.IsDue(now.Add(time.Hour))).To(gomega.BeTrue())
+	})
+})
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Actions recorded in audit log.
+const (
+	AuditActionUserInspected = "user.inspected"
+	AuditActionUserDisabled = "user.disabled"
+	AuditActionUserEnabled = "user.enabled"
+	AuditActionUserPasswordReset = "user.password_reset"
+	AuditActionUserRoleChanged = "user.role_changed"
+)
+
+// Record of action taken by admin.
+type AuditLog struct {
+	// ID of audit log.
+	id string
+	// Admin taking action. nil if taken by system.
+	actorId *value.UserId
+	// Action taken.
+	action string
+	// User action was taken on.
+	targetUserId value.UserId
+	// Additional detail of action.
+	detail string
+	// Time action was taken.
+	createdAt time.Time
+}
+
+// Create new audit log.
+func NewAuditLog(
+	id string,
+	actorId *value.UserId,
+	action string,
+	targetUserId value.UserId,
+	detail string,
+	createdAt time.Time,
+) *AuditLog {
+	return &AuditLog{id, actorId, action, targetUserId, detail, createdAt}
+}
+
+// Get ID of audit log.
+func (l *AuditLog) Id() string {
+	return l.id
+}
+
+// Get admin taking action.
+func (l *AuditLog) ActorId() *value.UserId {
+	return l.actorId
+}
+
+// Get action taken.
+func (l *AuditLog) Action() string {
+	return l.action
+}
+
+// Get user action was taken on.
+func (l *AuditLog) TargetUserId() value.UserId {
+	return l.targetUserId
+}
+
+// Get additional detail of action.
+func (l *AuditLog) Detail() string {
+	return l.detail
+}
+
+// Get time action was taken.
+func (l *AuditLog) CreatedAt() time.Time {
+	return l.createdAt
+}
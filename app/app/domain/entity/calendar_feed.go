@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Secret iCalendar feed of user's todo items, for calendar apps that cannot log in.
+type CalendarFeed struct {
+	// Owner of calendar feed. User has one feed at most.
+	userId value.UserId
+	// Hash of token in feed URL.
+	tokenHash string
+	// Time calendar feed was created.
+	createdAt time.Time
+}
+
+// Create new calendar feed.
+func NewCalendarFeed(userId value.UserId, tokenHash string, createdAt time.Time) *CalendarFeed {
+	return &CalendarFeed{userId, tokenHash, createdAt}
+}
+
+// Get owner of calendar feed.
+func (f *CalendarFeed) UserId() value.UserId {
+	return f.userId
+}
+
+// Get hash of token in feed URL.
+func (f *CalendarFeed) TokenHash() string {
+	return f.tokenHash
+}
+
+// Get time calendar feed was created.
+func (f *CalendarFeed) CreatedAt() time.Time {
+	return f.createdAt
+}
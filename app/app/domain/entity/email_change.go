@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Email change of user waiting for confirmation by new address.
+type EmailChange struct {
+	// ID of email change.
+	id string
+	// User changing email.
+	userId value.UserId
+	// Email before change.
+	oldEmail value.Email
+	// Email after change.
+	newEmail value.Email
+	// Hash of token sent to new address.
+	confirmTokenHash string
+	// Hash of token sent to old address.
+	revertTokenHash string
+	// Expiration of confirm token.
+	expiresAt time.Time
+	// Old address can revert change until this time.
+	revertibleUntil time.Time
+	// Time change was confirmed. nil if pending.
+	confirmedAt *time.Time
+	// Time change was reverted. nil if not reverted.
+	revertedAt *time.Time
+}
+
+// Create new email change.
+func NewEmailChange(
+	id string,
+	userId value.UserId,
+	oldEmail value.Email,
+	newEmail value.Email,
+	confirmTokenHash string,
+	revertTokenHash string,
+	expiresAt time.Time,
+	revertibleUntil time.Time,
+	confirmedAt *time.Time,
+	revertedAt *time.Time,
+) *EmailChange {
+	return &EmailChange{
+		id,
+		userId,
+		oldEmail,
+		newEmail,
+		confirmTokenHash,
+		revertTokenHash,
+		expiresAt,
+		revertibleUntil,
+		confirmedAt,
+		revertedAt,
+	}
+}
+
+// Get ID of email change.
+func (e *EmailChange) Id() string {
+	return e.id
+}
+
+// Get user changing email.
+func (e *EmailChange) UserId() value.UserId {
+	return e.userId
+}
+
+// Get email before change.
+func (e *EmailChange) OldEmail() value.Email {
+	return e.oldEmail
+}
+
+// Get email after change.
+func (e *EmailChange) NewEmail() value.Email {
+	return e.newEmail
+}
+
+// Get hash of confirm token.
+func (e *EmailChange) ConfirmTokenHash() string {
+	return e.confirmTokenHash
+}
+
+// Get hash of revert token.
+func (e *EmailChange) RevertTokenHash() string {
+	return e.revertTokenHash
+}
+
+// Get expiration of confirm token.
+func (e *EmailChange) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
+// Get end of revert window.
+func (e *EmailChange) RevertibleUntil() time.Time {
+	return e.revertibleUntil
+}
+
+// Get time change was confirmed.
+func (e *EmailChange) ConfirmedAt() *time.Time {
+	return e.confirmedAt
+}
+
+// Get time change was reverted.
+func (e *EmailChange) RevertedAt() *time.Time {
+	return e.revertedAt
+}
+
+// Check if change can be confirmed at given time.
+func (e *EmailChange) IsConfirmable(now time.Time) bool {
+	return e.confirmedAt == nil && e.revertedAt == nil && now.Before(e.expiresAt)
+}
+
+// Check if change can be reverted at given time.
+func (e *EmailChange) IsRevertible(now time.Time) bool {
+	return e.revertedAt == nil && now.Before(e.revertibleUntil)
+}
+
+// Confirm change.
+func (e *EmailChange) Confirm(now time.Time) {
+	e.confirmedAt = &now
+}
+
+// Revert change.
+func (e *EmailChange) Revert(now time.Time) {
+	e.revertedAt = &now
+}
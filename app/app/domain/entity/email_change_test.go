@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("EmailChange test", func() {
+
+	newChange := func(now time.Time) *entity.EmailChange {
+		return entity.NewEmailChange(
+			"1",
+			value.NewUserId("1"),
+			value.NewEmail("old@example.com"),
+			value.NewEmail("new@example.com"),
+			"confirm-hash",
+			"revert-hash",
+			now.Add(time.Hour),
+			now.Add(24 * time.Hour),
+			nil,
+			nil,
+		)
+	}
+
+	ginkgo.It("should be confirmable only once", func() {
+		now := time.Now()
+		change := newChange(now)
+		gomega.Expect(change.IsConfirmable(now)).To(gomega.BeTrue())
+		change.Confirm(now)
+		gomega.Expect(change.IsConfirmable(now)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should not be confirmable after expiration", func() {
+		now := time.Now()
+		change := newChange(now)
+		gomega.Expect(change.IsConfirmable(now.Add(2 * time.Hour))).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should be revertible after confirmed within window", func() {
+		now := time.Now()
+		change := newChange(now)
+		change.Confirm(now)
+		gomega.Expect(change.IsRevertible(now.Add(2 * time.Hour))).To(gomega.BeTrue())
+		gomega.Expect(change.IsRevertible(now.Add(25 * time.Hour))).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should not be confirmable nor revertible after reverted", func() {
+		now := time.Now()
+		change := newChange(now)
+		change.Revert(now)
+		gomega.Expect(change.IsConfirmable(now)).To(gomega.BeFalse())
+		gomega.Expect(change.IsRevertible(now)).To(gomega.BeFalse())
+	})
+})
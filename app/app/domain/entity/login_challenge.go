@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Maximum failed attempts before challenge is discarded.
+const MaxLoginChallengeAttempts = 5
+
+// Pending login waiting for second factor.
+type LoginChallenge struct {
+	// Hash of challenge token.
+	tokenHash string
+	// User logging in.
+	userId value.UserId
+	// Expiration of challenge.
+	expiresAt time.Time
+	// Number of failed attempts.
+	failedAttempts int
+}
+
+// Create new login challenge.
+func NewLoginChallenge(tokenHash string, userId value.UserId, expiresAt time.Time, failedAttempts int) *LoginChallenge {
+	return &LoginChallenge{tokenHash, userId, expiresAt, failedAttempts}
+}
+
+// Get hash of challenge token.
+func (l *LoginChallenge) TokenHash() string {
+	return l.tokenHash
+}
+
+// Get user logging in.
+func (l *LoginChallenge) UserId() value.UserId {
+	return l.userId
+}
+
+// Get expiration of challenge.
+func (l *LoginChallenge) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+// Get number of failed attempts.
+func (l *LoginChallenge) FailedAttempts() int {
+	return l.failedAttempts
+}
+
+// Check if challenge can still be answered at given time.
+func (l *LoginChallenge) IsUsable(now time.Time) bool {
+	return now.Before(l.expiresAt) && l.failedAttempts < MaxLoginChallengeAttempts
+}
+
+// Record failed attempt.
+func (l *LoginChallenge) Fail() {
+	l.failedAttempts++
+}
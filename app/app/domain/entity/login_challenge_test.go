@@ -0,0 +1,29 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("LoginChallenge test", func() {
+
+	ginkgo.It("should not be usable after expiration", func() {
+		now := time.Now()
+		challenge := entity.NewLoginChallenge("hash", value.NewUserId("1"), now.Add(-time.Second), 0)
+		gomega.Expect(challenge.IsUsable(now)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should not be usable after too many failures", func() {
+		now := time.Now()
+		challenge := entity.NewLoginChallenge("hash", value.NewUserId("1"), now.Add(time.Minute), 0)
+		for i := 0; i < entity.MaxLoginChallengeAttempts; i++ {
+			gomega.Expect(challenge.IsUsable(now)).To(gomega.BeTrue())
+			challenge.Fail()
+		}
+		gomega.Expect(challenge.IsUsable(now)).To(gomega.BeFalse())
+	})
+})
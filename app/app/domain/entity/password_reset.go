@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Password reset request of user.
+type PasswordReset struct {
+	// Hash of reset token.
+	tokenHash string
+	// User requested reset.
+	userId value.UserId
+	// Expiration of reset token.
+	expiresAt time.Time
+	// Time reset token was used. nil if not used yet.
+	usedAt *time.Time
+}
+
+// Create new password reset.
+func NewPasswordReset(tokenHash string, userId value.UserId, expiresAt time.Time, usedAt *time.Time) *PasswordReset {
+	return &PasswordReset{tokenHash, userId, expiresAt, usedAt}
+}
+
+// Get hash of reset token.
+func (p *PasswordReset) TokenHash() string {
+	return p.tokenHash
+}
+
+// Get user requested reset.
+func (p *PasswordReset) UserId() value.UserId {
+	return p.userId
+}
+
+// Get expiration of reset token.
+func (p *PasswordReset) ExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+// Get time reset token was used.
+func (p *PasswordReset) UsedAt() *time.Time {
+	return p.usedAt
+}
+
+// Check if reset token can be used at given time.
+func (p *PasswordReset) IsUsable(now time.Time) bool {
+	return p.usedAt == nil && now.Before(p.expiresAt)
+}
+
+// Mark reset token as used.
+func (p *PasswordReset) Use(now time.Time) {
+	p.usedAt = &now
+}
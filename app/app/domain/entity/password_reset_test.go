@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("PasswordReset test", func() {
+
+	ginkgo.It("should be usable before expiration", func() {
+		now := time.Now()
+		reset := entity.NewPasswordReset("hash", value.NewUserId("1"), now.Add(time.Minute), nil)
+		gomega.Expect(reset.IsUsable(now)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should not be usable after expiration", func() {
+		now := time.Now()
+		reset := entity.NewPasswordReset("hash", value.NewUserId("1"), now.Add(-time.Minute), nil)
+		gomega.Expect(reset.IsUsable(now)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should not be usable after used", func() {
+		now := time.Now()
+		reset := entity.NewPasswordReset("hash", value.NewUserId("1"), now.Add(time.Minute), nil)
+		reset.Use(now)
+		gomega.Expect(reset.UsedAt()).ToNot(gomega.BeNil())
+		gomega.Expect(reset.IsUsable(now)).To(gomega.BeFalse())
+	})
+})
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Project sharing todo items with members.
+type Project struct {
+	// ID of project.
+	id string
+	// Name of project.
+	name string
+	// Time project was created.
+	createdAt time.Time
+}
+
+// Create new project.
+func NewProject(id string, name string, createdAt time.Time) *Project {
+	return &Project{id, name, createdAt}
+}
+
+// Get ID of project.
+func (p *Project) Id() string {
+	return p.id
+}
+
+// Get name of project.
+func (p *Project) Name() string {
+	return p.name
+}
+
+// Get time project was created.
+func (p *Project) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// Change name of project.
+func (p *Project) ChangeName(name string) {
+	p.name = name
+}
+
+// Membership of user in project.
+type ProjectMember struct {
+	// Project user belongs to.
+	projectId string
+	// Member of project.
+	userId value.UserId
+	// Permission of member.
+	permission value.Permission
+}
+
+// Create new project member.
+func NewProjectMember(projectId string, userId value.UserId, permission value.Permission) *ProjectMember {
+	return &ProjectMember{projectId, userId, permission}
+}
+
+// Get project user belongs to.
+func (m *ProjectMember) ProjectId() string {
+	return m.projectId
+}
+
+// Get member of project.
+func (m *ProjectMember) UserId() value.UserId {
+	return m.userId
+}
+
+// Get permission of member.
+func (m *ProjectMember) Permission() value.Permission {
+	return m.permission
+}
+
+// Change permission of member.
+func (m *ProjectMember) ChangePermission(permission value.Permission) {
+	m.permission = permission
+}
+
+// Invitation to project sent by email.
+type ProjectInvite struct {
+	// ID of invite.
+	id string
+	// Project user is invited to.
+	projectId string
+	// Invited email.
+	email value.Email
+	// Permission given on acceptance.
+	permission value.Permission
+	// Hash of invite token.
+	tokenHash string
+	// Member who sent invite.
+	invitedBy value.UserId
+	// Expiration of invite.
+	expiresAt time.Time
+	// Time invite was accepted. nil if pending.
+	acceptedAt *time.Time
+}
+
+// Create new project invite.
+func NewProjectInvite(
+	id string,
+	projectId string,
+	email value.Email,
+	permission value.Permission,
+	tokenHash string,
+	invitedBy value.UserId,
+	expiresAt time.Time,
+	acceptedAt *time.Time,
+) *ProjectInvite {
+	return &ProjectInvite{id, projectId, email, permission, tokenHash, invitedBy, expiresAt, acceptedAt}
+}
+
+// Get ID of invite.
+func (i *ProjectInvite) Id() string {
+	return i.id
+}
+
+// Get project user is invited to.
+func (i *ProjectInvite) ProjectId() string {
+	return i.projectId
+}
+
+// Get invited email.
+func (i *ProjectInvite) Email() value.Email {
+	return i.email
+}
+
+// Get permission given on acceptance.
+func (i *ProjectInvite) Permission() value.Permission {
+	return i.permission
+}
+
+// Get hash of invite token.
+func (i *ProjectInvite) TokenHash() string {
+	return i.tokenHash
+}
+
+// Get member who sent invite.
+func (i *ProjectInvite) InvitedBy() value.UserId {
+	return i.invitedBy
+}
+
+// Get expiration of invite.
+func (i *ProjectInvite) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+// Get time invite was accepted.
+func (i *ProjectInvite) AcceptedAt() *time.Time {
+	return i.acceptedAt
+}
+
+// Check if invite can still be accepted at given time.
+func (i *ProjectInvite) IsAcceptable(now time.Time) bool {
+	return i.acceptedAt == nil && now.Before(i.expiresAt)
+}
+
+// Mark invite as accepted.
+func (i *ProjectInvite) Accept(at time.Time) {
+	i.acceptedAt = &at
+}
@@ -0,0 +1,49 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("ProjectInvite test", func() {
+
+	newInvite := func(expiresAt time.Time) *entity.ProjectInvite {
+		return entity.NewProjectInvite(
+			"1",
+			"1",
+			value.NewEmail("invitee@example.com"),
+			value.PermissionEditor,
+			"hash",
+			value.NewUserId("1"),
+			expiresAt,
+			nil,
+		)
+	}
+
+	ginkgo.It("should be acceptable before expiration", func() {
+		now := time.Now()
+		invite := newInvite(now.Add(time.Hour))
+		gomega.Expect(invite.IsAcceptable(now)).To(gomega.BeTrue())
+		gomega.Expect(invite.IsAcceptable(now.Add(time.Hour))).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should be accepted only once", func() {
+		now := time.Now()
+		invite := newInvite(now.Add(time.Hour))
+		invite.Accept(now)
+		gomega.Expect(invite.IsAcceptable(now)).To(gomega.BeFalse())
+	})
+})
+
+var _ = ginkgo.Describe("ProjectMember test", func() {
+
+	ginkgo.It("should change permission", func() {
+		member := entity.NewProjectMember("1", value.NewUserId("1"), value.PermissionViewer)
+		member.ChangePermission(value.PermissionEditor)
+		gomega.Expect(member.Permission()).To(gomega.Equal(value.PermissionEditor))
+	})
+})
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Login session of user.
+type Session struct {
+	// Hash of session token.
+	tokenHash string
+	// Owner of session.
+	userId value.UserId
+	// Expiration of session.
+	expiresAt time.Time
+}
+
+// Create new session.
+func NewSession(tokenHash string, userId value.UserId, expiresAt time.Time) *Session {
+	return &Session{tokenHash, userId, expiresAt}
+}
+
+// Get hash of session token.
+func (s *Session) TokenHash() string {
+	return s.tokenHash
+}
+
+// Get owner of session.
+func (s *Session) UserId() value.UserId {
+	return s.userId
+}
+
+// Get expiration of session.
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// Check if session is expired at given time.
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
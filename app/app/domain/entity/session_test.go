@@ -0,0 +1,25 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Session test", func() {
+
+	ginkgo.It("should not be expired before expiration", func() {
+		now := time.Now()
+		session := entity.NewSession("hash", value.NewUserId("1"), now.Add(time.Hour))
+		gomega.Expect(session.IsExpired(now)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should be expired after expiration", func() {
+		now := time.Now()
+		session := entity.NewSession("hash", value.NewUserId("1"), now.Add(-time.Hour))
+		gomega.Expect(session.IsExpired(now)).To(gomega.BeTrue())
+	})
+})
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Record of change of todo item assignee.
+type TodoAssignment struct {
+	// ID of assignment.
+	id string
+	// Todo item assignee was changed on.
+	todoId value.TodoItemId
+	// New assignee. nil if unassigned.
+	assigneeId *value.UserId
+	// User who changed assignee.
+	assignedBy value.UserId
+	// Time assignee was changed.
+	createdAt time.Time
+}
+
+// Create new todo assignment.
+func NewTodoAssignment(
+	id string,
+	todoId value.TodoItemId,
+	assigneeId *value.UserId,
+	assignedBy value.UserId,
+	createdAt time.Time,
+) *TodoAssignment {
+	return &TodoAssignment{id, todoId, assigneeId, assignedBy, createdAt}
+}
+
+// Get ID of assignment.
+func (a *TodoAssignment) Id() string {
+	return a.id
+}
+
+// Get todo item assignee was changed on.
+func (a *TodoAssignment) TodoId() value.TodoItemId {
+	return a.todoId
+}
+
+// Get new assignee.
+func (a *TodoAssignment) AssigneeId() *value.UserId {
+	return a.assigneeId
+}
+
+// Get user who changed assignee.
+func (a *TodoAssignment) AssignedBy() value.UserId {
+	return a.assignedBy
+}
+
+// Get time assignee was changed.
+func (a *TodoAssignment) CreatedAt() time.Time {
+	return a.createdAt
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// File attached to todo item. Content lives in blob storage.
+type TodoAttachment struct {
+	// ID of attachment.
+	id string
+	// Todo item attached to.
+	todoId value.TodoItemId
+	// User who uploaded file.
+	uploaderId value.UserId
+	// Original file name.
+	fileName string
+	// Content type sniffed from content.
+	contentType string
+	// Size of content in bytes.
+	size int64
+	// Key of content in blob storage.
+	storageKey string
+	// Time file was uploaded.
+	createdAt time.Time
+}
+
+// Create new todo attachment.
+func NewTodoAttachment(
+	id string,
+	todoId value.TodoItemId,
+	uploaderId value.UserId,
+	fileName string,
+	contentType string,
+	size int64,
+	storageKey string,
+	createdAt time.Time,
+) *TodoAttachment {
+	return &TodoAttachment{id, todoId, uploaderId, fileName, contentType, size, storageKey, createdAt}
+}
+
+// Get ID of attachment.
+func (a *TodoAttachment) Id() string {
+	return a.id
+}
+
+// Get todo item attached to.
+func (a *TodoAttachment) TodoId() value.TodoItemId {
+	return a.todoId
+}
+
+// Get user who uploaded file.
+func (a *TodoAttachment) UploaderId() value.UserId {
+	return a.uploaderId
+}
+
+// Get original file name.
+func (a *TodoAttachment) FileName() string {
+	return a.fileName
+}
+
+// Get content type.
+func (a *TodoAttachment) ContentType() string {
+	return a.contentType
+}
+
+// Get size of content in bytes.
+func (a *TodoAttachment) Size() int64 {
+	return a.size
+}
+
+// Get key of content in blob storage.
+func (a *TodoAttachment) StorageKey() string {
+	return a.storageKey
+}
+
+// Get time file was uploaded.
+func (a *TodoAttachment) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// Check if user uploaded file.
+func (a *TodoAttachment) IsUploadedBy(userId value.UserId) bool {
+	return a.uploaderId == userId
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Matches "@username" not preceded by word character, so emails are not mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]*\w)`)
+
+// Comment on todo item.
+type TodoComment struct {
+	// ID of comment.
+	id string
+	// Todo item commented on.
+	todoId value.TodoItemId
+	// Author of comment.
+	authorId value.UserId
+	// Body of comment in Markdown.
+	body string
+	// User names mentioned in body.
+	mentions []string
+	// Time comment was created.
+	createdAt time.Time
+	// Time comment was last edited. nil if never edited.
+	editedAt *time.Time
+}
+
+// Create new todo comment.
+func NewTodoComment(
+	id string,
+	todoId value.TodoItemId,
+	authorId value.UserId,
+	body string,
+	mentions []string,
+	createdAt time.Time,
+	editedAt *time.Time,
+) *TodoComment {
+	return &TodoComment{id, todoId, authorId, body, mentions, createdAt, editedAt}
+}
+
+// Get ID of comment.
+func (c *TodoComment) Id() string {
+	return c.id
+}
+
+// Get todo item commented on.
+func (c *TodoComment) TodoId() value.TodoItemId {
+	return c.todoId
+}
+
+// Get author of comment.
+func (c *TodoComment) AuthorId() value.UserId {
+	return c.authorId
+}
+
+// Get body of comment.
+func (c *TodoComment) Body() string {
+	return c.body
+}
+
+// Get user names mentioned in body.
+func (c *TodoComment) Mentions() []string {
+	return c.mentions
+}
+
+// Get time comment was created.
+func (c *TodoComment) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Get time comment was last edited.
+func (c *TodoComment) EditedAt() *time.Time {
+	return c.editedAt
+}
+
+// Check if user wrote comment.
+func (c *TodoComment) IsWrittenBy(userId value.UserId) bool {
+	return c.authorId == userId
+}
+
+// Replace body of comment, parsing its mentions again.
+func (c *TodoComment) Edit(body string, at time.Time) {
+	c.body = body
+	c.mentions = ParseMentions(body)
+	c.editedAt = &at
+}
+
+// Parse unique user names mentioned as "@username" in order of appearance.
+func ParseMentions(body string) []string {
+
+	mentions := make([]string, 0)
+
+	seen := make(map[string]bool)
+
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+
+		if seen[m[1]] {
+			continue
+		}
+
+		seen[m[1]] = true
+
+		mentions = append(mentions, m[1])
+	}
+
+	return mentions
+}
@@ -0,0 +1,34 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("TodoComment test", func() {
+
+	ginkgo.It("should parse unique mentions", func() {
+		mentions := entity.ParseMentions("@alice and @bob.k, ask @alice. mail bob@example.com")
+		gomega.Expect(mentions).To(gomega.Equal([]string{"alice", "bob.k"}))
+	})
+
+	ginkgo.It("should parse mentions again on edit", func() {
+		comment := entity.NewTodoComment(
+			"1",
+			value.NewTodoItemId("1"),
+			value.NewUserId("1"),
+			"hi @alice",
+			entity.ParseMentions("hi @alice"),
+			time.Now(),
+			nil,
+		)
+		comment.Edit("hi @carol", time.Now())
+		gomega.Expect(comment.Mentions()).To(gomega.Equal([]string{"carol"}))
+		gomega.Expect(comment.EditedAt()).ToNot(gomega.BeNil())
+		gomega.Expect(comment.IsWrittenBy(value.NewUserId("1"))).To(gomega.BeTrue())
+	})
+})
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Types of todo event.
+const (
+	TodoEventCreated = "todo.created"
+	TodoEventUpdated = "todo.updated"
+	TodoEventCompleted = "todo.completed"
+	TodoEventDeleted = "todo.deleted"
+	// Events after last event ID were lost, so todo items should be reloaded. Carries no todo item.
+	TodoEventReset = "todo.reset"
+)
+
+// Change of todo item pushed to user.
+type TodoEvent struct {
+	// ID increasing in order events are published. Used to resume stream.
+	id int64
+	// User event is sent to.
+	userId value.UserId
+	// One of TodoEvent constants.
+	eventType string
+	// Todo item after change, or before change if deleted. nil if reset.
+	todo *TodoItem
+	// Time event was published.
+	occurredAt time.Time
+}
+
+// Create new todo event.
+func NewTodoEvent(id int64, userId value.UserId, eventType string, todo *TodoItem, occurredAt time.Time) *TodoEvent {
+	return &TodoEvent{id, userId, eventType, todo, occurredAt}
+}
+
+// Get id of todo event.
+func (e *TodoEvent) Id() int64 {
+	return e.id
+}
+
+// Get user event is sent to.
+func (e *TodoEvent) UserId() value.UserId {
+	return e.userId
+}
+
+// Get type of todo event.
+func (e *TodoEvent) Type() string {
+	return e.eventType
+}
+
+// Get todo item after change, or before change if deleted.
+func (e *TodoEvent) Todo() *TodoItem {
+	return e.todo
+}
+
+// Get time event was published.
+func (e *TodoEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
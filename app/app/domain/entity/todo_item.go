@@ -14,11 +14,15 @@ type TodoItem struct {
 	isDone bool
 
 	userId value.UserId
+	// Project todo item is shared in. Empty if personal.
+	projectId string
+	// User todo item is assigned to. nil if unassigned.
+	assigneeId *value.UserId
 }
 
 // Create new todo item.
-func NewTodoItem(id value.TodoItemId, title value.TodoItemTitle, description value.TodoItemDescription, isDone bool, userId value.UserId) *TodoItem {
-	return &TodoItem{id, title, description, isDone, userId}
+func NewTodoItem(id value.TodoItemId, title value.TodoItemTitle, description value.TodoItemDescription, isDone bool, userId value.UserId, projectId string, assigneeId *value.UserId) *TodoItem {
+	return &TodoItem{id, title, description, isDone, userId, projectId, assigneeId}
 }
 
 // Get id of todo item.
@@ -45,6 +49,31 @@ func (t *TodoItem) UserId() value.UserId {
 	return t.userId
 }
 
+// Get project todo item is shared in. Empty if personal.
+func (t *TodoItem) ProjectId() string {
+	return t.projectId
+}
+
+// Get user todo item is assigned to. nil if unassigned.
+func (t *TodoItem) AssigneeId() *value.UserId {
+	return t.assigneeId
+}
+
+// Check if todo item is assigned to user.
+func (t *TodoItem) IsAssignedTo(userId value.UserId) bool {
+	return t.assigneeId != nil && *t.assigneeId == userId
+}
+
+// Assign todo item to user.
+func (t *TodoItem) AssignTo(userId value.UserId) {
+	t.assigneeId = &userId
+}
+
+// Unassign todo item.
+func (t *TodoItem) Unassign() {
+	t.assigneeId = nil
+}
+
 // Check if other is same todo item.
 func (t *TodoItem) Is(other *TodoItem) bool {
 	return t.id == other.id
@@ -70,4 +99,33 @@ func (t *TodoItem) ChangeTitle(title string) {
 func (t *TodoItem) ChangeDescription(description string) {
 	newDescription := value.NewTodoItemDescription(description)
 	t.description = newDescription
-}
\ No newline at end of file
+}
+
+// Move todo item to project. Empty project makes it personal todo item of its user.
+func (t *TodoItem) MoveToProject(projectId string) {
+	t.projectId = projectId
+}
+
+// Copy todo item, e.g. to keep its state before changing it.
+func (t *TodoItem) Copy() *TodoItem {
+	return NewTodoItem(t.id, t.title, t.description, t.isDone, t.userId, t.projectId, t.assigneeId)
+}
+
+// Check if other todo item has same state, including its ID.
+func (t *TodoItem) Equals(other *TodoItem) bool {
+
+	if t.assigneeId == nil || other.assigneeId == nil {
+		if t.assigneeId != other.assigneeId {
+			return false
+		}
+	} else if *t.assigneeId != *other.assigneeId {
+		return false
+	}
+
+	return t.id == other.id &&
+		t.title == other.title &&
+		t.description == other.description &&
+		t.isDone == other.isDone &&
+		t.userId == other.userId &&
+		t.projectId == other.projectId
+}
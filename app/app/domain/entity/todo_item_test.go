@@ -16,6 +16,8 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description"),
 			false,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		other := entity.NewTodoItem(
 			value.NewTodoItemId("1"),
@@ -23,6 +25,8 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description2"),
 			false,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		gomega.Expect(todo.Is(other)).To(gomega.BeTrue())
 	})
@@ -34,6 +38,8 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description"),
 			false,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		todo.Complete()
 		gomega.Expect(todo.IsDone()).To(gomega.BeTrue())
@@ -46,6 +52,8 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description"),
 			true,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		todo.Uncomplete()
 		gomega.Expect(todo.IsDone()).To(gomega.BeFalse())
@@ -58,6 +66,8 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description"),
 			false,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		todo.ChangeTitle("title2")
 		gomega.Expect(todo.Title() == value.NewTodoItemTitle("title2")).To(gomega.BeTrue())
@@ -70,8 +80,27 @@ var _ = ginkgo.Describe("TodoItem test", func() {
 			value.NewTodoItemDescription("description"),
 			false,
 			value.NewUserId("1"),
+			"",
+			nil,
 		)
 		todo.ChangeDescription("description2")
 		gomega.Expect(todo.Description() == value.NewTodoItemDescription("description2")).To(gomega.BeTrue())
 	})
+
+	ginkgo.It("should assign and unassign todo item", func() {
+		todo := entity.NewTodoItem(
+			value.NewTodoItemId("1"),
+			value.NewTodoItemTitle("title"),
+			value.NewTodoItemDescription("description"),
+			false,
+			value.NewUserId("1"),
+			"",
+			nil,
+		)
+		todo.AssignTo(value.NewUserId("2"))
+		gomega.Expect(todo.IsAssignedTo(value.NewUserId("2"))).To(gomega.BeTrue())
+		gomega.Expect(todo.IsAssignedTo(value.NewUserId("1"))).To(gomega.BeFalse())
+		todo.Unassign()
+		gomega.Expect(todo.AssigneeId()).To(gomega.BeNil())
+	})
 })
\ No newline at end of file
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Fields of todo item merged one by one on sync.
+const (
+	TodoFieldTitle = "title"
+	TodoFieldDescription = "description"
+	TodoFieldIsDone = "isDone"
+)
+
+// Outcomes of client operation on sync.
+const (
+	TodoSyncApplied = "applied"
+	TodoSyncRejected = "rejected"
+)
+
+// Time field of todo item was written and by what. Later write wins.
+type TodoClock struct {
+	changedAt time.Time
+	// ID of client operation wrote field. Empty if written through API.
+	origin string
+}
+
+// Create new todo clock.
+func NewTodoClock(changedAt time.Time, origin string) TodoClock {
+	return TodoClock{changedAt, origin}
+}
+
+// Get time field was written.
+func (c TodoClock) ChangedAt() time.Time {
+	return c.changedAt
+}
+
+// Get ID of client operation wrote field. Empty if written through API.
+func (c TodoClock) Origin() string {
+	return c.origin
+}
+
+// Check if write with this clock wins over write with other.
+// Writes at same time are ordered by origin, so every replica resolves them alike.
+func (c TodoClock) After(other TodoClock) bool {
+
+	if !c.changedAt.Equal(other.changedAt) {
+		return c.changedAt.After(other.changedAt)
+	}
+
+	return c.origin > other.origin
+}
+
+// Todo item as of its last change, for sync.
+type TodoRevision struct {
+	todoId value.TodoItemId
+	// nil if deleted.
+	todo *TodoItem
+	// Clocks of todo fields by field name. Empty if deleted.
+	clocks map[string]TodoClock
+	// Sequence number of last change. Grows with every change of any todo item. 0 if not saved yet.
+	seq int64
+}
+
+// Create new todo revision.
+func NewTodoRevision(todoId value.TodoItemId, todo *TodoItem, clocks map[string]TodoClock, seq int64) *TodoRevision {
+
+	if clocks == nil {
+		clocks = make(map[string]TodoClock)
+	}
+
+	return &TodoRevision{todoId, todo, clocks, seq}
+}
+
+// Get ID of todo item.
+func (r *TodoRevision) TodoId() value.TodoItemId {
+	return r.todoId
+}
+
+// Get todo item. nil if deleted.
+func (r *TodoRevision) Todo() *TodoItem {
+	return r.todo
+}
+
+// Get clocks of todo fields by field name.
+func (r *TodoRevision) Clocks() map[string]TodoClock {
+	return r.clocks
+}
+
+// Get sequence number of last change. 0 if not saved yet.
+func (r *TodoRevision) Seq() int64 {
+	return r.seq
+}
+
+// Check if todo item is deleted. Deleted todo items stay deleted on sync.
+func (r *TodoRevision) IsDeleted() bool {
+	return r.todo == nil
+}
+
+// Take field for write with given clock if it wins over last write of field.
+func (r *TodoRevision) Claim(field string, clock TodoClock) bool {
+
+	if current, ok := r.clocks[field]; ok && !clock.After(current) {
+		return false
+	}
+
+	r.clocks[field] = clock
+
+	return true
+}
+
+// Outcome of client operation, kept so retried operation is not applied twice.
+type TodoSyncOperation struct {
+	// ID given by client.
+	id string
+	userId value.UserId
+	// ID of todo item operated on, as given by client.
+	todoId string
+	// One of TodoSync constants.
+	status string
+	// Reason of rejection. Empty if applied.
+	message string
+}
+
+// Create new todo sync operation.
+func NewTodoSyncOperation(id string, userId value.UserId, todoId string, status string, message string) *TodoSyncOperation {
+	return &TodoSyncOperation{id, userId, todoId, status, message}
+}
+
+// Get ID given by client.
+func (o *TodoSyncOperation) Id() string {
+	return o.id
+}
+
+// Get user sent operation.
+func (o *TodoSyncOperation) UserId() value.UserId {
+	return o.userId
+}
+
+// Get ID of todo item operated on, as given by client.
+func (o *TodoSyncOperation) TodoId() string {
+	return o.todoId
+}
+
+// Get outcome.
+func (o *TodoSyncOperation) Status() string {
+	return o.status
+}
+
+// Get reason of rejection. Empty if applied.
+func (o *TodoSyncOperation) Message() string {
+	return o.message
+}
@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("TodoSync test", func() {
+
+	now := time.Now()
+
+	ginkgo.It("should order clocks by time then origin", func() {
+		gomega.Expect(entity.NewTodoClock(now.Add(time.Second), "a").After(entity.NewTodoClock(now, "b"))).To(gomega.BeTrue())
+		gomega.Expect(entity.NewTodoClock(now, "b").After(entity.NewTodoClock(now, "a"))).To(gomega.BeTrue())
+		gomega.Expect(entity.NewTodoClock(now, "a").After(entity.NewTodoClock(now, "b"))).To(gomega.BeFalse())
+		gomega.Expect(entity.NewTodoClock(now, "a").After(entity.NewTodoClock(now, "a"))).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should claim field for later write only", func() {
+		revision := entity.NewTodoRevision(value.NewTodoItemId("1"), nil, map[string]entity.TodoClock{
+			entity.TodoFieldTitle: entity.NewTodoClock(now, ""),
+		}, 1)
+		gomega.Expect(revision.Claim(entity.TodoFieldTitle, entity.NewTodoClock(now.Add(-time.Second), "op-1"))).To(gomega.BeFalse())
+		gomega.Expect(revision.Claim(entity.TodoFieldTitle, entity.NewTodoClock(now, "op-1"))).To(gomega.BeTrue())
+		gomega.Expect(revision.Clocks()[entity.TodoFieldTitle].Origin()).To(gomega.Equal("op-1"))
+		gomega.Expect(revision.Claim(entity.TodoFieldIsDone, entity.NewTodoClock(now.Add(-time.Hour), "op-2"))).To(gomega.BeTrue())
+		gomega.Expect(revision.IsDeleted()).To(gomega.BeTrue())
+	})
+})
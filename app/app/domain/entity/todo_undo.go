@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// State of todo item before and after mutation.
+type TodoChange struct {
+	// State before mutation. nil if todo item was added.
+	before *TodoItem
+	// State after mutation. nil if todo item was deleted.
+	after *TodoItem
+}
+
+// Create new todo change.
+func NewTodoChange(before *TodoItem, after *TodoItem) *TodoChange {
+	return &TodoChange{before, after}
+}
+
+// Get state before mutation. nil if todo item was added.
+func (c *TodoChange) Before() *TodoItem {
+	return c.before
+}
+
+// Get state after mutation. nil if todo item was deleted.
+func (c *TodoChange) After() *TodoItem {
+	return c.after
+}
+
+// Get id of changed todo item.
+func (c *TodoChange) TodoId() value.TodoItemId {
+
+	if c.before != nil {
+		return c.before.Id()
+	}
+
+	return c.after.Id()
+}
+
+// Undo of todo mutation. Undoing restores todo items to their state before mutation.
+type TodoUndo struct {
+	// Hash of undo token.
+	tokenHash string
+	// User made mutation. Only they can undo it.
+	userId value.UserId
+	// Todo items changed by mutation.
+	changes []*TodoChange
+	// Expiration of undo token.
+	expiresAt time.Time
+	// Time undo token was used. nil if not used yet.
+	usedAt *time.Time
+}
+
+// Create new todo undo.
+func NewTodoUndo(tokenHash string, userId value.UserId, changes []*TodoChange, expiresAt time.Time, usedAt *time.Time) *TodoUndo {
+	return &TodoUndo{tokenHash, userId, changes, expiresAt, usedAt}
+}
+
+// Get hash of undo token.
+func (u *TodoUndo) TokenHash() string {
+	return u.tokenHash
+}
+
+// Get user made mutation.
+func (u *TodoUndo) UserId() value.UserId {
+	return u.userId
+}
+
+// Get todo items changed by mutation.
+func (u *TodoUndo) Changes() []*TodoChange {
+	return u.changes
+}
+
+// Get expiration of undo token.
+func (u *TodoUndo) ExpiresAt() time.Time {
+	return u.expiresAt
+}
+
+// Get time undo token was used.
+func (u *TodoUndo) UsedAt() *time.Time {
+	return u.usedAt
+}
+
+// Check if undo token can be used at given time.
+func (u *TodoUndo) IsUsable(now time.Time) bool {
+	return u.usedAt == nil && now.Before(u.expiresAt)
+}
+
+// Mark undo token as used.
+func (u *TodoUndo) Use(now time.Time) {
+	u.usedAt = &now
+}
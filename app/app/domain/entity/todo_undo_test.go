@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("TodoUndo test", func() {
+
+	todo := func() *entity.TodoItem {
+		return entity.NewTodoItem(
+			value.NewTodoItemId("1"),
+			value.NewTodoItemTitle("title"),
+			value.NewTodoItemDescription("description"),
+			false,
+			value.NewUserId("1"),
+			"",
+			nil,
+		)
+	}
+
+	ginkgo.It("should keep state of copied todo item", func() {
+		before := todo()
+		after := before.Copy()
+		after.Complete()
+		gomega.Expect(before.IsDone()).To(gomega.BeFalse())
+		gomega.Expect(before.Equals(after)).To(gomega.BeFalse())
+		after.Uncomplete()
+		gomega.Expect(before.Equals(after)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should compare assignee of todo items", func() {
+		before := todo()
+		after := before.Copy()
+		after.AssignTo(value.NewUserId("2"))
+		gomega.Expect(before.Equals(after)).To(gomega.BeFalse())
+		before.AssignTo(value.NewUserId("2"))
+		gomega.Expect(before.Equals(after)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should identify todo item of change", func() {
+		gomega.Expect(entity.NewTodoChange(nil, todo()).TodoId()).To(gomega.Equal(value.NewTodoItemId("1")))
+		gomega.Expect(entity.NewTodoChange(todo(), nil).TodoId()).To(gomega.Equal(value.NewTodoItemId("1")))
+	})
+
+	ginkgo.It("should be usable once before expiration", func() {
+		now := time.Now()
+		undo := entity.NewTodoUndo("hash", value.NewUserId("1"), nil, now.Add(time.Minute), nil)
+		gomega.Expect(undo.IsUsable(now)).To(gomega.BeTrue())
+		gomega.Expect(undo.IsUsable(now.Add(time.Minute))).To(gomega.BeFalse())
+		undo.Use(now)
+		gomega.Expect(undo.IsUsable(now)).To(gomega.BeFalse())
+	})
+})
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// TOTP two-factor authentication of user.
+type TwoFactor struct {
+	// User this belongs to.
+	userId value.UserId
+	// Base32 encoded TOTP secret.
+	secret string
+	// Enabled after user confirmed enrollment with valid code.
+	isEnabled bool
+	// Hashes of unused recovery codes.
+	recoveryCodeHashes []string
+	// Last time step accepted. Codes of this step or earlier are rejected.
+	lastUsedStep int64
+}
+
+// Create new two-factor authentication.
+func NewTwoFactor(userId value.UserId, secret string, isEnabled bool, recoveryCodeHashes []string, lastUsedStep int64) *TwoFactor {
+	return &TwoFactor{userId, secret, isEnabled, recoveryCodeHashes, lastUsedStep}
+}
+
+// Get user this belongs to.
+func (t *TwoFactor) UserId() value.UserId {
+	return t.userId
+}
+
+// Get TOTP secret.
+func (t *TwoFactor) Secret() string {
+	return t.secret
+}
+
+// Check if two-factor authentication is enabled.
+func (t *TwoFactor) IsEnabled() bool {
+	return t.isEnabled
+}
+
+// Get hashes of unused recovery codes.
+func (t *TwoFactor) RecoveryCodeHashes() []string {
+	return t.recoveryCodeHashes
+}
+
+// Get last time step accepted.
+func (t *TwoFactor) LastUsedStep() int64 {
+	return t.lastUsedStep
+}
+
+// Enable two-factor authentication with new recovery codes.
+func (t *TwoFactor) Enable(recoveryCodeHashes []string) {
+	t.isEnabled = true
+	t.recoveryCodeHashes = recoveryCodeHashes
+}
+
+// Accept code of time step. Returns false when step was already used.
+func (t *TwoFactor) UseStep(step int64) bool {
+	if step <= t.lastUsedStep {
+		return false
+	}
+	t.lastUsedStep = step
+	return true
+}
+
+// Consume recovery code by its hash. Returns false when not found.
+func (t *TwoFactor) UseRecoveryCode(hash string) bool {
+	i := slices.Index(t.recoveryCodeHashes, hash)
+	if i < 0 {
+		return false
+	}
+	t.recoveryCodeHashes = slices.Delete(slices.Clone(t.recoveryCodeHashes), i, i + 1)
+	return true
+}
@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("TwoFactor test", func() {
+
+	ginkgo.It("should enable with recovery codes", func() {
+		twoFactor := entity.NewTwoFactor(value.NewUserId("1"), "SECRET", false, nil, 0)
+		twoFactor.Enable([]string{"a", "b"})
+		gomega.Expect(twoFactor.IsEnabled()).To(gomega.BeTrue())
+		gomega.Expect(twoFactor.RecoveryCodeHashes()).To(gomega.Equal([]string{"a", "b"}))
+	})
+
+	ginkgo.It("should reject replayed step", func() {
+		twoFactor := entity.NewTwoFactor(value.NewUserId("1"), "SECRET", true, nil, 0)
+		gomega.Expect(twoFactor.UseStep(10)).To(gomega.BeTrue())
+		gomega.Expect(twoFactor.UseStep(10)).To(gomega.BeFalse())
+		gomega.Expect(twoFactor.UseStep(9)).To(gomega.BeFalse())
+		gomega.Expect(twoFactor.UseStep(11)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should consume recovery code once", func() {
+		twoFactor := entity.NewTwoFactor(value.NewUserId("1"), "SECRET", true, []string{"a", "b"}, 0)
+		gomega.Expect(twoFactor.UseRecoveryCode("a")).To(gomega.BeTrue())
+		gomega.Expect(twoFactor.UseRecoveryCode("a")).To(gomega.BeFalse())
+		gomega.Expect(twoFactor.RecoveryCodeHashes()).To(gomega.Equal([]string{"b"}))
+	})
+})
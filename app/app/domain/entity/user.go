@@ -12,11 +12,15 @@ type User struct {
 	email value.Email
 	// Password of user.
 	password value.Password
+	// Role of user.
+	role value.Role
+	// Disabled user cannot login.
+	isDisabled bool
 }
 
 // Create new user.
-func NewUser(id value.UserId, userName value.UserName, email value.Email, password value.Password) *User {
-	return &User{ id, userName, email, password }
+func NewUser(id value.UserId, userName value.UserName, email value.Email, password value.Password, role value.Role, isDisabled bool) *User {
+	return &User{ id, userName, email, password, role, isDisabled }
 }
 
 // Get user ID.
@@ -39,6 +43,21 @@ func (u *User) Password() value.Password {
 	return u.password
 }
 
+// Get role.
+func (u *User) Role() value.Role {
+	return u.role
+}
+
+// Check if user is admin.
+func (u *User) IsAdmin() bool {
+	return u.role == value.RoleAdmin
+}
+
+// Check if user is disabled.
+func (u *User) IsDisabled() bool {
+	return u.isDisabled
+}
+
 // Rename user.
 func (u *User) Rename(name string) {
 	userName := value.NewUserName(name)
@@ -56,6 +75,21 @@ func (u *User) ChangePassword(password string) {
 	u.password = newPassword
 }
 
+// Change role.
+func (u *User) ChangeRole(role value.Role) {
+	u.role = role
+}
+
+// Disable user.
+func (u *User) Disable() {
+	u.isDisabled = true
+}
+
+// Enable disabled user.
+func (u *User) Enable() {
+	u.isDisabled = false
+}
+
 // Check if other is same user.
 func (u *User) Is(other *User) bool {
 	return u.id == other.id && u.email == other.email
@@ -15,12 +15,16 @@ var _ = ginkgo.Describe("User test", func() {
 			value.NewUserName("user_name_1"),
 			value.NewEmail("example@test.com"),
 			value.NewPassword("password"),
+			value.RoleUser,
+			false,
 		)
 		other := entity.NewUser(
 			value.NewUserId("1"),
 			value.NewUserName("user_name_1"),
 			value.NewEmail("example@test.com"),
 			value.NewPassword("password"),
+			value.RoleUser,
+			false,
 		)
 		gomega.Expect(user.Is(other)).To(gomega.BeTrue())
 	})
@@ -31,6 +35,8 @@ var _ = ginkgo.Describe("User test", func() {
 			value.NewUserName("user_name_1"),
 			value.NewEmail("example@test.com"),
 			value.NewPassword("password"),
+			value.RoleUser,
+			false,
 		)
 		user.Rename("user_name_2")
 		gomega.Expect(user.UserName() == value.NewUserName("user_name_2")).To(gomega.BeTrue())
@@ -42,9 +48,40 @@ var _ = ginkgo.Describe("User test", func() {
 			value.NewUserName("user_name_1"),
 			value.NewEmail("example@test.com"),
 			value.NewPassword("password"),
+			value.RoleUser,
+			false,
 		)
 		user.ChangeEmail("example@test2.com")
 		gomega.Expect(user.Email() == value.NewEmail("example@test2.com")).To(gomega.BeTrue())
 		gomega.Expect(user.Email() == value.NewEmail("example@test.com")).To(gomega.BeFalse())
 	})
+
+	ginkgo.It("should disable and enable user", func() {
+		user := entity.NewUser(
+			value.NewUserId("1"),
+			value.NewUserName("user_name_1"),
+			value.NewEmail("example@test.com"),
+			value.NewPassword("password"),
+			value.RoleUser,
+			false,
+		)
+		user.Disable()
+		gomega.Expect(user.IsDisabled()).To(gomega.BeTrue())
+		user.Enable()
+		gomega.Expect(user.IsDisabled()).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should change role", func() {
+		user := entity.NewUser(
+			value.NewUserId("1"),
+			value.NewUserName("user_name_1"),
+			value.NewEmail("example@test.com"),
+			value.NewPassword("password"),
+			value.RoleUser,
+			false,
+		)
+		gomega.Expect(user.IsAdmin()).To(gomega.BeFalse())
+		user.ChangeRole(value.RoleAdmin)
+		gomega.Expect(user.IsAdmin()).To(gomega.BeTrue())
+	})
 })
\ No newline at end of file
@@ -23,4 +23,9 @@ func NewEmail(value string) Email {
 // Get value of email.
 func (e Email) Value() string {
 	return e.value
-}
\ No newline at end of file
+}
+// Check if value is valid email.
+func IsEmail(value string) bool {
+	matched, _ := regexp.MatchString("^[^\\s@]+@[^\\s@]+\\.[^\\s@]+$", strings.TrimSpace(value))
+	return matched
+}
@@ -0,0 +1,47 @@
+package value
+
+import (
+	"strings"
+)
+
+// Permission of user on shared project.
+type Permission struct {
+	value string
+	// Higher rank includes permissions of lower ranks.
+	rank int
+}
+
+var (
+	// Can read todo items.
+	PermissionViewer = Permission{"viewer", 1}
+	// Can also add, change and delete todo items.
+	PermissionEditor = Permission{"editor", 2}
+	// Can also manage members and project itself.
+	PermissionOwner = Permission{"owner", 3}
+)
+
+func NewPermission(value string) Permission {
+	value = strings.TrimSpace(value)
+	for _, p := range []Permission{PermissionViewer, PermissionEditor, PermissionOwner} {
+		if p.value == value {
+			return p
+		}
+	}
+	panic("invalid permission")
+}
+
+// Check if value is valid permission.
+func IsPermission(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == PermissionViewer.value || value == PermissionEditor.value || value == PermissionOwner.value
+}
+
+// Get value of permission.
+func (p Permission) Value() string {
+	return p.value
+}
+
+// Check if permission includes required permission.
+func (p Permission) Allows(required Permission) bool {
+	return p.rank >= required.rank
+}
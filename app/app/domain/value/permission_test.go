@@ -0,0 +1,27 @@
+package value_test
+
+import (
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Permission test", func() {
+
+	ginkgo.It("should panic on unknown permission", func() {
+		gomega.Expect(func() { value.NewPermission("admin") }).To(gomega.Panic())
+		gomega.Expect(value.IsPermission("admin")).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should equal when same value", func() {
+		gomega.Expect(value.NewPermission("editor") == value.PermissionEditor).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should include lower permissions", func() {
+		gomega.Expect(value.PermissionOwner.Allows(value.PermissionEditor)).To(gomega.BeTrue())
+		gomega.Expect(value.PermissionEditor.Allows(value.PermissionEditor)).To(gomega.BeTrue())
+		gomega.Expect(value.PermissionEditor.Allows(value.PermissionViewer)).To(gomega.BeTrue())
+		gomega.Expect(value.PermissionViewer.Allows(value.PermissionEditor)).To(gomega.BeFalse())
+		gomega.Expect(value.PermissionEditor.Allows(value.PermissionOwner)).To(gomega.BeFalse())
+	})
+})
@@ -0,0 +1,38 @@
+package value
+
+import (
+	"strings"
+)
+
+type Role struct {
+	value string
+}
+
+var (
+	// Regular user.
+	RoleUser = Role{"user"}
+	// Operator managing users of instance.
+	RoleAdmin = Role{"admin"}
+)
+
+func NewRole(value string) Role {
+	value = strings.TrimSpace(value)
+	switch value {
+	case RoleUser.value:
+		return RoleUser
+	case RoleAdmin.value:
+		return RoleAdmin
+	}
+	panic("invalid role")
+}
+
+// Check if value is valid role.
+func IsRole(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == RoleUser.value || value == RoleAdmin.value
+}
+
+// Get value of role.
+func (r Role) Value() string {
+	return r.value
+}
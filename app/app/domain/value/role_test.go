@@ -0,0 +1,24 @@
+package value_test
+
+import (
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Role test", func() {
+
+	ginkgo.It("should panic on unknown role", func() {
+		gomega.Expect(func() { value.NewRole("root") }).To(gomega.Panic())
+		gomega.Expect(value.IsRole("root")).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should equal when same value", func() {
+		gomega.Expect(value.NewRole("admin") == value.RoleAdmin).To(gomega.BeTrue())
+		gomega.Expect(value.NewRole("user") == value.RoleUser).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should get value", func() {
+		gomega.Expect(value.RoleAdmin.Value()).To(gomega.Equal("admin"))
+	})
+})
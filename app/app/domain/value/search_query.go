@@ -0,0 +1,132 @@
+package value
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Max characters of search query.
+const MaxSearchQueryLength = 256
+
+// Max terms of search query.
+const MaxSearchTerms = 16
+
+// Full-text search query.
+//
+// Words must all match. "quoted words" must match as phrase
+// and a word ending with * matches as prefix, e.g. `deploy* "release notes"`.
+type SearchQuery struct {
+	value string
+	terms []SearchTerm
+}
+
+// Term of search query. Single word or phrase.
+type SearchTerm struct {
+	// Lowercase words consisting of letters and digits only.
+	words []string
+	// Last word matches as prefix.
+	prefix bool
+}
+
+func NewSearchQuery(value string) SearchQuery {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > MaxSearchQueryLength {
+		panic("search query is too long")
+	}
+	terms := parseSearchTerms(value)
+	if len(terms) == 0 || len(terms) > MaxSearchTerms {
+		panic("invalid search query")
+	}
+	return SearchQuery{value, terms}
+}
+
+// Check if value is valid search query.
+func IsSearchQuery(value string) bool {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > MaxSearchQueryLength {
+		return false
+	}
+	terms := parseSearchTerms(value)
+	return len(terms) > 0 && len(terms) <= MaxSearchTerms
+}
+
+// Get query as entered.
+func (q SearchQuery) Value() string {
+	return q.value
+}
+
+// Get terms of query.
+func (q SearchQuery) Terms() []SearchTerm {
+	return q.terms
+}
+
+// Get words of term.
+func (t SearchTerm) Words() []string {
+	return t.words
+}
+
+// Check if last word matches as prefix.
+func (t SearchTerm) IsPrefix() bool {
+	return t.prefix
+}
+
+// Check if term is phrase of several words.
+func (t SearchTerm) IsPhrase() bool {
+	return len(t.words) > 1
+}
+
+// Split text into lowercase words of letters and digits.
+func SearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func parseSearchTerms(value string) []SearchTerm {
+
+	terms := make([]SearchTerm, 0)
+
+	add := func(text string, prefix bool) {
+		if words := SearchWords(text); len(words) > 0 {
+			terms = append(terms, SearchTerm{words, prefix})
+		}
+	}
+
+	for value != "" {
+
+		value = strings.TrimLeftFunc(value, unicode.IsSpace)
+
+		if strings.HasPrefix(value, `"`) {
+
+			// Unclosed quote runs to end of query.
+			end := strings.Index(value[1:], `"`)
+
+			if end < 0 {
+				add(value[1:], false)
+				break
+			}
+
+			add(value[1:end + 1], false)
+
+			value = value[end + 2:]
+
+			continue
+		}
+
+		end := strings.IndexFunc(value, unicode.IsSpace)
+
+		if end < 0 {
+			end = len(value)
+		}
+
+		// Hyphenated and dotted words like "e-mail" match as phrase.
+		word := value[:end]
+
+		add(word, strings.HasSuffix(word, "*"))
+
+		value = value[end:]
+	}
+
+	return terms
+}
@@ -0,0 +1,49 @@
+package value_test
+
+import (
+	"strings"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("SearchQuery test", func() {
+
+	ginkgo.It("should parse words, prefixes and phrases", func() {
+
+		terms := value.NewSearchQuery(`Deploy* "Release  notes" e-mail`).Terms()
+
+		gomega.Expect(terms).To(gomega.HaveLen(3))
+
+		gomega.Expect(terms[0].Words()).To(gomega.Equal([]string{"deploy"}))
+		gomega.Expect(terms[0].IsPrefix()).To(gomega.BeTrue())
+		gomega.Expect(terms[0].IsPhrase()).To(gomega.BeFalse())
+
+		gomega.Expect(terms[1].Words()).To(gomega.Equal([]string{"release", "notes"}))
+		gomega.Expect(terms[1].IsPrefix()).To(gomega.BeFalse())
+		gomega.Expect(terms[1].IsPhrase()).To(gomega.BeTrue())
+
+		gomega.Expect(terms[2].Words()).To(gomega.Equal([]string{"e", "mail"}))
+	})
+
+	ginkgo.It("should drop syntax characters", func() {
+
+		terms := value.NewSearchQuery(`fix:* & !bug | "unclosed`).Terms()
+
+		gomega.Expect(terms).To(gomega.HaveLen(3))
+		gomega.Expect(terms[0].Words()).To(gomega.Equal([]string{"fix"}))
+		gomega.Expect(terms[1].Words()).To(gomega.Equal([]string{"bug"}))
+		gomega.Expect(terms[2].Words()).To(gomega.Equal([]string{"unclosed"}))
+	})
+
+	ginkgo.It("should panic on query without words", func() {
+		gomega.Expect(func() { value.NewSearchQuery(` "" * & `) }).To(gomega.Panic())
+		gomega.Expect(value.IsSearchQuery(` "" * & `)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should reject too long query", func() {
+		gomega.Expect(value.IsSearchQuery(strings.Repeat("a", 257))).To(gomega.BeFalse())
+		gomega.Expect(value.IsSearchQuery(strings.Repeat("a ", 17))).To(gomega.BeFalse())
+	})
+})
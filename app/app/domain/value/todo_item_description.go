@@ -1,6 +1,12 @@
 package value
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum number of characters of todo item description.
+const MaxTodoItemDescriptionLength = 255
 
 // Description of ToDo item.
 type TodoItemDescription struct {
@@ -19,4 +25,10 @@ func NewTodoItemDescription(value string) TodoItemDescription {
 // Get value of todo item description.
 func (t TodoItemDescription) Value() string {
 	return t.value
-}
\ No newline at end of file
+}
+
+// Check if value is valid todo item description.
+func IsTodoItemDescription(value string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && utf8.RuneCountInString(value) <= MaxTodoItemDescriptionLength
+}
@@ -1,6 +1,8 @@
 package value_test
 
 import (
+	"strings"
+
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -22,4 +24,11 @@ var _ = ginkgo.Describe("TodoItemDescription test", func() {
 		description := value.NewTodoItemDescription("description")
 		gomega.Expect(description.Value()).To(gomega.Equal("description"))
 	})
-})
\ No newline at end of file
+
+	ginkgo.It("should check description", func() {
+		gomega.Expect(value.IsTodoItemDescription(" description ")).To(gomega.BeTrue())
+		gomega.Expect(value.IsTodoItemDescription(strings.Repeat("あ", value.MaxTodoItemDescriptionLength))).To(gomega.BeTrue())
+		gomega.Expect(value.IsTodoItemDescription(strings.Repeat("あ", value.MaxTodoItemDescriptionLength + 1))).To(gomega.BeFalse())
+		gomega.Expect(value.IsTodoItemDescription("  ")).To(gomega.BeFalse())
+	})
+})
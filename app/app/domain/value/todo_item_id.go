@@ -1,6 +1,7 @@
 package value
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -20,4 +21,9 @@ func NewTodoItemId(value string) TodoItemId {
 // Get value of todo item ID.
 func (t TodoItemId) Value() string {
 	return t.value
-}
\ No newline at end of file
+}
+// Check if value is valid ID for new todo item, as generated by clients.
+func IsTodoItemId(value string) bool {
+	matched, _ := regexp.MatchString("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$", value)
+	return matched
+}
@@ -22,6 +22,10 @@ var _ = ginkgo.Describe("TodoItemId test", func() {
         id := value.NewTodoItemId("item-123")
         gomega.Expect(id.Value()).To(gomega.Equal("item-123"))
     })
-})
-
 
+    ginkgo.It("should check client generated ID", func() {
+        gomega.Expect(value.IsTodoItemId("0b7e7f38-5c1e-4c52-9a57-1f0d8c6b2f4e")).To(gomega.BeTrue())
+        gomega.Expect(value.IsTodoItemId("item-123")).To(gomega.BeFalse())
+        gomega.Expect(value.IsTodoItemId("")).To(gomega.BeFalse())
+    })
+})
@@ -1,6 +1,12 @@
 package value
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum number of characters of todo item title.
+const MaxTodoItemTitleLength = 255
 
 // Title of ToDo item.
 type TodoItemTitle struct {
@@ -19,4 +25,10 @@ func NewTodoItemTitle(value string) TodoItemTitle {
 // Get value of todo item title.
 func (t TodoItemTitle) Value() string {
 	return t.value
-}
\ No newline at end of file
+}
+
+// Check if value is valid todo item title.
+func IsTodoItemTitle(value string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && utf8.RuneCountInString(value) <= MaxTodoItemTitleLength
+}
@@ -1,6 +1,8 @@
 package value_test
 
 import (
+	"strings"
+
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -22,4 +24,11 @@ var _ = ginkgo.Describe("TodoItemTitle test", func() {
 		title := value.NewTodoItemTitle("title")
 		gomega.Expect(title.Value()).To(gomega.Equal("title"))
 	})
-})
\ No newline at end of file
+
+	ginkgo.It("should check title", func() {
+		gomega.Expect(value.IsTodoItemTitle(" title ")).To(gomega.BeTrue())
+		gomega.Expect(value.IsTodoItemTitle(strings.Repeat("あ", value.MaxTodoItemTitleLength))).To(gomega.BeTrue())
+		gomega.Expect(value.IsTodoItemTitle(strings.Repeat("あ", value.MaxTodoItemTitleLength + 1))).To(gomega.BeFalse())
+		gomega.Expect(value.IsTodoItemTitle("  ")).To(gomega.BeFalse())
+	})
+})
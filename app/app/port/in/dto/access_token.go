@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type AccessTokenDto struct {
+	Id         string
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	// nil if access token never expires.
+	ExpiresAt  *time.Time
+	// nil if access token was never used.
+	LastUsedAt *time.Time
+}
+
+type CreateAccessTokenCommand struct {
+	UserId    string
+	Name      string
+	Scopes    []string
+	// nil if access token never expires.
+	ExpiresAt *time.Time
+}
+
+// Result of access token creation.
+type CreatedAccessTokenDto struct {
+	AccessToken *AccessTokenDto
+	// Plain access token. Shown only once.
+	Token string
+}
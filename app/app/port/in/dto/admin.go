@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ListUsersQuery struct {
+	// Matches part of user name or email. Empty matches all.
+	Search string
+	Page PageQuery
+}
+
+// Usage of instance by user.
+type UserUsageDto struct {
+	User *UserDto
+	TodoItems int
+	CompletedTodoItems int
+	AccessTokens int
+	TwoFactorEnabled bool
+}
+
+type AuditLogDto struct {
+	Id string
+	// Empty if taken by system.
+	ActorId string
+	Action string
+	TargetUserId string
+	Detail string
+	CreatedAt time.Time
+}
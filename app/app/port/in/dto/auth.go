@@ -4,4 +4,31 @@ package dto
 type LoginCommand struct {
 	Email string
 	Password string
-}
\ No newline at end of file
+}
+
+// Result of login.
+type LoginResultDto struct {
+	User *UserDto
+	// Session token. Must be sent back on following requests.
+	// Empty while second factor is pending.
+	Token string
+	// Whether second factor is required to complete login.
+	TwoFactorRequired bool
+	// Token to answer with second factor.
+	ChallengeToken string
+}
+
+type ResetPasswordCommand struct {
+	Token string
+	Password string
+}
+
+// Authenticated user of request.
+type PrincipalDto struct {
+	UserId string
+	Role string
+	// Scopes granted to request.
+	Scopes []string
+	// ID of access token authenticating request. Empty when authenticated by session.
+	AccessTokenId string
+}
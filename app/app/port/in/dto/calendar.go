@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// Calendar feed of user. Token is only known when feed is created.
+type CalendarFeedDto struct {
+	Token string
+	CreatedAt time.Time
+}
+
+// Todo items of calendar feed.
+type CalendarFeedTodosDto struct {
+	UserId string
+	Todos []*TodoItemDto
+}
@@ -0,0 +1,15 @@
+package dto
+
+type PageQuery struct {
+	// Page number starting from 1.
+	Page int
+	PerPage int
+}
+
+// Page of items with total count.
+type PageDto[T any] struct {
+	Items []T
+	Total int
+	Page int
+	PerPage int
+}
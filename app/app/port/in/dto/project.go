@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+type ProjectDto struct {
+	Id string
+	Name string
+	// Permission of requesting user.
+	Permission string
+	CreatedAt time.Time
+}
+
+type ProjectMemberDto struct {
+	UserId string
+	UserName string
+	Email string
+	Permission string
+}
+
+type CreateProjectCommand struct {
+	UserId string
+	Name string
+}
+
+type RenameProjectCommand struct {
+	UserId string
+	ProjectId string
+	Name string
+}
+
+type ChangeProjectMemberCommand struct {
+	UserId string
+	ProjectId string
+	MemberId string
+	Permission string
+}
+
+type InviteProjectMemberCommand struct {
+	UserId string
+	ProjectId string
+	Email string
+	Permission string
+}
+
+type AcceptProjectInviteCommand struct {
+	UserId string
+	Token string
+}
@@ -1,17 +1,27 @@
 package dto
 
+import "time"
+
 type TodoItemDto struct {
 	Id string
 	Title string
 	Description string
 	IsDone bool
 	UserId string
+	// Empty if personal.
+	ProjectId string
+	// Empty if unassigned.
+	AssigneeId string
 }
 
 type AddTodoCommand struct {
 	UserId string
 	Title string
 	Description string
+	// Project to add todo item to. Empty if personal.
+	ProjectId string
+	// Add todo item already done.
+	IsDone bool
 }
 
 type UpdateTodoCommand struct {
@@ -19,4 +29,19 @@ type UpdateTodoCommand struct {
 	Title string
 	Description string
 	UserId string
-}
\ No newline at end of file
+}
+
+type AssignTodoCommand struct {
+	UserId string
+	TodoId string
+	AssigneeId string
+}
+
+// Change of todo item assignee.
+type TodoAssignmentDto struct {
+	Id string
+	// Empty if unassigned.
+	AssigneeId string
+	AssignedBy string
+	CreatedAt time.Time
+}
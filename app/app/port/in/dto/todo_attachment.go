@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+type TodoAttachmentDto struct {
+	Id string
+	TodoId string
+	UploaderId string
+	FileName string
+	ContentType string
+	Size int64
+	CreatedAt time.Time
+}
+
+type UploadTodoAttachmentCommand struct {
+	UserId string
+	TodoId string
+	FileName string
+	// Size of content in bytes as declared by client.
+	Size int64
+	Content io.Reader
+}
+
+// Attachment opened for download. Caller closes Content.
+type TodoAttachmentContent struct {
+	Attachment *TodoAttachmentDto
+	Content io.ReadCloser
+}
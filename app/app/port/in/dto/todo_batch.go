@@ -0,0 +1,37 @@
+package dto
+
+// Operations of todo batch.
+const (
+	BatchOpComplete = "complete"
+	BatchOpUncomplete = "uncomplete"
+	BatchOpDelete = "delete"
+	BatchOpUpdate = "update"
+	BatchOpMove = "move"
+)
+
+type BatchTodoCommand struct {
+	UserId string
+	// Apply nothing if any operation fails. Otherwise apply those that succeed.
+	Atomic bool
+	Operations []*BatchTodoOperation
+}
+
+type BatchTodoOperation struct {
+	// One of BatchOp constants.
+	Op string
+	TodoId string
+	// New title for update. nil keeps title.
+	Title *string
+	// New description for update. nil keeps description.
+	Description *string
+	// Project to move to. Empty moves to personal todo items.
+	ProjectId string
+}
+
+// Outcome of operation, in order of operations.
+type BatchTodoResultDto struct {
+	Op string
+	TodoId string
+	// nil if operation succeeded.
+	Error error
+}
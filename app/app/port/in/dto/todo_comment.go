@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type TodoCommentDto struct {
+	Id string
+	TodoId string
+	AuthorId string
+	AuthorName string
+	// Body in Markdown, not sanitized.
+	Body string
+	// User names mentioned in body.
+	Mentions []string
+	CreatedAt time.Time
+	// nil if never edited.
+	EditedAt *time.Time
+}
+
+type AddTodoCommentCommand struct {
+	UserId string
+	TodoId string
+	Body string
+}
+
+type EditTodoCommentCommand struct {
+	UserId string
+	TodoId string
+	CommentId string
+	Body string
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// Change of todo item pushed to user.
+type TodoEventDto struct {
+	// Pass as last event ID to resume after this event.
+	Id string
+	// One of entity.TodoEvent constants.
+	Type string
+	// Todo item after change, or before change if deleted. nil if reset.
+	Todo *TodoItemDto
+	OccurredAt time.Time
+}
@@ -0,0 +1,24 @@
+package dto
+
+type ImportTodosCommand struct {
+	UserId string
+	Todos []*AddTodoCommand
+	// Check todo items without adding them.
+	DryRun bool
+}
+
+// Why todo item of import cannot be added.
+type ImportTodoErrorDto struct {
+	// Position of todo item in command, starting from 0.
+	Index int
+	Error error
+}
+
+type ImportedTodosDto struct {
+	// IDs of created todo items, in order of commands. Empty on dry run or errors.
+	TodoIds []string
+	// Token to undo import. Empty on dry run or errors.
+	UndoToken string
+	// Errors of todo items. Nothing is added if there are any.
+	Errors []*ImportTodoErrorDto
+}
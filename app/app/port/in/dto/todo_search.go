@@ -0,0 +1,19 @@
+package dto
+
+type SearchTodoCommand struct {
+	UserId string
+	Query string
+	Limit int
+}
+
+type TodoSearchResultDto struct {
+	Todo *TodoItemDto
+	Rank float64
+	Snippet []SnippetFragment
+}
+
+// Part of snippet, either matched or surrounding text.
+type SnippetFragment struct {
+	Text string
+	Highlighted bool
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// Operations of todo sync.
+const (
+	SyncOpUpsert = "upsert"
+	SyncOpDelete = "delete"
+)
+
+type SyncTodoCommand struct {
+	UserId string
+	// Token returned by last sync. Empty syncs from start.
+	Since string
+	// Changes made on client since last sync, in order made.
+	Operations []*SyncTodoOperation
+}
+
+type SyncTodoOperation struct {
+	// Generated by client, so retried operation applies once.
+	Id string
+	// One of SyncOp constants.
+	Op string
+	// Generated by client when it created todo item.
+	TodoId string
+	// New title. nil keeps title.
+	Title *string
+	// New description. nil keeps description.
+	Description *string
+	// New completion. nil keeps completion.
+	IsDone *bool
+	// Project of todo item created by operation. Empty if personal. Ignored for existing todo item.
+	ProjectId string
+	// Time change was made on client.
+	ChangedAt time.Time
+}
+
+// Outcome of operation, in order of operations.
+type SyncTodoResultDto struct {
+	Id string
+	TodoId string
+	// One of entity.TodoSync constants.
+	Status string
+	// Reason of rejection. Empty if applied.
+	Message string
+}
+
+// Todo item changed on server since last sync.
+type TodoSyncChangeDto struct {
+	TodoId string
+	// nil if deleted.
+	Todo *TodoItemDto
+}
+
+type SyncTodoDto struct {
+	Results []*SyncTodoResultDto
+	// Changes in order made, changes of this sync included.
+	Changes []*TodoSyncChangeDto
+	// Pass as since on next sync.
+	Token string
+	// More changes are left. Sync again with token to get them.
+	HasMore bool
+	// Projects user is member of. Local todo items of other projects are no longer accessible.
+	ProjectIds []string
+}
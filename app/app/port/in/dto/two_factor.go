@@ -0,0 +1,14 @@
+package dto
+
+type TwoFactorEnrollmentDto struct {
+	// Base32 encoded secret for manual entry.
+	Secret string
+	// otpauth:// URI for QR code.
+	Uri string
+}
+
+type VerifyLoginCommand struct {
+	ChallengeToken string
+	// TOTP code or recovery code.
+	Code string
+}
@@ -1,13 +1,34 @@
 package dto
 
+import "time"
+
 type UserDto struct {
 	Id string
 	UserName string
 	Email string
+	Role string
+	IsDisabled bool
 }
 
 type AddUserCommand struct {
 	UserName string
 	Email string
 	Password string
+}
+
+type ChangeUserInfoResultDto struct {
+	// Whether email change is waiting for confirmation by new address.
+	EmailChangePending bool
+}
+
+// All data of user for export.
+type UserExportDto struct {
+	User *UserDto
+	TodoItems []*TodoItemDto
+	ExportedAt time.Time
+}
+
+type AccountDeletionDto struct {
+	RequestedAt time.Time
+	ScheduledAt time.Time
 }
\ No newline at end of file
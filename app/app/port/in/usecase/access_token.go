@@ -0,0 +1,18 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type CreateAccessTokenUsecase interface {
+	// Create personal access token.
+	Create(command *dto.CreateAccessTokenCommand) (*dto.CreatedAccessTokenDto, error)
+}
+
+type ListAccessTokensUsecase interface {
+	// List personal access tokens of user.
+	List(userId string) ([]*dto.AccessTokenDto, error)
+}
+
+type RevokeAccessTokenUsecase interface {
+	// Revoke personal access token of user.
+	Revoke(userId string, accessTokenId string) error
+}
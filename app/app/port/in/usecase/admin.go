@@ -0,0 +1,43 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type ListUsersUsecase interface {
+	// Search users.
+	List(query *dto.ListUsersQuery) (*dto.PageDto[*dto.UserDto], error)
+}
+
+type InspectUserUsecase interface {
+	// Get usage of user. Recorded in audit log.
+	Inspect(actorId string, userId string) (*dto.UserUsageDto, error)
+}
+
+type DisableUserUsecase interface {
+	// Disable user and sign out all sessions. Recorded in audit log.
+	Disable(actorId string, userId string) error
+}
+
+type EnableUserUsecase interface {
+	// Enable disabled user. Recorded in audit log.
+	Enable(actorId string, userId string) error
+}
+
+type ForcePasswordResetUsecase interface {
+	// Mail password reset token to user and sign out all sessions. Recorded in audit log.
+	ForceReset(actorId string, userId string) error
+}
+
+type ChangeUserRoleUsecase interface {
+	// Change role of user. Recorded in audit log.
+	ChangeRole(actorId string, userId string, role string) error
+}
+
+type GrantAdminUsecase interface {
+	// Grant admin role to user on behalf of system. Recorded in audit log.
+	Grant(email string) error
+}
+
+type ListAuditLogsUsecase interface {
+	// List audit logs, newest first.
+	List(query *dto.PageQuery) (*dto.PageDto[*dto.AuditLogDto], error)
+}
@@ -6,5 +6,21 @@ import (
 
 type LoginUsecase interface {
 	// Login user.
-	Login(user *dto.LoginCommand) (*dto.UserDto, error)
-}
\ No newline at end of file
+	Login(user *dto.LoginCommand) (*dto.LoginResultDto, error)
+}
+
+type RequestPasswordResetUsecase interface {
+	// Send password reset token to email if registered.
+	RequestReset(email string) error
+}
+
+type ResetPasswordUsecase interface {
+	// Reset password with reset token.
+	Reset(command *dto.ResetPasswordCommand) error
+}
+
+type AuthenticateUsecase interface {
+	// Authenticate request by session token or personal access token.
+	// Returns nil if token is not valid.
+	Authenticate(token string) (*dto.PrincipalDto, error)
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type CalendarFeedUsecase interface {
+	// Get calendar feed of user without its token. nil if user has none.
+	Get(userId string) (*dto.CalendarFeedDto, error)
+	// Create calendar feed with new token. URL of previous feed stops working.
+	Rotate(userId string) (*dto.CalendarFeedDto, error)
+	// Delete calendar feed of user.
+	Revoke(userId string) error
+	// List todo items of calendar feed by its token.
+	ListTodos(token string) (*dto.CalendarFeedTodosDto, error)
+}
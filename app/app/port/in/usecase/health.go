@@ -0,0 +1,6 @@
+package usecase
+
+type CheckReadinessUsecase interface {
+	// Check application can serve requests, returning why not.
+	Check() error
+}
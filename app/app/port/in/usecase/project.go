@@ -0,0 +1,70 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type CreateProjectUsecase interface {
+	// Create new project owned by user.
+	Create(project *dto.CreateProjectCommand) (*dto.ProjectDto, error)
+}
+
+type ListProjectsUsecase interface {
+	// List projects user is member of.
+	List(userId string) ([]*dto.ProjectDto, error)
+}
+
+type GetProjectUsecase interface {
+	// Get project user is member of.
+	Get(userId string, projectId string) (*dto.ProjectDto, error)
+}
+
+type RenameProjectUsecase interface {
+	// Rename project. Owner only.
+	Rename(project *dto.RenameProjectCommand) error
+}
+
+type DeleteProjectUsecase interface {
+	// Delete project with its todo items. Owner only.
+	Delete(userId string, projectId string) error
+}
+
+type ListProjectTodosUsecase interface {
+	// List todo items of project.
+	List(userId string, projectId string) ([]*dto.TodoItemDto, error)
+}
+
+type ListProjectTodosBatchUsecase interface {
+	// List todo items of many projects at once, keyed by project ID.
+	// Projects user is not member of are left out.
+	ListMany(userId string, projectIds []string) (map[string][]*dto.TodoItemDto, error)
+}
+
+type ListProjectMembersUsecase interface {
+	// List members of project.
+	List(userId string, projectId string) ([]*dto.ProjectMemberDto, error)
+}
+
+type ListProjectMembersBatchUsecase interface {
+	// List members of many projects at once, keyed by project ID.
+	// Projects user is not member of are left out.
+	ListMany(userId string, projectIds []string) (map[string][]*dto.ProjectMemberDto, error)
+}
+
+type ChangeProjectMemberUsecase interface {
+	// Change permission of project member. Owner only.
+	Change(command *dto.ChangeProjectMemberCommand) error
+}
+
+type RemoveProjectMemberUsecase interface {
+	// Remove member from project. Owner only, except for leaving by oneself.
+	Remove(userId string, projectId string, memberId string) error
+}
+
+type InviteProjectMemberUsecase interface {
+	// Mail invite to project. Owner only.
+	Invite(command *dto.InviteProjectMemberCommand) error
+}
+
+type AcceptProjectInviteUsecase interface {
+	// Join project by invite token.
+	Accept(command *dto.AcceptProjectInviteCommand) (*dto.ProjectDto, error)
+}
@@ -3,13 +3,13 @@ package usecase
 import "github.com/kkatou7209/godo/app/port/in/dto"
 
 type AddTodoUsecase interface {
-	// Add new todo item.
-	Add(todo *dto.AddTodoCommand) error
+	// Add new todo item. Returns token to undo it.
+	Add(todo *dto.AddTodoCommand) (string, error)
 }
 
 type GetTodoUsecase interface {
-	// Get todo item.
-	Get(todoId string) (*dto.TodoItemDto, error)
+	// Get todo item user has access to.
+	Get(userId string, todoId string) (*dto.TodoItemDto, error)
 }
 
 type ListTodoUsecase interface {
@@ -18,21 +18,43 @@ type ListTodoUsecase interface {
 }
 
 type UpdateTodoUsecase interface {
-	// Update todo.
-	Update(*dto.UpdateTodoCommand) error
+	// Update todo. Returns token to undo it.
+	Update(*dto.UpdateTodoCommand) (string, error)
 }
 
 type CompleteTodoUsecase interface {
-	// Complete todo item.
-	Complete(userId string, todoId string) error
+	// Complete todo item. Returns token to undo it.
+	Complete(userId string, todoId string) (string, error)
 }
 
 type UncompleteTodoUsecase interface {
-	// Uncomplete todo item.
-	Uncomplete(userId string, todoId string) error
+	// Uncomplete todo item. Returns token to undo it.
+	Uncomplete(userId string, todoId string) (string, error)
 }
 
 type DeleteTodoUsecase interface {
-	// Delete todo item.
-	Delete(userId string, todoId string) error
-}
\ No newline at end of file
+	// Delete todo item. Returns token to undo it.
+	Delete(userId string, todoId string) (string, error)
+}
+
+type AssignTodoUsecase interface {
+	// Assign todo item to user having access to it.
+	// Returns token to undo it, empty if todo item was already assigned to user.
+	Assign(command *dto.AssignTodoCommand) (string, error)
+}
+
+type UnassignTodoUsecase interface {
+	// Unassign todo item.
+	// Returns token to undo it, empty if todo item was not assigned.
+	Unassign(userId string, todoId string) (string, error)
+}
+
+type ListAssignedTodosUsecase interface {
+	// List open todo items assigned to user.
+	List(userId string) ([]*dto.TodoItemDto, error)
+}
+
+type ListTodoAssignmentsUsecase interface {
+	// List assignee changes of todo item, oldest first.
+	List(userId string, todoId string) ([]*dto.TodoAssignmentDto, error)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type ListTodoAttachmentsUsecase interface {
+	// List attachments of todo item, oldest first.
+	List(userId string, todoId string) ([]*dto.TodoAttachmentDto, error)
+}
+
+type UploadTodoAttachmentUsecase interface {
+	// Attach file to todo item.
+	Upload(command *dto.UploadTodoAttachmentCommand) (*dto.TodoAttachmentDto, error)
+}
+
+type DownloadTodoAttachmentUsecase interface {
+	// Open attached file for reading.
+	Download(userId string, todoId string, attachmentId string) (*dto.TodoAttachmentContent, error)
+}
+
+type DeleteTodoAttachmentUsecase interface {
+	// Delete attached file. Uploader or owner of todo item only.
+	Delete(userId string, todoId string, attachmentId string) error
+}
@@ -0,0 +1,9 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type BatchTodoUsecase interface {
+	// Run operations on todo items in one transaction and return token to undo them.
+	// Results are returned along with validation.ErrBatchAborted when atomic batch fails.
+	Run(command *dto.BatchTodoCommand) ([]*dto.BatchTodoResultDto, string, error)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type ListTodoCommentsUsecase interface {
+	// List comments on todo item, oldest first.
+	List(userId string, todoId string) ([]*dto.TodoCommentDto, error)
+}
+
+type ListTodoCommentsBatchUsecase interface {
+	// List comments on many todo items at once, oldest first, keyed by todo item ID.
+	// Todo items user cannot see are left out.
+	ListMany(userId string, todoIds []string) (map[string][]*dto.TodoCommentDto, error)
+}
+
+type AddTodoCommentUsecase interface {
+	// Comment on todo item.
+	Add(command *dto.AddTodoCommentCommand) (*dto.TodoCommentDto, error)
+}
+
+type EditTodoCommentUsecase interface {
+	// Edit comment. Author only.
+	Edit(command *dto.EditTodoCommentCommand) (*dto.TodoCommentDto, error)
+}
+
+type DeleteTodoCommentUsecase interface {
+	// Delete comment. Author or owner of todo item only.
+	Delete(userId string, todoId string, commentId string) error
+}
@@ -0,0 +1,9 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type SubscribeTodoEventsUsecase interface {
+	// Subscribe to changes of todo items user has access to, resuming after last event ID if not empty.
+	// Events are received until cancel is called or channel is closed, when client should resume.
+	Subscribe(userId string, lastEventId string) (events <-chan *dto.TodoEventDto, cancel func(), err error)
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type ImportTodosUsecase interface {
+	// Check all todo items and add them at once if all are valid.
+	// Invalid todo items are returned with their errors, not as error.
+	Import(command *dto.ImportTodosCommand) (*dto.ImportedTodosDto, error)
+}
+
+type ExportTodosUsecase interface {
+	// Pass personal todo items of user and those of projects user is member of to each, a page at a time.
+	// Export stops at first error of each.
+	Export(userId string, each func(todo *dto.TodoItemDto) error) error
+}
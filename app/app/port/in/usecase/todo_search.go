@@ -0,0 +1,8 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type SearchTodoUsecase interface {
+	// Search todo items user can see, best match first.
+	Search(command *dto.SearchTodoCommand) ([]*dto.TodoSearchResultDto, error)
+}
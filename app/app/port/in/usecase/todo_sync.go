@@ -0,0 +1,9 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type SyncTodoUsecase interface {
+	// Apply changes made on client and return changes made on server since last sync.
+	// Fields conflicting with changes made elsewhere go to the latest change. Deleting wins over changing.
+	Sync(command *dto.SyncTodoCommand) (*dto.SyncTodoDto, error)
+}
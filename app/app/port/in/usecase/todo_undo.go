@@ -0,0 +1,7 @@
+package usecase
+
+type UndoTodoUsecase interface {
+	// Restore todo items changed by mutation to their state before it.
+	// Fails if any of them changed since.
+	Undo(userId string, token string) error
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import "github.com/kkatou7209/godo/app/port/in/dto"
+
+type EnrollTwoFactorUsecase interface {
+	// Start two-factor enrollment. Not enabled until confirmed.
+	Enroll(userId string) (*dto.TwoFactorEnrollmentDto, error)
+}
+
+type ConfirmTwoFactorUsecase interface {
+	// Confirm enrollment with code. Returns recovery codes, shown only once.
+	Confirm(userId string, code string) ([]string, error)
+}
+
+type DisableTwoFactorUsecase interface {
+	// Disable two-factor authentication.
+	Disable(userId string, password string) error
+}
+
+type VerifyLoginUsecase interface {
+	// Complete pending login with second factor.
+	Verify(command *dto.VerifyLoginCommand) (*dto.LoginResultDto, error)
+}
@@ -13,11 +13,41 @@ type GetUserUsecase interface {
 }
 
 type ChangeUserInfoUsecase interface {
-	// Change user info.
-	ChangeInfo(user *dto.UserDto) error
+	// Change user info. Email change is staged until new address confirms it.
+	ChangeInfo(user *dto.UserDto) (*dto.ChangeUserInfoResultDto, error)
 }
 
 type ChangeUserPasswordUsecase interface {
 	// Change user password.
 	ChangePassword(userId string, password string, oldPassword string) error
+}
+
+type ConfirmEmailChangeUsecase interface {
+	// Confirm email change with token sent to new address.
+	Confirm(token string) error
+}
+
+type RevertEmailChangeUsecase interface {
+	// Revert email change with token sent to old address.
+	Revert(token string) error
+}
+
+type ExportUserDataUsecase interface {
+	// Export profile and todo items of user.
+	Export(userId string) (*dto.UserExportDto, error)
+}
+
+type RequestAccountDeletionUsecase interface {
+	// Schedule account deletion after grace period.
+	RequestDeletion(userId string, password string) (*dto.AccountDeletionDto, error)
+}
+
+type CancelAccountDeletionUsecase interface {
+	// Cancel scheduled account deletion.
+	CancelDeletion(userId string) error
+}
+
+type PurgeDeletedAccountsUsecase interface {
+	// Permanently delete accounts whose grace period is over. Returns number of deleted accounts.
+	Purge() (int, error)
 }
\ No newline at end of file
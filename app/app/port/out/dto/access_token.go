@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateAccessTokenCommand struct {
+	UserId    value.UserId
+	Name      string
+	TokenHash string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
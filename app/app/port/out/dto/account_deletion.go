@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateAccountDeletionCommand struct {
+	UserId      value.UserId
+	RequestedAt time.Time
+	ScheduledAt time.Time
+}
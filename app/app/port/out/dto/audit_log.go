@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+type CreateAuditLogCommand struct {
+	// nil if taken by system.
+	ActorId      *value.UserId
+	Action       string
+	TargetUserId value.UserId
+	Detail       string
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateEmailChangeCommand struct {
+	UserId           value.UserId
+	OldEmail         value.Email
+	NewEmail         value.Email
+	ConfirmTokenHash string
+	RevertTokenHash  string
+	ExpiresAt        time.Time
+	RevertibleUntil  time.Time
+}
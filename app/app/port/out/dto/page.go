@@ -0,0 +1,7 @@
+package dto
+
+// Range of rows to fetch.
+type PageQuery struct {
+	Offset int
+	Limit  int
+}
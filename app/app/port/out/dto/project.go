@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateProjectCommand struct {
+	Name    string
+	// Becomes first owner of project.
+	OwnerId value.UserId
+}
+
+type CreateProjectInviteCommand struct {
+	ProjectId  string
+	Email      value.Email
+	Permission value.Permission
+	TokenHash  string
+	InvitedBy  value.UserId
+	ExpiresAt  time.Time
+}
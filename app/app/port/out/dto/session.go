@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateSessionCommand struct {
+	TokenHash string
+	UserId    value.UserId
+	ExpiresAt time.Time
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+type CreateTodoAssignmentCommand struct {
+	TodoId     value.TodoItemId
+	// nil if unassigned.
+	AssigneeId *value.UserId
+	AssignedBy value.UserId
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+type CreateTodoAttachmentCommand struct {
+	TodoId      value.TodoItemId
+	UploaderId  value.UserId
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+type CreateTodoCommentCommand struct {
+	TodoId   value.TodoItemId
+	AuthorId value.UserId
+	Body     string
+	Mentions []string
+}
@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type PublishTodoEventCommand struct {
+	UserId value.UserId
+	// One of entity.TodoEvent constants.
+	Type string
+	Todo *entity.TodoItem
+}
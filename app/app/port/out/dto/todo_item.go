@@ -1,6 +1,9 @@
 package dto
 
-import "github.com/kkatou7209/godo/app/domain/value"
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
 
 type TodoItemDto struct {
 	Id 			value.TodoItemId
@@ -13,4 +16,26 @@ type CreateTodoCommand struct {
 	UserId 		value.UserId
 	Title 		value.TodoItemTitle
 	Description value.TodoItemDescription
-}
\ No newline at end of file
+	// Empty if personal.
+	ProjectId 	string
+	IsDone 		bool
+}
+
+type ListTodoPageQuery struct {
+	UserId 		value.UserId
+	// Projects of which todo items are listed with personal ones of user.
+	ProjectIds 	[]string
+	// ID of last todo item of previous page. Empty for first page.
+	After 		string
+	Limit 		int
+}
+
+type TodoBatchCommand struct {
+	// Todo items to save as they are.
+	Updates 	[]*entity.TodoItem
+	Deletes 	[]value.TodoItemId
+	// Deleted todo items to put back with their IDs.
+	Restores 	[]*entity.TodoItem
+	// Sequence numbers of todo items as read, by ID. Those given are written only if unchanged since.
+	Seqs 		map[value.TodoItemId]int64
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Marks start of matched text in snippets.
+const SnippetHighlightStart = "\x02"
+
+// Marks end of matched text in snippets.
+const SnippetHighlightStop = "\x03"
+
+type SearchTodoQuery struct {
+	// Personal todo items of user are searched.
+	UserId     value.UserId
+	// Todo items of these projects are searched too.
+	ProjectIds []string
+	Query      value.SearchQuery
+	Limit      int
+}
+
+type TodoSearchHit struct {
+	Todo    *entity.TodoItem
+	// Higher is better.
+	Rank    float64
+	// Excerpt of matched text, matches enclosed in highlight marks.
+	Snippet string
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type ListTodoChangesQuery struct {
+	// Personal todo items of user are listed.
+	UserId     value.UserId
+	// Todo items of these projects are listed too.
+	ProjectIds []string
+	// Changes after this sequence number are listed. 0 lists all todo items.
+	Since      int64
+	Limit      int
+}
+
+type TodoSyncCommand struct {
+	// Todo items to add or overwrite along with clocks of their fields.
+	// Saved only if unchanged since read, as told by sequence number of revision.
+	Saves      []*entity.TodoRevision
+	// Todo items to delete, saved only if unchanged since read.
+	Deletes    []*entity.TodoRevision
+	// Outcomes of client operations.
+	Operations []*entity.TodoSyncOperation
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateTodoUndoCommand struct {
+	TokenHash string
+	UserId    value.UserId
+	Changes   []*entity.TodoChange
+	ExpiresAt time.Time
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CreateTwoFactorCommand struct {
+	UserId value.UserId
+	Secret string
+}
+
+type CreateLoginChallengeCommand struct {
+	TokenHash string
+	UserId    value.UserId
+	ExpiresAt time.Time
+}
@@ -6,4 +6,9 @@ type CreateUserCommand struct {
 	UserName value.UserName
 	Email value.Email
 	Password value.Password
-}
\ No newline at end of file
+}
+type ListUsersQuery struct {
+	// Matches part of user name or email. Empty matches all.
+	Search string
+	Page   PageQuery
+}
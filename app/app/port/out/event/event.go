@@ -0,0 +1,23 @@
+package event
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type TodoEventBus interface {
+	// Publish todo events to sessions of their users. IDs are given in order of events.
+	Publish(events []*dto.PublishTodoEventCommand) error
+	// Subscribe to todo events of user published after given event ID.
+	// Zero receives only events published from now on.
+	Subscribe(userId value.UserId, lastEventId int64) (TodoEventSubscription, error)
+}
+
+type TodoEventSubscription interface {
+	// Events in order they were published.
+	// Closed when subscription is closed, or when subscriber falls too far behind and should resume.
+	Events() <-chan *entity.TodoEvent
+	// Stop receiving events.
+	Close()
+}
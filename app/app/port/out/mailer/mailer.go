@@ -0,0 +1,8 @@
+package mailer
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+type Mailer interface {
+	// Send mail.
+	Send(to value.Email, subject string, body string) error
+}
@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateAccessTokenPersistence interface {
+	// Create new access token.
+	Create(token *dto.CreateAccessTokenCommand) error
+}
+
+type GetAccessTokenPersistence interface {
+	// Get access token by its ID.
+	Get(id string) (*entity.AccessToken, error)
+	// Get access token by hash of its token.
+	GetByTokenHash(tokenHash string) (*entity.AccessToken, error)
+}
+
+type ListAccessTokenPersistence interface {
+	// List access tokens of user.
+	List(userId value.UserId) ([]*entity.AccessToken, error)
+}
+
+type UpdateAccessTokenPersistence interface {
+	// Update access token.
+	Update(token *entity.AccessToken) error
+}
+
+type DeleteAccessTokenPersistence interface {
+	// Delete access token by its ID.
+	Delete(id string) error
+}
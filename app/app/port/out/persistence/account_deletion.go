@@ -0,0 +1,26 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateAccountDeletionPersistence interface {
+	// Create new account deletion.
+	Create(deletion *dto.CreateAccountDeletionCommand) error
+}
+
+type GetAccountDeletionPersistence interface {
+	// Get account deletion of user.
+	Get(userId value.UserId) (*entity.AccountDeletion, error)
+	// List account deletions due at given time.
+	ListDue(now time.Time) ([]*entity.AccountDeletion, error)
+}
+
+type DeleteAccountDeletionPersistence interface {
+	// Delete account deletion of user.
+	Delete(userId value.UserId) error
+}
@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateAuditLogPersistence interface {
+	// Create new audit log.
+	Create(log *dto.CreateAuditLogCommand) error
+}
+
+type ListAuditLogPersistence interface {
+	// List audit logs, newest first, with total count.
+	List(page dto.PageQuery) ([]*entity.AuditLog, int, error)
+}
@@ -0,0 +1,23 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type SaveCalendarFeedPersistence interface {
+	// Save calendar feed, replacing one user had.
+	Save(feed *entity.CalendarFeed) error
+}
+
+type GetCalendarFeedPersistence interface {
+	// Get calendar feed of user. nil if user has none.
+	Get(userId value.UserId) (*entity.CalendarFeed, error)
+	// Get calendar feed by hash of its token.
+	GetByTokenHash(tokenHash string) (*entity.CalendarFeed, error)
+}
+
+type DeleteCalendarFeedPersistence interface {
+	// Delete calendar feed of user.
+	Delete(userId value.UserId) error
+}
@@ -0,0 +1,23 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateEmailChangePersistence interface {
+	// Create new email change.
+	Create(change *dto.CreateEmailChangeCommand) error
+}
+
+type GetEmailChangePersistence interface {
+	// Get email change by hash of confirm token.
+	GetByConfirmTokenHash(tokenHash string) (*entity.EmailChange, error)
+	// Get email change by hash of revert token.
+	GetByRevertTokenHash(tokenHash string) (*entity.EmailChange, error)
+}
+
+type UpdateEmailChangePersistence interface {
+	// Update email change.
+	Update(change *entity.EmailChange) error
+}
@@ -0,0 +1,6 @@
+package persistence
+
+type CheckHealthPersistence interface {
+	// Check database is reachable and has schema repositories use.
+	CheckHealth() error
+}
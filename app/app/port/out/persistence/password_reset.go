@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreatePasswordResetPersistence interface {
+	// Create new password reset.
+	Create(reset *dto.CreatePasswordResetCommand) error
+}
+
+type GetPasswordResetPersistence interface {
+	// Get password reset by hash of its token.
+	Get(tokenHash string) (*entity.PasswordReset, error)
+}
+
+type UpdatePasswordResetPersistence interface {
+	// Mark password reset used at time of its use, if unused and unexpired then.
+	// false when it was used or expired meanwhile, so token works only once.
+	Update(reset *entity.PasswordReset) (bool, error)
+}
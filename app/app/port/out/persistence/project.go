@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateProjectPersistence interface {
+	// Create new project together with its owner membership.
+	Create(project *dto.CreateProjectCommand) (*entity.Project, error)
+}
+
+type GetProjectPersistence interface {
+	// Get project.
+	Get(projectId string) (*entity.Project, error)
+}
+
+type ListProjectPersistence interface {
+	// List projects user is member of.
+	List(userId value.UserId) ([]*entity.Project, error)
+}
+
+type UpdateProjectPersistence interface {
+	// Update project.
+	Update(project *entity.Project) error
+}
+
+type DeleteProjectPersistence interface {
+	// Delete project with its todo items, members and invites.
+	Delete(projectId string) error
+}
+
+type GetProjectMemberPersistence interface {
+	// Get membership of user in project.
+	GetMember(projectId string, userId value.UserId) (*entity.ProjectMember, error)
+	// List members of project.
+	ListMembers(projectId string) ([]*entity.ProjectMember, error)
+}
+
+type ListProjectMemberBatchPersistence interface {
+	// List members of projects in one round trip.
+	ListMembersOfProjects(projectIds []string) ([]*entity.ProjectMember, error)
+}
+
+type SaveProjectMemberPersistence interface {
+	// Add member to project or update existing membership.
+	SaveMember(member *entity.ProjectMember) error
+}
+
+type DeleteProjectMemberPersistence interface {
+	// Remove member from project.
+	DeleteMember(projectId string, userId value.UserId) error
+}
+
+type CreateProjectInvitePersistence interface {
+	// Create new project invite.
+	CreateInvite(invite *dto.CreateProjectInviteCommand) error
+}
+
+type GetProjectInvitePersistence interface {
+	// Get project invite by hash of its token.
+	GetInviteByTokenHash(tokenHash string) (*entity.ProjectInvite, error)
+}
+
+type UpdateProjectInvitePersistence interface {
+	// Update project invite.
+	UpdateInvite(invite *entity.ProjectInvite) error
+}
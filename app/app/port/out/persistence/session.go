@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateSessionPersistence interface {
+	// Create new session.
+	Create(session *dto.CreateSessionCommand) error
+}
+
+type GetSessionPersistence interface {
+	// Get session by hash of its token.
+	Get(tokenHash string) (*entity.Session, error)
+}
+
+type DeleteSessionPersistence interface {
+	// Delete session by hash of its token.
+	Delete(tokenHash string) error
+	// Delete all sessions of user.
+	DeleteByUserId(userId value.UserId) error
+}
@@ -0,0 +1,17 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateTodoAssignmentPersistence interface {
+	// Record change of todo item assignee.
+	Create(assignment *dto.CreateTodoAssignmentCommand) error
+}
+
+type ListTodoAssignmentPersistence interface {
+	// List assignee changes of todo item, oldest first.
+	List(todoId value.TodoItemId) ([]*entity.TodoAssignment, error)
+}
@@ -0,0 +1,27 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateTodoAttachmentPersistence interface {
+	// Create metadata of file attached to todo item.
+	Create(attachment *dto.CreateTodoAttachmentCommand) (*entity.TodoAttachment, error)
+}
+
+type GetTodoAttachmentPersistence interface {
+	// Get attachment by its ID.
+	Get(id string) (*entity.TodoAttachment, error)
+}
+
+type ListTodoAttachmentPersistence interface {
+	// List attachments of todo item, oldest first.
+	List(todoId value.TodoItemId) ([]*entity.TodoAttachment, error)
+}
+
+type DeleteTodoAttachmentPersistence interface {
+	// Delete attachment metadata by its ID.
+	Delete(id string) error
+}
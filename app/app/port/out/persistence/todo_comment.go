@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateTodoCommentPersistence interface {
+	// Create new comment on todo item.
+	Create(comment *dto.CreateTodoCommentCommand) (*entity.TodoComment, error)
+}
+
+type GetTodoCommentPersistence interface {
+	// Get comment by its ID.
+	Get(id string) (*entity.TodoComment, error)
+}
+
+type ListTodoCommentPersistence interface {
+	// List comments on todo item, oldest first.
+	List(todoId value.TodoItemId) ([]*entity.TodoComment, error)
+}
+
+type ListTodoCommentBatchPersistence interface {
+	// List comments on todo items in one round trip, oldest first.
+	ListByTodos(todoIds []value.TodoItemId) ([]*entity.TodoComment, error)
+}
+
+type UpdateTodoCommentPersistence interface {
+	// Update comment.
+	Update(comment *entity.TodoComment) error
+}
+
+type DeleteTodoCommentPersistence interface {
+	// Delete comment by its ID.
+	Delete(id string) error
+}
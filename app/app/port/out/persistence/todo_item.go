@@ -8,12 +8,21 @@ import (
 
 type CreateTodoPersistence interface {
 	// Create new todo item.
-	Create(todo *dto.CreateTodoCommand) error
+	Create(todo *dto.CreateTodoCommand) (*entity.TodoItem, error)
 }
 
 type ListTodoPersistence interface {
-	// List todo items.
+	// List personal todo items of user.
 	List(userId value.UserId) ([]*entity.TodoItem, error)
+	// List todo items shared in project.
+	ListByProject(projectId string) ([]*entity.TodoItem, error)
+	// List open todo items assigned to user.
+	ListAssigned(userId value.UserId) ([]*entity.TodoItem, error)
+}
+
+type ListTodoPagePersistence interface {
+	// List personal and project todo items ordered by ID, a page at a time.
+	ListPage(query *dto.ListTodoPageQuery) ([]*entity.TodoItem, error)
 }
 
 type UpdateTodoPersistence interface {
@@ -29,4 +38,25 @@ type GetTodoPersistence interface {
 type DeleteTodoPersistence interface {
 	// Delete todo item.
 	Delete(todoId value.TodoItemId) error
-}
\ No newline at end of file
+}
+
+type GetTodoBatchPersistence interface {
+	// Get todo items by IDs in one round trip. Missing ones are left out.
+	GetMany(todoIds []value.TodoItemId) ([]*entity.TodoItem, error)
+}
+
+type ListTodoBatchPersistence interface {
+	// List todo items of projects in one round trip.
+	ListByProjects(projectIds []string) ([]*entity.TodoItem, error)
+}
+
+type CreateTodoBatchPersistence interface {
+	// Create new todo items in one transaction, all or none.
+	CreateMany(todos []*dto.CreateTodoCommand) ([]*entity.TodoItem, error)
+}
+
+type WriteTodoBatchPersistence interface {
+	// Update, delete and restore todo items in one transaction.
+	// Nothing is written and ErrTodoRevisionChanged is returned if any todo item in Seqs changed since read.
+	WriteBatch(batch *dto.TodoBatchCommand) error
+}
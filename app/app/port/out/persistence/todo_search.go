@@ -0,0 +1,8 @@
+package persistence
+
+import "github.com/kkatou7209/godo/app/port/out/dto"
+
+type SearchTodoPersistence interface {
+	// Search titles, descriptions and comments of todo items, best match first.
+	Search(query *dto.SearchTodoQuery) ([]*dto.TodoSearchHit, error)
+}
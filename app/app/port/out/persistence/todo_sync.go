@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"errors"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+// Todo item written by sync changed after it was read.
+var ErrTodoRevisionChanged = errors.New("todo item changed since read")
+
+type ListTodoChangePersistence interface {
+	// List revisions of todo items changed after sequence number, in order of sequence number.
+	// Deleted todo items are included unless listing from start.
+	ListChanges(query *dto.ListTodoChangesQuery) ([]*entity.TodoRevision, error)
+}
+
+type GetTodoRevisionPersistence interface {
+	// Get current revisions of todo items, deleted ones included. Unknown ones are left out.
+	GetRevisions(todoIds []value.TodoItemId) ([]*entity.TodoRevision, error)
+}
+
+type GetTodoSyncOperationPersistence interface {
+	// Get outcomes of client operations of user. Unknown ones are left out.
+	GetOperations(userId value.UserId, operationIds []string) ([]*entity.TodoSyncOperation, error)
+}
+
+type WriteTodoSyncPersistence interface {
+	// Save and delete todo items and record outcomes of client operations in one transaction.
+	// Nothing is written and ErrTodoRevisionChanged is returned if any todo item changed since read.
+	WriteSync(command *dto.TodoSyncCommand) error
+}
@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateTodoUndoPersistence interface {
+	// Create new todo undo.
+	Create(undo *dto.CreateTodoUndoCommand) error
+}
+
+type GetTodoUndoPersistence interface {
+	// Get todo undo by hash of its token.
+	Get(tokenHash string) (*entity.TodoUndo, error)
+}
+
+type UpdateTodoUndoPersistence interface {
+	// Update todo undo.
+	Update(undo *entity.TodoUndo) error
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type CreateTwoFactorPersistence interface {
+	// Create new pending two-factor authentication.
+	Create(twoFactor *dto.CreateTwoFactorCommand) error
+}
+
+type GetTwoFactorPersistence interface {
+	// Get two-factor authentication of user.
+	Get(userId value.UserId) (*entity.TwoFactor, error)
+}
+
+type UpdateTwoFactorPersistence interface {
+	// Update two-factor authentication.
+	Update(twoFactor *entity.TwoFactor) error
+}
+
+type DeleteTwoFactorPersistence interface {
+	// Delete two-factor authentication of user.
+	Delete(userId value.UserId) error
+}
+
+type CreateLoginChallengePersistence interface {
+	// Create new login challenge.
+	Create(challenge *dto.CreateLoginChallengeCommand) error
+}
+
+type GetLoginChallengePersistence interface {
+	// Get login challenge by hash of its token.
+	Get(tokenHash string) (*entity.LoginChallenge, error)
+}
+
+type UpdateLoginChallengePersistence interface {
+	// Count failed attempt of login challenge in one statement,
+	// so parallel attempts are all counted.
+	Fail(tokenHash string) error
+}
+
+type DeleteLoginChallengePersistence interface {
+	// Delete login challenge by hash of its token, if usable at given time.
+	// false when it expired, failed too often or was deleted meanwhile, so it is redeemed only once.
+	Delete(tokenHash string, now time.Time) (bool, error)
+}
@@ -13,6 +13,11 @@ type GetUserPersistence interface {
 	GetByEmail(email value.Email) (*entity.User, error)
 }
 
+type GetUserBatchPersistence interface {
+	// Get users by IDs in one round trip. Missing ones are left out.
+	GetMany(userIds []value.UserId) ([]*entity.User, error)
+}
+
 type UpdateUserPersistence interface {
 	// Update user.
 	Update(user *entity.User) error
@@ -21,4 +26,13 @@ type UpdateUserPersistence interface {
 type CreateUserPersistence interface {
 	// Create new user.
 	Create(user *dto.CreateUserCommand) error
-}
\ No newline at end of file
+}
+
+type DeleteUserPersistence interface {
+	// Delete user and all data owned by user at once.
+	Delete(userId value.UserId) error
+}
+type ListUserPersistence interface {
+	// List users matching query, ordered by email, with total count.
+	List(query *dto.ListUsersQuery) ([]*entity.User, int, error)
+}
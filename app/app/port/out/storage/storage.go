@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// Returned by BlobStorage.Get when no blob is stored under key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+type BlobStorage interface {
+	// Store content under key, replacing existing blob.
+	Put(key string, content io.Reader, size int64, contentType string) error
+	// Open blob stored under key. Caller closes it.
+	Get(key string) (io.ReadCloser, error)
+	// Delete blob stored under key. Deleting missing blob is not an error.
+	Delete(key string) error
+}
@@ -0,0 +1,10 @@
+package token
+
+type TokenGenerator interface {
+	// Generate new random token.
+	Generate() (string, error)
+	// Generate new random code short enough to type by hand.
+	GenerateCode() (string, error)
+	// Hash token for storing.
+	Hash(token string) string
+}
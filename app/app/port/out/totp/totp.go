@@ -0,0 +1,12 @@
+package totp
+
+import "time"
+
+type TotpProvider interface {
+	// Generate new base32 encoded secret.
+	GenerateSecret() (string, error)
+	// Build otpauth:// URI for authenticator apps.
+	Uri(secret string, account string) string
+	// Verify code at given time. Returns time step code matched.
+	Verify(secret string, code string, at time.Time) (int64, bool)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Prefix of personal access tokens, telling them apart from session tokens.
+const AccessTokenPrefix = "godo_pat_"
+
+// CreateAccessTokenUsecase implementation.
+type CreateAccessTokenService struct {
+	createAccessTokenPersistence persistence.CreateAccessTokenPersistence
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewCreateAccessTokenService(
+	createAccessTokenPersistence persistence.CreateAccessTokenPersistence,
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence,
+	tokenGenerator token.TokenGenerator,
+) *CreateAccessTokenService {
+	return &CreateAccessTokenService{createAccessTokenPersistence, getAccessTokenPersistence, tokenGenerator}
+}
+
+func (s *CreateAccessTokenService) Create(command *inDto.CreateAccessTokenCommand) (*inDto.CreatedAccessTokenDto, error) {
+
+	name := strings.TrimSpace(command.Name)
+
+	if name == "" {
+		return nil, validation.ErrInvalidAccessTokenName
+	}
+
+	for _, scope := range command.Scopes {
+		if !entity.IsScope(scope) {
+			return nil, validation.ErrInvalidScope
+		}
+	}
+
+	if command.ExpiresAt != nil && !command.ExpiresAt.After(time.Now()) {
+		return nil, validation.ErrInvalidAccessTokenExpiry
+	}
+
+	generated, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	plain := AccessTokenPrefix + generated
+	tokenHash := s.tokenGenerator.Hash(plain)
+
+	err = s.createAccessTokenPersistence.Create(&outDto.CreateAccessTokenCommand{
+		UserId: value.NewUserId(command.UserId),
+		Name: name,
+		TokenHash: tokenHash,
+		Scopes: command.Scopes,
+		ExpiresAt: command.ExpiresAt,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.getAccessTokenPersistence.GetByTokenHash(tokenHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.CreatedAccessTokenDto{
+		AccessToken: toAccessTokenDto(created),
+		Token: plain,
+	}, nil
+}
+
+// ListAccessTokensUsecase implementation.
+type ListAccessTokensService struct {
+	listAccessTokenPersistence persistence.ListAccessTokenPersistence
+}
+
+func NewListAccessTokensService(listAccessTokenPersistence persistence.ListAccessTokenPersistence) *ListAccessTokensService {
+	return &ListAccessTokensService{listAccessTokenPersistence}
+}
+
+func (s *ListAccessTokensService) List(userId string) ([]*inDto.AccessTokenDto, error) {
+
+	tokens, err := s.listAccessTokenPersistence.List(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.AccessTokenDto, len(tokens))
+
+	for i, t := range tokens {
+		dtos[i] = toAccessTokenDto(t)
+	}
+
+	return dtos, nil
+}
+
+// RevokeAccessTokenUsecase implementation.
+type RevokeAccessTokenService struct {
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence
+	deleteAccessTokenPersistence persistence.DeleteAccessTokenPersistence
+}
+
+func NewRevokeAccessTokenService(
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence,
+	deleteAccessTokenPersistence persistence.DeleteAccessTokenPersistence,
+) *RevokeAccessTokenService {
+	return &RevokeAccessTokenService{getAccessTokenPersistence, deleteAccessTokenPersistence}
+}
+
+func (s *RevokeAccessTokenService) Revoke(userId string, accessTokenId string) error {
+
+	accessToken, err := s.getAccessTokenPersistence.Get(accessTokenId)
+
+	if err != nil {
+		return err
+	}
+
+	// Tokens of other users are reported as missing, not as forbidden.
+	if accessToken == nil || accessToken.UserId() != value.NewUserId(userId) {
+		return validation.ErrAccessTokenNotFound
+	}
+
+	return s.deleteAccessTokenPersistence.Delete(accessToken.Id())
+}
+
+func toAccessTokenDto(t *entity.AccessToken) *inDto.AccessTokenDto {
+	return &inDto.AccessTokenDto{
+		Id: t.Id(),
+		Name: t.Name(),
+		Scopes: t.Scopes(),
+		CreatedAt: t.CreatedAt(),
+		ExpiresAt: t.ExpiresAt(),
+		LastUsedAt: t.LastUsedAt(),
+	}
+}
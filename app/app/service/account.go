@@ -0,0 +1,308 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
+	"github.com/kkatou7209/godo/app/port/out/password"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Period account can still be restored after deletion was requested.
+const AccountDeletionGracePeriod = 14 * 24 * time.Hour
+
+// ExportUserDataUsecase implementation.
+type ExportUserDataService struct {
+	getUserPersistence persistence.GetUserPersistence
+	listTodoPersistence persistence.ListTodoPersistence
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listProjectPersistence persistence.ListProjectPersistence
+}
+
+func NewExportUserDataService(
+	getUserPersistence persistence.GetUserPersistence,
+	listTodoPersistence persistence.ListTodoPersistence,
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+) *ExportUserDataService {
+	return &ExportUserDataService{getUserPersistence, listTodoPersistence, listTodoBatchPersistence, listProjectPersistence}
+}
+
+func (s *ExportUserDataService) Export(userId string) (*inDto.UserExportDto, error) {
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	todos, err := s.listTodoPersistence.List(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.listProjectPersistence.List(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectTodos, err := listProjectTodos(s.listTodoBatchPersistence, projects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Todo items user created in projects, not those of other members.
+	for _, todo := range projectTodos {
+		if todo.UserId() == user.Id() {
+			todos = append(todos, todo)
+		}
+	}
+
+	todoDtos := make([]*inDto.TodoItemDto, len(todos))
+
+	for i, todo := range todos {
+		todoDtos[i] = &inDto.TodoItemDto{
+			Id: todo.Id().Value(),
+			Title: todo.Title().Value(),
+			Description: todo.Description().Value(),
+			IsDone: todo.IsDone(),
+			UserId: todo.UserId().Value(),
+		}
+	}
+
+	return &inDto.UserExportDto{
+		User: toUserDto(user),
+		TodoItems: todoDtos,
+		ExportedAt: time.Now(),
+	}, nil
+}
+
+// RequestAccountDeletionUsecase implementation.
+type RequestAccountDeletionService struct {
+	getUserPersistence persistence.GetUserPersistence
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence
+	createAccountDeletionPersistence persistence.CreateAccountDeletionPersistence
+	passwordHasher password.PasswordHasher
+	mailer mailer.Mailer
+}
+
+func NewRequestAccountDeletionService(
+	getUserPersistence persistence.GetUserPersistence,
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence,
+	createAccountDeletionPersistence persistence.CreateAccountDeletionPersistence,
+	passwordHasher password.PasswordHasher,
+	mailer mailer.Mailer,
+) *RequestAccountDeletionService {
+	return &RequestAccountDeletionService{
+		getUserPersistence,
+		getAccountDeletionPersistence,
+		createAccountDeletionPersistence,
+		passwordHasher,
+		mailer,
+	}
+}
+
+func (s *RequestAccountDeletionService) RequestDeletion(userId string, password string) (*inDto.AccountDeletionDto, error) {
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	if !s.passwordHasher.Verify(password, user.Password().Value()) {
+		return nil, validation.ErrInvalidPassword
+	}
+
+	current, err := s.getAccountDeletionPersistence.Get(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil {
+		return nil, validation.ErrAccountDeletionAlreadyRequested
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(AccountDeletionGracePeriod)
+
+	err = s.createAccountDeletionPersistence.Create(&outDto.CreateAccountDeletionCommand{
+		UserId: user.Id(),
+		RequestedAt: now,
+		ScheduledAt: scheduledAt,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.Send(
+		user.Email(),
+		"Your GoDo account will be deleted",
+		fmt.Sprintf(
+			"Your GoDo account and all of its todo items will be deleted permanently on %s.\n\n" +
+			"You can cancel the deletion from your account until then.",
+			scheduledAt.UTC().Format(time.RFC1123),
+		),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.AccountDeletionDto{
+		RequestedAt: now,
+		ScheduledAt: scheduledAt,
+	}, nil
+}
+
+// CancelAccountDeletionUsecase implementation.
+type CancelAccountDeletionService struct {
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence
+	deleteAccountDeletionPersistence persistence.DeleteAccountDeletionPersistence
+}
+
+func NewCancelAccountDeletionService(
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence,
+	deleteAccountDeletionPersistence persistence.DeleteAccountDeletionPersistence,
+) *CancelAccountDeletionService {
+	return &CancelAccountDeletionService{getAccountDeletionPersistence, deleteAccountDeletionPersistence}
+}
+
+func (s *CancelAccountDeletionService) CancelDeletion(userId string) error {
+
+	deletion, err := s.getAccountDeletionPersistence.Get(value.NewUserId(userId))
+
+	if err != nil {
+		return err
+	}
+
+	if deletion == nil {
+		return validation.ErrAccountDeletionNotRequested
+	}
+
+	return s.deleteAccountDeletionPersistence.Delete(deletion.UserId())
+}
+
+// PurgeDeletedAccountsUsecase implementation.
+type PurgeDeletedAccountsService struct {
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence
+	deleteUserPersistence persistence.DeleteUserPersistence
+	listTodoPersistence persistence.ListTodoPersistence
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listProjectPersistence persistence.ListProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+}
+
+func NewPurgeDeletedAccountsService(
+	getAccountDeletionPersistence persistence.GetAccountDeletionPersistence,
+	deleteUserPersistence persistence.DeleteUserPersistence,
+	listTodoPersistence persistence.ListTodoPersistence,
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+) *PurgeDeletedAccountsService {
+	return &PurgeDeletedAccountsService{
+		getAccountDeletionPersistence,
+		deleteUserPersistence,
+		listTodoPersistence,
+		listTodoBatchPersistence,
+		listProjectPersistence,
+		getProjectMemberPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+	}
+}
+
+func (s *PurgeDeletedAccountsService) Purge() (int, error) {
+
+	deletions, err := s.getAccountDeletionPersistence.ListDue(time.Now())
+
+	if err != nil {
+		return 0, err
+	}
+
+	for i, deletion := range deletions {
+
+		keys, err := s.listDeletedAttachmentKeys(deletion.UserId())
+
+		if err != nil {
+			return i, err
+		}
+
+		if err := s.deleteUserPersistence.Delete(deletion.UserId()); err != nil {
+			return i, err
+		}
+
+		if err := deleteBlobs(s.blobStorage, keys); err != nil {
+			return i, err
+		}
+	}
+
+	return len(deletions), nil
+}
+
+// List storage keys of files deleted along with user:
+// those on personal todo items and on projects user is the only member of.
+func (s *PurgeDeletedAccountsService) listDeletedAttachmentKeys(userId value.UserId) ([]string, error) {
+
+	todos, err := s.listTodoPersistence.List(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.listProjectPersistence.List(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]*entity.Project, 0, len(projects))
+
+	for _, project := range projects {
+
+		members, err := s.getProjectMemberPersistence.ListMembers(project.Id())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(members) <= 1 {
+			deleted = append(deleted, project)
+		}
+	}
+
+	projectTodos, err := listProjectTodos(s.listTodoBatchPersistence, deleted)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos = append(todos, projectTodos...)
+
+	return listTodoAttachmentKeys(s.listTodoAttachmentPersistence, todos)
+}
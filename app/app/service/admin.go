@@ -0,0 +1,457 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+const (
+	// Page size when not given.
+	DefaultPerPage = 20
+	// Largest page size allowed.
+	MaxPerPage = 100
+)
+
+// Fill defaults of page query and convert it to rows to fetch.
+func toPageQuery(query inDto.PageQuery) (inDto.PageQuery, outDto.PageQuery) {
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+
+	if query.PerPage < 1 {
+		query.PerPage = DefaultPerPage
+	}
+
+	if query.PerPage > MaxPerPage {
+		query.PerPage = MaxPerPage
+	}
+
+	return query, outDto.PageQuery{
+		Offset: (query.Page - 1) * query.PerPage,
+		Limit: query.PerPage,
+	}
+}
+
+// Get user action is taken on.
+func getTargetUser(getUserPersistence persistence.GetUserPersistence, userId string) (*entity.User, error) {
+
+	user, err := getUserPersistence.GetById(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// Record admin action. Empty actor means action taken by system.
+func recordAudit(
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+	actorId string,
+	action string,
+	target value.UserId,
+	detail string,
+) error {
+
+	var actor *value.UserId
+
+	if actorId != "" {
+		a := value.NewUserId(actorId)
+		actor = &a
+	}
+
+	return createAuditLogPersistence.Create(&outDto.CreateAuditLogCommand{
+		ActorId: actor,
+		Action: action,
+		TargetUserId: target,
+		Detail: detail,
+	})
+}
+
+// ListUsersUsecase implementation.
+type ListUsersService struct {
+	listUserPersistence persistence.ListUserPersistence
+}
+
+func NewListUsersService(listUserPersistence persistence.ListUserPersistence) *ListUsersService {
+	return &ListUsersService{listUserPersistence}
+}
+
+func (s *ListUsersService) List(query *inDto.ListUsersQuery) (*inDto.PageDto[*inDto.UserDto], error) {
+
+	page, rows := toPageQuery(query.Page)
+
+	users, total, err := s.listUserPersistence.List(&outDto.ListUsersQuery{
+		Search: query.Search,
+		Page: rows,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.UserDto, len(users))
+
+	for i, user := range users {
+		dtos[i] = toUserDto(user)
+	}
+
+	return &inDto.PageDto[*inDto.UserDto]{
+		Items: dtos,
+		Total: total,
+		Page: page.Page,
+		PerPage: page.PerPage,
+	}, nil
+}
+
+// InspectUserUsecase implementation.
+type InspectUserService struct {
+	getUserPersistence persistence.GetUserPersistence
+	listTodoPersistence persistence.ListTodoPersistence
+	listAccessTokenPersistence persistence.ListAccessTokenPersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+}
+
+func NewInspectUserService(
+	getUserPersistence persistence.GetUserPersistence,
+	listTodoPersistence persistence.ListTodoPersistence,
+	listAccessTokenPersistence persistence.ListAccessTokenPersistence,
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+) *InspectUserService {
+	return &InspectUserService{
+		getUserPersistence,
+		listTodoPersistence,
+		listAccessTokenPersistence,
+		getTwoFactorPersistence,
+		createAuditLogPersistence,
+	}
+}
+
+func (s *InspectUserService) Inspect(actorId string, userId string) (*inDto.UserUsageDto, error) {
+
+	user, err := getTargetUser(s.getUserPersistence, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := s.listTodoPersistence.List(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	completed := 0
+
+	for _, todo := range todos {
+		if todo.IsDone() {
+			completed++
+		}
+	}
+
+	tokens, err := s.listAccessTokenPersistence.List(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.getTwoFactorPersistence.Get(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(s.createAuditLogPersistence, actorId, entity.AuditActionUserInspected, user.Id(), ""); err != nil {
+		return nil, err
+	}
+
+	return &inDto.UserUsageDto{
+		User: toUserDto(user),
+		TodoItems: len(todos),
+		CompletedTodoItems: completed,
+		AccessTokens: len(tokens),
+		TwoFactorEnabled: twoFactor != nil && twoFactor.IsEnabled(),
+	}, nil
+}
+
+// DisableUserUsecase implementation.
+type DisableUserService struct {
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	deleteSessionPersistence persistence.DeleteSessionPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+}
+
+func NewDisableUserService(
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	deleteSessionPersistence persistence.DeleteSessionPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+) *DisableUserService {
+	return &DisableUserService{
+		getUserPersistence,
+		updateUserPersistence,
+		deleteSessionPersistence,
+		createAuditLogPersistence,
+	}
+}
+
+func (s *DisableUserService) Disable(actorId string, userId string) error {
+
+	if actorId == userId {
+		return validation.ErrCannotManageSelf
+	}
+
+	user, err := getTargetUser(s.getUserPersistence, userId)
+
+	if err != nil {
+		return err
+	}
+
+	user.Disable()
+
+	if err := s.updateUserPersistence.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.deleteSessionPersistence.DeleteByUserId(user.Id()); err != nil {
+		return err
+	}
+
+	return recordAudit(s.createAuditLogPersistence, actorId, entity.AuditActionUserDisabled, user.Id(), "")
+}
+
+// EnableUserUsecase implementation.
+type EnableUserService struct {
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+}
+
+func NewEnableUserService(
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+) *EnableUserService {
+	return &EnableUserService{getUserPersistence, updateUserPersistence, createAuditLogPersistence}
+}
+
+func (s *EnableUserService) Enable(actorId string, userId string) error {
+
+	user, err := getTargetUser(s.getUserPersistence, userId)
+
+	if err != nil {
+		return err
+	}
+
+	user.Enable()
+
+	if err := s.updateUserPersistence.Update(user); err != nil {
+		return err
+	}
+
+	return recordAudit(s.createAuditLogPersistence, actorId, entity.AuditActionUserEnabled, user.Id(), "")
+}
+
+// ForcePasswordResetUsecase implementation.
+type ForcePasswordResetService struct {
+	getUserPersistence persistence.GetUserPersistence
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence
+	deleteSessionPersistence persistence.DeleteSessionPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+	tokenGenerator token.TokenGenerator
+	mailer mailer.Mailer
+}
+
+func NewForcePasswordResetService(
+	getUserPersistence persistence.GetUserPersistence,
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence,
+	deleteSessionPersistence persistence.DeleteSessionPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+	tokenGenerator token.TokenGenerator,
+	mailer mailer.Mailer,
+) *ForcePasswordResetService {
+	return &ForcePasswordResetService{
+		getUserPersistence,
+		createPasswordResetPersistence,
+		deleteSessionPersistence,
+		createAuditLogPersistence,
+		tokenGenerator,
+		mailer,
+	}
+}
+
+func (s *ForcePasswordResetService) ForceReset(actorId string, userId string) error {
+
+	user, err := getTargetUser(s.getUserPersistence, userId)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteSessionPersistence.DeleteByUserId(user.Id()); err != nil {
+		return err
+	}
+
+	if err := sendPasswordReset(s.createPasswordResetPersistence, s.tokenGenerator, s.mailer, user); err != nil {
+		return err
+	}
+
+	return recordAudit(s.createAuditLogPersistence, actorId, entity.AuditActionUserPasswordReset, user.Id(), "")
+}
+
+// ChangeUserRoleUsecase implementation.
+type ChangeUserRoleService struct {
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+}
+
+func NewChangeUserRoleService(
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+) *ChangeUserRoleService {
+	return &ChangeUserRoleService{getUserPersistence, updateUserPersistence, createAuditLogPersistence}
+}
+
+func (s *ChangeUserRoleService) ChangeRole(actorId string, userId string, role string) error {
+
+	if !value.IsRole(role) {
+		return validation.ErrInvalidRole
+	}
+
+	// Admin demoting self could leave instance without admin.
+	if actorId == userId {
+		return validation.ErrCannotManageSelf
+	}
+
+	user, err := getTargetUser(s.getUserPersistence, userId)
+
+	if err != nil {
+		return err
+	}
+
+	return changeRole(s.updateUserPersistence, s.createAuditLogPersistence, actorId, user, value.NewRole(role))
+}
+
+// GrantAdminUsecase implementation.
+type GrantAdminService struct {
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	createAuditLogPersistence persistence.CreateAuditLogPersistence
+}
+
+func NewGrantAdminService(
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+) *GrantAdminService {
+	return &GrantAdminService{getUserPersistence, updateUserPersistence, createAuditLogPersistence}
+}
+
+func (s *GrantAdminService) Grant(email string) error {
+
+	user, err := s.getUserPersistence.GetByEmail(value.NewEmail(email))
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return validation.ErrUserNotFound
+	}
+
+	if user.IsAdmin() {
+		return nil
+	}
+
+	return changeRole(s.updateUserPersistence, s.createAuditLogPersistence, "", user, value.RoleAdmin)
+}
+
+func changeRole(
+	updateUserPersistence persistence.UpdateUserPersistence,
+	createAuditLogPersistence persistence.CreateAuditLogPersistence,
+	actorId string,
+	user *entity.User,
+	role value.Role,
+) error {
+
+	old := user.Role()
+
+	user.ChangeRole(role)
+
+	if err := updateUserPersistence.Update(user); err != nil {
+		return err
+	}
+
+	return recordAudit(
+		createAuditLogPersistence,
+		actorId,
+		entity.AuditActionUserRoleChanged,
+		user.Id(),
+		fmt.Sprintf("%s -> %s", old.Value(), role.Value()),
+	)
+}
+
+// ListAuditLogsUsecase implementation.
+type ListAuditLogsService struct {
+	listAuditLogPersistence persistence.ListAuditLogPersistence
+}
+
+func NewListAuditLogsService(listAuditLogPersistence persistence.ListAuditLogPersistence) *ListAuditLogsService {
+	return &ListAuditLogsService{listAuditLogPersistence}
+}
+
+func (s *ListAuditLogsService) List(query *inDto.PageQuery) (*inDto.PageDto[*inDto.AuditLogDto], error) {
+
+	page, rows := toPageQuery(*query)
+
+	logs, total, err := s.listAuditLogPersistence.List(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.AuditLogDto, len(logs))
+
+	for i, log := range logs {
+
+		actorId := ""
+
+		if log.ActorId() != nil {
+			actorId = log.ActorId().Value()
+		}
+
+		dtos[i] = &inDto.AuditLogDto{
+			Id: log.Id(),
+			ActorId: actorId,
+			Action: log.Action(),
+			TargetUserId: log.TargetUserId().Value(),
+			Detail: log.Detail(),
+			CreatedAt: log.CreatedAt(),
+		}
+	}
+
+	return &inDto.PageDto[*inDto.AuditLogDto]{
+		Items: dtos,
+		Total: total,
+		Page: page.Page,
+		PerPage: page.PerPage,
+	}, nil
+}
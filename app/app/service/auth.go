@@ -1,31 +1,60 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/kkatou7209/godo/app/port/in/dto"
 	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
 	"github.com/kkatou7209/godo/app/port/out/password"
 	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
 	"github.com/kkatou7209/godo/app/validation"
 )
 
+const (
+	// Lifetime of login session.
+	SessionLifetime = 7 * 24 * time.Hour
+	// Lifetime of password reset token.
+	PasswordResetLifetime = 30 * time.Minute
+	// Lifetime of login challenge waiting for second factor.
+	LoginChallengeLifetime = 5 * time.Minute
+)
+
 // LoginUsecase implementation.
 type LoginService struct {
 	getUserPersistence persistence.GetUserPersistence
+	createSessionPersistence persistence.CreateSessionPersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	createLoginChallengePersistence persistence.CreateLoginChallengePersistence
 	passwordHasher password.PasswordHasher
+	tokenGenerator token.TokenGenerator
 }
 
 func NewLoginService(
 	getUserPersistence persistence.GetUserPersistence,
+	createSessionPersistence persistence.CreateSessionPersistence,
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	createLoginChallengePersistence persistence.CreateLoginChallengePersistence,
 	passwordHasher password.PasswordHasher,
+	tokenGenerator token.TokenGenerator,
 ) *LoginService {
 	return &LoginService{
 		getUserPersistence,
+		createSessionPersistence,
+		getTwoFactorPersistence,
+		createLoginChallengePersistence,
 		passwordHasher,
+		tokenGenerator,
 	}
 }
 
-func (s *LoginService) Login(credential *inDto.LoginCommand) (*dto.UserDto, error) {
+func (s *LoginService) Login(credential *inDto.LoginCommand) (*dto.LoginResultDto, error) {
 
 	user, err := s.getUserPersistence.GetByEmail(value.NewEmail(credential.Email))
 
@@ -41,9 +70,329 @@ func (s *LoginService) Login(credential *inDto.LoginCommand) (*dto.UserDto, erro
 		return nil, validation.ErrInvalidPassword
 	}
 
-	return &dto.UserDto{
-		Id: user.Id().Value(),
-		UserName: user.UserName().Value(),
-		Email: user.Email().Value(),
+	if user.IsDisabled() {
+		return nil, validation.ErrUserDisabled
+	}
+
+	twoFactor, err := s.getTwoFactorPersistence.Get(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor != nil && twoFactor.IsEnabled() {
+
+		challengeToken, err := s.tokenGenerator.Generate()
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.createLoginChallengePersistence.Create(&outDto.CreateLoginChallengeCommand{
+			TokenHash: s.tokenGenerator.Hash(challengeToken),
+			UserId: user.Id(),
+			ExpiresAt: time.Now().Add(LoginChallengeLifetime),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.LoginResultDto{
+			TwoFactorRequired: true,
+			ChallengeToken: challengeToken,
+		}, nil
+	}
+
+	sessionToken, err := startSession(s.createSessionPersistence, s.tokenGenerator, user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResultDto{
+		User: toUserDto(user),
+		Token: sessionToken,
 	}, nil
-}
\ No newline at end of file
+}
+
+// Create new session of user and return its token.
+func startSession(
+	createSessionPersistence persistence.CreateSessionPersistence,
+	tokenGenerator token.TokenGenerator,
+	userId value.UserId,
+) (string, error) {
+
+	sessionToken, err := tokenGenerator.Generate()
+
+	if err != nil {
+		return "", err
+	}
+
+	err = createSessionPersistence.Create(&outDto.CreateSessionCommand{
+		TokenHash: tokenGenerator.Hash(sessionToken),
+		UserId: userId,
+		ExpiresAt: time.Now().Add(SessionLifetime),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return sessionToken, nil
+}
+
+// RequestPasswordResetUsecase implementation.
+type RequestPasswordResetService struct {
+	getUserPersistence persistence.GetUserPersistence
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence
+	tokenGenerator token.TokenGenerator
+	mailer mailer.Mailer
+}
+
+func NewRequestPasswordResetService(
+	getUserPersistence persistence.GetUserPersistence,
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence,
+	tokenGenerator token.TokenGenerator,
+	mailer mailer.Mailer,
+) *RequestPasswordResetService {
+	return &RequestPasswordResetService{
+		getUserPersistence,
+		createPasswordResetPersistence,
+		tokenGenerator,
+		mailer,
+	}
+}
+
+func (s *RequestPasswordResetService) RequestReset(email string) error {
+
+	user, err := s.getUserPersistence.GetByEmail(value.NewEmail(email))
+
+	if err != nil {
+		return err
+	}
+
+	// Do not tell caller whether email is registered.
+	if user == nil {
+		return nil
+	}
+
+	return sendPasswordReset(s.createPasswordResetPersistence, s.tokenGenerator, s.mailer, user)
+}
+
+// Create password reset token of user and mail it.
+func sendPasswordReset(
+	createPasswordResetPersistence persistence.CreatePasswordResetPersistence,
+	tokenGenerator token.TokenGenerator,
+	mailer mailer.Mailer,
+	user *entity.User,
+) error {
+
+	resetToken, err := tokenGenerator.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	err = createPasswordResetPersistence.Create(&outDto.CreatePasswordResetCommand{
+		TokenHash: tokenGenerator.Hash(resetToken),
+		UserId: user.Id(),
+		ExpiresAt: time.Now().Add(PasswordResetLifetime),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return mailer.Send(
+		user.Email(),
+		"Reset your GoDo password",
+		fmt.Sprintf(
+			"We received a request to reset your password.\n\n" +
+			"Reset token: %s\n\n" +
+			"This token expires in %d minutes and can be used only once. " +
+			"If you did not request a reset, you can ignore this mail.",
+			resetToken,
+			int(PasswordResetLifetime.Minutes()),
+		),
+	)
+}
+
+// ResetPasswordUsecase implementation.
+type ResetPasswordService struct {
+	getPasswordResetPersistence persistence.GetPasswordResetPersistence
+	updatePasswordResetPersistence persistence.UpdatePasswordResetPersistence
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	deleteSessionPersistence persistence.DeleteSessionPersistence
+	passwordHasher password.PasswordHasher
+	tokenGenerator token.TokenGenerator
+}
+
+func NewResetPasswordService(
+	getPasswordResetPersistence persistence.GetPasswordResetPersistence,
+	updatePasswordResetPersistence persistence.UpdatePasswordResetPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	deleteSessionPersistence persistence.DeleteSessionPersistence,
+	passwordHasher password.PasswordHasher,
+	tokenGenerator token.TokenGenerator,
+) *ResetPasswordService {
+	return &ResetPasswordService{
+		getPasswordResetPersistence,
+		updatePasswordResetPersistence,
+		getUserPersistence,
+		updateUserPersistence,
+		deleteSessionPersistence,
+		passwordHasher,
+		tokenGenerator,
+	}
+}
+
+func (s *ResetPasswordService) Reset(command *inDto.ResetPasswordCommand) error {
+
+	now := time.Now()
+
+	reset, err := s.getPasswordResetPersistence.Get(s.tokenGenerator.Hash(command.Token))
+
+	if err != nil {
+		return err
+	}
+
+	if reset == nil || !reset.IsUsable(now) {
+		return validation.ErrInvalidResetToken
+	}
+
+	user, err := s.getUserPersistence.GetById(reset.UserId())
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return validation.ErrInvalidResetToken
+	}
+
+	// Burn the token before changing password so it cannot be replayed.
+	reset.Use(now)
+
+	used, err := s.updatePasswordResetPersistence.Update(reset)
+
+	if err != nil {
+		return err
+	}
+
+	// Another request used the token first.
+	if !used {
+		return validation.ErrInvalidResetToken
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(command.Password)
+
+	if err != nil {
+		return err
+	}
+
+	user.ChangePassword(hashedPassword)
+
+	if err := s.updateUserPersistence.Update(user); err != nil {
+		return err
+	}
+
+	return s.deleteSessionPersistence.DeleteByUserId(user.Id())
+}
+
+// AuthenticateUsecase implementation.
+type AuthenticateService struct {
+	getUserPersistence persistence.GetUserPersistence
+	getSessionPersistence persistence.GetSessionPersistence
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence
+	updateAccessTokenPersistence persistence.UpdateAccessTokenPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewAuthenticateService(
+	getUserPersistence persistence.GetUserPersistence,
+	getSessionPersistence persistence.GetSessionPersistence,
+	getAccessTokenPersistence persistence.GetAccessTokenPersistence,
+	updateAccessTokenPersistence persistence.UpdateAccessTokenPersistence,
+	tokenGenerator token.TokenGenerator,
+) *AuthenticateService {
+	return &AuthenticateService{
+		getUserPersistence,
+		getSessionPersistence,
+		getAccessTokenPersistence,
+		updateAccessTokenPersistence,
+		tokenGenerator,
+	}
+}
+
+func (s *AuthenticateService) Authenticate(plain string) (*inDto.PrincipalDto, error) {
+
+	if plain == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+	tokenHash := s.tokenGenerator.Hash(plain)
+
+	var principal *inDto.PrincipalDto
+
+	if strings.HasPrefix(plain, AccessTokenPrefix) {
+
+		accessToken, err := s.getAccessTokenPersistence.GetByTokenHash(tokenHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if accessToken == nil || accessToken.IsExpired(now) {
+			return nil, nil
+		}
+
+		accessToken.Use(now)
+
+		if err := s.updateAccessTokenPersistence.Update(accessToken); err != nil {
+			return nil, err
+		}
+
+		principal = &inDto.PrincipalDto{
+			UserId: accessToken.UserId().Value(),
+			Scopes: accessToken.Scopes(),
+			AccessTokenId: accessToken.Id(),
+		}
+
+	} else {
+
+		session, err := s.getSessionPersistence.Get(tokenHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if session == nil || session.IsExpired(now) {
+			return nil, nil
+		}
+
+		// Sessions act on behalf of user with every scope.
+		principal = &inDto.PrincipalDto{
+			UserId: session.UserId().Value(),
+			Scopes: entity.Scopes,
+		}
+	}
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(principal.UserId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Access tokens of disabled user stop working too.
+	if user == nil || user.IsDisabled() {
+		return nil, nil
+	}
+
+	principal.Role = user.Role().Value()
+
+	return principal, nil
+}
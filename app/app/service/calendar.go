@@ -0,0 +1,114 @@
+package service
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// CalendarFeedUsecase implementation.
+type CalendarFeedService struct {
+	saveCalendarFeedPersistence persistence.SaveCalendarFeedPersistence
+	getCalendarFeedPersistence persistence.GetCalendarFeedPersistence
+	deleteCalendarFeedPersistence persistence.DeleteCalendarFeedPersistence
+	getUserPersistence persistence.GetUserPersistence
+	listTodoPersistence persistence.ListTodoPersistence
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listProjectPersistence persistence.ListProjectPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewCalendarFeedService(
+	saveCalendarFeedPersistence persistence.SaveCalendarFeedPersistence,
+	getCalendarFeedPersistence persistence.GetCalendarFeedPersistence,
+	deleteCalendarFeedPersistence persistence.DeleteCalendarFeedPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	listTodoPersistence persistence.ListTodoPersistence,
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+	tokenGenerator token.TokenGenerator,
+) *CalendarFeedService {
+	return &CalendarFeedService{
+		saveCalendarFeedPersistence,
+		getCalendarFeedPersistence,
+		deleteCalendarFeedPersistence,
+		getUserPersistence,
+		listTodoPersistence,
+		listTodoBatchPersistence,
+		listProjectPersistence,
+		tokenGenerator,
+	}
+}
+
+func (s *CalendarFeedService) Get(userId string) (*inDto.CalendarFeedDto, error) {
+
+	feed, err := s.getCalendarFeedPersistence.Get(value.NewUserId(userId))
+
+	if err != nil || feed == nil {
+		return nil, err
+	}
+
+	return &inDto.CalendarFeedDto{CreatedAt: feed.CreatedAt()}, nil
+}
+
+func (s *CalendarFeedService) Rotate(userId string) (*inDto.CalendarFeedDto, error) {
+
+	feedToken, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	feed := entity.NewCalendarFeed(value.NewUserId(userId), s.tokenGenerator.Hash(feedToken), time.Now())
+
+	if err := s.saveCalendarFeedPersistence.Save(feed); err != nil {
+		return nil, err
+	}
+
+	return &inDto.CalendarFeedDto{Token: feedToken, CreatedAt: feed.CreatedAt()}, nil
+}
+
+func (s *CalendarFeedService) Revoke(userId string) error {
+	return s.deleteCalendarFeedPersistence.Delete(value.NewUserId(userId))
+}
+
+func (s *CalendarFeedService) ListTodos(feedToken string) (*inDto.CalendarFeedTodosDto, error) {
+
+	if feedToken == "" {
+		return nil, validation.ErrCalendarFeedNotFound
+	}
+
+	feed, err := s.getCalendarFeedPersistence.GetByTokenHash(s.tokenGenerator.Hash(feedToken))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if feed == nil {
+		return nil, validation.ErrCalendarFeedNotFound
+	}
+
+	// Feed of disabled user stops with rest of their access.
+	user, err := s.getUserPersistence.GetById(feed.UserId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || user.IsDisabled() {
+		return nil, validation.ErrCalendarFeedNotFound
+	}
+
+	todos, err := NewListTodoService(s.listTodoPersistence, s.listTodoBatchPersistence, s.listProjectPersistence).List(feed.UserId().Value())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.CalendarFeedTodosDto{UserId: feed.UserId().Value(), Todos: todos}, nil
+}
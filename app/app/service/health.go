@@ -0,0 +1,22 @@
+package service
+
+import "github.com/kkatou7209/godo/app/port/out/persistence"
+
+// CheckReadinessUsecase implementation.
+type CheckReadinessService struct {
+	checkHealthPersistence persistence.CheckHealthPersistence
+}
+
+func NewCheckReadinessService(checkHealthPersistence persistence.CheckHealthPersistence) *CheckReadinessService {
+	return &CheckReadinessService{checkHealthPersistence}
+}
+
+func (s *CheckReadinessService) Check() error {
+
+	// Nothing to check without persistence.
+	if s.checkHealthPersistence == nil {
+		return nil
+	}
+
+	return s.checkHealthPersistence.CheckHealth()
+}
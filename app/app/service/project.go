@@ -0,0 +1,905 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Lifetime of project invite token.
+const ProjectInviteLifetime = 7 * 24 * time.Hour
+
+// Get membership of user in project, failing unless it allows required permission.
+// Projects user is not member of are reported as not found.
+func authorizeProject(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	projectId string,
+	userId value.UserId,
+	required value.Permission,
+) (*entity.ProjectMember, error) {
+
+	member, err := getProjectMemberPersistence.GetMember(projectId, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil {
+		return nil, validation.ErrProjectNotFound
+	}
+
+	if !member.Permission().Allows(required) {
+		return nil, validation.ErrPermissionDenied
+	}
+
+	return member, nil
+}
+
+// Check if user has required permission on todo item.
+// Personal todo items are accessible by their owner only.
+func authorizeTodo(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	todo *entity.TodoItem,
+	userId value.UserId,
+	required value.Permission,
+) error {
+
+	if todo.ProjectId() == "" {
+
+		if todo.UserId() != userId {
+			return validation.ErrTodoNotDound
+		}
+
+		return nil
+	}
+
+	_, err := authorizeProject(getProjectMemberPersistence, todo.ProjectId(), userId, required)
+
+	if err == validation.ErrProjectNotFound {
+		return validation.ErrTodoNotDound
+	}
+
+	return err
+}
+
+// Check if project keeps an owner other than given member.
+func hasOtherOwner(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	projectId string,
+	userId value.UserId,
+) (bool, error) {
+
+	members, err := getProjectMemberPersistence.ListMembers(projectId)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range members {
+		if m.UserId() != userId && m.Permission() == value.PermissionOwner {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Members of projects loaded in one round trip, so batches authorize against them without more.
+type loadedProjectMembers map[string][]*entity.ProjectMember
+
+func loadProjectMembers(
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence,
+	projectIds []string,
+) (loadedProjectMembers, error) {
+
+	loaded := make(loadedProjectMembers)
+
+	if len(projectIds) == 0 {
+		return loaded, nil
+	}
+
+	members, err := listProjectMemberBatchPersistence.ListMembersOfProjects(projectIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		loaded[m.ProjectId()] = append(loaded[m.ProjectId()], m)
+	}
+
+	return loaded, nil
+}
+
+func (l loadedProjectMembers) GetMember(projectId string, userId value.UserId) (*entity.ProjectMember, error) {
+
+	for _, m := range l[projectId] {
+		if m.UserId() == userId {
+			return m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (l loadedProjectMembers) ListMembers(projectId string) ([]*entity.ProjectMember, error) {
+	return l[projectId], nil
+}
+
+// Projects among given ones user is allowed to view, without duplicates.
+func (l loadedProjectMembers) viewable(projectIds []string, userId value.UserId) []string {
+
+	viewable := make([]string, 0, len(projectIds))
+
+	for _, projectId := range projectIds {
+
+		if slices.Contains(viewable, projectId) {
+			continue
+		}
+
+		if _, err := authorizeProject(l, projectId, userId, value.PermissionViewer); err == nil {
+			viewable = append(viewable, projectId)
+		}
+	}
+
+	return viewable
+}
+
+func validateProjectName(name string) (string, error) {
+
+	name = strings.TrimSpace(name)
+
+	if name == "" || len(name) > 255 {
+		return "", validation.ErrInvalidProjectName
+	}
+
+	return name, nil
+}
+
+func toProjectDto(project *entity.Project, member *entity.ProjectMember) *inDto.ProjectDto {
+	return &inDto.ProjectDto{
+		Id: project.Id(),
+		Name: project.Name(),
+		Permission: member.Permission().Value(),
+		CreatedAt: project.CreatedAt(),
+	}
+}
+
+// CreateProjectUsecase implementation.
+type CreateProjectService struct {
+	createProjectPersistence persistence.CreateProjectPersistence
+}
+
+func NewCreateProjectService(createProjectPersistence persistence.CreateProjectPersistence) *CreateProjectService {
+	return &CreateProjectService{createProjectPersistence}
+}
+
+func (s *CreateProjectService) Create(command *inDto.CreateProjectCommand) (*inDto.ProjectDto, error) {
+
+	name, err := validateProjectName(command.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ownerId := value.NewUserId(command.UserId)
+
+	project, err := s.createProjectPersistence.Create(&outDto.CreateProjectCommand{
+		Name: name,
+		OwnerId: ownerId,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProjectDto(project, entity.NewProjectMember(project.Id(), ownerId, value.PermissionOwner)), nil
+}
+
+// ListProjectsUsecase implementation.
+type ListProjectsService struct {
+	listProjectPersistence persistence.ListProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+}
+
+func NewListProjectsService(
+	listProjectPersistence persistence.ListProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *ListProjectsService {
+	return &ListProjectsService{listProjectPersistence, getProjectMemberPersistence}
+}
+
+func (s *ListProjectsService) List(userId string) ([]*inDto.ProjectDto, error) {
+
+	uid := value.NewUserId(userId)
+
+	projects, err := s.listProjectPersistence.List(uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.ProjectDto, 0, len(projects))
+
+	for _, project := range projects {
+
+		member, err := s.getProjectMemberPersistence.GetMember(project.Id(), uid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Left project in the meantime.
+		if member == nil {
+			continue
+		}
+
+		dtos = append(dtos, toProjectDto(project, member))
+	}
+
+	return dtos, nil
+}
+
+// GetProjectUsecase implementation.
+type GetProjectService struct {
+	getProjectPersistence persistence.GetProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+}
+
+func NewGetProjectService(
+	getProjectPersistence persistence.GetProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *GetProjectService {
+	return &GetProjectService{getProjectPersistence, getProjectMemberPersistence}
+}
+
+func (s *GetProjectService) Get(userId string, projectId string) (*inDto.ProjectDto, error) {
+
+	member, err := authorizeProject(s.getProjectMemberPersistence, projectId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.getProjectPersistence.Get(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil {
+		return nil, validation.ErrProjectNotFound
+	}
+
+	return toProjectDto(project, member), nil
+}
+
+// RenameProjectUsecase implementation.
+type RenameProjectService struct {
+	getProjectPersistence persistence.GetProjectPersistence
+	updateProjectPersistence persistence.UpdateProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+}
+
+func NewRenameProjectService(
+	getProjectPersistence persistence.GetProjectPersistence,
+	updateProjectPersistence persistence.UpdateProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *RenameProjectService {
+	return &RenameProjectService{getProjectPersistence, updateProjectPersistence, getProjectMemberPersistence}
+}
+
+func (s *RenameProjectService) Rename(command *inDto.RenameProjectCommand) error {
+
+	name, err := validateProjectName(command.Name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = authorizeProject(s.getProjectMemberPersistence, command.ProjectId, value.NewUserId(command.UserId), value.PermissionOwner)
+
+	if err != nil {
+		return err
+	}
+
+	project, err := s.getProjectPersistence.Get(command.ProjectId)
+
+	if err != nil {
+		return err
+	}
+
+	if project == nil {
+		return validation.ErrProjectNotFound
+	}
+
+	project.ChangeName(name)
+
+	return s.updateProjectPersistence.Update(project)
+}
+
+// DeleteProjectUsecase implementation.
+type DeleteProjectService struct {
+	deleteProjectPersistence persistence.DeleteProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoPersistence persistence.ListTodoPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+}
+
+func NewDeleteProjectService(
+	deleteProjectPersistence persistence.DeleteProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoPersistence persistence.ListTodoPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+) *DeleteProjectService {
+	return &DeleteProjectService{
+		deleteProjectPersistence,
+		getProjectMemberPersistence,
+		listTodoPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+	}
+}
+
+func (s *DeleteProjectService) Delete(userId string, projectId string) error {
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, projectId, value.NewUserId(userId), value.PermissionOwner)
+
+	if err != nil {
+		return err
+	}
+
+	todos, err := s.listTodoPersistence.ListByProject(projectId)
+
+	if err != nil {
+		return err
+	}
+
+	keys, err := listTodoAttachmentKeys(s.listTodoAttachmentPersistence, todos)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteProjectPersistence.Delete(projectId); err != nil {
+		return err
+	}
+
+	return deleteBlobs(s.blobStorage, keys)
+}
+
+// ListProjectTodosUsecase implementation.
+type ListProjectTodosService struct {
+	listTodoPersistence persistence.ListTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+}
+
+func NewListProjectTodosService(
+	listTodoPersistence persistence.ListTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *ListProjectTodosService {
+	return &ListProjectTodosService{listTodoPersistence, getProjectMemberPersistence}
+}
+
+func (s *ListProjectTodosService) List(userId string, projectId string) ([]*inDto.TodoItemDto, error) {
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, projectId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := s.listTodoPersistence.ListByProject(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.TodoItemDto, len(todos))
+
+	for i, todo := range todos {
+		dtos[i] = toTodoItemDto(todo)
+	}
+
+	return dtos, nil
+}
+
+// ListProjectTodosBatchUsecase implementation.
+type ListProjectTodosBatchService struct {
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence
+}
+
+func NewListProjectTodosBatchService(
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence,
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence,
+) *ListProjectTodosBatchService {
+	return &ListProjectTodosBatchService{listTodoBatchPersistence, listProjectMemberBatchPersistence}
+}
+
+func (s *ListProjectTodosBatchService) ListMany(userId string, projectIds []string) (map[string][]*inDto.TodoItemDto, error) {
+
+	members, err := loadProjectMembers(s.listProjectMemberBatchPersistence, projectIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	viewable := members.viewable(projectIds, value.NewUserId(userId))
+
+	dtos := make(map[string][]*inDto.TodoItemDto, len(viewable))
+
+	if len(viewable) == 0 {
+		return dtos, nil
+	}
+
+	for _, projectId := range viewable {
+		dtos[projectId] = make([]*inDto.TodoItemDto, 0)
+	}
+
+	todos, err := s.listTodoBatchPersistence.ListByProjects(viewable)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, todo := range todos {
+		dtos[todo.ProjectId()] = append(dtos[todo.ProjectId()], toTodoItemDto(todo))
+	}
+
+	return dtos, nil
+}
+
+// ListProjectMembersUsecase implementation.
+type ListProjectMembersService struct {
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getUserPersistence persistence.GetUserPersistence
+}
+
+func NewListProjectMembersService(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+) *ListProjectMembersService {
+	return &ListProjectMembersService{getProjectMemberPersistence, getUserPersistence}
+}
+
+func (s *ListProjectMembersService) List(userId string, projectId string) ([]*inDto.ProjectMemberDto, error) {
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, projectId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.getProjectMemberPersistence.ListMembers(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.ProjectMemberDto, 0, len(members))
+
+	for _, member := range members {
+
+		user, err := s.getUserPersistence.GetById(member.UserId())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			continue
+		}
+
+		dtos = append(dtos, &inDto.ProjectMemberDto{
+			UserId: user.Id().Value(),
+			UserName: user.UserName().Value(),
+			Email: user.Email().Value(),
+			Permission: member.Permission().Value(),
+		})
+	}
+
+	return dtos, nil
+}
+
+// ListProjectMembersBatchUsecase implementation.
+type ListProjectMembersBatchService struct {
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence
+	getUserBatchPersistence persistence.GetUserBatchPersistence
+}
+
+func NewListProjectMembersBatchService(
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence,
+	getUserBatchPersistence persistence.GetUserBatchPersistence,
+) *ListProjectMembersBatchService {
+	return &ListProjectMembersBatchService{listProjectMemberBatchPersistence, getUserBatchPersistence}
+}
+
+func (s *ListProjectMembersBatchService) ListMany(userId string, projectIds []string) (map[string][]*inDto.ProjectMemberDto, error) {
+
+	members, err := loadProjectMembers(s.listProjectMemberBatchPersistence, projectIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	viewable := members.viewable(projectIds, value.NewUserId(userId))
+
+	dtos := make(map[string][]*inDto.ProjectMemberDto, len(viewable))
+
+	userIds := make([]value.UserId, 0)
+
+	for _, projectId := range viewable {
+		for _, m := range members[projectId] {
+			userIds = append(userIds, m.UserId())
+		}
+	}
+
+	users, err := getUsers(s.getUserBatchPersistence, userIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectId := range viewable {
+
+		dtos[projectId] = make([]*inDto.ProjectMemberDto, 0, len(members[projectId]))
+
+		for _, member := range members[projectId] {
+
+			user, ok := users[member.UserId()]
+
+			if !ok {
+				continue
+			}
+
+			dtos[projectId] = append(dtos[projectId], &inDto.ProjectMemberDto{
+				UserId: user.Id().Value(),
+				UserName: user.UserName().Value(),
+				Email: user.Email().Value(),
+				Permission: member.Permission().Value(),
+			})
+		}
+	}
+
+	return dtos, nil
+}
+
+// ChangeProjectMemberUsecase implementation.
+type ChangeProjectMemberService struct {
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	saveProjectMemberPersistence persistence.SaveProjectMemberPersistence
+}
+
+func NewChangeProjectMemberService(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	saveProjectMemberPersistence persistence.SaveProjectMemberPersistence,
+) *ChangeProjectMemberService {
+	return &ChangeProjectMemberService{getProjectMemberPersistence, saveProjectMemberPersistence}
+}
+
+func (s *ChangeProjectMemberService) Change(command *inDto.ChangeProjectMemberCommand) error {
+
+	if !value.IsPermission(command.Permission) {
+		return validation.ErrInvalidPermission
+	}
+
+	permission := value.NewPermission(command.Permission)
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, command.ProjectId, value.NewUserId(command.UserId), value.PermissionOwner)
+
+	if err != nil {
+		return err
+	}
+
+	memberId := value.NewUserId(command.MemberId)
+
+	member, err := s.getProjectMemberPersistence.GetMember(command.ProjectId, memberId)
+
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		return validation.ErrProjectMemberNotFound
+	}
+
+	if member.Permission() == value.PermissionOwner && permission != value.PermissionOwner {
+
+		ok, err := hasOtherOwner(s.getProjectMemberPersistence, command.ProjectId, memberId)
+
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return validation.ErrLastProjectOwner
+		}
+	}
+
+	member.ChangePermission(permission)
+
+	return s.saveProjectMemberPersistence.SaveMember(member)
+}
+
+// RemoveProjectMemberUsecase implementation.
+type RemoveProjectMemberService struct {
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	deleteProjectMemberPersistence persistence.DeleteProjectMemberPersistence
+}
+
+func NewRemoveProjectMemberService(
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	deleteProjectMemberPersistence persistence.DeleteProjectMemberPersistence,
+) *RemoveProjectMemberService {
+	return &RemoveProjectMemberService{getProjectMemberPersistence, deleteProjectMemberPersistence}
+}
+
+func (s *RemoveProjectMemberService) Remove(userId string, projectId string, memberId string) error {
+
+	required := value.PermissionOwner
+
+	// Any member can leave project.
+	if userId == memberId {
+		required = value.PermissionViewer
+	}
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, projectId, value.NewUserId(userId), required)
+
+	if err != nil {
+		return err
+	}
+
+	mid := value.NewUserId(memberId)
+
+	member, err := s.getProjectMemberPersistence.GetMember(projectId, mid)
+
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		return validation.ErrProjectMemberNotFound
+	}
+
+	if member.Permission() == value.PermissionOwner {
+
+		ok, err := hasOtherOwner(s.getProjectMemberPersistence, projectId, mid)
+
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return validation.ErrLastProjectOwner
+		}
+	}
+
+	return s.deleteProjectMemberPersistence.DeleteMember(projectId, mid)
+}
+
+// InviteProjectMemberUsecase implementation.
+type InviteProjectMemberService struct {
+	getProjectPersistence persistence.GetProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createProjectInvitePersistence persistence.CreateProjectInvitePersistence
+	getUserPersistence persistence.GetUserPersistence
+	tokenGenerator token.TokenGenerator
+	mailer mailer.Mailer
+}
+
+func NewInviteProjectMemberService(
+	getProjectPersistence persistence.GetProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createProjectInvitePersistence persistence.CreateProjectInvitePersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	tokenGenerator token.TokenGenerator,
+	mailer mailer.Mailer,
+) *InviteProjectMemberService {
+	return &InviteProjectMemberService{
+		getProjectPersistence,
+		getProjectMemberPersistence,
+		createProjectInvitePersistence,
+		getUserPersistence,
+		tokenGenerator,
+		mailer,
+	}
+}
+
+func (s *InviteProjectMemberService) Invite(command *inDto.InviteProjectMemberCommand) error {
+
+	if !value.IsEmail(command.Email) {
+		return validation.ErrInvalidEmail
+	}
+
+	if !value.IsPermission(command.Permission) {
+		return validation.ErrInvalidPermission
+	}
+
+	inviterId := value.NewUserId(command.UserId)
+
+	_, err := authorizeProject(s.getProjectMemberPersistence, command.ProjectId, inviterId, value.PermissionOwner)
+
+	if err != nil {
+		return err
+	}
+
+	project, err := s.getProjectPersistence.Get(command.ProjectId)
+
+	if err != nil {
+		return err
+	}
+
+	if project == nil {
+		return validation.ErrProjectNotFound
+	}
+
+	email := value.NewEmail(command.Email)
+
+	invitee, err := s.getUserPersistence.GetByEmail(email)
+
+	if err != nil {
+		return err
+	}
+
+	if invitee != nil {
+
+		member, err := s.getProjectMemberPersistence.GetMember(command.ProjectId, invitee.Id())
+
+		if err != nil {
+			return err
+		}
+
+		if member != nil {
+			return validation.ErrAlreadyProjectMember
+		}
+	}
+
+	inviteToken, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	permission := value.NewPermission(command.Permission)
+
+	err = s.createProjectInvitePersistence.CreateInvite(&outDto.CreateProjectInviteCommand{
+		ProjectId: command.ProjectId,
+		Email: email,
+		Permission: permission,
+		TokenHash: s.tokenGenerator.Hash(inviteToken),
+		InvitedBy: inviterId,
+		ExpiresAt: time.Now().Add(ProjectInviteLifetime),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(
+		email,
+		fmt.Sprintf("You are invited to %s on GoDo", project.Name()),
+		fmt.Sprintf(
+			"You were invited to join project %s as %s.\n\n" +
+			"Invite token: %s\n\n" +
+			"Sign in or sign up with this address and accept the invite with the token. " +
+			"This token expires in %d days.",
+			project.Name(),
+			permission.Value(),
+			inviteToken,
+			int(ProjectInviteLifetime.Hours() / 24),
+		),
+	)
+}
+
+// AcceptProjectInviteUsecase implementation.
+type AcceptProjectInviteService struct {
+	getProjectInvitePersistence persistence.GetProjectInvitePersistence
+	updateProjectInvitePersistence persistence.UpdateProjectInvitePersistence
+	getProjectPersistence persistence.GetProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	saveProjectMemberPersistence persistence.SaveProjectMemberPersistence
+	getUserPersistence persistence.GetUserPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewAcceptProjectInviteService(
+	getProjectInvitePersistence persistence.GetProjectInvitePersistence,
+	updateProjectInvitePersistence persistence.UpdateProjectInvitePersistence,
+	getProjectPersistence persistence.GetProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	saveProjectMemberPersistence persistence.SaveProjectMemberPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	tokenGenerator token.TokenGenerator,
+) *AcceptProjectInviteService {
+	return &AcceptProjectInviteService{
+		getProjectInvitePersistence,
+		updateProjectInvitePersistence,
+		getProjectPersistence,
+		getProjectMemberPersistence,
+		saveProjectMemberPersistence,
+		getUserPersistence,
+		tokenGenerator,
+	}
+}
+
+func (s *AcceptProjectInviteService) Accept(command *inDto.AcceptProjectInviteCommand) (*inDto.ProjectDto, error) {
+
+	if strings.TrimSpace(command.Token) == "" {
+		return nil, validation.ErrInvalidProjectInvite
+	}
+
+	invite, err := s.getProjectInvitePersistence.GetInviteByTokenHash(s.tokenGenerator.Hash(command.Token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if invite == nil || !invite.IsAcceptable(now) {
+		return nil, validation.ErrInvalidProjectInvite
+	}
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(command.UserId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	if !strings.EqualFold(user.Email().Value(), invite.Email().Value()) {
+		return nil, validation.ErrProjectInviteEmailMismatch
+	}
+
+	project, err := s.getProjectPersistence.Get(invite.ProjectId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if project == nil {
+		return nil, validation.ErrInvalidProjectInvite
+	}
+
+	member, err := s.getProjectMemberPersistence.GetMember(project.Id(), user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Accepting never lowers permission of existing member.
+	if member == nil {
+		member = entity.NewProjectMember(project.Id(), user.Id(), invite.Permission())
+	} else if !member.Permission().Allows(invite.Permission()) {
+		member.ChangePermission(invite.Permission())
+	}
+
+	if err := s.saveProjectMemberPersistence.SaveMember(member); err != nil {
+		return nil, err
+	}
+
+	invite.Accept(now)
+
+	if err := s.updateProjectInvitePersistence.UpdateInvite(invite); err != nil {
+		return nil, err
+	}
+
+	return toProjectDto(project, member), nil
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Get todo item user has required permission on.
+func getAuthorizedTodo(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	todoId string,
+	userId value.UserId,
+	required value.Permission,
+) (*entity.TodoItem, error) {
+
+	todo, err := getTodoPersistence.Get(value.NewTodoItemId(todoId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if todo == nil {
+		return nil, validation.ErrTodoNotDound
+	}
+
+	if err := authorizeTodo(getProjectMemberPersistence, todo, userId, required); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// AssignTodoUsecase implementation.
+type AssignTodoService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	updateTodoPersistence persistence.UpdateTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getUserPersistence persistence.GetUserPersistence
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
+}
+
+func NewAssignTodoService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	updateTodoPersistence persistence.UpdateTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *AssignTodoService {
+	return &AssignTodoService{
+		getTodoPersistence,
+		updateTodoPersistence,
+		getProjectMemberPersistence,
+		getUserPersistence,
+		createTodoAssignmentPersistence,
+		createTodoUndoPersistence,
+		tokenGenerator,
+		todoEventBus,
+	}
+}
+
+func (s *AssignTodoService) Assign(command *inDto.AssignTodoCommand) (string, error) {
+
+	userId := value.NewUserId(command.UserId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, command.TodoId, userId, value.PermissionEditor)
+
+	if err != nil {
+		return "", err
+	}
+
+	if command.AssigneeId == "" {
+		return "", validation.ErrInvalidAssignee
+	}
+
+	assigneeId := value.NewUserId(command.AssigneeId)
+
+	assignee, err := s.getUserPersistence.GetById(assigneeId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if assignee == nil {
+		return "", validation.ErrInvalidAssignee
+	}
+
+	err = authorizeTodo(s.getProjectMemberPersistence, todo, assigneeId, value.PermissionViewer)
+
+	if err == validation.ErrTodoNotDound {
+		return "", validation.ErrInvalidAssignee
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if todo.IsAssignedTo(assigneeId) {
+		return "", nil
+	}
+
+	before := todo.Copy()
+
+	todo.AssignTo(assigneeId)
+
+	if err := s.updateTodoPersistence.Update(todo); err != nil {
+		return "", err
+	}
+
+	err = s.createTodoAssignmentPersistence.Create(&outDto.CreateTodoAssignmentCommand{
+		TodoId: todo.Id(),
+		AssigneeId: &assigneeId,
+		AssignedBy: userId,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(before, todo)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, userId, change)
+}
+
+// UnassignTodoUsecase implementation.
+type UnassignTodoService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	updateTodoPersistence persistence.UpdateTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
+}
+
+func NewUnassignTodoService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	updateTodoPersistence persistence.UpdateTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *UnassignTodoService {
+	return &UnassignTodoService{
+		getTodoPersistence,
+		updateTodoPersistence,
+		getProjectMemberPersistence,
+		createTodoAssignmentPersistence,
+		createTodoUndoPersistence,
+		tokenGenerator,
+		todoEventBus,
+	}
+}
+
+func (s *UnassignTodoService) Unassign(userId string, todoId string) (string, error) {
+
+	uid := value.NewUserId(userId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, uid, value.PermissionEditor)
+
+	if err != nil {
+		return "", err
+	}
+
+	if todo.AssigneeId() == nil {
+		return "", nil
+	}
+
+	before := todo.Copy()
+
+	todo.Unassign()
+
+	if err := s.updateTodoPersistence.Update(todo); err != nil {
+		return "", err
+	}
+
+	err = s.createTodoAssignmentPersistence.Create(&outDto.CreateTodoAssignmentCommand{
+		TodoId: todo.Id(),
+		AssigneeId: nil,
+		AssignedBy: uid,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(before, todo)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, uid, change)
+}
+
+// ListAssignedTodosUsecase implementation.
+type ListAssignedTodosService struct {
+	listTodoPersistence persistence.ListTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+}
+
+func NewListAssignedTodosService(
+	listTodoPersistence persistence.ListTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *ListAssignedTodosService {
+	return &ListAssignedTodosService{listTodoPersistence, getProjectMemberPersistence}
+}
+
+func (s *ListAssignedTodosService) List(userId string) ([]*inDto.TodoItemDto, error) {
+
+	uid := value.NewUserId(userId)
+
+	todos, err := s.listTodoPersistence.ListAssigned(uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.TodoItemDto, 0, len(todos))
+
+	for _, todo := range todos {
+
+		err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionViewer)
+
+		// Access was lost after assignment.
+		if err == validation.ErrTodoNotDound {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		dtos = append(dtos, toTodoItemDto(todo))
+	}
+
+	return dtos, nil
+}
+
+// ListTodoAssignmentsUsecase implementation.
+type ListTodoAssignmentsService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAssignmentPersistence persistence.ListTodoAssignmentPersistence
+}
+
+func NewListTodoAssignmentsService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAssignmentPersistence persistence.ListTodoAssignmentPersistence,
+) *ListTodoAssignmentsService {
+	return &ListTodoAssignmentsService{getTodoPersistence, getProjectMemberPersistence, listTodoAssignmentPersistence}
+}
+
+func (s *ListTodoAssignmentsService) List(userId string, todoId string) ([]*inDto.TodoAssignmentDto, error) {
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.listTodoAssignmentPersistence.List(todo.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.TodoAssignmentDto, len(assignments))
+
+	for i, a := range assignments {
+
+		dtos[i] = &inDto.TodoAssignmentDto{
+			Id: a.Id(),
+			AssignedBy: a.AssignedBy().Value(),
+			CreatedAt: a.CreatedAt(),
+		}
+
+		if a.AssigneeId() != nil {
+			dtos[i].AssigneeId = a.AssigneeId().Value()
+		}
+	}
+
+	return dtos, nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Max size of attached file in bytes.
+const MaxTodoAttachmentSize = 10 << 20
+
+// Max characters of attached file name.
+const maxTodoAttachmentFileNameLength = 255
+
+// Bytes read for sniffing content type. Same as http.DetectContentType considers.
+const sniffLength = 512
+
+// Strip directories and control characters clients may send in file name.
+func validateTodoAttachmentFileName(fileName string) (string, error) {
+
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+
+	fileName = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, fileName))
+
+	if fileName == "" || fileName == "." || fileName == "/" || utf8.RuneCountInString(fileName) > maxTodoAttachmentFileNameLength {
+		return "", validation.ErrInvalidTodoAttachment
+	}
+
+	return fileName, nil
+}
+
+// Get attachment of todo item.
+func getTodoAttachment(
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence,
+	todo *entity.TodoItem,
+	attachmentId string,
+) (*entity.TodoAttachment, error) {
+
+	attachment, err := getTodoAttachmentPersistence.Get(attachmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment == nil || attachment.TodoId() != todo.Id() {
+		return nil, validation.ErrTodoAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+// List storage keys of files attached to todo items.
+// Collect them before deleting todo items as metadata is deleted along with them.
+func listTodoAttachmentKeys(
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	todos []*entity.TodoItem,
+) ([]string, error) {
+
+	keys := make([]string, 0)
+
+	for _, todo := range todos {
+
+		attachments, err := listTodoAttachmentPersistence.List(todo.Id())
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attachment := range attachments {
+			keys = append(keys, attachment.StorageKey())
+		}
+	}
+
+	return keys, nil
+}
+
+// Delete blobs, trying all keys even if some fail.
+func deleteBlobs(blobStorage storage.BlobStorage, keys []string) error {
+
+	errs := make([]error, 0)
+
+	for _, key := range keys {
+		if err := blobStorage.Delete(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func toTodoAttachmentDto(attachment *entity.TodoAttachment) *inDto.TodoAttachmentDto {
+	return &inDto.TodoAttachmentDto{
+		Id: attachment.Id(),
+		TodoId: attachment.TodoId().Value(),
+		UploaderId: attachment.UploaderId().Value(),
+		FileName: attachment.FileName(),
+		ContentType: attachment.ContentType(),
+		Size: attachment.Size(),
+		CreatedAt: attachment.CreatedAt(),
+	}
+}
+
+// ListTodoAttachmentsUsecase implementation.
+type ListTodoAttachmentsService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+}
+
+func NewListTodoAttachmentsService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+) *ListTodoAttachmentsService {
+	return &ListTodoAttachmentsService{getTodoPersistence, getProjectMemberPersistence, listTodoAttachmentPersistence}
+}
+
+func (s *ListTodoAttachmentsService) List(userId string, todoId string) ([]*inDto.TodoAttachmentDto, error) {
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.listTodoAttachmentPersistence.List(todo.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*inDto.TodoAttachmentDto, len(attachments))
+
+	for i, attachment := range attachments {
+		dtos[i] = toTodoAttachmentDto(attachment)
+	}
+
+	return dtos, nil
+}
+
+// UploadTodoAttachmentUsecase implementation.
+type UploadTodoAttachmentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoAttachmentPersistence persistence.CreateTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+	tokenGenerator token.TokenGenerator
+}
+
+func NewUploadTodoAttachmentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoAttachmentPersistence persistence.CreateTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+	tokenGenerator token.TokenGenerator,
+) *UploadTodoAttachmentService {
+	return &UploadTodoAttachmentService{
+		getTodoPersistence,
+		getProjectMemberPersistence,
+		createTodoAttachmentPersistence,
+		blobStorage,
+		tokenGenerator,
+	}
+}
+
+// Editors can attach files. Content type is sniffed from content, not trusted from client.
+func (s *UploadTodoAttachmentService) Upload(command *inDto.UploadTodoAttachmentCommand) (*inDto.TodoAttachmentDto, error) {
+
+	if command.Size > MaxTodoAttachmentSize {
+		return nil, validation.ErrTodoAttachmentTooLarge
+	}
+
+	if command.Size <= 0 {
+		return nil, validation.ErrInvalidTodoAttachment
+	}
+
+	fileName, err := validateTodoAttachmentFileName(command.FileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, command.TodoId, userId, value.PermissionEditor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, min(command.Size, sniffLength))
+
+	n, err := io.ReadFull(command.Content, head)
+
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	// Content shorter than declared.
+	if int64(n) < int64(len(head)) {
+		return nil, validation.ErrInvalidTodoAttachment
+	}
+
+	contentType := http.DetectContentType(head)
+
+	key, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	key = "todo-attachments/" + todo.Id().Value() + "/" + key
+
+	content := io.MultiReader(bytes.NewReader(head), io.LimitReader(command.Content, command.Size - int64(n)))
+
+	if err := s.blobStorage.Put(key, content, command.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.createTodoAttachmentPersistence.Create(&outDto.CreateTodoAttachmentCommand{
+		TodoId: todo.Id(),
+		UploaderId: userId,
+		FileName: fileName,
+		ContentType: contentType,
+		Size: command.Size,
+		StorageKey: key,
+	})
+
+	if err != nil {
+		return nil, errors.Join(err, s.blobStorage.Delete(key))
+	}
+
+	return toTodoAttachmentDto(attachment), nil
+}
+
+// DownloadTodoAttachmentUsecase implementation.
+type DownloadTodoAttachmentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+}
+
+func NewDownloadTodoAttachmentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+) *DownloadTodoAttachmentService {
+	return &DownloadTodoAttachmentService{getTodoPersistence, getProjectMemberPersistence, getTodoAttachmentPersistence, blobStorage}
+}
+
+func (s *DownloadTodoAttachmentService) Download(userId string, todoId string, attachmentId string) (*inDto.TodoAttachmentContent, error) {
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := getTodoAttachment(s.getTodoAttachmentPersistence, todo, attachmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.blobStorage.Get(attachment.StorageKey())
+
+	if err == storage.ErrBlobNotFound {
+		return nil, validation.ErrTodoAttachmentNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.TodoAttachmentContent{
+		Attachment: toTodoAttachmentDto(attachment),
+		Content: content,
+	}, nil
+}
+
+// DeleteTodoAttachmentUsecase implementation.
+type DeleteTodoAttachmentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence
+	deleteTodoAttachmentPersistence persistence.DeleteTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+}
+
+func NewDeleteTodoAttachmentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getTodoAttachmentPersistence persistence.GetTodoAttachmentPersistence,
+	deleteTodoAttachmentPersistence persistence.DeleteTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+) *DeleteTodoAttachmentService {
+	return &DeleteTodoAttachmentService{
+		getTodoPersistence,
+		getProjectMemberPersistence,
+		getTodoAttachmentPersistence,
+		deleteTodoAttachmentPersistence,
+		blobStorage,
+	}
+}
+
+func (s *DeleteTodoAttachmentService) Delete(userId string, todoId string, attachmentId string) error {
+
+	uid := value.NewUserId(userId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, uid, value.PermissionEditor)
+
+	if err != nil {
+		return err
+	}
+
+	attachment, err := getTodoAttachment(s.getTodoAttachmentPersistence, todo, attachmentId)
+
+	if err != nil {
+		return err
+	}
+
+	// Owners moderate files of others.
+	if !attachment.IsUploadedBy(uid) {
+		if err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionOwner); err != nil {
+			return err
+		}
+	}
+
+	if err := s.deleteTodoAttachmentPersistence.Delete(attachment.Id()); err != nil {
+		return err
+	}
+
+	return s.blobStorage.Delete(attachment.StorageKey())
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Max operations of todo batch.
+const MaxBatchOperations = 100
+
+// Remembers memberships so batch looks each project up once.
+type cachedProjectMemberPersistence struct {
+	persistence.GetProjectMemberPersistence
+	members map[string]*entity.ProjectMember
+}
+
+func (p *cachedProjectMemberPersistence) GetMember(projectId string, userId value.UserId) (*entity.ProjectMember, error) {
+
+	// Batch runs for single user, so project alone identifies membership.
+	if member, ok := p.members[projectId]; ok {
+		return member, nil
+	}
+
+	member, err := p.GetProjectMemberPersistence.GetMember(projectId, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.members[projectId] = member
+
+	return member, nil
+}
+
+// BatchTodoUsecase implementation.
+type BatchTodoService struct {
+	getTodoBatchPersistence persistence.GetTodoBatchPersistence
+	writeTodoBatchPersistence persistence.WriteTodoBatchPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
+}
+
+func NewBatchTodoService(
+	getTodoBatchPersistence persistence.GetTodoBatchPersistence,
+	writeTodoBatchPersistence persistence.WriteTodoBatchPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *BatchTodoService {
+	return &BatchTodoService{
+		getTodoBatchPersistence,
+		writeTodoBatchPersistence,
+		getProjectMemberPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+		createTodoUndoPersistence,
+		tokenGenerator,
+		todoEventBus,
+	}
+}
+
+// Operations apply in order, so later ones see changes of earlier ones.
+// Everything that succeeds is written in one transaction.
+func (s *BatchTodoService) Run(command *inDto.BatchTodoCommand) ([]*inDto.BatchTodoResultDto, string, error) {
+
+	if len(command.Operations) == 0 || len(command.Operations) > MaxBatchOperations {
+		return nil, "", validation.ErrInvalidBatchSize
+	}
+
+	todoIds := make([]value.TodoItemId, 0, len(command.Operations))
+
+	for _, op := range command.Operations {
+		// Invalid IDs fail only their own operation.
+		if value.IsTodoItemId(op.TodoId) {
+			todoIds = append(todoIds, value.NewTodoItemId(op.TodoId))
+		}
+	}
+
+	found, err := s.getTodoBatchPersistence.GetMany(todoIds)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	todos := make(map[value.TodoItemId]*entity.TodoItem, len(found))
+	// States before batch to undo it.
+	originals := make(map[value.TodoItemId]*entity.TodoItem, len(found))
+
+	for _, todo := range found {
+		todos[todo.Id()] = todo
+		originals[todo.Id()] = todo.Copy()
+	}
+
+	run := &todoBatchRun{
+		userId: value.NewUserId(command.UserId),
+		members: &cachedProjectMemberPersistence{s.getProjectMemberPersistence, make(map[string]*entity.ProjectMember)},
+		todos: todos,
+		changed: make(map[value.TodoItemId]bool),
+		deleted: make([]*entity.TodoItem, 0),
+	}
+
+	results := make([]*inDto.BatchTodoResultDto, len(command.Operations))
+
+	failed := false
+
+	for i, op := range command.Operations {
+
+		err := run.apply(op)
+
+		if err != nil {
+
+			// Unexpected errors abort batch in any mode.
+			if _, ok := err.(*validation.ValidationError); !ok {
+				return nil, "", err
+			}
+
+			failed = true
+		}
+
+		results[i] = &inDto.BatchTodoResultDto{Op: op.Op, TodoId: op.TodoId, Error: err}
+	}
+
+	if failed && command.Atomic {
+		return results, "", validation.ErrBatchAborted
+	}
+
+	batch := &outDto.TodoBatchCommand{
+		Updates: make([]*entity.TodoItem, 0),
+		Deletes: make([]value.TodoItemId, 0),
+	}
+
+	changes := make([]*entity.TodoChange, 0)
+
+	for _, todoId := range todoIds {
+		if run.changed[todoId] {
+			batch.Updates = append(batch.Updates, todos[todoId])
+			changes = append(changes, entity.NewTodoChange(originals[todoId], todos[todoId]))
+			delete(run.changed, todoId)
+		}
+	}
+
+	for _, todo := range run.deleted {
+		batch.Deletes = append(batch.Deletes, todo.Id())
+		changes = append(changes, entity.NewTodoChange(originals[todo.Id()], nil))
+	}
+
+	keys, err := listTodoAttachmentKeys(s.listTodoAttachmentPersistence, run.deleted)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.writeTodoBatchPersistence.WriteBatch(batch); err != nil {
+		return nil, "", err
+	}
+
+	if err := deleteBlobs(s.blobStorage, keys); err != nil {
+		return nil, "", err
+	}
+
+	if err := publishTodoChanges(s.todoEventBus, run.members, changes...); err != nil {
+		return nil, "", err
+	}
+
+	undoToken, err := recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, run.userId, changes...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return results, undoToken, nil
+}
+
+// State of batch while operations are applied.
+type todoBatchRun struct {
+	userId value.UserId
+	members *cachedProjectMemberPersistence
+	// Todo items not deleted by batch so far.
+	todos map[value.TodoItemId]*entity.TodoItem
+	changed map[value.TodoItemId]bool
+	deleted []*entity.TodoItem
+}
+
+// Apply operation. Todo item is left untouched when operation fails.
+func (r *todoBatchRun) apply(op *inDto.BatchTodoOperation) error {
+
+	if !value.IsTodoItemId(op.TodoId) {
+		return validation.ErrInvalidTodoItemId
+	}
+
+	todo, ok := r.todos[value.NewTodoItemId(op.TodoId)]
+
+	if !ok {
+		return validation.ErrTodoNotDound
+	}
+
+	if err := authorizeTodo(r.members, todo, r.userId, value.PermissionEditor); err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case inDto.BatchOpComplete:
+		todo.Complete()
+	case inDto.BatchOpUncomplete:
+		todo.Uncomplete()
+	case inDto.BatchOpUpdate:
+		if op.Title != nil && !value.IsTodoItemTitle(*op.Title) {
+			return validation.ErrInvalidTodoTitle
+		}
+		if op.Description != nil && !value.IsTodoItemDescription(*op.Description) {
+			return validation.ErrInvalidTodoDescription
+		}
+		if op.Title != nil {
+			todo.ChangeTitle(*op.Title)
+		}
+		if op.Description != nil {
+			todo.ChangeDescription(*op.Description)
+		}
+	case inDto.BatchOpMove:
+		if err := r.authorizeMove(todo, op.ProjectId); err != nil {
+			return err
+		}
+		todo.MoveToProject(op.ProjectId)
+	case inDto.BatchOpDelete:
+		delete(r.todos, todo.Id())
+		delete(r.changed, todo.Id())
+		r.deleted = append(r.deleted, todo)
+		return nil
+	default:
+		return validation.ErrInvalidBatchOperation
+	}
+
+	r.changed[todo.Id()] = true
+
+	return nil
+}
+
+// Moving needs editor permission on target project.
+// Only its own user can take todo item out of project, as it becomes their personal one.
+func (r *todoBatchRun) authorizeMove(todo *entity.TodoItem, projectId string) error {
+
+	if projectId == "" {
+
+		if todo.UserId() != r.userId {
+			return validation.ErrPermissionDenied
+		}
+
+		return nil
+	}
+
+	_, err := authorizeProject(r.members, projectId, r.userId, value.PermissionEditor)
+
+	return err
+}
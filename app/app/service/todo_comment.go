@@ -0,0 +1,385 @@
+package service
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Max characters of comment body.
+const MaxTodoCommentLength = 10000
+
+func validateTodoCommentBody(body string) (string, error) {
+
+	body = strings.TrimSpace(body)
+
+	if body == "" || utf8.RuneCountInString(body) > MaxTodoCommentLength {
+		return "", validation.ErrInvalidTodoCommentBody
+	}
+
+	return body, nil
+}
+
+// Get comment on todo item.
+func getTodoComment(
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence,
+	todo *entity.TodoItem,
+	commentId string,
+) (*entity.TodoComment, error) {
+
+	comment, err := getTodoCommentPersistence.Get(commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment == nil || comment.TodoId() != todo.Id() {
+		return nil, validation.ErrTodoCommentNotFound
+	}
+
+	return comment, nil
+}
+
+func toTodoCommentDto(comment *entity.TodoComment, author *entity.User) *inDto.TodoCommentDto {
+
+	dto := &inDto.TodoCommentDto{
+		Id: comment.Id(),
+		TodoId: comment.TodoId().Value(),
+		AuthorId: comment.AuthorId().Value(),
+		Body: comment.Body(),
+		Mentions: comment.Mentions(),
+		CreatedAt: comment.CreatedAt(),
+		EditedAt: comment.EditedAt(),
+	}
+
+	if author != nil {
+		dto.AuthorName = author.UserName().Value()
+	}
+
+	return dto
+}
+
+// ListTodoCommentsUsecase implementation.
+type ListTodoCommentsService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoCommentPersistence persistence.ListTodoCommentPersistence
+	getUserPersistence persistence.GetUserPersistence
+}
+
+func NewListTodoCommentsService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoCommentPersistence persistence.ListTodoCommentPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+) *ListTodoCommentsService {
+	return &ListTodoCommentsService{getTodoPersistence, getProjectMemberPersistence, listTodoCommentPersistence, getUserPersistence}
+}
+
+func (s *ListTodoCommentsService) List(userId string, todoId string) ([]*inDto.TodoCommentDto, error) {
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, value.NewUserId(userId), value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.listTodoCommentPersistence.List(todo.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make(map[value.UserId]*entity.User)
+
+	dtos := make([]*inDto.TodoCommentDto, len(comments))
+
+	for i, comment := range comments {
+
+		author, ok := authors[comment.AuthorId()]
+
+		if !ok {
+
+			author, err = s.getUserPersistence.GetById(comment.AuthorId())
+
+			if err != nil {
+				return nil, err
+			}
+
+			authors[comment.AuthorId()] = author
+		}
+
+		dtos[i] = toTodoCommentDto(comment, author)
+	}
+
+	return dtos, nil
+}
+
+// ListTodoCommentsBatchUsecase implementation.
+type ListTodoCommentsBatchService struct {
+	getTodoBatchPersistence persistence.GetTodoBatchPersistence
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence
+	listTodoCommentBatchPersistence persistence.ListTodoCommentBatchPersistence
+	getUserBatchPersistence persistence.GetUserBatchPersistence
+}
+
+func NewListTodoCommentsBatchService(
+	getTodoBatchPersistence persistence.GetTodoBatchPersistence,
+	listProjectMemberBatchPersistence persistence.ListProjectMemberBatchPersistence,
+	listTodoCommentBatchPersistence persistence.ListTodoCommentBatchPersistence,
+	getUserBatchPersistence persistence.GetUserBatchPersistence,
+) *ListTodoCommentsBatchService {
+	return &ListTodoCommentsBatchService{getTodoBatchPersistence, listProjectMemberBatchPersistence, listTodoCommentBatchPersistence, getUserBatchPersistence}
+}
+
+func (s *ListTodoCommentsBatchService) ListMany(userId string, todoIds []string) (map[string][]*inDto.TodoCommentDto, error) {
+
+	dtos := make(map[string][]*inDto.TodoCommentDto)
+
+	ids := make([]value.TodoItemId, 0, len(todoIds))
+
+	for _, id := range todoIds {
+		if value.IsTodoItemId(id) {
+			ids = append(ids, value.NewTodoItemId(id))
+		}
+	}
+
+	if len(ids) == 0 {
+		return dtos, nil
+	}
+
+	todos, err := s.getTodoBatchPersistence.GetMany(ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectIds := make([]string, 0)
+
+	for _, todo := range todos {
+		if todo.ProjectId() != "" {
+			projectIds = append(projectIds, todo.ProjectId())
+		}
+	}
+
+	members, err := loadProjectMembers(s.listProjectMemberBatchPersistence, projectIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	uid := value.NewUserId(userId)
+
+	visible := make([]value.TodoItemId, 0, len(todos))
+
+	for _, todo := range todos {
+		if authorizeTodo(members, todo, uid, value.PermissionViewer) == nil {
+			visible = append(visible, todo.Id())
+			dtos[todo.Id().Value()] = make([]*inDto.TodoCommentDto, 0)
+		}
+	}
+
+	if len(visible) == 0 {
+		return dtos, nil
+	}
+
+	comments, err := s.listTodoCommentBatchPersistence.ListByTodos(visible)
+
+	if err != nil {
+		return nil, err
+	}
+
+	authorIds := make([]value.UserId, len(comments))
+
+	for i, comment := range comments {
+		authorIds[i] = comment.AuthorId()
+	}
+
+	authors, err := getUsers(s.getUserBatchPersistence, authorIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		todoId := comment.TodoId().Value()
+		dtos[todoId] = append(dtos[todoId], toTodoCommentDto(comment, authors[comment.AuthorId()]))
+	}
+
+	return dtos, nil
+}
+
+// AddTodoCommentUsecase implementation.
+type AddTodoCommentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoCommentPersistence persistence.CreateTodoCommentPersistence
+	getUserPersistence persistence.GetUserPersistence
+}
+
+func NewAddTodoCommentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoCommentPersistence persistence.CreateTodoCommentPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+) *AddTodoCommentService {
+	return &AddTodoCommentService{getTodoPersistence, getProjectMemberPersistence, createTodoCommentPersistence, getUserPersistence}
+}
+
+// Anyone who can see todo item can comment on it.
+func (s *AddTodoCommentService) Add(command *inDto.AddTodoCommentCommand) (*inDto.TodoCommentDto, error) {
+
+	body, err := validateTodoCommentBody(command.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, command.TodoId, userId, value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.createTodoCommentPersistence.Create(&outDto.CreateTodoCommentCommand{
+		TodoId: todo.Id(),
+		AuthorId: userId,
+		Body: body,
+		Mentions: entity.ParseMentions(body),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.getUserPersistence.GetById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toTodoCommentDto(comment, author), nil
+}
+
+// EditTodoCommentUsecase implementation.
+type EditTodoCommentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence
+	updateTodoCommentPersistence persistence.UpdateTodoCommentPersistence
+	getUserPersistence persistence.GetUserPersistence
+}
+
+func NewEditTodoCommentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence,
+	updateTodoCommentPersistence persistence.UpdateTodoCommentPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+) *EditTodoCommentService {
+	return &EditTodoCommentService{
+		getTodoPersistence,
+		getProjectMemberPersistence,
+		getTodoCommentPersistence,
+		updateTodoCommentPersistence,
+		getUserPersistence,
+	}
+}
+
+func (s *EditTodoCommentService) Edit(command *inDto.EditTodoCommentCommand) (*inDto.TodoCommentDto, error) {
+
+	body, err := validateTodoCommentBody(command.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, command.TodoId, userId, value.PermissionViewer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := getTodoComment(s.getTodoCommentPersistence, todo, command.CommentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !comment.IsWrittenBy(userId) {
+		return nil, validation.ErrPermissionDenied
+	}
+
+	comment.Edit(body, time.Now())
+
+	if err := s.updateTodoCommentPersistence.Update(comment); err != nil {
+		return nil, err
+	}
+
+	author, err := s.getUserPersistence.GetById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toTodoCommentDto(comment, author), nil
+}
+
+// DeleteTodoCommentUsecase implementation.
+type DeleteTodoCommentService struct {
+	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence
+	deleteTodoCommentPersistence persistence.DeleteTodoCommentPersistence
+}
+
+func NewDeleteTodoCommentService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	getTodoCommentPersistence persistence.GetTodoCommentPersistence,
+	deleteTodoCommentPersistence persistence.DeleteTodoCommentPersistence,
+) *DeleteTodoCommentService {
+	return &DeleteTodoCommentService{
+		getTodoPersistence,
+		getProjectMemberPersistence,
+		getTodoCommentPersistence,
+		deleteTodoCommentPersistence,
+	}
+}
+
+func (s *DeleteTodoCommentService) Delete(userId string, todoId string, commentId string) error {
+
+	uid := value.NewUserId(userId)
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, uid, value.PermissionViewer)
+
+	if err != nil {
+		return err
+	}
+
+	comment, err := getTodoComment(s.getTodoCommentPersistence, todo, commentId)
+
+	if err != nil {
+		return err
+	}
+
+	// Owners moderate comments of others.
+	if !comment.IsWrittenBy(uid) {
+		if err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionOwner); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteTodoCommentPersistence.Delete(comment.Id())
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Type of event telling users who keep access what the change did.
+func todoEventType(change *entity.TodoChange) string {
+
+	switch {
+	case change.Before() == nil:
+		return entity.TodoEventCreated
+	case change.After() == nil:
+		return entity.TodoEventDeleted
+	case !change.Before().IsDone() && change.After().IsDone():
+		return entity.TodoEventCompleted
+	default:
+		return entity.TodoEventUpdated
+	}
+}
+
+// Users having access to todo item, looking each project up once.
+type todoEventRecipients struct {
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	members map[string][]value.UserId
+}
+
+func (r *todoEventRecipients) of(todo *entity.TodoItem) ([]value.UserId, error) {
+
+	if todo == nil {
+		return nil, nil
+	}
+
+	if todo.ProjectId() == "" {
+		return []value.UserId{todo.UserId()}, nil
+	}
+
+	if userIds, ok := r.members[todo.ProjectId()]; ok {
+		return userIds, nil
+	}
+
+	members, err := r.getProjectMemberPersistence.ListMembers(todo.ProjectId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]value.UserId, len(members))
+
+	for i, member := range members {
+		userIds[i] = member.UserId()
+	}
+
+	r.members[todo.ProjectId()] = userIds
+
+	return userIds, nil
+}
+
+// Push changes to users having access to changed todo items.
+// Users gaining or losing access by move see todo item created or deleted.
+func publishTodoChanges(
+	todoEventBus event.TodoEventBus,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	changes ...*entity.TodoChange,
+) error {
+
+	recipients := &todoEventRecipients{getProjectMemberPersistence, make(map[string][]value.UserId)}
+
+	events := make([]*outDto.PublishTodoEventCommand, 0)
+
+	for _, change := range changes {
+
+		before, err := recipients.of(change.Before())
+
+		if err != nil {
+			return err
+		}
+
+		after, err := recipients.of(change.After())
+
+		if err != nil {
+			return err
+		}
+
+		kept := make(map[value.UserId]bool, len(before))
+
+		for _, userId := range before {
+			kept[userId] = false
+		}
+
+		for _, userId := range after {
+
+			eventType := entity.TodoEventCreated
+
+			if _, ok := kept[userId]; ok {
+				eventType = todoEventType(change)
+				kept[userId] = true
+			}
+
+			events = append(events, &outDto.PublishTodoEventCommand{UserId: userId, Type: eventType, Todo: change.After()})
+		}
+
+		for _, userId := range before {
+			if !kept[userId] {
+				events = append(events, &outDto.PublishTodoEventCommand{UserId: userId, Type: entity.TodoEventDeleted, Todo: change.Before()})
+			}
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return todoEventBus.Publish(events)
+}
+
+// SubscribeTodoEventsUsecase implementation.
+type SubscribeTodoEventsService struct {
+	todoEventBus event.TodoEventBus
+}
+
+func NewSubscribeTodoEventsService(todoEventBus event.TodoEventBus) *SubscribeTodoEventsService {
+	return &SubscribeTodoEventsService{todoEventBus}
+}
+
+func (s *SubscribeTodoEventsService) Subscribe(userId string, lastEventId string) (<-chan *inDto.TodoEventDto, func(), error) {
+
+	var lastId int64
+
+	if lastEventId != "" {
+
+		id, err := strconv.ParseInt(lastEventId, 10, 64)
+
+		if err != nil || id < 0 {
+			return nil, nil, validation.ErrInvalidLastEventId
+		}
+
+		lastId = id
+	}
+
+	subscription, err := s.todoEventBus.Subscribe(value.NewUserId(userId), lastId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan *inDto.TodoEventDto)
+
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			subscription.Close()
+		})
+	}
+
+	go func() {
+
+		defer close(events)
+
+		for e := range subscription.Events() {
+			select {
+			case events <- toTodoEventDto(e):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return events, cancel, nil
+}
+
+func toTodoEventDto(e *entity.TodoEvent) *inDto.TodoEventDto {
+
+	dto := &inDto.TodoEventDto{
+		Id: strconv.FormatInt(e.Id(), 10),
+		Type: e.Type(),
+		OccurredAt: e.OccurredAt(),
+	}
+
+	if e.Todo() != nil {
+		dto.Todo = toTodoItemDto(e.Todo())
+	}
+
+	return dto
+}
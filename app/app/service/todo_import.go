@@ -0,0 +1,185 @@
+package service
+
+import (
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+const (
+	// Maximum number of todo items imported at once.
+	MaxImportTodos = 500
+	// Number of todo items exported per query.
+	exportPageSize = 500
+)
+
+// ImportTodosUsecase implementation.
+type ImportTodosService struct {
+	createTodoBatchPersistence persistence.CreateTodoBatchPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
+}
+
+func NewImportTodosService(
+	createTodoBatchPersistence persistence.CreateTodoBatchPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *ImportTodosService {
+	return &ImportTodosService{createTodoBatchPersistence, getProjectMemberPersistence, createTodoUndoPersistence, tokenGenerator, todoEventBus}
+}
+
+// Check all todo items before adding any, so import is not left half done by invalid input.
+func (s *ImportTodosService) Import(command *inDto.ImportTodosCommand) (*inDto.ImportedTodosDto, error) {
+
+	if len(command.Todos) == 0 || len(command.Todos) > MaxImportTodos {
+		return nil, validation.ErrInvalidImportSize
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	imported := &inDto.ImportedTodosDto{TodoIds: make([]string, 0), Errors: make([]*inDto.ImportTodoErrorDto, 0)}
+
+	// Outcome of permission check by project.
+	authorized := make(map[string]error)
+
+	for i, todo := range command.Todos {
+
+		err := s.check(todo, userId, authorized)
+
+		if _, ok := err.(*validation.ValidationError); ok {
+			imported.Errors = append(imported.Errors, &inDto.ImportTodoErrorDto{Index: i, Error: err})
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if command.DryRun || len(imported.Errors) > 0 {
+		return imported, nil
+	}
+
+	todos := make([]*dto.CreateTodoCommand, len(command.Todos))
+
+	for i, todo := range command.Todos {
+		todos[i] = &dto.CreateTodoCommand{
+			UserId: userId,
+			Title: value.NewTodoItemTitle(todo.Title),
+			Description: value.NewTodoItemDescription(todo.Description),
+			ProjectId: todo.ProjectId,
+			IsDone: todo.IsDone,
+		}
+	}
+
+	// All or none, so failed import can be retried as is.
+	created, err := s.createTodoBatchPersistence.CreateMany(todos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*entity.TodoChange, len(created))
+
+	for i, todo := range created {
+		imported.TodoIds = append(imported.TodoIds, todo.Id().Value())
+		changes[i] = entity.NewTodoChange(nil, todo)
+	}
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, changes...); err != nil {
+		return nil, err
+	}
+
+	undoToken, err := recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, userId, changes...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	imported.UndoToken = undoToken
+
+	return imported, nil
+}
+
+func (s *ImportTodosService) check(todo *inDto.AddTodoCommand, userId value.UserId, authorized map[string]error) error {
+
+	if !value.IsTodoItemTitle(todo.Title) {
+		return validation.ErrInvalidTodoTitle
+	}
+
+	if !value.IsTodoItemDescription(todo.Description) {
+		return validation.ErrInvalidTodoDescription
+	}
+
+	if todo.ProjectId == "" {
+		return nil
+	}
+
+	err, ok := authorized[todo.ProjectId]
+
+	if !ok {
+		_, err = authorizeProject(s.getProjectMemberPersistence, todo.ProjectId, userId, value.PermissionEditor)
+		authorized[todo.ProjectId] = err
+	}
+
+	return err
+}
+
+// ExportTodosUsecase implementation.
+type ExportTodosService struct {
+	listTodoPagePersistence persistence.ListTodoPagePersistence
+	listProjectPersistence persistence.ListProjectPersistence
+}
+
+func NewExportTodosService(listTodoPagePersistence persistence.ListTodoPagePersistence, listProjectPersistence persistence.ListProjectPersistence) *ExportTodosService {
+	return &ExportTodosService{listTodoPagePersistence, listProjectPersistence}
+}
+
+func (s *ExportTodosService) Export(userId string, each func(todo *inDto.TodoItemDto) error) error {
+
+	uid := value.NewUserId(userId)
+
+	projects, err := s.listProjectPersistence.List(uid)
+
+	if err != nil {
+		return err
+	}
+
+	projectIds := make([]string, len(projects))
+
+	for i, project := range projects {
+		projectIds[i] = project.Id()
+	}
+
+	query := &dto.ListTodoPageQuery{UserId: uid, ProjectIds: projectIds, Limit: exportPageSize}
+
+	for {
+
+		todos, err := s.listTodoPagePersistence.ListPage(query)
+
+		if err != nil {
+			return err
+		}
+
+		for _, todo := range todos {
+			if err := each(toTodoItemDto(todo)); err != nil {
+				return err
+			}
+		}
+
+		if len(todos) < query.Limit {
+			return nil
+		}
+
+		query.After = todos[len(todos) - 1].Id().Value()
+	}
+}
@@ -1,85 +1,177 @@
 package service
 
 import (
-	"errors"
-	"strings"
-
+	"github.com/kkatou7209/godo/app/domain/entity"
 	"github.com/kkatou7209/godo/app/domain/value"
 	inDto "github.com/kkatou7209/godo/app/port/in/dto"
 	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
 	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
 	"github.com/kkatou7209/godo/app/validation"
 )
 
+func toTodoItemDto(todo *entity.TodoItem) *inDto.TodoItemDto {
+
+	dto := &inDto.TodoItemDto{
+		Id: todo.Id().Value(),
+		Title: todo.Title().Value(),
+		Description: todo.Description().Value(),
+		IsDone: todo.IsDone(),
+		UserId: todo.UserId().Value(),
+		ProjectId: todo.ProjectId(),
+	}
+
+	if todo.AssigneeId() != nil {
+		dto.AssigneeId = todo.AssigneeId().Value()
+	}
+
+	return dto
+}
+
+// List todo items of projects in one round trip.
+func listProjectTodos(listTodoBatchPersistence persistence.ListTodoBatchPersistence, projects []*entity.Project) ([]*entity.TodoItem, error) {
+
+	if len(projects) == 0 {
+		return []*entity.TodoItem{}, nil
+	}
+
+	projectIds := make([]string, len(projects))
+
+	for i, project := range projects {
+		projectIds[i] = project.Id()
+	}
+
+	return listTodoBatchPersistence.ListByProjects(projectIds)
+}
+
 // AddTodoUsecase implementation.
 type AddTodoService struct {
 	createTodoPersistence persistence.CreateTodoPersistence
-	
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
 }
 
-func NewAddTodoService(createTodoPersistence persistence.CreateTodoPersistence) *AddTodoService {
-	return &AddTodoService{createTodoPersistence}
+func NewAddTodoService(
+	createTodoPersistence persistence.CreateTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *AddTodoService {
+	return &AddTodoService{createTodoPersistence, getProjectMemberPersistence, createTodoUndoPersistence, tokenGenerator, todoEventBus}
 }
 
-func (s *AddTodoService) Add(todo *inDto.AddTodoCommand) error {
-	return s.createTodoPersistence.Create(&dto.CreateTodoCommand{
-		UserId: 	 value.NewUserId(todo.UserId),
+func (s *AddTodoService) Add(todo *inDto.AddTodoCommand) (string, error) {
+
+	if !value.IsTodoItemTitle(todo.Title) {
+		return "", validation.ErrInvalidTodoTitle
+	}
+
+	if !value.IsTodoItemDescription(todo.Description) {
+		return "", validation.ErrInvalidTodoDescription
+	}
+
+	userId := value.NewUserId(todo.UserId)
+
+	if todo.ProjectId != "" {
+		if _, err := authorizeProject(s.getProjectMemberPersistence, todo.ProjectId, userId, value.PermissionEditor); err != nil {
+			return "", err
+		}
+	}
+
+	created, err := s.createTodoPersistence.Create(&dto.CreateTodoCommand{
+		UserId: 	 userId,
 		Title: 		 value.NewTodoItemTitle(todo.Title),
 		Description: value.NewTodoItemDescription(todo.Description),
+		ProjectId: 	 todo.ProjectId,
+		IsDone: 	 todo.IsDone,
 	})
+
+	if err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(nil, created)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, userId, change)
 }
 
 // GetTodoUsecase implementation.
 type GetTodoService struct {
 	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
 }
 
-func NewGetTodoService(getTodoPersistence persistence.GetTodoPersistence) *GetTodoService {
-	return &GetTodoService{getTodoPersistence}
+func NewGetTodoService(
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+) *GetTodoService {
+	return &GetTodoService{getTodoPersistence, getProjectMemberPersistence}
 }
 
-func (s *GetTodoService) Get(todoId string) (*inDto.TodoItemDto, error) {
-	
-	todo, err := s.getTodoPersistence.Get(value.NewTodoItemId(todoId))
-	
+func (s *GetTodoService) Get(userId string, todoId string) (*inDto.TodoItemDto, error) {
+
+	todo, err := getAuthorizedTodo(s.getTodoPersistence, s.getProjectMemberPersistence, todoId, value.NewUserId(userId), value.PermissionViewer)
+
 	if err != nil {
 		return nil, err
 	}
-	
-	return &inDto.TodoItemDto{
-		Id: todo.Id().Value(),
-		Title: todo.Title().Value(),
-		Description: todo.Description().Value(),
-		IsDone: todo.IsDone(),
-	}, nil
+
+	return toTodoItemDto(todo), nil
 }
 
 // ListTodoUsecase implementation.
 type ListTodoService struct {
 	listTodoPersistence persistence.ListTodoPersistence
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence
+	listProjectPersistence persistence.ListProjectPersistence
 }
 
-func NewListTodoService(listTodoPersistence persistence.ListTodoPersistence) *ListTodoService {
-	return &ListTodoService{listTodoPersistence}
+func NewListTodoService(
+	listTodoPersistence persistence.ListTodoPersistence,
+	listTodoBatchPersistence persistence.ListTodoBatchPersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+) *ListTodoService {
+	return &ListTodoService{listTodoPersistence, listTodoBatchPersistence, listProjectPersistence}
 }
 
+// List personal todo items of user followed by those of projects user is member of.
 func (s *ListTodoService) List(userId string) ([]*inDto.TodoItemDto, error) {
+
+	uid := value.NewUserId(userId)
 	
-	todos, err := s.listTodoPersistence.List(value.NewUserId(userId))
+	todos, err := s.listTodoPersistence.List(uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.listProjectPersistence.List(uid)
 
 	if err != nil {
 		return nil, err
 	}
 
+	shared, err := listProjectTodos(s.listTodoBatchPersistence, projects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos = append(todos, shared...)
+
 	dtoTodos := make([]*inDto.TodoItemDto, len(todos))
 	
 	for i, todo := range todos {
-		dtoTodos[i] = &inDto.TodoItemDto{
-			Id: todo.Id().Value(),
-			Title: todo.Title().Value(),
-			Description: todo.Description().Value(),
-			IsDone: todo.IsDone(),
-		}
+		dtoTodos[i] = toTodoItemDto(todo)
 	}
 
 	return dtoTodos, nil
@@ -88,114 +180,250 @@ func (s *ListTodoService) List(userId string) ([]*inDto.TodoItemDto, error) {
 type UpdateTodoService struct {
 	updateTodoPersistence persistence.UpdateTodoPersistence
 	getTodoPersistence    persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
 }
 
-func NewUpdateTodoService(updateTodoPersistence persistence.UpdateTodoPersistence, getTodoPersistence persistence.GetTodoPersistence) *UpdateTodoService {
-	return &UpdateTodoService{updateTodoPersistence, getTodoPersistence}
+func NewUpdateTodoService(
+	updateTodoPersistence persistence.UpdateTodoPersistence,
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *UpdateTodoService {
+	return &UpdateTodoService{updateTodoPersistence, getTodoPersistence, getProjectMemberPersistence, createTodoUndoPersistence, tokenGenerator, todoEventBus}
 }
 
-func (s *UpdateTodoService) Update(todoDto *inDto.UpdateTodoCommand) error {
+func (s *UpdateTodoService) Update(todoDto *inDto.UpdateTodoCommand) (string, error) {
+
+	userId := value.NewUserId(todoDto.UserId)
 
 	todo, err := s.getTodoPersistence.Get(value.NewTodoItemId(todoDto.Id))
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if todo == nil {
-		return validation.ErrTodoNotDound
+		return "", validation.ErrTodoNotDound
 	}
 
-	if strings.TrimSpace(todoDto.Title) == "" {
-		return errors.New("title cannot be empty")
+	if !value.IsTodoItemTitle(todoDto.Title) {
+		return "", validation.ErrInvalidTodoTitle
 	}
 
-	
-	if todo.UserId() != value.NewUserId(todoDto.UserId) {
-		return validation.ErrInvalidUser
+	if !value.IsTodoItemDescription(todoDto.Description) {
+		return "", validation.ErrInvalidTodoDescription
+	}
+
+	if err := authorizeTodo(s.getProjectMemberPersistence, todo, userId, value.PermissionEditor); err != nil {
+		return "", err
 	}
 
+	before := todo.Copy()
+
 	todo.ChangeDescription(todoDto.Description)
 	todo.ChangeTitle(todoDto.Title)
 
-	return s.updateTodoPersistence.Update(todo)
+	if err := s.updateTodoPersistence.Update(todo); err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(before, todo)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, userId, change)
 }
 
 // CompleteTodoUsecase implementation.
 type CompleteTodoService struct {
 	completeTodoPersistence persistence.UpdateTodoPersistence
 	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
 }
 
-func (s *CompleteTodoService) Complete(userId string, todoId string) error {
+func (s *CompleteTodoService) Complete(userId string, todoId string) (string, error) {
+
+	uid := value.NewUserId(userId)
 
 	todo, err := s.getTodoPersistence.Get(value.NewTodoItemId(todoId))
 	
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if todo == nil {
+		return "", validation.ErrTodoNotDound
 	}
 
-	if todo.UserId() != value.NewUserId(userId) {
-		return errors.New("invalid user")
+	if err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionEditor); err != nil {
+		return "", err
 	}
+
+	before := todo.Copy()
 	
 	todo.Complete()
 	
-	return s.completeTodoPersistence.Update(todo)
+	if err := s.completeTodoPersistence.Update(todo); err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(before, todo)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, uid, change)
 }
 
-func NewCompleteTodoService(completeTodoPersistence persistence.UpdateTodoPersistence, getTodoPersistence persistence.GetTodoPersistence) *CompleteTodoService {
-	return &CompleteTodoService{completeTodoPersistence, getTodoPersistence}
+func NewCompleteTodoService(
+	completeTodoPersistence persistence.UpdateTodoPersistence,
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *CompleteTodoService {
+	return &CompleteTodoService{completeTodoPersistence, getTodoPersistence, getProjectMemberPersistence, createTodoUndoPersistence, tokenGenerator, todoEventBus}
 }
 
 // UncompleteTodoUsecase implementation.
 type UncompleteTodoService struct {
 	uncompleteTodoPersistence persistence.UpdateTodoPersistence
 	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
 }
 
-func NewUncompleteTodoService(uncompleteTodoPersistence persistence.UpdateTodoPersistence, getTodoPersistence persistence.GetTodoPersistence) *UncompleteTodoService {
-	return &UncompleteTodoService{uncompleteTodoPersistence, getTodoPersistence}
+func NewUncompleteTodoService(
+	uncompleteTodoPersistence persistence.UpdateTodoPersistence,
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *UncompleteTodoService {
+	return &UncompleteTodoService{uncompleteTodoPersistence, getTodoPersistence, getProjectMemberPersistence, createTodoUndoPersistence, tokenGenerator, todoEventBus}
 }
 
-func (s *UncompleteTodoService) Uncomplete(userId string, todoId string) error {
+func (s *UncompleteTodoService) Uncomplete(userId string, todoId string) (string, error) {
+
+	uid := value.NewUserId(userId)
 	
 	todo, err := s.getTodoPersistence.Get(value.NewTodoItemId(todoId))
 	
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if todo == nil {
+		return "", validation.ErrTodoNotDound
 	}
 
-	if todo.UserId() != value.NewUserId(userId) {
-		return errors.New("invalid user")
+	if err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionEditor); err != nil {
+		return "", err
 	}
+
+	before := todo.Copy()
 	
 	todo.Uncomplete()
 	
-	return s.uncompleteTodoPersistence.Update(todo)
+	if err := s.uncompleteTodoPersistence.Update(todo); err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(before, todo)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
+	}
+
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, uid, change)
 }
 
 // DeleteTodoUsecase implementation.
 type DeleteTodoService struct {
 	deleteTodoPersistence persistence.DeleteTodoPersistence
 	getTodoPersistence persistence.GetTodoPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
 }
 
-func NewDeleteTodoService(deleteTodoPersistence persistence.DeleteTodoPersistence, getTodoPersistence persistence.GetTodoPersistence) *DeleteTodoService {
-	return &DeleteTodoService{deleteTodoPersistence, getTodoPersistence}
+func NewDeleteTodoService(
+	deleteTodoPersistence persistence.DeleteTodoPersistence,
+	getTodoPersistence persistence.GetTodoPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *DeleteTodoService {
+	return &DeleteTodoService{
+		deleteTodoPersistence,
+		getTodoPersistence,
+		getProjectMemberPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+		createTodoUndoPersistence,
+		tokenGenerator,
+		todoEventBus,
+	}
 }
 
-func (s *DeleteTodoService) Delete(userId string, todoId string) error {
+func (s *DeleteTodoService) Delete(userId string, todoId string) (string, error) {
+
+	uid := value.NewUserId(userId)
 
 	todo, err := s.getTodoPersistence.Get(value.NewTodoItemId(todoId))
 	
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if todo == nil {
+		return "", validation.ErrTodoNotDound
+	}
+
+	if err := authorizeTodo(s.getProjectMemberPersistence, todo, uid, value.PermissionEditor); err != nil {
+		return "", err
+	}
+
+	keys, err := listTodoAttachmentKeys(s.listTodoAttachmentPersistence, []*entity.TodoItem{todo})
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.deleteTodoPersistence.Delete(todo.Id()); err != nil {
+		return "", err
 	}
 
-	if todo.UserId() != value.NewUserId(userId) {
-		return errors.New("invalid user")
+	if err := deleteBlobs(s.blobStorage, keys); err != nil {
+		return "", err
+	}
+
+	change := entity.NewTodoChange(todo, nil)
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, change); err != nil {
+		return "", err
 	}
 
-	return s.deleteTodoPersistence.Delete(todo.Id())
+	return recordTodoUndo(s.createTodoUndoPersistence, s.tokenGenerator, uid, change)
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Split snippet with highlight marks into fragments.
+func toSnippetFragments(snippet string) []inDto.SnippetFragment {
+
+	fragments := make([]inDto.SnippetFragment, 0)
+
+	for snippet != "" {
+
+		start := strings.Index(snippet, outDto.SnippetHighlightStart)
+
+		if start < 0 {
+			fragments = append(fragments, inDto.SnippetFragment{Text: snippet})
+			break
+		}
+
+		if start > 0 {
+			fragments = append(fragments, inDto.SnippetFragment{Text: snippet[:start]})
+		}
+
+		snippet = snippet[start + len(outDto.SnippetHighlightStart):]
+
+		stop := strings.Index(snippet, outDto.SnippetHighlightStop)
+
+		if stop < 0 {
+			stop = len(snippet)
+		}
+
+		fragments = append(fragments, inDto.SnippetFragment{Text: snippet[:stop], Highlighted: true})
+
+		snippet = strings.TrimPrefix(snippet[stop:], outDto.SnippetHighlightStop)
+	}
+
+	return fragments
+}
+
+// SearchTodoUsecase implementation.
+type SearchTodoService struct {
+	searchTodoPersistence persistence.SearchTodoPersistence
+	listProjectPersistence persistence.ListProjectPersistence
+}
+
+func NewSearchTodoService(
+	searchTodoPersistence persistence.SearchTodoPersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+) *SearchTodoService {
+	return &SearchTodoService{searchTodoPersistence, listProjectPersistence}
+}
+
+// Search personal todo items of user and those of projects user is member of.
+func (s *SearchTodoService) Search(command *inDto.SearchTodoCommand) ([]*inDto.TodoSearchResultDto, error) {
+
+	if !value.IsSearchQuery(command.Query) {
+		return nil, validation.ErrInvalidSearchQuery
+	}
+
+	limit := command.Limit
+
+	if limit < 1 {
+		limit = DefaultPerPage
+	}
+
+	if limit > MaxPerPage {
+		limit = MaxPerPage
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	projects, err := s.listProjectPersistence.List(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectIds := make([]string, len(projects))
+
+	for i, project := range projects {
+		projectIds[i] = project.Id()
+	}
+
+	hits, err := s.searchTodoPersistence.Search(&outDto.SearchTodoQuery{
+		UserId: userId,
+		ProjectIds: projectIds,
+		Query: value.NewSearchQuery(command.Query),
+		Limit: limit,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*inDto.TodoSearchResultDto, len(hits))
+
+	for i, hit := range hits {
+		results[i] = &inDto.TodoSearchResultDto{
+			Todo: toTodoItemDto(hit.Todo),
+			Rank: hit.Rank,
+			Snippet: toSnippetFragments(hit.Snippet),
+		}
+	}
+
+	return results, nil
+}
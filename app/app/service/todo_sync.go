@@ -0,0 +1,479 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Max client operations of one sync.
+const MaxSyncOperations = 500
+
+// Max changes returned by one sync. Client syncs again for the rest.
+const MaxSyncChanges = 500
+
+// Max length of client operation IDs.
+const maxSyncOperationIdLength = 64
+
+// Times sync is applied when todo items keep changing meanwhile.
+const syncAttempts = 3
+
+// Parse sync token into sequence number of last change client has. Empty token is 0.
+func parseSyncToken(token string) (int64, error) {
+
+	if token == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(token, 10, 64)
+
+	if err != nil || seq < 0 {
+		return 0, validation.ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
+
+// SyncTodoUsecase implementation.
+type SyncTodoService struct {
+	getTodoRevisionPersistence persistence.GetTodoRevisionPersistence
+	writeTodoSyncPersistence persistence.WriteTodoSyncPersistence
+	getTodoSyncOperationPersistence persistence.GetTodoSyncOperationPersistence
+	listTodoChangePersistence persistence.ListTodoChangePersistence
+	listProjectPersistence persistence.ListProjectPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+	todoEventBus event.TodoEventBus
+}
+
+func NewSyncTodoService(
+	getTodoRevisionPersistence persistence.GetTodoRevisionPersistence,
+	writeTodoSyncPersistence persistence.WriteTodoSyncPersistence,
+	getTodoSyncOperationPersistence persistence.GetTodoSyncOperationPersistence,
+	listTodoChangePersistence persistence.ListTodoChangePersistence,
+	listProjectPersistence persistence.ListProjectPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+	todoEventBus event.TodoEventBus,
+) *SyncTodoService {
+	return &SyncTodoService{
+		getTodoRevisionPersistence,
+		writeTodoSyncPersistence,
+		getTodoSyncOperationPersistence,
+		listTodoChangePersistence,
+		listProjectPersistence,
+		getProjectMemberPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+		todoEventBus,
+	}
+}
+
+// Operations apply in order, each at most once however often it is sent.
+// Failing operations are rejected without stopping the others.
+func (s *SyncTodoService) Sync(command *inDto.SyncTodoCommand) (*inDto.SyncTodoDto, error) {
+
+	since, err := parseSyncToken(command.Since)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(command.Operations) > MaxSyncOperations {
+		return nil, validation.ErrInvalidSyncSize
+	}
+
+	for _, op := range command.Operations {
+		if op.Id == "" || len(op.Id) > maxSyncOperationIdLength {
+			return nil, validation.ErrInvalidSyncOperationId
+		}
+	}
+
+	userId := value.NewUserId(command.UserId)
+
+	var outcomes []*entity.TodoSyncOperation
+
+	for attempt := 1; ; attempt++ {
+
+		outcomes, err = s.apply(userId, command.Operations)
+
+		if err != persistence.ErrTodoRevisionChanged || attempt == syncAttempts {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*inDto.SyncTodoResultDto, len(outcomes))
+
+	for i, outcome := range outcomes {
+		results[i] = &inDto.SyncTodoResultDto{
+			Id: outcome.Id(),
+			TodoId: outcome.TodoId(),
+			Status: outcome.Status(),
+			Message: outcome.Message(),
+		}
+	}
+
+	projects, err := s.listProjectPersistence.List(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectIds := make([]string, len(projects))
+
+	for i, project := range projects {
+		projectIds[i] = project.Id()
+	}
+
+	revisions, err := s.listTodoChangePersistence.ListChanges(&outDto.ListTodoChangesQuery{
+		UserId: userId,
+		ProjectIds: projectIds,
+		Since: since,
+		Limit: MaxSyncChanges + 1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(revisions) > MaxSyncChanges
+
+	if hasMore {
+		revisions = revisions[:MaxSyncChanges]
+	}
+
+	changes := make([]*inDto.TodoSyncChangeDto, len(revisions))
+
+	for i, revision := range revisions {
+
+		change := &inDto.TodoSyncChangeDto{TodoId: revision.TodoId().Value()}
+
+		if !revision.IsDeleted() {
+			change.Todo = toTodoItemDto(revision.Todo())
+		}
+
+		changes[i] = change
+
+		since = revision.Seq()
+	}
+
+	return &inDto.SyncTodoDto{
+		Results: results,
+		Changes: changes,
+		Token: strconv.FormatInt(since, 10),
+		HasMore: hasMore,
+		ProjectIds: projectIds,
+	}, nil
+}
+
+// Apply operations on todo items as currently stored and write them in one transaction.
+// Return outcomes in order of operations.
+func (s *SyncTodoService) apply(userId value.UserId, operations []*inDto.SyncTodoOperation) ([]*entity.TodoSyncOperation, error) {
+
+	outcomes := make([]*entity.TodoSyncOperation, len(operations))
+
+	if len(operations) == 0 {
+		return outcomes, nil
+	}
+
+	operationIds := make([]string, len(operations))
+
+	todoIds := make([]value.TodoItemId, 0, len(operations))
+
+	for i, op := range operations {
+
+		operationIds[i] = op.Id
+
+		if value.IsTodoItemId(op.TodoId) {
+			todoIds = append(todoIds, value.NewTodoItemId(op.TodoId))
+		}
+	}
+
+	known, err := s.getTodoSyncOperationPersistence.GetOperations(userId, operationIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]*entity.TodoSyncOperation, len(known))
+
+	for _, outcome := range known {
+		done[outcome.Id()] = outcome
+	}
+
+	found, err := s.getTodoRevisionPersistence.GetRevisions(todoIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	run := &todoSyncRun{
+		userId: userId,
+		members: &cachedProjectMemberPersistence{s.getProjectMemberPersistence, make(map[string]*entity.ProjectMember)},
+		now: time.Now(),
+		revisions: make(map[value.TodoItemId]*entity.TodoRevision, len(found)),
+		originals: make(map[value.TodoItemId]*entity.TodoItem, len(found)),
+		saved: make([]*entity.TodoRevision, 0),
+		deleted: make([]*entity.TodoRevision, 0),
+	}
+
+	for _, revision := range found {
+
+		run.revisions[revision.TodoId()] = revision
+
+		if !revision.IsDeleted() {
+			run.originals[revision.TodoId()] = revision.Todo().Copy()
+		}
+	}
+
+	applied := make([]*entity.TodoSyncOperation, 0)
+
+	for i, op := range operations {
+
+		outcome, ok := done[op.Id]
+
+		if !ok {
+
+			err := run.apply(op)
+
+			if err != nil {
+
+				if _, ok := err.(*validation.ValidationError); !ok {
+					return nil, err
+				}
+
+				outcome = entity.NewTodoSyncOperation(op.Id, userId, op.TodoId, entity.TodoSyncRejected, err.Error())
+			} else {
+				outcome = entity.NewTodoSyncOperation(op.Id, userId, op.TodoId, entity.TodoSyncApplied, "")
+			}
+
+			done[op.Id] = outcome
+			applied = append(applied, outcome)
+		}
+
+		outcomes[i] = outcome
+	}
+
+	changes := make([]*entity.TodoChange, 0)
+
+	for _, revision := range run.saved {
+		changes = append(changes, entity.NewTodoChange(run.originals[revision.TodoId()], revision.Todo()))
+	}
+
+	deletedTodos := make([]*entity.TodoItem, len(run.deleted))
+
+	for i, revision := range run.deleted {
+		deletedTodos[i] = revision.Todo()
+		changes = append(changes, entity.NewTodoChange(run.originals[revision.TodoId()], nil))
+	}
+
+	keys, err := listTodoAttachmentKeys(s.listTodoAttachmentPersistence, deletedTodos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.writeTodoSyncPersistence.WriteSync(&outDto.TodoSyncCommand{
+		Saves: run.saved,
+		Deletes: run.deleted,
+		Operations: applied,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteBlobs(s.blobStorage, keys); err != nil {
+		return nil, err
+	}
+
+	if err := publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, changes...); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// State of sync while operations are applied.
+type todoSyncRun struct {
+	userId value.UserId
+	members *cachedProjectMemberPersistence
+	now time.Time
+	// Todo items operated on as changed so far. Deleted ones have no todo item.
+	revisions map[value.TodoItemId]*entity.TodoRevision
+	// Todo items as read, to publish changes.
+	originals map[value.TodoItemId]*entity.TodoItem
+	saved []*entity.TodoRevision
+	// Todo items to delete as read.
+	deleted []*entity.TodoRevision
+}
+
+// Apply operation. Todo item is left untouched when operation fails.
+func (r *todoSyncRun) apply(op *inDto.SyncTodoOperation) error {
+
+	if !value.IsTodoItemId(op.TodoId) {
+		return validation.ErrInvalidTodoItemId
+	}
+
+	todoId := value.NewTodoItemId(op.TodoId)
+
+	revision, ok := r.revisions[todoId]
+
+	switch op.Op {
+	case inDto.SyncOpUpsert:
+
+		if op.Title != nil && strings.TrimSpace(*op.Title) == "" ||
+			op.Description != nil && strings.TrimSpace(*op.Description) == "" {
+			return validation.ErrInvalidTodoInput
+		}
+
+		if !ok {
+			return r.create(todoId, op)
+		}
+
+		if revision.IsDeleted() {
+			return validation.ErrTodoDeleted
+		}
+
+		return r.merge(revision, op)
+	case inDto.SyncOpDelete:
+
+		// Deleting unknown or deleted todo item leaves it deleted.
+		if !ok || revision.IsDeleted() {
+			return nil
+		}
+
+		if err := authorizeTodo(r.members, revision.Todo(), r.userId, value.PermissionEditor); err != nil {
+			return err
+		}
+
+		r.unsave(revision)
+
+		// Todo items added by this sync were never stored.
+		if revision.Seq() != 0 {
+			r.deleted = append(r.deleted, revision)
+		}
+
+		r.revisions[todoId] = entity.NewTodoRevision(todoId, nil, nil, revision.Seq())
+
+		return nil
+	default:
+		return validation.ErrInvalidSyncOperation
+	}
+}
+
+// Add todo item created on client with its ID.
+func (r *todoSyncRun) create(todoId value.TodoItemId, op *inDto.SyncTodoOperation) error {
+
+	if op.Title == nil || op.Description == nil {
+		return validation.ErrInvalidTodoInput
+	}
+
+	if op.ProjectId != "" {
+		if _, err := authorizeProject(r.members, op.ProjectId, r.userId, value.PermissionEditor); err != nil {
+			return err
+		}
+	}
+
+	todo := entity.NewTodoItem(
+		todoId,
+		value.NewTodoItemTitle(*op.Title),
+		value.NewTodoItemDescription(*op.Description),
+		op.IsDone != nil && *op.IsDone,
+		r.userId,
+		op.ProjectId,
+		nil,
+	)
+
+	revision := entity.NewTodoRevision(todoId, todo, nil, 0)
+
+	clock := r.clockOf(op)
+
+	for _, field := range []string{entity.TodoFieldTitle, entity.TodoFieldDescription, entity.TodoFieldIsDone} {
+		revision.Claim(field, clock)
+	}
+
+	r.revisions[todoId] = revision
+	r.saved = append(r.saved, revision)
+
+	return nil
+}
+
+// Change fields of todo item whose change on client is later than their last change.
+func (r *todoSyncRun) merge(revision *entity.TodoRevision, op *inDto.SyncTodoOperation) error {
+
+	todo := revision.Todo()
+
+	if err := authorizeTodo(r.members, todo, r.userId, value.PermissionEditor); err != nil {
+		return err
+	}
+
+	clock := r.clockOf(op)
+
+	changed := false
+
+	if op.Title != nil && revision.Claim(entity.TodoFieldTitle, clock) {
+		todo.ChangeTitle(*op.Title)
+		changed = true
+	}
+
+	if op.Description != nil && revision.Claim(entity.TodoFieldDescription, clock) {
+		todo.ChangeDescription(*op.Description)
+		changed = true
+	}
+
+	if op.IsDone != nil && revision.Claim(entity.TodoFieldIsDone, clock) {
+		if *op.IsDone {
+			todo.Complete()
+		} else {
+			todo.Uncomplete()
+		}
+		changed = true
+	}
+
+	if changed {
+		r.unsave(revision)
+		r.saved = append(r.saved, revision)
+	}
+
+	return nil
+}
+
+// Clock of change made by operation.
+// Changes dated ahead of server, e.g. by skewed client clock, count as made now, so they cannot win over later changes.
+func (r *todoSyncRun) clockOf(op *inDto.SyncTodoOperation) entity.TodoClock {
+
+	changedAt := op.ChangedAt
+
+	if changedAt.IsZero() || changedAt.After(r.now) {
+		changedAt = r.now
+	}
+
+	return entity.NewTodoClock(changedAt, op.Id)
+}
+
+// Drop todo item from those to save.
+func (r *todoSyncRun) unsave(revision *entity.TodoRevision) {
+
+	for i, saved := range r.saved {
+		if saved == revision {
+			r.saved = append(r.saved[:i], r.saved[i + 1:]...)
+			return
+		}
+	}
+}
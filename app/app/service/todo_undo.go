@@ -0,0 +1,239 @@
+package service
+
+import (
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// How long todo mutation can be undone.
+const TodoUndoLifetime = 10 * time.Minute
+
+// Record todo items changed by mutation and return token to undo it.
+// Nothing is recorded and token is empty when mutation changed nothing.
+func recordTodoUndo(
+	createTodoUndoPersistence persistence.CreateTodoUndoPersistence,
+	tokenGenerator token.TokenGenerator,
+	userId value.UserId,
+	changes ...*entity.TodoChange,
+) (string, error) {
+
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	undoToken, err := tokenGenerator.Generate()
+
+	if err != nil {
+		return "", err
+	}
+
+	err = createTodoUndoPersistence.Create(&outDto.CreateTodoUndoCommand{
+		TokenHash: tokenGenerator.Hash(undoToken),
+		UserId: userId,
+		Changes: changes,
+		ExpiresAt: time.Now().Add(TodoUndoLifetime),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return undoToken, nil
+}
+
+// Get ID of todo item assignee. Empty if unassigned.
+func assigneeOf(todo *entity.TodoItem) string {
+
+	if todo.AssigneeId() == nil {
+		return ""
+	}
+
+	return todo.AssigneeId().Value()
+}
+
+// UndoTodoUsecase implementation.
+type UndoTodoService struct {
+	getTodoUndoPersistence persistence.GetTodoUndoPersistence
+	updateTodoUndoPersistence persistence.UpdateTodoUndoPersistence
+	getTodoRevisionPersistence persistence.GetTodoRevisionPersistence
+	writeTodoBatchPersistence persistence.WriteTodoBatchPersistence
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence
+	blobStorage storage.BlobStorage
+	tokenGenerator token.TokenGenerator
+	todoEventBus event.TodoEventBus
+}
+
+func NewUndoTodoService(
+	getTodoUndoPersistence persistence.GetTodoUndoPersistence,
+	updateTodoUndoPersistence persistence.UpdateTodoUndoPersistence,
+	getTodoRevisionPersistence persistence.GetTodoRevisionPersistence,
+	writeTodoBatchPersistence persistence.WriteTodoBatchPersistence,
+	getProjectMemberPersistence persistence.GetProjectMemberPersistence,
+	createTodoAssignmentPersistence persistence.CreateTodoAssignmentPersistence,
+	listTodoAttachmentPersistence persistence.ListTodoAttachmentPersistence,
+	blobStorage storage.BlobStorage,
+	tokenGenerator token.TokenGenerator,
+	todoEventBus event.TodoEventBus,
+) *UndoTodoService {
+	return &UndoTodoService{
+		getTodoUndoPersistence,
+		updateTodoUndoPersistence,
+		getTodoRevisionPersistence,
+		writeTodoBatchPersistence,
+		getProjectMemberPersistence,
+		createTodoAssignmentPersistence,
+		listTodoAttachmentPersistence,
+		blobStorage,
+		tokenGenerator,
+		todoEventBus,
+	}
+}
+
+// Deleted todo items come back without comments and attachments, as those are gone with them.
+func (s *UndoTodoService) Undo(userId string, undoToken string) error {
+
+	now := time.Now()
+
+	uid := value.NewUserId(userId)
+
+	undo, err := s.getTodoUndoPersistence.Get(s.tokenGenerator.Hash(undoToken))
+
+	if err != nil {
+		return err
+	}
+
+	if undo == nil || !undo.IsUsable(now) || undo.UserId() != uid {
+		return validation.ErrInvalidUndoToken
+	}
+
+	todoIds := make([]value.TodoItemId, len(undo.Changes()))
+
+	for i, change := range undo.Changes() {
+		todoIds[i] = change.TodoId()
+	}
+
+	revisions, err := s.getTodoRevisionPersistence.GetRevisions(todoIds)
+
+	if err != nil {
+		return err
+	}
+
+	current := make(map[value.TodoItemId]*entity.TodoItem, len(revisions))
+
+	// Todo items are written only if still as read, so check below holds when writing.
+	seqs := make(map[value.TodoItemId]int64, len(revisions))
+
+	for _, revision := range revisions {
+		if !revision.IsDeleted() {
+			current[revision.TodoId()] = revision.Todo()
+		}
+		seqs[revision.TodoId()] = revision.Seq()
+	}
+
+	members := &cachedProjectMemberPersistence{s.getProjectMemberPersistence, make(map[string]*entity.ProjectMember)}
+
+	for _, change := range undo.Changes() {
+
+		todo, ok := current[change.TodoId()]
+
+		// Todo item must be exactly as mutation left it.
+		if change.After() == nil && ok ||
+			change.After() != nil && (!ok || !todo.Equals(change.After())) {
+			return validation.ErrUndoConflict
+		}
+
+		// Access may have been lost since mutation.
+		for _, state := range []*entity.TodoItem{change.Before(), change.After()} {
+			if state == nil {
+				continue
+			}
+			if err := authorizeTodo(members, state, uid, value.PermissionEditor); err != nil {
+				return err
+			}
+		}
+	}
+
+	batch := &outDto.TodoBatchCommand{
+		Updates: make([]*entity.TodoItem, 0),
+		Deletes: make([]value.TodoItemId, 0),
+		Restores: make([]*entity.TodoItem, 0),
+		Seqs: seqs,
+	}
+
+	added := make([]*entity.TodoItem, 0)
+
+	for _, change := range undo.Changes() {
+		switch {
+		case change.Before() == nil:
+			batch.Deletes = append(batch.Deletes, change.TodoId())
+			added = append(added, change.After())
+		case change.After() == nil:
+			batch.Restores = append(batch.Restores, change.Before())
+		default:
+			batch.Updates = append(batch.Updates, change.Before())
+		}
+	}
+
+	keys, err := listTodoAttachmentKeys(s.listTodoAttachmentPersistence, added)
+
+	if err != nil {
+		return err
+	}
+
+	// Burn the token before writing so it cannot be replayed.
+	undo.Use(now)
+
+	if err := s.updateTodoUndoPersistence.Update(undo); err != nil {
+		return err
+	}
+
+	err = s.writeTodoBatchPersistence.WriteBatch(batch)
+
+	if err == persistence.ErrTodoRevisionChanged {
+		return validation.ErrUndoConflict
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Keep assignment history in line with restored assignees.
+	for _, change := range undo.Changes() {
+
+		if change.Before() == nil || change.After() == nil || assigneeOf(change.Before()) == assigneeOf(change.After()) {
+			continue
+		}
+
+		err := s.createTodoAssignmentPersistence.Create(&outDto.CreateTodoAssignmentCommand{
+			TodoId: change.TodoId(),
+			AssigneeId: change.Before().AssigneeId(),
+			AssignedBy: uid,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := deleteBlobs(s.blobStorage, keys); err != nil {
+		return err
+	}
+
+	undone := make([]*entity.TodoChange, len(undo.Changes()))
+
+	for i, change := range undo.Changes() {
+		undone[i] = entity.NewTodoChange(change.After(), change.Before())
+	}
+
+	return publishTodoChanges(s.todoEventBus, s.getProjectMemberPersistence, undone...)
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	inDto "github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/password"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
+	"github.com/kkatou7209/godo/app/port/out/totp"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Number of recovery codes issued on enrollment.
+const RecoveryCodeCount = 10
+
+// EnrollTwoFactorUsecase implementation.
+type EnrollTwoFactorService struct {
+	getUserPersistence persistence.GetUserPersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	createTwoFactorPersistence persistence.CreateTwoFactorPersistence
+	deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence
+	totpProvider totp.TotpProvider
+}
+
+func NewEnrollTwoFactorService(
+	getUserPersistence persistence.GetUserPersistence,
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	createTwoFactorPersistence persistence.CreateTwoFactorPersistence,
+	deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence,
+	totpProvider totp.TotpProvider,
+) *EnrollTwoFactorService {
+	return &EnrollTwoFactorService{
+		getUserPersistence,
+		getTwoFactorPersistence,
+		createTwoFactorPersistence,
+		deleteTwoFactorPersistence,
+		totpProvider,
+	}
+}
+
+func (s *EnrollTwoFactorService) Enroll(userId string) (*inDto.TwoFactorEnrollmentDto, error) {
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	current, err := s.getTwoFactorPersistence.Get(user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && current.IsEnabled() {
+		return nil, validation.ErrTwoFactorAlreadyEnabled
+	}
+
+	// Restarting enrollment replaces unconfirmed secret.
+	if current != nil {
+		if err := s.deleteTwoFactorPersistence.Delete(user.Id()); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := s.totpProvider.GenerateSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.createTwoFactorPersistence.Create(&outDto.CreateTwoFactorCommand{
+		UserId: user.Id(),
+		Secret: secret,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.TwoFactorEnrollmentDto{
+		Secret: secret,
+		Uri: s.totpProvider.Uri(secret, user.Email().Value()),
+	}, nil
+}
+
+// ConfirmTwoFactorUsecase implementation.
+type ConfirmTwoFactorService struct {
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence
+	totpProvider totp.TotpProvider
+	tokenGenerator token.TokenGenerator
+}
+
+func NewConfirmTwoFactorService(
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence,
+	totpProvider totp.TotpProvider,
+	tokenGenerator token.TokenGenerator,
+) *ConfirmTwoFactorService {
+	return &ConfirmTwoFactorService{
+		getTwoFactorPersistence,
+		updateTwoFactorPersistence,
+		totpProvider,
+		tokenGenerator,
+	}
+}
+
+func (s *ConfirmTwoFactorService) Confirm(userId string, code string) ([]string, error) {
+
+	twoFactor, err := s.getTwoFactorPersistence.Get(value.NewUserId(userId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor == nil {
+		return nil, validation.ErrTwoFactorNotEnrolled
+	}
+
+	if twoFactor.IsEnabled() {
+		return nil, validation.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := s.totpProvider.Verify(twoFactor.Secret(), code, time.Now())
+
+	if !ok || !twoFactor.UseStep(step) {
+		return nil, validation.ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+
+	for i := range codes {
+
+		code, err := s.tokenGenerator.GenerateCode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		codes[i] = code
+		hashes[i] = s.tokenGenerator.Hash(code)
+	}
+
+	twoFactor.Enable(hashes)
+
+	if err := s.updateTwoFactorPersistence.Update(twoFactor); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactorUsecase implementation.
+type DisableTwoFactorService struct {
+	getUserPersistence persistence.GetUserPersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence
+	passwordHasher password.PasswordHasher
+}
+
+func NewDisableTwoFactorService(
+	getUserPersistence persistence.GetUserPersistence,
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	deleteTwoFactorPersistence persistence.DeleteTwoFactorPersistence,
+	passwordHasher password.PasswordHasher,
+) *DisableTwoFactorService {
+	return &DisableTwoFactorService{
+		getUserPersistence,
+		getTwoFactorPersistence,
+		deleteTwoFactorPersistence,
+		passwordHasher,
+	}
+}
+
+func (s *DisableTwoFactorService) Disable(userId string, password string) error {
+
+	user, err := s.getUserPersistence.GetById(value.NewUserId(userId))
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return validation.ErrUserNotFound
+	}
+
+	if !s.passwordHasher.Verify(password, user.Password().Value()) {
+		return validation.ErrInvalidPassword
+	}
+
+	twoFactor, err := s.getTwoFactorPersistence.Get(user.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if twoFactor == nil {
+		return validation.ErrTwoFactorNotEnrolled
+	}
+
+	return s.deleteTwoFactorPersistence.Delete(user.Id())
+}
+
+// VerifyLoginUsecase implementation.
+type VerifyLoginService struct {
+	getLoginChallengePersistence persistence.GetLoginChallengePersistence
+	updateLoginChallengePersistence persistence.UpdateLoginChallengePersistence
+	deleteLoginChallengePersistence persistence.DeleteLoginChallengePersistence
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence
+	updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence
+	getUserPersistence persistence.GetUserPersistence
+	createSessionPersistence persistence.CreateSessionPersistence
+	totpProvider totp.TotpProvider
+	tokenGenerator token.TokenGenerator
+}
+
+func NewVerifyLoginService(
+	getLoginChallengePersistence persistence.GetLoginChallengePersistence,
+	updateLoginChallengePersistence persistence.UpdateLoginChallengePersistence,
+	deleteLoginChallengePersistence persistence.DeleteLoginChallengePersistence,
+	getTwoFactorPersistence persistence.GetTwoFactorPersistence,
+	updateTwoFactorPersistence persistence.UpdateTwoFactorPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	createSessionPersistence persistence.CreateSessionPersistence,
+	totpProvider totp.TotpProvider,
+	tokenGenerator token.TokenGenerator,
+) *VerifyLoginService {
+	return &VerifyLoginService{
+		getLoginChallengePersistence,
+		updateLoginChallengePersistence,
+		deleteLoginChallengePersistence,
+		getTwoFactorPersistence,
+		updateTwoFactorPersistence,
+		getUserPersistence,
+		createSessionPersistence,
+		totpProvider,
+		tokenGenerator,
+	}
+}
+
+func (s *VerifyLoginService) Verify(command *inDto.VerifyLoginCommand) (*inDto.LoginResultDto, error) {
+
+	now := time.Now()
+
+	challenge, err := s.getLoginChallengePersistence.Get(s.tokenGenerator.Hash(command.ChallengeToken))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge == nil || !challenge.IsUsable(now) {
+		return nil, validation.ErrInvalidLoginChallenge
+	}
+
+	twoFactor, err := s.getTwoFactorPersistence.Get(challenge.UserId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, validation.ErrInvalidLoginChallenge
+	}
+
+	code := strings.TrimSpace(command.Code)
+
+	step, ok := s.totpProvider.Verify(twoFactor.Secret(), code, now)
+
+	if ok {
+		ok = twoFactor.UseStep(step)
+	} else {
+		ok = twoFactor.UseRecoveryCode(s.tokenGenerator.Hash(strings.ToLower(code)))
+	}
+
+	if !ok {
+
+		if err := s.updateLoginChallengePersistence.Fail(challenge.TokenHash()); err != nil {
+			return nil, err
+		}
+
+		return nil, validation.ErrInvalidTwoFactorCode
+	}
+
+	redeemed, err := s.deleteLoginChallengePersistence.Delete(challenge.TokenHash(), now)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Another request redeemed challenge or used up its attempts first.
+	if !redeemed {
+		return nil, validation.ErrInvalidLoginChallenge
+	}
+
+	if err := s.updateTwoFactorPersistence.Update(twoFactor); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUserPersistence.GetById(challenge.UserId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	// Disabled after password was checked.
+	if user.IsDisabled() {
+		return nil, validation.ErrUserDisabled
+	}
+
+	sessionToken, err := startSession(s.createSessionPersistence, s.tokenGenerator, user.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inDto.LoginResultDto{
+		User: toUserDto(user),
+		Token: sessionToken,
+	}, nil
+}
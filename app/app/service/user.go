@@ -1,12 +1,17 @@
 package service
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/kkatou7209/godo/app/domain/entity"
 	"github.com/kkatou7209/godo/app/domain/value"
 	inDto "github.com/kkatou7209/godo/app/port/in/dto"
 	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/mailer"
 	"github.com/kkatou7209/godo/app/port/out/password"
 	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/app/port/out/token"
 	"github.com/kkatou7209/godo/app/validation"
 )
 
@@ -62,52 +67,326 @@ func (s *GetUserService) Get(userId string) (*inDto.UserDto, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if user == nil {
+		return nil, nil
+	}
 	
+	return toUserDto(user), nil
+}
+
+func toUserDto(user *entity.User) *inDto.UserDto {
 	return &inDto.UserDto{
 		Id: user.Id().Value(),
 		UserName: user.UserName().Value(),
 		Email: user.Email().Value(),
-	}, nil
+		Role: user.Role().Value(),
+		IsDisabled: user.IsDisabled(),
+	}
 }
 
+// Get users in one round trip, keyed by ID. Missing ones are left out.
+func getUsers(getUserBatchPersistence persistence.GetUserBatchPersistence, userIds []value.UserId) (map[value.UserId]*entity.User, error) {
+
+	users := make(map[value.UserId]*entity.User)
+
+	if len(userIds) == 0 {
+		return users, nil
+	}
+
+	found, err := getUserBatchPersistence.GetMany(userIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range found {
+		users[user.Id()] = user
+	}
+
+	return users, nil
+}
+
+const (
+	// Lifetime of token confirming email change.
+	EmailChangeLifetime = 24 * time.Hour
+	// Period old address can revert email change.
+	EmailChangeRevertWindow = 7 * 24 * time.Hour
+)
+
 // ChangeUserInfoUsecase implementation.
 type ChangeUserInfoService struct {
 	updateUserPersistence persistence.UpdateUserPersistence
 	getUserPersistence persistence.GetUserPersistence
+	createEmailChangePersistence persistence.CreateEmailChangePersistence
+	tokenGenerator token.TokenGenerator
+	mailer mailer.Mailer
 }
 
-func NewChangeUserInfoService(updateUserPersistence persistence.UpdateUserPersistence, getUserPersistence persistence.GetUserPersistence) *ChangeUserInfoService {
-	return &ChangeUserInfoService{updateUserPersistence, getUserPersistence}
+func NewChangeUserInfoService(
+	updateUserPersistence persistence.UpdateUserPersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	createEmailChangePersistence persistence.CreateEmailChangePersistence,
+	tokenGenerator token.TokenGenerator,
+	mailer mailer.Mailer,
+) *ChangeUserInfoService {
+	return &ChangeUserInfoService{
+		updateUserPersistence,
+		getUserPersistence,
+		createEmailChangePersistence,
+		tokenGenerator,
+		mailer,
+	}
 }
 
-func (s *ChangeUserInfoService) ChangeInfo(user *inDto.UserDto) error {
+func (s *ChangeUserInfoService) ChangeInfo(user *inDto.UserDto) (*inDto.ChangeUserInfoResultDto, error) {
 	
 	sameEmailUser, err := s.getUserPersistence.GetByEmail(value.NewEmail(user.Email))
 	
 	if err != nil {
-		return err
+		return nil, err
 	}
 	
 	if sameEmailUser != nil && sameEmailUser.Id() != value.NewUserId(user.Id) {
-		return validation.ErrEmailAlreadyExists
+		return nil, validation.ErrEmailAlreadyExists
 	}
 	
 	currentUser, err := s.getUserPersistence.GetById(value.NewUserId(user.Id))
 	
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if currentUser == nil {
+		return nil, validation.ErrUserNotFound
+	}
+
+	currentUser.Rename(user.UserName)
+
+	if err := s.updateUserPersistence.Update(currentUser); err != nil {
+		return nil, err
+	}
+
+	newEmail := value.NewEmail(user.Email)
+
+	if newEmail == currentUser.Email() {
+		return &inDto.ChangeUserInfoResultDto{EmailChangePending: false}, nil
+	}
+
+	// Email is switched only after new address confirms it.
+	if err := s.stageEmailChange(currentUser, newEmail); err != nil {
+		return nil, err
+	}
+
+	return &inDto.ChangeUserInfoResultDto{EmailChangePending: true}, nil
+}
+
+func (s *ChangeUserInfoService) stageEmailChange(user *entity.User, newEmail value.Email) error {
+
+	now := time.Now()
+
+	confirmToken, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	revertToken, err := s.tokenGenerator.Generate()
+
+	if err != nil {
+		return err
+	}
+
+	err = s.createEmailChangePersistence.Create(&outDto.CreateEmailChangeCommand{
+		UserId: user.Id(),
+		OldEmail: user.Email(),
+		NewEmail: newEmail,
+		ConfirmTokenHash: s.tokenGenerator.Hash(confirmToken),
+		RevertTokenHash: s.tokenGenerator.Hash(revertToken),
+		ExpiresAt: now.Add(EmailChangeLifetime),
+		RevertibleUntil: now.Add(EmailChangeRevertWindow),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(
+		newEmail,
+		"Confirm your new GoDo email",
+		fmt.Sprintf(
+			"Confirm this address as the new login email of your GoDo account.\n\n" +
+			"Confirm token: %s\n\n" +
+			"This token expires in %d hours.",
+			confirmToken,
+			int(EmailChangeLifetime.Hours()),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(
+		user.Email(),
+		"Your GoDo email is being changed",
+		fmt.Sprintf(
+			"A change of your login email to %s was requested.\n\n" +
+			"If this was not you, revert the change and sign out all sessions with the token below.\n\n" +
+			"Revert token: %s\n\n" +
+			"This token can be used for %d days.",
+			newEmail.Value(),
+			revertToken,
+			int(EmailChangeRevertWindow.Hours() / 24),
+		),
+	)
+}
+
+// ConfirmEmailChangeUsecase implementation.
+type ConfirmEmailChangeService struct {
+	getEmailChangePersistence persistence.GetEmailChangePersistence
+	updateEmailChangePersistence persistence.UpdateEmailChangePersistence
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewConfirmEmailChangeService(
+	getEmailChangePersistence persistence.GetEmailChangePersistence,
+	updateEmailChangePersistence persistence.UpdateEmailChangePersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	tokenGenerator token.TokenGenerator,
+) *ConfirmEmailChangeService {
+	return &ConfirmEmailChangeService{
+		getEmailChangePersistence,
+		updateEmailChangePersistence,
+		getUserPersistence,
+		updateUserPersistence,
+		tokenGenerator,
+	}
+}
+
+func (s *ConfirmEmailChangeService) Confirm(token string) error {
+
+	now := time.Now()
+
+	change, err := s.getEmailChangePersistence.GetByConfirmTokenHash(s.tokenGenerator.Hash(token))
+
+	if err != nil {
+		return err
+	}
+
+	if change == nil || !change.IsConfirmable(now) {
+		return validation.ErrInvalidEmailChangeToken
+	}
+
+	// Address may have been taken while change was pending.
+	sameEmailUser, err := s.getUserPersistence.GetByEmail(change.NewEmail())
+
+	if err != nil {
+		return err
+	}
+
+	if sameEmailUser != nil && sameEmailUser.Id() != change.UserId() {
+		return validation.ErrEmailAlreadyExists
+	}
+
+	user, err := s.getUserPersistence.GetById(change.UserId())
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
 		return validation.ErrUserNotFound
 	}
-	
-	return s.updateUserPersistence.Update(entity.NewUser(
-		value.NewUserId(user.Id),
-		value.NewUserName(user.UserName),
-		value.NewEmail(user.Email),
-		currentUser.Password(),
-	))
+
+	// Only the address it was requested from can be replaced.
+	if user.Email() != change.OldEmail() {
+		return validation.ErrInvalidEmailChangeToken
+	}
+
+	change.Confirm(now)
+
+	if err := s.updateEmailChangePersistence.Update(change); err != nil {
+		return err
+	}
+
+	user.ChangeEmail(change.NewEmail().Value())
+
+	return s.updateUserPersistence.Update(user)
+}
+
+// RevertEmailChangeUsecase implementation.
+type RevertEmailChangeService struct {
+	getEmailChangePersistence persistence.GetEmailChangePersistence
+	updateEmailChangePersistence persistence.UpdateEmailChangePersistence
+	getUserPersistence persistence.GetUserPersistence
+	updateUserPersistence persistence.UpdateUserPersistence
+	deleteSessionPersistence persistence.DeleteSessionPersistence
+	tokenGenerator token.TokenGenerator
+}
+
+func NewRevertEmailChangeService(
+	getEmailChangePersistence persistence.GetEmailChangePersistence,
+	updateEmailChangePersistence persistence.UpdateEmailChangePersistence,
+	getUserPersistence persistence.GetUserPersistence,
+	updateUserPersistence persistence.UpdateUserPersistence,
+	deleteSessionPersistence persistence.DeleteSessionPersistence,
+	tokenGenerator token.TokenGenerator,
+) *RevertEmailChangeService {
+	return &RevertEmailChangeService{
+		getEmailChangePersistence,
+		updateEmailChangePersistence,
+		getUserPersistence,
+		updateUserPersistence,
+		deleteSessionPersistence,
+		tokenGenerator,
+	}
+}
+
+func (s *RevertEmailChangeService) Revert(token string) error {
+
+	now := time.Now()
+
+	change, err := s.getEmailChangePersistence.GetByRevertTokenHash(s.tokenGenerator.Hash(token))
+
+	if err != nil {
+		return err
+	}
+
+	if change == nil || !change.IsRevertible(now) {
+		return validation.ErrInvalidEmailChangeToken
+	}
+
+	user, err := s.getUserPersistence.GetById(change.UserId())
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return validation.ErrUserNotFound
+	}
+
+	change.Revert(now)
+
+	if err := s.updateEmailChangePersistence.Update(change); err != nil {
+		return err
+	}
+
+	if change.ConfirmedAt() != nil {
+
+		user.ChangeEmail(change.OldEmail().Value())
+
+		if err := s.updateUserPersistence.Update(user); err != nil {
+			return err
+		}
+	}
+
+	// Change may have been made from hijacked session.
+	return s.deleteSessionPersistence.DeleteByUserId(user.Id())
 }
 
 // ChangeUserPasswordUsecase implementation.
@@ -7,14 +7,77 @@ var (
 	ErrTodoNotDound = NewValidationError("todo not found")
 	ErrInvalidPassword = NewValidationError("invalid password")
 	ErrInvalidTodoInput = NewValidationError("invalid todo input")
+	ErrInvalidResetToken = NewValidationError("invalid or expired reset token")
+	ErrTwoFactorAlreadyEnabled = NewValidationError("two-factor authentication already enabled")
+	ErrTwoFactorNotEnrolled = NewValidationError("two-factor authentication not enrolled")
+	ErrInvalidTwoFactorCode = NewValidationError("invalid two-factor code")
+	ErrInvalidLoginChallenge = NewValidationError("invalid or expired login challenge")
+	ErrInvalidEmailChangeToken = NewValidationError("invalid or expired email change token")
+	ErrAccountDeletionAlreadyRequested = NewValidationError("account deletion already requested")
+	ErrAccountDeletionNotRequested = NewValidationError("account deletion not requested")
+	ErrInvalidAccessTokenName = NewValidationError("access token name cannot be empty")
+	ErrInvalidScope = NewValidationError("invalid scope")
+	ErrInvalidAccessTokenExpiry = NewValidationError("access token expiry must be in the future")
+	ErrAccessTokenNotFound = NewValidationError("access token not found")
+	ErrUserDisabled = NewValidationError("user is disabled")
+	ErrInvalidRole = NewValidationError("invalid role")
+	ErrCannotManageSelf = NewValidationError("admin cannot disable or demote self")
+	ErrInvalidEmail = NewValidationError("invalid email")
+	ErrProjectNotFound = NewValidationError("project not found")
+	ErrInvalidProjectName = NewValidationError("project name cannot be empty")
+	ErrPermissionDenied = NewValidationError("permission denied")
+	ErrInvalidPermission = NewValidationError("invalid permission")
+	ErrProjectMemberNotFound = NewValidationError("project member not found")
+	ErrAlreadyProjectMember = NewValidationError("user is already project member")
+	ErrLastProjectOwner = NewValidationError("project must keep at least one owner")
+	ErrInvalidProjectInvite = NewValidationError("invalid or expired project invite")
+	ErrProjectInviteEmailMismatch = NewValidationError("project invite was sent to another email")
+	ErrInvalidAssignee = NewValidationError("assignee has no access to todo item")
+	ErrTodoCommentNotFound = NewValidationError("comment not found")
+	ErrInvalidTodoCommentBody = NewValidationError("comment body must not be empty or too long")
+	ErrTodoAttachmentNotFound = NewValidationError("attachment not found")
+	ErrInvalidTodoAttachment = NewValidationError("attachment must have a file name and content")
+	ErrTodoAttachmentTooLarge = NewValidationError("attachment is too large")
+	ErrInvalidSearchQuery = NewValidationError("search query must contain words and not be too long")
+	ErrInvalidBatchOperation = NewValidationError("unknown batch operation")
+	ErrInvalidBatchSize = NewValidationError("batch must have between 1 and 100 operations")
+	ErrBatchAborted = NewValidationError("batch aborted, no operation applied")
+	ErrInvalidUndoToken = NewValidationError("invalid or expired undo token")
+	ErrUndoConflict = NewValidationError("todo item changed since, cannot undo")
+	ErrInvalidLastEventId = NewValidationError("invalid last event ID")
+	ErrInvalidSyncToken = NewValidationError("invalid sync token")
+	ErrInvalidSyncSize = NewValidationError("sync must have at most 500 operations")
+	ErrInvalidSyncOperationId = NewValidationError("sync operations need IDs of at most 64 characters")
+	ErrInvalidSyncOperation = NewValidationError("unknown sync operation")
+	ErrInvalidTodoItemId = NewValidationError("todo item ID must be UUID")
+	ErrTodoDeleted = NewValidationError("todo item is deleted")
+	ErrCalendarFeedNotFound = NewValidationError("calendar feed not found")
+	ErrInvalidImportSize = NewValidationError("import must have between 1 and 500 todo items")
+	ErrInvalidTodoTitle = NewValidationError("title must not be empty or longer than 255 characters")
+	ErrInvalidTodoDescription = NewValidationError("description must not be empty or longer than 255 characters")
 )
 
 type ValidationError struct {
 	msg string
 }
 
+// Errors above by message, so that clients of API can tell them from messages of responses.
+var byMessage = map[string]*ValidationError{}
+
 func NewValidationError(msg string) *ValidationError {
-	return &ValidationError{msg}
+
+	e := &ValidationError{msg}
+
+	if _, ok := byMessage[msg]; !ok {
+		byMessage[msg] = e
+	}
+
+	return e
+}
+
+// Validation error of message. nil if message is of no validation error.
+func Find(msg string) *ValidationError {
+	return byMessage[msg]
 }
 
 func (e *ValidationError) Error() string {
@@ -0,0 +1,182 @@
+package cert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Permissions of private keys and of files safe to share.
+const (
+	PrivatePerm fs.FileMode = 0600
+	PublicPerm fs.FileMode = 0644
+)
+
+// Size of RSA keys signing tokens, in bits.
+const KeyBits = 2048
+
+// Self-signed certificate valid for hosts, names or IP addresses, for serving HTTPS in development.
+// Certificate and its ECDSA P-256 key are PEM encoded.
+func SelfSigned(hosts []string, validFor time.Duration) (certPem []byte, keyPem []byte, err error) {
+
+	if len(hosts) == 0 {
+		return nil, nil, errors.New("no hosts to issue certificate for")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Tolerate clocks of clients running behind.
+	notBefore := time.Now().Add(-time.Hour)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: hosts[0],
+			Organization: []string{"GoDo development"},
+		},
+		NotBefore: notBefore,
+		NotAfter: notBefore.Add(validFor),
+		KeyUsage: x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+
+	return certPem, keyPem, nil
+}
+
+// RSA key pair for signing tokens, PEM encoded.
+func KeyPair(bits int) (privatePem []byte, publicPem []byte, err error) {
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privatePem = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	publicPem = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes})
+
+	return privatePem, publicPem, nil
+}
+
+// File to write with permissions.
+type File struct {
+	Path string
+	Content []byte
+	Perm fs.FileMode
+}
+
+// Write files, creating their directories.
+// Unless force, nothing is written when any file exists, and error wraps fs.ErrExist.
+// Each file is replaced at once, so readers never see it half written.
+func WriteFiles(files []File, force bool) error {
+
+	if !force {
+
+		existing := []string{}
+
+		for _, file := range files {
+			if _, err := os.Lstat(file.Path); err == nil {
+				existing = append(existing, file.Path)
+			}
+		}
+
+		if len(existing) > 0 {
+			return fmt.Errorf("%s: %w", strings.Join(existing, ", "), fs.ErrExist)
+		}
+	}
+
+	for _, file := range files {
+		if err := writeFile(file); err != nil {
+			return fmt.Errorf("%s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func writeFile(file File) error {
+
+	dir := filepath.Dir(file.Path)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "." + filepath.Base(file.Path) + ".*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	// Set regardless of umask.
+	if err := tmp.Chmod(file.Perm); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(file.Content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file.Path)
+}
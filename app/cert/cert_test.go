@@ -0,0 +1,129 @@
+package cert_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/cert"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCert(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cert test.")
+}
+
+var _ = Describe("Cert", func() {
+
+	It("issues self-signed certificate for names and addresses", func() {
+
+		certPem, keyPem, err := cert.SelfSigned([]string{"localhost", "godo.test", "127.0.0.1", "::1"}, 24 * time.Hour)
+
+		Expect(err).To(BeNil())
+
+		_, err = tls.X509KeyPair(certPem, keyPem)
+
+		Expect(err).To(BeNil())
+
+		block, _ := pem.Decode(certPem)
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+
+		Expect(err).To(BeNil())
+
+		Expect(certificate.DNSNames).To(Equal([]string{"localhost", "godo.test"}))
+		Expect(certificate.IPAddresses).To(HaveLen(2))
+		Expect(certificate.IPAddresses[1].Equal(net.IPv6loopback)).To(BeTrue())
+		Expect(certificate.NotAfter).To(BeTemporally("~", time.Now().Add(23 * time.Hour), time.Minute))
+
+		roots := x509.NewCertPool()
+
+		roots.AddCert(certificate)
+
+		for _, host := range []string{"localhost", "godo.test", "127.0.0.1"} {
+			_, err := certificate.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+			Expect(err).To(BeNil(), host)
+		}
+
+		_, err = certificate.Verify(x509.VerifyOptions{DNSName: "example.com", Roots: roots})
+
+		Expect(err).NotTo(BeNil())
+
+		_, _, err = cert.SelfSigned(nil, time.Hour)
+
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("writes private files unreadable by others, refusing to overwrite", func() {
+
+		dir := filepath.Join(GinkgoT().TempDir(), "cert")
+
+		files := []cert.File{
+			{Path: filepath.Join(dir, "tls.key"), Content: []byte("key"), Perm: cert.PrivatePerm},
+			{Path: filepath.Join(dir, "tls.crt"), Content: []byte("crt"), Perm: cert.PublicPerm},
+		}
+
+		Expect(cert.WriteFiles(files, false)).To(Succeed())
+
+		info, err := os.Stat(files[0].Path)
+
+		Expect(err).To(BeNil())
+		Expect(info.Mode().Perm()).To(Equal(fs.FileMode(0600)))
+
+		info, err = os.Stat(files[1].Path)
+
+		Expect(err).To(BeNil())
+		Expect(info.Mode().Perm()).To(Equal(fs.FileMode(0644)))
+
+		// Nothing is written when any file exists.
+		Expect(os.Remove(files[1].Path)).To(Succeed())
+
+		files[0].Content = []byte("new key")
+
+		err = cert.WriteFiles(files, false)
+
+		Expect(err).To(MatchError(fs.ErrExist))
+		Expect(err).To(MatchError(ContainSubstring("tls.key")))
+		Expect(files[1].Path).NotTo(BeAnExistingFile())
+
+		Expect(cert.WriteFiles(files, true)).To(Succeed())
+
+		content, err := os.ReadFile(files[0].Path)
+
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("new key"))
+
+		entries, err := os.ReadDir(dir)
+
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(2))
+	})
+
+	It("generates token key pair", func() {
+
+		privatePem, publicPem, err := cert.KeyPair(cert.KeyBits)
+
+		Expect(err).To(BeNil())
+
+		block, _ := pem.Decode(privatePem)
+
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+
+		Expect(err).To(BeNil())
+
+		block, _ = pem.Decode(publicPem)
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+
+		Expect(err).To(BeNil())
+		Expect(privateKey.PublicKey.Equal(publicKey)).To(BeTrue())
+	})
+})
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type User struct {
+	Id string `json:"id"`
+	Username string `json:"username"`
+	Email string `json:"email"`
+	Role string `json:"role"`
+}
+
+type LoginResult struct {
+	// Session token. Empty while second factor is pending.
+	Token string
+	// Logged in user. nil while second factor is pending.
+	User *User
+	TwoFactorRequired bool
+	// Token to verify login with second factor.
+	ChallengeToken string
+}
+
+type loginChallenge struct {
+	TwoFactorRequired bool `json:"twoFactorRequired"`
+	ChallengeToken string `json:"challengeToken"`
+}
+
+// Sign up new user.
+func (c *Client) SignUp(ctx context.Context, username string, email string, password string) error {
+
+	_, err := c.do(ctx, http.MethodPost, "/auth/signup", map[string]string{
+		"username": username,
+		"email": email,
+		"password": password,
+	}, nil)
+
+	return err
+}
+
+// Log in with credentials. Client sends session token from then on.
+// If user enabled two-factor authentication, complete login with VerifyLogin.
+func (c *Client) Login(ctx context.Context, email string, password string) (*LoginResult, error) {
+
+	return c.login(ctx, "/auth/login", map[string]string{
+		"email": email,
+		"password": password,
+	})
+}
+
+// Complete login with TOTP code or recovery code. Client sends session token from then on.
+func (c *Client) VerifyLogin(ctx context.Context, challengeToken string, code string) (*LoginResult, error) {
+
+	return c.login(ctx, "/auth/login/verify", map[string]string{
+		"challengeToken": challengeToken,
+		"code": code,
+	})
+}
+
+func (c *Client) login(ctx context.Context, path string, body any) (*LoginResult, error) {
+
+	var out struct {
+		User
+		loginChallenge
+	}
+
+	res, err := c.do(ctx, http.MethodPost, path, body, &out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusAccepted {
+		return &LoginResult{
+			TwoFactorRequired: true,
+			ChallengeToken: out.ChallengeToken,
+		}, nil
+	}
+
+	result := &LoginResult{User: &out.User}
+
+	for _, cookie := range res.Cookies() {
+		if cookie.Name == sessionCookieName {
+			result.Token = cookie.Value
+		}
+	}
+
+	c.SetToken(result.Token)
+
+	return result, nil
+}
+
+// Mail password reset token to email if registered. Succeeds either way.
+func (c *Client) ForgotPassword(ctx context.Context, email string) error {
+
+	_, err := c.do(ctx, http.MethodPost, "/auth/password/forgot", map[string]string{"email": email}, nil)
+
+	return err
+}
+
+// Reset password with token mailed by ForgotPassword.
+func (c *Client) ResetPassword(ctx context.Context, token string, password string) error {
+
+	_, err := c.do(ctx, http.MethodPost, "/auth/password/reset", map[string]string{
+		"token": token,
+		"password": password,
+	}, nil)
+
+	return err
+}
+
+// Confirm email change with token mailed to new address.
+func (c *Client) ConfirmEmailChange(ctx context.Context, token string) error {
+
+	_, err := c.do(ctx, http.MethodPost, "/auth/email/confirm", map[string]string{"token": token}, nil)
+
+	return err
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Same as middleware.SessionCookieName.
+const sessionCookieName = "x-api-token"
+
+// Retries of idempotent requests by default.
+const DefaultRetries = 2
+
+// Wait before first retry by default. Doubles on each retry.
+const DefaultBackoff = 200 * time.Millisecond
+
+// Client of GoDo REST API, authenticated by session token or personal access token.
+// Safe for concurrent use.
+type Client struct {
+	baseUrl string
+	http *http.Client
+	retries int
+	backoff time.Duration
+	mu sync.RWMutex
+	token string
+}
+
+// Create client of API served at base URL, e.g. "http://localhost:8000".
+func New(baseUrl string) *Client {
+	return &Client{
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+		http: http.DefaultClient,
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+	}
+}
+
+// Use http client to send requests.
+func (c *Client) SetHttpClient(httpClient *http.Client) *Client {
+	c.http = httpClient
+	return c
+}
+
+// Retry GET, PUT and DELETE requests failing by network or by 429, 502, 503 and 504 responses.
+// Other requests are never retried, as they may have taken effect. Zero retries disables retrying.
+func (c *Client) SetRetry(retries int, backoff time.Duration) *Client {
+	c.retries = retries
+	c.backoff = backoff
+	return c
+}
+
+// Send token as bearer. Login sets session token by itself.
+func (c *Client) SetToken(token string) *Client {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
+
+	return c
+}
+
+// Token sent as bearer. Empty if anonymous.
+func (c *Client) Token() string {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.token
+}
+
+// Envelope of every JSON response.
+type payload[T any] struct {
+	Status bool `json:"status"`
+	Message string `json:"message"`
+	Data *T `json:"data"`
+	Errors map[string]string `json:"errors"`
+}
+
+// Send request with body as JSON and decode data of response into out unless nil.
+func (c *Client) do(ctx context.Context, method string, path string, body any, out any) (*http.Response, error) {
+
+	var b []byte
+
+	if body != nil {
+
+		var err error
+
+		b, err = json.Marshal(body)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	retries := 0
+
+	if idempotent(method) {
+		retries = c.retries
+	}
+
+	wait := c.backoff
+
+	for attempt := 0; ; attempt++ {
+
+		res, raw, err := c.send(ctx, method, path, b)
+
+		if attempt < retries && ctx.Err() == nil && retryable(res, err) {
+
+			if after := retryAfter(res); after > wait {
+				wait = after
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+
+			wait *= 2
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return res, decode(res, raw, out)
+	}
+}
+
+// Send request once, returning response with its body read.
+func (c *Client) send(ctx context.Context, method string, path string, body []byte) (*http.Response, []byte, error) {
+
+	var reader io.Reader
+
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl + path, reader)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer " + token)
+	}
+
+	res, err := c.http.Do(req)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, raw, nil
+}
+
+// Decode data of payload into out unless nil, or error of payload.
+func decode(res *http.Response, raw []byte, out any) error {
+
+	p := &payload[json.RawMessage]{}
+
+	if err := json.Unmarshal(raw, p); err != nil {
+
+		if res.StatusCode >= 400 {
+			return &Error{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(raw))}
+		}
+
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return &Error{StatusCode: res.StatusCode, Message: p.Message, Errors: p.Errors}
+	}
+
+	if out != nil && p.Data != nil {
+		if err := json.Unmarshal(*p.Data, out); err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Repeating request has same effect as sending it once.
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+// Request failed by network or by server being unavailable for now.
+func retryable(res *http.Response, err error) bool {
+
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// Wait asked by Retry-After header in seconds. Zero if none.
+func retryAfter(res *http.Response) time.Duration {
+
+	if res == nil {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// Path of user.
+func userPath(userId string) string {
+	return "/user/" + userId
+}
@@ -0,0 +1,295 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Client test.")
+}
+
+// Request seen by fake API.
+type received struct {
+	Method string
+	Path string
+	Authorization string
+	Body map[string]any
+}
+
+// Fake API answering every request with status and payload, recording requests.
+func fakeApi(status int, payload map[string]any, cookies ...*http.Cookie) (*httptest.Server, *[]received) {
+
+	requests := &[]received{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		req := received{
+			Method: r.Method,
+			Path: r.URL.Path,
+			Authorization: r.Header.Get("Authorization"),
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&req.Body)
+
+		*requests = append(*requests, req)
+
+		for _, cookie := range cookies {
+			http.SetCookie(w, cookie)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		_ = json.NewEncoder(w).Encode(payload)
+	}))
+
+	return ts, requests
+}
+
+// Fake API answering 503 to first failures requests, and undo token to later ones.
+func flakyApi(failures int32) (*httptest.Server, *atomic.Int32) {
+
+	count := &atomic.Int32{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if count.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]any{"status": false, "message": "unavailable"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": true, "data": map[string]any{"undoToken": "undo"}})
+	}))
+
+	return ts, count
+}
+
+var _ = Describe("Client test", func() {
+
+	ctx := context.Background()
+
+	It("should keep session token of login", func() {
+
+		ts, requests := fakeApi(http.StatusOK, map[string]any{
+			"status": true,
+			"data": map[string]any{"id": "user-1", "username": "client", "email": "client@example.com", "role": "user"},
+		}, &http.Cookie{Name: "x-api-token", Value: "session-token"})
+
+		defer ts.Close()
+
+		api := client.New(ts.URL + "/")
+
+		result, err := api.Login(ctx, "client@example.com", "password")
+
+		Expect(err).To(BeNil())
+		Expect(result.Token).To(Equal("session-token"))
+		Expect(result.User.Id).To(Equal("user-1"))
+		Expect(api.Token()).To(Equal("session-token"))
+
+		_, err = api.DeleteTodo(ctx, "user-1", "todo-1")
+
+		Expect(err).To(BeNil())
+		Expect(*requests).To(HaveLen(2))
+		Expect((*requests)[0].Path).To(Equal("/auth/login"))
+		Expect((*requests)[0].Body).To(Equal(map[string]any{"email": "client@example.com", "password": "password"}))
+		Expect((*requests)[0].Authorization).To(BeEmpty())
+		Expect((*requests)[1].Path).To(Equal("/user/user-1/todo-item/todo-1"))
+		Expect((*requests)[1].Authorization).To(Equal("Bearer session-token"))
+	})
+
+	It("should answer pending two-factor login", func() {
+
+		ts, _ := fakeApi(http.StatusAccepted, map[string]any{
+			"status": true,
+			"data": map[string]any{"twoFactorRequired": true, "challengeToken": "challenge"},
+		})
+
+		defer ts.Close()
+
+		api := client.New(ts.URL)
+
+		result, err := api.Login(ctx, "client@example.com", "password")
+
+		Expect(err).To(BeNil())
+		Expect(result.TwoFactorRequired).To(BeTrue())
+		Expect(result.ChallengeToken).To(Equal("challenge"))
+		Expect(result.User).To(BeNil())
+		Expect(api.Token()).To(BeEmpty())
+	})
+
+	It("should decode todo items and undo tokens", func() {
+
+		ts, requests := fakeApi(http.StatusOK, map[string]any{
+			"status": true,
+			"data": []map[string]any{{"id": "todo-1", "title": "title", "description": "description", "isDone": true, "projectId": "project-1"}},
+		})
+
+		defer ts.Close()
+
+		api := client.New(ts.URL).SetToken("token")
+
+		todos, err := api.ListTodos(ctx, "user-1")
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(Equal([]*client.Todo{{Id: "todo-1", Title: "title", Description: "description", IsDone: true, ProjectId: "project-1"}}))
+
+		ts, requests = fakeApi(http.StatusOK, map[string]any{"status": true, "data": map[string]any{"undoToken": "undo"}})
+
+		defer ts.Close()
+
+		api = client.New(ts.URL).SetToken("token")
+
+		undoToken, err := api.CompleteTodo(ctx, "user-1", "todo-1")
+
+		Expect(err).To(BeNil())
+		Expect(undoToken).To(Equal("undo"))
+		Expect((*requests)[0].Method).To(Equal(http.MethodPatch))
+		Expect((*requests)[0].Path).To(Equal("/user/user-1/todo-item/todo-1/complete"))
+
+		_, err = api.UpdateTodo(ctx, "user-1", "todo-1", "new title", "new description")
+
+		Expect(err).To(BeNil())
+		Expect((*requests)[1].Method).To(Equal(http.MethodPut))
+		Expect((*requests)[1].Body).To(Equal(map[string]any{"title": "new title", "description": "new description"}))
+	})
+
+	It("should return error answered by API", func() {
+
+		ts, _ := fakeApi(http.StatusBadRequest, map[string]any{
+			"status": false,
+			"message": "title is empty",
+			"errors": map[string]string{"title": "empty cannot be set"},
+		})
+
+		defer ts.Close()
+
+		_, err := client.New(ts.URL).AddTodo(ctx, "user-1", "", "", "")
+
+		Expect(err).To(HaveOccurred())
+
+		apiErr, ok := err.(*client.Error)
+
+		Expect(ok).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(apiErr.Errors).To(HaveKeyWithValue("title", "empty cannot be set"))
+		Expect(apiErr.Error()).To(Equal("title is empty"))
+	})
+
+	It("should unwrap validation errors", func() {
+
+		ts, _ := fakeApi(http.StatusBadRequest, map[string]any{"status": false, "message": "todo not found"})
+
+		defer ts.Close()
+
+		_, err := client.New(ts.URL).CompleteTodo(ctx, "user-1", "todo-1")
+
+		Expect(errors.Is(err, validation.ErrTodoNotDound)).To(BeTrue())
+		Expect(errors.Is(err, validation.ErrPermissionDenied)).To(BeFalse())
+
+		ts, _ = fakeApi(http.StatusBadRequest, map[string]any{
+			"status": false,
+			"message": "validation error",
+			"errors": map[string]string{"couse": "email already exists"},
+		})
+
+		defer ts.Close()
+
+		err = client.New(ts.URL).SignUp(ctx, "client", "client@example.com", "password")
+
+		Expect(errors.Is(err, validation.ErrEmailAlreadyExists)).To(BeTrue())
+		Expect(err.Error()).To(Equal("validation error: email already exists"))
+
+		ts, _ = fakeApi(http.StatusUnauthorized, map[string]any{"status": false, "message": "authentication required"})
+
+		defer ts.Close()
+
+		_, err = client.New(ts.URL).ListTodos(ctx, "user-1")
+
+		var apiErr *client.Error
+
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Unauthorized()).To(BeTrue())
+		Expect(errors.Unwrap(err)).To(BeNil())
+	})
+
+	It("should retry idempotent requests", func() {
+
+		ts, count := flakyApi(2)
+
+		defer ts.Close()
+
+		api := client.New(ts.URL).SetRetry(2, time.Millisecond)
+
+		undoToken, err := api.DeleteTodo(ctx, "user-1", "todo-1")
+
+		Expect(err).To(BeNil())
+		Expect(undoToken).To(Equal("undo"))
+		Expect(count.Load()).To(Equal(int32(3)))
+	})
+
+	It("should give up retrying", func() {
+
+		ts, count := flakyApi(10)
+
+		defer ts.Close()
+
+		_, err := client.New(ts.URL).SetRetry(2, time.Millisecond).ListTodos(ctx, "user-1")
+
+		var apiErr *client.Error
+
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(count.Load()).To(Equal(int32(3)))
+	})
+
+	It("should not retry other requests", func() {
+
+		ts, count := flakyApi(1)
+
+		defer ts.Close()
+
+		api := client.New(ts.URL).SetRetry(2, time.Millisecond)
+
+		_, err := api.AddTodo(ctx, "user-1", "title", "description", "")
+
+		Expect(err).To(HaveOccurred())
+		Expect(count.Load()).To(Equal(int32(1)))
+
+		_, err = api.CompleteTodo(ctx, "user-1", "todo-1")
+
+		Expect(err).To(BeNil())
+		Expect(count.Load()).To(Equal(int32(2)))
+	})
+
+	It("should stop retrying when context is done", func() {
+
+		ts, count := flakyApi(10)
+
+		defer ts.Close()
+
+		timeout, cancel := context.WithTimeout(ctx, 50 * time.Millisecond)
+
+		defer cancel()
+
+		_, err := client.New(ts.URL).SetRetry(5, time.Second).ListTodos(timeout, "user-1")
+
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(count.Load()).To(Equal(int32(1)))
+	})
+})
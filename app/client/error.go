@@ -0,0 +1,64 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Error answered by API.
+//
+// Errors of use cases unwrap to the validation errors they mirror, e.g.
+//
+//	errors.Is(err, validation.ErrTodoNotDound)
+type Error struct {
+	StatusCode int
+	Message string
+	// Messages by field, or by "couse" and "errors" for errors of no field.
+	Errors map[string]string
+}
+
+func (e *Error) Error() string {
+
+	message := e.Message
+
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	for _, key := range []string{"couse", "errors"} {
+		if detail, ok := e.Errors[key]; ok && detail != message {
+			return fmt.Sprintf("%s: %s", message, detail)
+		}
+	}
+
+	return message
+}
+
+// Validation error answered, nil if none.
+// Handlers put it either in message or in errors, depending on route.
+func (e *Error) Unwrap() error {
+
+	if found := validation.Find(e.Message); found != nil {
+		return found
+	}
+
+	for _, detail := range e.Errors {
+		if found := validation.Find(detail); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// Request was not authenticated, or token is invalid or expired.
+func (e *Error) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// Authenticated user cannot do request, e.g. token lacks scope.
+func (e *Error) Forbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type Todo struct {
+	Id string `json:"id"`
+	Title string `json:"title"`
+	Description string `json:"description"`
+	IsDone bool `json:"isDone"`
+	// Empty if personal.
+	ProjectId string `json:"projectId"`
+	// Empty if unassigned.
+	AssigneeId string `json:"assigneeId"`
+}
+
+type Project struct {
+	Id string `json:"id"`
+	Name string `json:"name"`
+	// Permission of requesting user.
+	Permission string `json:"permission"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type undo struct {
+	UndoToken string `json:"undoToken"`
+}
+
+// Todo items of user, personal ones and those of projects.
+func (c *Client) ListTodos(ctx context.Context, userId string) ([]*Todo, error) {
+
+	todos := make([]*Todo, 0)
+
+	if _, err := c.do(ctx, http.MethodGet, userPath(userId) + "/todo-items", nil, &todos); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// Add todo item, personal unless project ID is given. Returns undo token.
+func (c *Client) AddTodo(ctx context.Context, userId string, title string, description string, projectId string) (string, error) {
+
+	return c.mutateTodo(ctx, http.MethodPost, userPath(userId) + "/todo-item", map[string]string{
+		"title": title,
+		"description": description,
+		"projectId": projectId,
+	})
+}
+
+// Change title and description of todo item. Returns undo token.
+func (c *Client) UpdateTodo(ctx context.Context, userId string, todoId string, title string, description string) (string, error) {
+
+	return c.mutateTodo(ctx, http.MethodPut, todoPath(userId, todoId), map[string]string{
+		"title": title,
+		"description": description,
+	})
+}
+
+// Complete todo item. Returns undo token.
+func (c *Client) CompleteTodo(ctx context.Context, userId string, todoId string) (string, error) {
+	return c.mutateTodo(ctx, http.MethodPatch, todoPath(userId, todoId) + "/complete", nil)
+}
+
+// Uncomplete todo item. Returns undo token.
+func (c *Client) UncompleteTodo(ctx context.Context, userId string, todoId string) (string, error) {
+	return c.mutateTodo(ctx, http.MethodPatch, todoPath(userId, todoId) + "/uncomplete", nil)
+}
+
+// Delete todo item. Returns undo token.
+func (c *Client) DeleteTodo(ctx context.Context, userId string, todoId string) (string, error) {
+	return c.mutateTodo(ctx, http.MethodDelete, todoPath(userId, todoId), nil)
+}
+
+// Projects user is member of.
+func (c *Client) ListProjects(ctx context.Context, userId string) ([]*Project, error) {
+
+	projects := make([]*Project, 0)
+
+	if _, err := c.do(ctx, http.MethodGet, userPath(userId) + "/projects", nil, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (c *Client) mutateTodo(ctx context.Context, method string, path string, body any) (string, error) {
+
+	out := &undo{}
+
+	if _, err := c.do(ctx, method, path, body, out); err != nil {
+		return "", err
+	}
+
+	return out.UndoToken, nil
+}
+
+func todoPath(userId string, todoId string) string {
+	return userPath(userId) + "/todo-item/" + todoId
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// User of ID. Users can only get themselves.
+func (c *Client) GetUser(ctx context.Context, userId string) (*User, error) {
+
+	user := &User{}
+
+	if _, err := c.do(ctx, http.MethodGet, userPath(userId), nil, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Change user name and email. New email takes effect once confirmed with token mailed to it.
+func (c *Client) UpdateUser(ctx context.Context, userId string, username string, email string) error {
+
+	_, err := c.do(ctx, http.MethodPut, userPath(userId), map[string]string{
+		"username": username,
+		"email": email,
+	}, nil)
+
+	return err
+}
+
+// Change password. Needs session token, not personal access token.
+func (c *Client) ChangePassword(ctx context.Context, userId string, oldPassword string, newPassword string) error {
+
+	_, err := c.do(ctx, http.MethodPatch, userPath(userId) + "/password", map[string]string{
+		"oldPassword": oldPassword,
+		"newPassword": newPassword,
+	}, nil)
+
+	return err
+}
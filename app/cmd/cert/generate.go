@@ -1,79 +1,59 @@
 package main
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
+
+	"github.com/kkatou7209/godo/cert"
+	"github.com/urfave/cli/v2"
 )
 
 func main() {
 
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2024)
-
-	if err != nil {
-		log.Fatalf("fail to generate key: %v", err)
-		return
-	}
-
-	info, err := os.Stat("cert")
-
-	if os.IsNotExist(err) || !info.IsDir() {
-
-		err = os.Mkdir("cert", 0755)
-
-		if err != nil {
-			log.Fatalf("fail create ket file directory: %v", err)
-			return
-		}
-	}
-
-	privateKeyFile, err := os.Create("cert/private.pem")
-
-	if err != nil {
-		log.Fatalf("fail create key file: %v", err)
-		return
-	}
-
-	defer privateKeyFile.Close()
-
-	publicKeyFile, err := os.Create("cert/public.pem")
-
-	if err != nil {
-		log.Fatalf("fail create key file: %v", err)
-		return
-	}
-
-	defer publicKeyFile.Close()
-
-	privateBytes := x509.MarshalPKCS1PrivateKey(privateKey)
-
-	privateBlock := &pem.Block{
-		Type: "RSA PRIVATE KEY",
-		Bytes: privateBytes,
-	}
-
-	publicBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
-	
-	if err != nil {
-		log.Fatalf("fail to create bytes from public key: %v", err)
-		return
-	}
-
-	publicBlock := &pem.Block{
-		Type:  "PUBLIC KEY",
-		Bytes: publicBytes,
-	}
-
-	if err = pem.Encode(privateKeyFile, privateBlock); err != nil {
-		log.Fatalf("fail to write private key on file: %v", err)
-		return
-	}
-
-	if err = pem.Encode(publicKeyFile, publicBlock); err != nil {
-		log.Fatalf("fail to write public key on file: %v", err)
-		return
+	app := &cli.App{
+		Name: "cert",
+		Usage: "Generate RSA key pair for signing tokens",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "dir",
+				Aliases: []string{"d"},
+				Value: "cert",
+				Usage: "Specify the directory of private.pem and public.pem.",
+			},
+			&cli.BoolFlag{
+				Name: "force",
+				Aliases: []string{"f"},
+				Usage: "Overwrite existing key files.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			privatePem, publicPem, err := cert.KeyPair(cert.KeyBits)
+
+			if err != nil {
+				return fmt.Errorf("fail to generate key: %w", err)
+			}
+
+			dir := c.String("dir")
+
+			err = cert.WriteFiles([]cert.File{
+				{Path: filepath.Join(dir, "private.pem"), Content: privatePem, Perm: cert.PrivatePerm},
+				{Path: filepath.Join(dir, "public.pem"), Content: publicPem, Perm: cert.PublicPerm},
+			}, c.Bool("force"))
+
+			if errors.Is(err, fs.ErrExist) {
+				return fmt.Errorf("%w: use --force to overwrite", err)
+			}
+
+			return err
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
 	}
 }
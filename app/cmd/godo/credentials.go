@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Session kept between commands.
+type credentials struct {
+	Server string `json:"server"`
+	Token string `json:"token"`
+	UserId string `json:"userId"`
+}
+
+var errNotLoggedIn = errors.New("not logged in: run \"godo login\" first")
+
+// Path of credentials file in user config dir, e.g. ~/.config/godo/credentials.json.
+func credentialsPath() (string, error) {
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "godo", "credentials.json"), nil
+}
+
+func loadCredentials() (*credentials, error) {
+
+	path, err := credentialsPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNotLoggedIn
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &credentials{}
+
+	if err := json.Unmarshal(b, cred); err != nil {
+		return nil, err
+	}
+
+	if cred.Token == "" || cred.UserId == "" {
+		return nil, errNotLoggedIn
+	}
+
+	return cred, nil
+}
+
+// Save credentials readable only by user, as token acts as user.
+func saveCredentials(cred *credentials) error {
+
+	path, err := credentialsPath()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(cred, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	// Write beside and rename, so that failed write keeps previous credentials.
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func deleteCredentials() error {
+
+	path, err := credentialsPath()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
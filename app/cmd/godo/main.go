@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kkatou7209/godo/client"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Shared so that piped lines are not lost in buffers of earlier prompts.
+var stdin = bufio.NewReader(os.Stdin)
+
+func main() {
+
+	log.SetFlags(0)
+
+	cli := &cli.App{
+		Name: "godo",
+		Usage: "Manage GoDo todo items from terminal",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "server",
+				Aliases: []string{"s"},
+				Value: envOr("GODO_SERVER", "http://localhost:8000"),
+				Usage: "Specify the URL of GoDo API. Defaults to the server logged in to.",
+			},
+			&cli.StringFlag{
+				Name: "output",
+				Aliases: []string{"o"},
+				Value: formatTable,
+				Usage: "Specify the output format (table, json or id).",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return validFormat(c.String("output"))
+		},
+		Commands: []*cli.Command{
+			{
+				Name: "login",
+				Usage: "Log in and keep session in user config dir",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name: "email",
+						Aliases: []string{"e"},
+						Usage: "Specify the email. Asked when empty.",
+					},
+				},
+				Action: login,
+			},
+			{
+				Name: "logout",
+				Usage: "Forget kept session",
+				Action: func(c *cli.Context) error {
+					return deleteCredentials()
+				},
+			},
+			{
+				Name: "ls",
+				Usage: "List open todo items",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name: "done",
+						Usage: "List completed todo items instead.",
+					},
+					&cli.BoolFlag{
+						Name: "all",
+						Aliases: []string{"a"},
+						Usage: "List both open and completed todo items.",
+					},
+					&cli.StringFlag{
+						Name: "project",
+						Aliases: []string{"p"},
+						Usage: "Specify the project by ID or name. \"-\" lists personal todo items only.",
+					},
+				},
+				Action: listTodos,
+			},
+			{
+				Name: "add",
+				Usage: "Add todo item",
+				ArgsUsage: "<title>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name: "description",
+						Aliases: []string{"d"},
+						Usage: "Specify the description.",
+					},
+					&cli.StringFlag{
+						Name: "project",
+						Aliases: []string{"p"},
+						Usage: "Specify the project by ID or name. Todo item is personal when empty.",
+					},
+				},
+				Action: addTodo,
+			},
+			{
+				Name: "done",
+				Usage: "Complete todo items",
+				ArgsUsage: "<id>...",
+				Action: completeTodos,
+			},
+			{
+				Name: "edit",
+				Usage: "Change title or description of todo item",
+				ArgsUsage: "<id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name: "title",
+						Aliases: []string{"t"},
+						Usage: "Specify the new title.",
+					},
+					&cli.StringFlag{
+						Name: "description",
+						Aliases: []string{"d"},
+						Usage: "Specify the new description.",
+					},
+				},
+				Action: editTodo,
+			},
+			{
+				Name: "rm",
+				Usage: "Delete todo items",
+				ArgsUsage: "<id>...",
+				Action: deleteTodos,
+			},
+		},
+	}
+
+	if err := cli.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func login(c *cli.Context) error {
+
+	email := c.String("email")
+
+	if email == "" {
+
+		line, err := prompt("Email: ")
+
+		if err != nil {
+			return err
+		}
+
+		email = line
+	}
+
+	password, err := promptSecret("Password: ")
+
+	if err != nil {
+		return err
+	}
+
+	server := c.String("server")
+
+	api := client.New(server)
+
+	result, err := api.Login(c.Context, email, password)
+
+	if err != nil {
+		return err
+	}
+
+	if result.TwoFactorRequired {
+
+		code, err := prompt("Two-factor code: ")
+
+		if err != nil {
+			return err
+		}
+
+		result, err = api.VerifyLogin(c.Context, result.ChallengeToken, code)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if result.Token == "" {
+		return fmt.Errorf("server answered no session token")
+	}
+
+	err = saveCredentials(&credentials{
+		Server: server,
+		Token: result.Token,
+		UserId: result.User.Id,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.ErrWriter, "Logged in as %s.\n", result.User.Username)
+
+	return nil
+}
+
+// Client authenticated by kept session, and ID of logged in user.
+func session(c *cli.Context) (*client.Client, string, error) {
+
+	cred, err := loadCredentials()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	server := cred.Server
+
+	if c.IsSet("server") || server == "" {
+		server = c.String("server")
+	}
+
+	return client.New(server).SetToken(cred.Token), cred.UserId, nil
+}
+
+// Read line from terminal.
+func prompt(label string) (string, error) {
+
+	fmt.Fprint(os.Stderr, label)
+
+	line, err := stdin.ReadString('\n')
+
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no input: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// Read line from terminal without echo. Piped input is read as is.
+func promptSecret(label string) (string, error) {
+
+	fd := int(os.Stdin.Fd())
+
+	// Spaces may be part of password, so only line break is dropped.
+	if !term.IsTerminal(fd) {
+
+		fmt.Fprint(os.Stderr, label)
+
+		line, err := stdin.ReadString('\n')
+
+		if err != nil && line == "" {
+			return "", fmt.Errorf("no input: %w", err)
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, label)
+
+	b, err := term.ReadPassword(fd)
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func envOr(key string, fallback string) string {
+
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return fallback
+}
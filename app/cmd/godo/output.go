@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kkatou7209/godo/client"
+)
+
+// Formats of output.
+const (
+	formatTable = "table"
+	formatJson = "json"
+	// Only IDs, one per line, for piping.
+	formatId = "id"
+)
+
+var formats = []string{formatTable, formatJson, formatId}
+
+func validFormat(format string) error {
+
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown output format %q: use one of %s", format, strings.Join(formats, ", "))
+}
+
+// Print todo items in format. Project names are keyed by project ID.
+func printTodos(w io.Writer, format string, todos []*client.Todo, projectNames map[string]string) error {
+
+	switch format {
+
+	case formatJson:
+		return printJson(w, todos)
+
+	case formatId:
+
+		for _, todo := range todos {
+			fmt.Fprintln(w, todo.Id)
+		}
+
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "ID\tDONE\tTITLE\tPROJECT")
+
+	for _, todo := range todos {
+
+		done := ""
+
+		if todo.IsDone {
+			done = "x"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", todo.Id, done, oneLine(todo.Title), projectNames[todo.ProjectId])
+	}
+
+	return tw.Flush()
+}
+
+// Print result of mutation on todo item in format.
+func printMutation(w io.Writer, format string, todoId string, undoToken string, message string) error {
+
+	switch format {
+
+	case formatJson:
+		return printJson(w, map[string]string{"id": todoId, "undoToken": undoToken})
+
+	case formatId:
+
+		if todoId != "" {
+			fmt.Fprintln(w, todoId)
+		}
+
+		return nil
+	}
+
+	fmt.Fprintln(w, message)
+
+	return nil
+}
+
+func printJson(w io.Writer, v any) error {
+
+	enc := json.NewEncoder(w)
+
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// Keep table rows on one line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
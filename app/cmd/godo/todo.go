@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kkatou7209/godo/client"
+	"github.com/urfave/cli/v2"
+)
+
+func listTodos(c *cli.Context) error {
+
+	api, userId, err := session(c)
+
+	if err != nil {
+		return err
+	}
+
+	todos, err := api.ListTodos(c.Context, userId)
+
+	if err != nil {
+		return err
+	}
+
+	projects, err := api.ListProjects(c.Context, userId)
+
+	if err != nil {
+		return err
+	}
+
+	projectId := ""
+
+	if c.String("project") == "-" {
+		projectId = "-"
+	} else if c.String("project") != "" {
+
+		project, err := findProject(projects, c.String("project"))
+
+		if err != nil {
+			return err
+		}
+
+		projectId = project.Id
+	}
+
+	listed := make([]*client.Todo, 0, len(todos))
+
+	for _, todo := range todos {
+
+		if !c.Bool("all") && todo.IsDone != c.Bool("done") {
+			continue
+		}
+
+		if projectId == "-" && todo.ProjectId != "" {
+			continue
+		}
+
+		if projectId != "" && projectId != "-" && todo.ProjectId != projectId {
+			continue
+		}
+
+		listed = append(listed, todo)
+	}
+
+	projectNames := make(map[string]string, len(projects))
+
+	for _, project := range projects {
+		projectNames[project.Id] = project.Name
+	}
+
+	return printTodos(c.App.Writer, c.String("output"), listed, projectNames)
+}
+
+func addTodo(c *cli.Context) error {
+
+	if c.NArg() != 1 {
+		return fmt.Errorf("give title of todo item as one argument")
+	}
+
+	api, userId, err := session(c)
+
+	if err != nil {
+		return err
+	}
+
+	projectId := ""
+
+	if c.String("project") != "" {
+
+		projects, err := api.ListProjects(c.Context, userId)
+
+		if err != nil {
+			return err
+		}
+
+		project, err := findProject(projects, c.String("project"))
+
+		if err != nil {
+			return err
+		}
+
+		projectId = project.Id
+	}
+
+	title := c.Args().First()
+
+	undoToken, err := api.AddTodo(c.Context, userId, title, c.String("description"), projectId)
+
+	if err != nil {
+		return err
+	}
+
+	return printMutation(c.App.Writer, c.String("output"), "", undoToken, fmt.Sprintf("Added %q.", title))
+}
+
+func completeTodos(c *cli.Context) error {
+
+	return eachTodo(c, func(api *client.Client, userId string, todo *client.Todo) (string, string, error) {
+
+		undoToken, err := api.CompleteTodo(c.Context, userId, todo.Id)
+
+		return undoToken, fmt.Sprintf("Completed %q.", todo.Title), err
+	})
+}
+
+func deleteTodos(c *cli.Context) error {
+
+	return eachTodo(c, func(api *client.Client, userId string, todo *client.Todo) (string, string, error) {
+
+		undoToken, err := api.DeleteTodo(c.Context, userId, todo.Id)
+
+		return undoToken, fmt.Sprintf("Deleted %q.", todo.Title), err
+	})
+}
+
+func editTodo(c *cli.Context) error {
+
+	if c.NArg() != 1 {
+		return fmt.Errorf("give ID of todo item as one argument")
+	}
+
+	if !c.IsSet("title") && !c.IsSet("description") {
+		return fmt.Errorf("give new title or description")
+	}
+
+	api, userId, err := session(c)
+
+	if err != nil {
+		return err
+	}
+
+	todos, err := api.ListTodos(c.Context, userId)
+
+	if err != nil {
+		return err
+	}
+
+	todo, err := findTodo(todos, c.Args().First())
+
+	if err != nil {
+		return err
+	}
+
+	// API replaces both, so unchanged one is sent as is.
+	title := todo.Title
+
+	if c.IsSet("title") {
+		title = c.String("title")
+	}
+
+	description := todo.Description
+
+	if c.IsSet("description") {
+		description = c.String("description")
+	}
+
+	undoToken, err := api.UpdateTodo(c.Context, userId, todo.Id, title, description)
+
+	if err != nil {
+		return err
+	}
+
+	return printMutation(c.App.Writer, c.String("output"), todo.Id, undoToken, fmt.Sprintf("Updated %q.", title))
+}
+
+// Run mutation on each todo item given as argument, stopping at first error.
+func eachTodo(c *cli.Context, mutate func(api *client.Client, userId string, todo *client.Todo) (string, string, error)) error {
+
+	if c.NArg() == 0 {
+		return fmt.Errorf("give IDs of todo items as arguments")
+	}
+
+	api, userId, err := session(c)
+
+	if err != nil {
+		return err
+	}
+
+	todos, err := api.ListTodos(c.Context, userId)
+
+	if err != nil {
+		return err
+	}
+
+	// Resolve all first, so that typo in one ID changes nothing.
+	targets := make([]*client.Todo, c.NArg())
+
+	for i, id := range c.Args().Slice() {
+
+		todo, err := findTodo(todos, id)
+
+		if err != nil {
+			return err
+		}
+
+		targets[i] = todo
+	}
+
+	for _, todo := range targets {
+
+		undoToken, message, err := mutate(api, userId, todo)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", todo.Id, err)
+		}
+
+		if err := printMutation(c.App.Writer, c.String("output"), todo.Id, undoToken, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Todo item of ID, or of unique prefix of ID so that long IDs need not be typed.
+func findTodo(todos []*client.Todo, id string) (*client.Todo, error) {
+
+	var found *client.Todo
+
+	for _, todo := range todos {
+
+		if todo.Id == id {
+			return todo, nil
+		}
+
+		if !strings.HasPrefix(todo.Id, id) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("todo item %q is ambiguous", id)
+		}
+
+		found = todo
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("todo item %q not found", id)
+	}
+
+	return found, nil
+}
+
+// Project of ID, or of name ignoring case.
+func findProject(projects []*client.Project, idOrName string) (*client.Project, error) {
+
+	var found *client.Project
+
+	for _, project := range projects {
+
+		if project.Id == idOrName {
+			return project, nil
+		}
+
+		if !strings.EqualFold(project.Name, idOrName) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("project name %q is ambiguous: give its ID", idOrName)
+		}
+
+		found = project
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("project %q not found", idOrName)
+	}
+
+	return found, nil
+}
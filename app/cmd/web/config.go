@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/kkatou7209/godo/config"
+	"github.com/urfave/cli/v2"
+)
+
+// Flags overriding config file and environment variables when given.
+var flags = []cli.Flag{
+	&cli.StringFlag{
+		Name: "config",
+		Aliases: []string{"C"},
+		EnvVars: []string{"GODO_CONFIG"},
+		Usage: "Specify the config file (.yaml, .yml or .toml).",
+	},
+	&cli.StringSliceFlag{
+		Name: "set",
+		Usage: "Override config key, e.g. --set rate_limit.rate=10.",
+	},
+	&cli.StringFlag{
+		Name: "host",
+		Aliases: []string{"H"},
+		Usage: "Specify the host name.",
+	},
+	&cli.StringFlag{
+		Name: "port",
+		Aliases: []string{"p"},
+		Usage: "Specify the port number.",
+	},
+	&cli.StringFlag{
+		Name: "grpc-port",
+		Usage: "Specify the port number of gRPC API. gRPC is disabled when 0.",
+	},
+	&cli.StringFlag{
+		Name: "connection",
+		Aliases: []string{"c"},
+		Usage: "Specify the database connection string.",
+	},
+	&cli.StringFlag{
+		Name: "tls-cert",
+		Usage: "Specify the TLS certificate file. HTTPS is served when given with --tls-key.",
+	},
+	&cli.StringFlag{
+		Name: "tls-key",
+		Usage: "Specify the TLS private key file.",
+	},
+	&cli.StringFlag{
+		Name: "tls-min-version",
+		Usage: "Specify the oldest TLS version accepted (1.2 or 1.3).",
+	},
+	&cli.StringFlag{
+		Name: "tls-redirect-port",
+		Usage: "Specify the port of plain HTTP redirecting to HTTPS. Redirect is disabled when 0.",
+	},
+	&cli.StringFlag{
+		Name: "smtp",
+		Usage: "Specify the SMTP server address (host:port). Mails are logged when empty.",
+	},
+	&cli.StringFlag{
+		Name: "smtp-from",
+		Usage: "Specify the sender address of mails.",
+	},
+	&cli.StringFlag{
+		Name: "smtp-user",
+		Usage: "Specify the SMTP user name.",
+	},
+	&cli.StringFlag{
+		Name: "smtp-password",
+		Usage: "Specify the SMTP password.",
+	},
+	&cli.StringFlag{
+		Name: "storage",
+		Usage: "Specify where attached files are stored (local or s3).",
+	},
+	&cli.StringFlag{
+		Name: "storage-dir",
+		Usage: "Specify the directory of attached files for local storage.",
+	},
+	&cli.StringFlag{
+		Name: "s3-endpoint",
+		Usage: "Specify the S3 compatible endpoint URL, e.g. http://minio:9000.",
+	},
+	&cli.StringFlag{
+		Name: "s3-region",
+		Usage: "Specify the S3 region.",
+	},
+	&cli.StringFlag{
+		Name: "s3-bucket",
+		Usage: "Specify the S3 bucket.",
+	},
+	&cli.StringFlag{
+		Name: "s3-access-key",
+		Usage: "Specify the S3 access key.",
+	},
+	&cli.StringFlag{
+		Name: "s3-secret-key",
+		Usage: "Specify the S3 secret key.",
+	},
+	&cli.StringFlag{
+		Name: "events",
+		Usage: "Specify how todo events reach sessions (memory, or postgres when running several replicas).",
+	},
+	&cli.StringSliceFlag{
+		Name: "admin",
+		Usage: "Specify email of registered user granted admin role on startup.",
+	},
+	&cli.StringFlag{
+		Name: "log-level",
+		Usage: "Specify the log level (debug, info, warn or error).",
+	},
+	&cli.StringFlag{
+		Name: "log-format",
+		Usage: "Specify the log format (text or json).",
+	},
+}
+
+// Config keys of flags above.
+var flagKeys = [][2]string{
+	{"host", "server.host"},
+	{"port", "server.port"},
+	{"grpc-port", "server.grpc_port"},
+	{"connection", "database.url"},
+	{"tls-cert", "tls.cert_file"},
+	{"tls-key", "tls.key_file"},
+	{"tls-min-version", "tls.min_version"},
+	{"tls-redirect-port", "tls.redirect_port"},
+	{"smtp", "mailer.smtp_addr"},
+	{"smtp-from", "mailer.from"},
+	{"smtp-user", "mailer.user"},
+	{"smtp-password", "mailer.password"},
+	{"storage", "storage.kind"},
+	{"storage-dir", "storage.dir"},
+	{"s3-endpoint", "storage.s3.endpoint"},
+	{"s3-region", "storage.s3.region"},
+	{"s3-bucket", "storage.s3.bucket"},
+	{"s3-access-key", "storage.s3.access_key"},
+	{"s3-secret-key", "storage.s3.secret_key"},
+	{"events", "events"},
+	{"log-level", "log.level"},
+	{"log-format", "log.format"},
+}
+
+// Load config file and environment variables, then apply flags given.
+func loadConfig(c *cli.Context) (*config.Config, error) {
+
+	cfg, err := config.Load(c.String("config"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flagKey := range flagKeys {
+		if c.IsSet(flagKey[0]) {
+			if err := cfg.Set(flagKey[1], c.String(flagKey[0])); err != nil {
+				return nil, fmt.Errorf("--%s: %w", flagKey[0], err)
+			}
+		}
+	}
+
+	if c.IsSet("admin") {
+		cfg.Admins = c.StringSlice("admin")
+	}
+
+	for _, setting := range c.StringSlice("set") {
+
+		key, value, ok := strings.Cut(setting, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("--set %s: not key=value", setting)
+		}
+
+		if err := cfg.Set(key, value); err != nil {
+			return nil, fmt.Errorf("--set: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Print effective configuration with secrets redacted, failing when it cannot be served.
+func printConfig(c *cli.Context) error {
+
+	cfg, err := loadConfig(c)
+
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Redacted().Write(os.Stdout, c.String("format")); err != nil {
+		return err
+	}
+
+	return cfg.Validate()
+}
+
+func newLogger(cfg config.LogConfig) *slog.Logger {
+
+	level := new(slog.LevelVar)
+
+	// Validated already.
+	_ = level.UnmarshalText([]byte(cfg.Level))
+
+	options := &slog.HandlerOptions{Level: level}
+
+	if cfg.Format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, options))
+	}
+
+	return slog.New(slog.NewTextHandler(os.Stderr, options))
+}
@@ -1,16 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/kkatou7209/godo/app"
+	eventPort "github.com/kkatou7209/godo/app/port/out/event"
+	mailerPort "github.com/kkatou7209/godo/app/port/out/mailer"
+	storagePort "github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/mailer"
 	"github.com/kkatou7209/godo/password"
 	"github.com/kkatou7209/godo/persistence/postgres"
+	"github.com/kkatou7209/godo/rpc"
+	"github.com/kkatou7209/godo/storage"
+	"github.com/kkatou7209/godo/token"
+	"github.com/kkatou7209/godo/totp"
 	"github.com/kkatou7209/godo/web"
+	"github.com/kkatou7209/godo/web/handler"
+	"github.com/kkatou7209/godo/web/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -18,31 +36,51 @@ func main() {
 	cli := &cli.App{
 		Name: "godo",
 		Usage: "Serve GoDo API",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name: "host",
-				Aliases: []string{"H"},
-				Value: "localhost",
-				Usage: "Specify the host name.",
-			},
-			&cli.StringFlag{
-				Name: "port",
-				Aliases: []string{"p"},
-				Value: "8000",
-				Usage: "Specify the port number.",
-			},
-			&cli.StringFlag{
-				Name: "connection",
-				Aliases: []string{"c"},
-				Value: os.Getenv("GODO_DATABASE_URL"),
-				Usage: "Specify the database connection string.",
+		Flags: flags,
+		Commands: []*cli.Command{
+			{
+				Name: "config",
+				Usage: "Inspect configuration",
+				Subcommands: []*cli.Command{
+					{
+						Name: "print",
+						Usage: "Print effective configuration with secrets redacted, then report problems",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "format",
+								Aliases: []string{"f"},
+								Value: "yaml",
+								Usage: "Specify the output format (yaml or toml).",
+							},
+						},
+						Action: printConfig,
+					},
+				},
 			},
+			certCommand,
 		},
 		Action: func(c *cli.Context) error {
 
-			host := c.String("host")
-			port := c.String("port")
-			conn := c.String("connection")
+			cfg, err := loadConfig(c)
+
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+
+			logger := newLogger(cfg.Log)
+
+			slog.SetDefault(logger)
+
+			// Second signal kills process, as stop is called once shutdown starts.
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+			defer stop()
+
+			conn := cfg.Database.ConnectionString()
 
 			app := app.New()
 
@@ -50,27 +88,383 @@ func main() {
 
 			userRepository := postgres.NewUserRepository(conn)
 
+			sessionRepository := postgres.NewSessionRepository(conn)
+
+			passwordResetRepository := postgres.NewPasswordResetRepository(conn)
+
+			twoFactorRepository := postgres.NewTwoFactorRepository(conn)
+
+			loginChallengeRepository := postgres.NewLoginChallengeRepository(conn)
+
+			emailChangeRepository := postgres.NewEmailChangeRepository(conn)
+
+			accountDeletionRepository := postgres.NewAccountDeletionRepository(conn)
+
+			accessTokenRepository := postgres.NewAccessTokenRepository(conn)
+
+			auditLogRepository := postgres.NewAuditLogRepository(conn)
+
+			projectRepository := postgres.NewProjectRepository(conn)
+
+			todoAssignmentRepository := postgres.NewTodoAssignmentRepository(conn)
+
+			todoCommentRepository := postgres.NewTodoCommentRepository(conn)
+
+			todoAttachmentRepository := postgres.NewTodoAttachmentRepository(conn)
+
+			todoSearchRepository := postgres.NewTodoSearchRepository(conn)
+
+			todoUndoRepository := postgres.NewTodoUndoRepository(conn)
+
+			todoSyncRepository := postgres.NewTodoSyncRepository(conn)
+
+			calendarFeedRepository := postgres.NewCalendarFeedRepository(conn)
+
+			healthRepository := postgres.NewHealthRepository(conn)
+
+			var appMailer mailerPort.Mailer = mailer.NewLogMailer()
+
+			if addr := cfg.Mailer.SmtpAddr; addr != "" {
+				appMailer = mailer.NewSmtpMailer(addr, cfg.Mailer.From, cfg.Mailer.User, cfg.Mailer.Password)
+			}
+
+			var blobStorage storagePort.BlobStorage
+
+			switch cfg.Storage.Kind {
+			case "local":
+				blobStorage = storage.NewLocalBlobStorage(cfg.Storage.Dir)
+			case "s3":
+				s3, err := storage.NewS3BlobStorage(
+					cfg.Storage.S3.Endpoint,
+					cfg.Storage.S3.Region,
+					cfg.Storage.S3.Bucket,
+					cfg.Storage.S3.AccessKey,
+					cfg.Storage.S3.SecretKey,
+				)
+
+				if err != nil {
+					return err
+				}
+
+				blobStorage = s3
+			default:
+				return fmt.Errorf("unknown storage %q", cfg.Storage.Kind)
+			}
+
+			var todoEventBus eventPort.TodoEventBus
+
+			// End event streams on shutdown, as they would hold it until timeout.
+			var closeEvents func()
+
+			switch cfg.Events {
+			case "memory":
+				memoryEventBus := event.NewMemoryTodoEventBus()
+
+				todoEventBus = memoryEventBus
+				closeEvents = memoryEventBus.Close
+			case "postgres":
+				// Shares pool of repositories.
+				postgresEventBus := event.NewPostgresTodoEventBus(conn)
+
+				listenCtx, stopListening := context.WithCancel(context.Background())
+
+				// Keep listening to events of all replicas, reconnecting on failure.
+				go func() {
+					for {
+						err := postgresEventBus.Listen(listenCtx)
+
+						if listenCtx.Err() != nil {
+							return
+						}
+
+						logger.Error("stopped listening to todo events", "error", err)
+
+						select {
+						case <-listenCtx.Done():
+							return
+						case <-time.After(5 * time.Second):
+						}
+					}
+				}()
+
+				todoEventBus = postgresEventBus
+				closeEvents = func() {
+					stopListening()
+					postgresEventBus.Close()
+				}
+			default:
+				return fmt.Errorf("unknown events %q", cfg.Events)
+			}
+
 			app.
 				SetCreateTodoPersistence(todoRepository).
 				SetListTodoPersistence(todoRepository).
+				SetListTodoPagePersistence(todoRepository).
 				SetGetTodoPersistence(todoRepository).
 				SetUpdateTodoPersistence(todoRepository).
 				SetDeleteTodoPersistence(todoRepository).
+				SetGetTodoBatchPersistence(todoRepository).
+				SetWriteTodoBatchPersistence(todoRepository).
+				SetCreateTodoBatchPersistence(todoRepository).
 				SetCreateUserPersistence(userRepository).
 				SetGetUserPersistence(userRepository).
 				SetUpdateUserPersistence(userRepository).
-				SetPasswordHasher(password.NewBycryptPasswordHasher())
+				SetDeleteUserPersistence(userRepository).
+				SetListUserPersistence(userRepository).
+				SetCreateSessionPersistence(sessionRepository).
+				SetGetSessionPersistence(sessionRepository).
+				SetDeleteSessionPersistence(sessionRepository).
+				SetCreatePasswordResetPersistence(passwordResetRepository).
+				SetGetPasswordResetPersistence(passwordResetRepository).
+				SetUpdatePasswordResetPersistence(passwordResetRepository).
+				SetCreateTwoFactorPersistence(twoFactorRepository).
+				SetGetTwoFactorPersistence(twoFactorRepository).
+				SetUpdateTwoFactorPersistence(twoFactorRepository).
+				SetDeleteTwoFactorPersistence(twoFactorRepository).
+				SetCreateLoginChallengePersistence(loginChallengeRepository).
+				SetGetLoginChallengePersistence(loginChallengeRepository).
+				SetUpdateLoginChallengePersistence(loginChallengeRepository).
+				SetDeleteLoginChallengePersistence(loginChallengeRepository).
+				SetCreateEmailChangePersistence(emailChangeRepository).
+				SetGetEmailChangePersistence(emailChangeRepository).
+				SetUpdateEmailChangePersistence(emailChangeRepository).
+				SetCreateAccountDeletionPersistence(accountDeletionRepository).
+				SetGetAccountDeletionPersistence(accountDeletionRepository).
+				SetDeleteAccountDeletionPersistence(accountDeletionRepository).
+				SetCreateAccessTokenPersistence(accessTokenRepository).
+				SetGetAccessTokenPersistence(accessTokenRepository).
+				SetListAccessTokenPersistence(accessTokenRepository).
+				SetUpdateAccessTokenPersistence(accessTokenRepository).
+				SetDeleteAccessTokenPersistence(accessTokenRepository).
+				SetCreateAuditLogPersistence(auditLogRepository).
+				SetListAuditLogPersistence(auditLogRepository).
+				SetCreateProjectPersistence(projectRepository).
+				SetGetProjectPersistence(projectRepository).
+				SetListProjectPersistence(projectRepository).
+				SetUpdateProjectPersistence(projectRepository).
+				SetDeleteProjectPersistence(projectRepository).
+				SetGetProjectMemberPersistence(projectRepository).
+				SetSaveProjectMemberPersistence(projectRepository).
+				SetDeleteProjectMemberPersistence(projectRepository).
+				SetCreateProjectInvitePersistence(projectRepository).
+				SetGetProjectInvitePersistence(projectRepository).
+				SetUpdateProjectInvitePersistence(projectRepository).
+				SetCreateTodoAssignmentPersistence(todoAssignmentRepository).
+				SetListTodoAssignmentPersistence(todoAssignmentRepository).
+				SetCreateTodoCommentPersistence(todoCommentRepository).
+				SetGetTodoCommentPersistence(todoCommentRepository).
+				SetListTodoCommentPersistence(todoCommentRepository).
+				SetUpdateTodoCommentPersistence(todoCommentRepository).
+				SetDeleteTodoCommentPersistence(todoCommentRepository).
+				SetCreateTodoAttachmentPersistence(todoAttachmentRepository).
+				SetGetTodoAttachmentPersistence(todoAttachmentRepository).
+				SetListTodoAttachmentPersistence(todoAttachmentRepository).
+				SetDeleteTodoAttachmentPersistence(todoAttachmentRepository).
+				SetSearchTodoPersistence(todoSearchRepository).
+				SetCreateTodoUndoPersistence(todoUndoRepository).
+				SetGetTodoUndoPersistence(todoUndoRepository).
+				SetUpdateTodoUndoPersistence(todoUndoRepository).
+				SetListTodoChangePersistence(todoSyncRepository).
+				SetGetTodoRevisionPersistence(todoSyncRepository).
+				SetGetTodoSyncOperationPersistence(todoSyncRepository).
+				SetWriteTodoSyncPersistence(todoSyncRepository).
+				SetSaveCalendarFeedPersistence(calendarFeedRepository).
+				SetGetCalendarFeedPersistence(calendarFeedRepository).
+				SetDeleteCalendarFeedPersistence(calendarFeedRepository).
+				SetListTodoBatchPersistence(todoRepository).
+				SetListTodoCommentBatchPersistence(todoCommentRepository).
+				SetGetUserBatchPersistence(userRepository).
+				SetListProjectMemberBatchPersistence(projectRepository).
+				SetCheckHealthPersistence(healthRepository).
+				SetPasswordHasher(password.NewBycryptPasswordHasher()).
+				SetTokenGenerator(token.NewRandomTokenGenerator()).
+				SetMailer(appMailer).
+				SetTotpProvider(totp.NewRfc6238Provider("GoDo")).
+				SetBlobStorage(blobStorage).
+				SetTodoEventBus(todoEventBus)
+
+			for _, email := range cfg.Admins {
+				if err := app.GrantAdminUsecase().Grant(email); err != nil {
+					logger.Error("failed to grant admin role", "email", email, "error", err)
+				}
+			}
 
 			e := echo.New()
 			e.HideBanner = true
 
-			web.MapRoutes(e, app)
+			if cfg.Log.Requests {
+				e.Use(middleware.LogRequests(logger))
+			}
+
+			if len(cfg.Cors.AllowOrigins) > 0 {
+				e.Use(middleware.Cors(cfg.Cors.AllowOrigins, cfg.Cors.AllowCredentials, cfg.Cors.MaxAge))
+			}
+
+			if cfg.RateLimit.Rate > 0 {
+				e.Use(middleware.RateLimit(cfg.RateLimit.Rate, cfg.RateLimit.Burst))
+			}
+
+			readiness := &handler.Readiness{}
 
-			return e.Start(fmt.Sprintf("%s:%s", host, port))
+			web.MapRoutes(e, app, readiness)
+
+			// Hard delete accounts whose grace period has passed, until shutdown.
+			purging := make(chan struct{})
+
+			go func() {
+				defer close(purging)
+
+				ticker := time.NewTicker(time.Hour)
+				defer ticker.Stop()
+
+				// Purge once on startup, so restarts more often than hourly do not put it off.
+				for {
+					purged, err := app.PurgeDeletedAccountsUsecase().Purge()
+
+					if err != nil {
+						logger.Error("failed to purge deleted accounts", "error", err)
+					}
+
+					if purged > 0 {
+						logger.Info("purged deleted accounts", "count", purged)
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+
+			var grpcServer *grpc.Server
+
+			if cfg.Server.GrpcPort != 0 {
+
+				listener, err := net.Listen("tcp", net.JoinHostPort(cfg.Server.Host, fmt.Sprint(cfg.Server.GrpcPort)))
+
+				if err != nil {
+					return err
+				}
+
+				grpcServer = rpc.NewServer(app)
+
+				go func() {
+					if err := grpcServer.Serve(listener); err != nil {
+						logger.Error("gRPC server stopped", "error", err)
+					}
+				}()
+			}
+
+			addr := net.JoinHostPort(cfg.Server.Host, fmt.Sprint(cfg.Server.Port))
+
+			served := make(chan error, 2)
+
+			if cfg.Tls.CertFile != "" {
+
+				tlsConfig, err := newTlsConfig(cfg.Tls)
+
+				if err != nil {
+					return err
+				}
+
+				e.TLSServer.Addr = addr
+				e.TLSServer.TLSConfig = tlsConfig
+
+				go func() {
+					served <- e.StartServer(e.TLSServer)
+				}()
+			} else {
+				go func() {
+					served <- e.Start(addr)
+				}()
+			}
+
+			var redirectServer *http.Server
+
+			if cfg.Tls.RedirectPort != 0 {
+
+				redirectServer = &http.Server{
+					Addr: net.JoinHostPort(cfg.Server.Host, fmt.Sprint(cfg.Tls.RedirectPort)),
+					Handler: redirectToHttps(cfg.Server.Port),
+					ReadHeaderTimeout: 10 * time.Second,
+				}
+
+				go func() {
+					served <- redirectServer.ListenAndServe()
+				}()
+			}
+
+			select {
+			case err := <-served:
+				// Could not listen, e.g. port in use.
+				return err
+			case <-ctx.Done():
+				stop()
+			}
+
+			logger.Info("draining before shutting down", "delay", cfg.Server.DrainDelay)
+
+			readiness.Drain()
+
+			time.Sleep(cfg.Server.DrainDelay)
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+
+			defer cancel()
+
+			closeEvents()
+
+			grpcStopped := make(chan struct{})
+
+			go func() {
+				defer close(grpcStopped)
+				stopGrpc(shutdownCtx, grpcServer)
+			}()
+
+			if redirectServer != nil {
+				_ = redirectServer.Shutdown(shutdownCtx)
+			}
+
+			if err := e.Shutdown(shutdownCtx); err != nil {
+				logger.Error("requests in flight cut off", "error", err)
+			}
+
+			<-grpcStopped
+			<-purging
+
+			// Waits for connections still acquired to be released.
+			postgres.Close()
+
+			logger.Info("shut down")
+
+			return nil
 		},
 	}
 
 	if err := cli.Run(os.Args); err != nil {
-		log.Fatal(err)
+		slog.Error("failed to run", "error", err)
+		os.Exit(1)
+	}
+}
+
+// Stop gRPC server letting calls in flight finish, cutting them off when context is done.
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+
+	if server == nil {
+		return
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/cert"
+	"github.com/kkatou7209/godo/config"
+	"github.com/urfave/cli/v2"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLS of HTTPS server, offering HTTP/2 before HTTP/1.1.
+func newTlsConfig(cfg config.TlsConfig) (*tls.Config, error) {
+
+	certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion: tlsVersions[cfg.MinVersion],
+		NextProtos: []string{"h2", "http/1.1"},
+	}, nil
+}
+
+// Redirect plain HTTP requests to same URL over HTTPS on port, keeping method and body.
+func redirectToHttps(port int) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		host := r.Host
+
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		host = strings.Trim(host, "[]")
+
+		if host == "" {
+			http.Error(w, "host is required", http.StatusBadRequest)
+			return
+		}
+
+		if port != 443 {
+			host = net.JoinHostPort(host, fmt.Sprint(port))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		target := &url.URL{
+			Scheme: "https",
+			Host: host,
+			Path: r.URL.Path,
+			RawPath: r.URL.RawPath,
+			RawQuery: r.URL.RawQuery,
+		}
+
+		http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+	})
+}
+
+// Generate self-signed certificate and token key pair for development.
+var certCommand = &cli.Command{
+	Name: "cert",
+	Usage: "Generate self-signed TLS certificate and token key pair for development",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name: "dir",
+			Aliases: []string{"d"},
+			Value: "cert",
+			Usage: "Specify the directory of tls.crt, tls.key, private.pem and public.pem.",
+		},
+		&cli.StringSliceFlag{
+			Name: "host",
+			Value: cli.NewStringSlice("localhost", "127.0.0.1", "::1"),
+			Usage: "Specify host name or IP address certificate is valid for.",
+		},
+		&cli.DurationFlag{
+			Name: "valid-for",
+			Value: 365 * 24 * time.Hour,
+			Usage: "Specify how long certificate is valid.",
+		},
+		&cli.BoolFlag{
+			Name: "force",
+			Aliases: []string{"f"},
+			Usage: "Overwrite existing files.",
+		},
+	},
+	Action: func(c *cli.Context) error {
+
+		certPem, keyPem, err := cert.SelfSigned(c.StringSlice("host"), c.Duration("valid-for"))
+
+		if err != nil {
+			return fmt.Errorf("fail to generate certificate: %w", err)
+		}
+
+		privatePem, publicPem, err := cert.KeyPair(cert.KeyBits)
+
+		if err != nil {
+			return fmt.Errorf("fail to generate key: %w", err)
+		}
+
+		dir := c.String("dir")
+
+		err = cert.WriteFiles([]cert.File{
+			{Path: filepath.Join(dir, "tls.crt"), Content: certPem, Perm: cert.PublicPerm},
+			{Path: filepath.Join(dir, "tls.key"), Content: keyPem, Perm: cert.PrivatePerm},
+			{Path: filepath.Join(dir, "private.pem"), Content: privatePem, Perm: cert.PrivatePerm},
+			{Path: filepath.Join(dir, "public.pem"), Content: publicPem, Perm: cert.PublicPerm},
+		}, c.Bool("force"))
+
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%w: use --force to overwrite", err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			c.App.Writer,
+			"Serve HTTPS with --tls-cert %s --tls-key %s\n",
+			filepath.Join(dir, "tls.crt"),
+			filepath.Join(dir, "tls.key"),
+		)
+
+		return nil
+	},
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/cert"
+	"github.com/kkatou7209/godo/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWeb(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Web command test.")
+}
+
+var _ = Describe("TLS", func() {
+
+	DescribeTable("redirects to same URL over HTTPS",
+		func(port int, host string, target string, location string) {
+
+			req := httptest.NewRequest(http.MethodPost, target, nil)
+			req.Host = host
+
+			rec := httptest.NewRecorder()
+
+			redirectToHttps(port).ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusPermanentRedirect))
+			Expect(rec.Header().Get("Location")).To(Equal(location))
+		},
+		Entry("default port", 443, "godo.test:8000", "/todos", "https://godo.test/todos"),
+		Entry("other port", 8443, "godo.test:8000", "/todos", "https://godo.test:8443/todos"),
+		Entry("host without port", 8443, "godo.test", "/", "https://godo.test:8443/"),
+		Entry("IPv6 on default port", 443, "[::1]:8000", "/todos", "https://[::1]/todos"),
+		Entry("IPv6 on other port", 8443, "[::1]:8000", "/todos", "https://[::1]:8443/todos"),
+		Entry("IPv6 without port", 8443, "[::1]", "/todos", "https://[::1]:8443/todos"),
+		Entry("query and escaped path", 443, "godo.test", "/todos/a%2Fb?done=true&q=a+b", "https://godo.test/todos/a%2Fb?done=true&q=a+b"),
+	)
+
+	It("refuses to redirect without host", func() {
+
+		req := httptest.NewRequest(http.MethodGet, "/todos", nil)
+		req.Host = ""
+
+		rec := httptest.NewRecorder()
+
+		redirectToHttps(443).ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("offers HTTP/2 with minimum version", func() {
+
+		dir := GinkgoT().TempDir()
+
+		certPem, keyPem, err := cert.SelfSigned([]string{"localhost"}, time.Hour)
+
+		Expect(err).To(BeNil())
+
+		cfg := config.TlsConfig{
+			CertFile: filepath.Join(dir, "tls.crt"),
+			KeyFile: filepath.Join(dir, "tls.key"),
+			MinVersion: "1.3",
+		}
+
+		Expect(os.WriteFile(cfg.CertFile, certPem, cert.PublicPerm)).To(Succeed())
+		Expect(os.WriteFile(cfg.KeyFile, keyPem, cert.PrivatePerm)).To(Succeed())
+
+		tlsConfig, err := newTlsConfig(cfg)
+
+		Expect(err).To(BeNil())
+		Expect(tlsConfig.Certificates).To(HaveLen(1))
+		Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		Expect(tlsConfig.NextProtos).To(Equal([]string{"h2", "http/1.1"}))
+
+		cfg.KeyFile = filepath.Join(dir, "missing.key")
+
+		_, err = newTlsConfig(cfg)
+
+		Expect(err).To(MatchError(ContainSubstring("tls:")))
+	})
+})
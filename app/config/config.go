@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Configuration of server, layered from defaults, config file, environment variables and flags, latter winning.
+//
+// Keys in config file are those of yaml and toml tags, environment variables those of env tags.
+// Values tagged secret are redacted when printed.
+type Config struct {
+	Server ServerConfig `yaml:"server" toml:"server"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Tls TlsConfig `yaml:"tls" toml:"tls"`
+	Jwt JwtConfig `yaml:"jwt" toml:"jwt"`
+	Cors CorsConfig `yaml:"cors" toml:"cors"`
+	RateLimit RateLimitConfig `yaml:"rate_limit" toml:"rate_limit"`
+	Mailer MailerConfig `yaml:"mailer" toml:"mailer"`
+	Storage StorageConfig `yaml:"storage" toml:"storage"`
+	// How todo events reach sessions: memory, or postgres when running several replicas.
+	Events string `yaml:"events" toml:"events" env:"GODO_EVENTS"`
+	// Emails of registered users granted admin role on startup.
+	Admins []string `yaml:"admins" toml:"admins" env:"GODO_ADMIN_EMAIL"`
+	Log LogConfig `yaml:"log" toml:"log"`
+}
+
+type ServerConfig struct {
+	Host string `yaml:"host" toml:"host" env:"GODO_HOST"`
+	Port int `yaml:"port" toml:"port" env:"GODO_PORT"`
+	// Port of gRPC API. 0 disables gRPC.
+	GrpcPort int `yaml:"grpc_port" toml:"grpc_port" env:"GODO_GRPC_PORT"`
+	// How long readiness fails before server stops taking connections, so load balancers notice.
+	DrainDelay time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"GODO_DRAIN_DELAY"`
+	// How long requests in flight may take to finish on shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"GODO_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	// URL or key=value connection string of Postgres.
+	Url string `yaml:"url" toml:"url" env:"GODO_DATABASE_URL" secret:"true"`
+	// Zero leaves pool settings below to pgx defaults.
+	MaxConns int `yaml:"max_conns" toml:"max_conns" env:"GODO_DATABASE_MAX_CONNS"`
+	MinConns int `yaml:"min_conns" toml:"min_conns" env:"GODO_DATABASE_MIN_CONNS"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime" toml:"max_conn_lifetime" env:"GODO_DATABASE_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" toml:"max_conn_idle_time" env:"GODO_DATABASE_MAX_CONN_IDLE_TIME"`
+}
+
+// Serve HTTPS, with HTTP/2, when both files are given. "godo cert" makes them for development.
+type TlsConfig struct {
+	CertFile string `yaml:"cert_file" toml:"cert_file" env:"GODO_TLS_CERT_FILE"`
+	KeyFile string `yaml:"key_file" toml:"key_file" env:"GODO_TLS_KEY_FILE"`
+	// Oldest TLS version accepted: 1.2 or 1.3.
+	MinVersion string `yaml:"min_version" toml:"min_version" env:"GODO_TLS_MIN_VERSION"`
+	// Port of plain HTTP redirecting to HTTPS. 0 disables redirect.
+	RedirectPort int `yaml:"redirect_port" toml:"redirect_port" env:"GODO_TLS_REDIRECT_PORT"`
+}
+
+// RSA key pair made by cmd/cert for signing tokens.
+type JwtConfig struct {
+	PrivateKeyFile string `yaml:"private_key_file" toml:"private_key_file" env:"GODO_JWT_PRIVATE_KEY_FILE"`
+	PublicKeyFile string `yaml:"public_key_file" toml:"public_key_file" env:"GODO_JWT_PUBLIC_KEY_FILE"`
+}
+
+// Cross-origin requests are refused unless origins are allowed.
+type CorsConfig struct {
+	// Origins like "https://godo.example.com", or "*" for any.
+	AllowOrigins []string `yaml:"allow_origins" toml:"allow_origins" env:"GODO_CORS_ALLOW_ORIGINS"`
+	// Let browsers send session cookie. Cannot be used with "*".
+	AllowCredentials bool `yaml:"allow_credentials" toml:"allow_credentials" env:"GODO_CORS_ALLOW_CREDENTIALS"`
+	// How long browsers may cache preflight responses.
+	MaxAge time.Duration `yaml:"max_age" toml:"max_age" env:"GODO_CORS_MAX_AGE"`
+}
+
+// Requests are limited per client IP. Zero rate disables limiting.
+type RateLimitConfig struct {
+	// Requests per second.
+	Rate float64 `yaml:"rate" toml:"rate" env:"GODO_RATE_LIMIT"`
+	// Requests allowed at once above rate.
+	Burst int `yaml:"burst" toml:"burst" env:"GODO_RATE_LIMIT_BURST"`
+}
+
+// Mails are logged when SMTP address is empty.
+type MailerConfig struct {
+	SmtpAddr string `yaml:"smtp_addr" toml:"smtp_addr" env:"GODO_SMTP_ADDR"`
+	From string `yaml:"from" toml:"from" env:"GODO_SMTP_FROM"`
+	User string `yaml:"user" toml:"user" env:"GODO_SMTP_USER"`
+	Password string `yaml:"password" toml:"password" env:"GODO_SMTP_PASSWORD" secret:"true"`
+}
+
+type StorageConfig struct {
+	// Where attached files are stored: local or s3.
+	Kind string `yaml:"kind" toml:"kind" env:"GODO_STORAGE"`
+	// Directory of attached files for local storage.
+	Dir string `yaml:"dir" toml:"dir" env:"GODO_STORAGE_DIR"`
+	S3 S3Config `yaml:"s3" toml:"s3"`
+}
+
+type S3Config struct {
+	// S3 compatible endpoint URL, e.g. http://minio:9000.
+	Endpoint string `yaml:"endpoint" toml:"endpoint" env:"GODO_S3_ENDPOINT"`
+	Region string `yaml:"region" toml:"region" env:"GODO_S3_REGION"`
+	Bucket string `yaml:"bucket" toml:"bucket" env:"GODO_S3_BUCKET"`
+	AccessKey string `yaml:"access_key" toml:"access_key" env:"GODO_S3_ACCESS_KEY" secret:"true"`
+	SecretKey string `yaml:"secret_key" toml:"secret_key" env:"GODO_S3_SECRET_KEY" secret:"true"`
+}
+
+type LogConfig struct {
+	// debug, info, warn or error.
+	Level string `yaml:"level" toml:"level" env:"GODO_LOG_LEVEL"`
+	// text or json.
+	Format string `yaml:"format" toml:"format" env:"GODO_LOG_FORMAT"`
+	// Log every HTTP request.
+	Requests bool `yaml:"requests" toml:"requests" env:"GODO_LOG_REQUESTS"`
+}
+
+// Configuration used when nothing overrides it.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Host: "localhost",
+			Port: 8000,
+			GrpcPort: 9000,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Tls: TlsConfig{
+			MinVersion: "1.2",
+		},
+		RateLimit: RateLimitConfig{
+			Burst: 20,
+		},
+		Storage: StorageConfig{
+			Kind: "local",
+			Dir: "data/blobs",
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+		},
+		Events: "memory",
+		Log: LogConfig{
+			Level: "info",
+			Format: "text",
+		},
+	}
+}
+
+// Connection string of repositories, carrying pool settings as pgxpool reads them.
+// Plain connections like listening to events need Url instead, as pgx sends unknown settings to server.
+func (d *DatabaseConfig) ConnectionString() string {
+
+	settings := [][2]string{}
+
+	if d.MaxConns > 0 {
+		settings = append(settings, [2]string{"pool_max_conns", fmt.Sprint(d.MaxConns)})
+	}
+
+	if d.MinConns > 0 {
+		settings = append(settings, [2]string{"pool_min_conns", fmt.Sprint(d.MinConns)})
+	}
+
+	if d.MaxConnLifetime > 0 {
+		settings = append(settings, [2]string{"pool_max_conn_lifetime", d.MaxConnLifetime.String()})
+	}
+
+	if d.MaxConnIdleTime > 0 {
+		settings = append(settings, [2]string{"pool_max_conn_idle_time", d.MaxConnIdleTime.String()})
+	}
+
+	if len(settings) == 0 {
+		return d.Url
+	}
+
+	if isDatabaseUrl(d.Url) {
+
+		u, err := url.Parse(d.Url)
+
+		// Left to pgx to report.
+		if err != nil {
+			return d.Url
+		}
+
+		query := u.Query()
+
+		for _, setting := range settings {
+			query.Set(setting[0], setting[1])
+		}
+
+		u.RawQuery = query.Encode()
+
+		return u.String()
+	}
+
+	connectionString := d.Url
+
+	for _, setting := range settings {
+		connectionString += " " + setting[0] + "=" + setting[1]
+	}
+
+	return strings.TrimSpace(connectionString)
+}
+
+func isDatabaseUrl(connectionString string) bool {
+	return strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://")
+}
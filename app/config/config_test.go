@@ -0,0 +1,223 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config test.")
+}
+
+// Write config file of name into temporary directory.
+func writeFile(name string, content string) string {
+
+	path := filepath.Join(GinkgoT().TempDir(), name)
+
+	Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+
+	return path
+}
+
+// Problems of validation error.
+func problemsOf(err error) []string {
+
+	validationErr, ok := err.(*config.ValidationError)
+
+	Expect(ok).To(BeTrue(), "not validation error: %v", err)
+
+	return validationErr.Problems
+}
+
+var _ = Describe("Config", func() {
+
+	BeforeEach(func() {
+		// Keep environment of test runner out of the way.
+		for _, key := range []string{"GODO_PORT", "GODO_DATABASE_URL", "GODO_CORS_ALLOW_ORIGINS", "GODO_RATE_LIMIT"} {
+			GinkgoT().Setenv(key, "")
+		}
+	})
+
+	It("layers defaults, file, environment variables and flags", func() {
+
+		path := writeFile("godo.yaml", `
+server:
+  port: 8080
+  grpc_port: 9090
+database:
+  url: postgres://godo@db/godo
+  max_conn_lifetime: 1h
+cors:
+  allow_origins: [https://a.example.com]
+`)
+
+		GinkgoT().Setenv("GODO_PORT", "8081")
+		GinkgoT().Setenv("GODO_CORS_ALLOW_ORIGINS", "https://b.example.com, https://c.example.com")
+
+		cfg, err := config.Load(path)
+
+		Expect(err).To(BeNil())
+
+		Expect(cfg.Set("server.grpc_port", "9091")).To(Succeed())
+
+		Expect(cfg.Server.Host).To(Equal("localhost"))
+		Expect(cfg.Server.Port).To(Equal(8081))
+		Expect(cfg.Server.GrpcPort).To(Equal(9091))
+		Expect(cfg.Database.Url).To(Equal("postgres://godo@db/godo"))
+		Expect(cfg.Database.MaxConnLifetime).To(Equal(time.Hour))
+		Expect(cfg.Cors.AllowOrigins).To(Equal([]string{"https://b.example.com", "https://c.example.com"}))
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("loads toml file", func() {
+
+		path := writeFile("godo.toml", `
+events = "postgres"
+
+[database]
+url = "host=db user=godo"
+max_conn_idle_time = "5m"
+
+[rate_limit]
+rate = 2.5
+`)
+
+		cfg, err := config.Load(path)
+
+		Expect(err).To(BeNil())
+
+		Expect(cfg.Events).To(Equal("postgres"))
+		Expect(cfg.Database.MaxConnIdleTime).To(Equal(5 * time.Minute))
+		Expect(cfg.RateLimit.Rate).To(Equal(2.5))
+		Expect(cfg.RateLimit.Burst).To(Equal(20))
+	})
+
+	It("refuses unknown keys and formats", func() {
+
+		_, err := config.Load(writeFile("godo.yaml", "server:\n  prot: 8080\n"))
+
+		Expect(err).To(MatchError(ContainSubstring("prot")))
+
+		_, err = config.Load(writeFile("godo.toml", "[server]\nprot = 8080\n"))
+
+		Expect(err).To(MatchError(ContainSubstring("server.prot")))
+
+		_, err = config.Load(writeFile("godo.json", "{}"))
+
+		Expect(err).To(MatchError(ContainSubstring("unknown format")))
+
+		Expect(config.Default().Set("server.prot", "8080")).To(MatchError(ContainSubstring("unknown config key")))
+	})
+
+	It("names environment variables of bad values", func() {
+
+		GinkgoT().Setenv("GODO_PORT", "eighty")
+		GinkgoT().Setenv("GODO_RATE_LIMIT", "fast")
+
+		_, err := config.Load("")
+
+		Expect(problemsOf(err)).To(ConsistOf(
+			`GODO_PORT: "eighty" is not an integer`,
+			`GODO_RATE_LIMIT: "fast" is not a number`,
+		))
+	})
+
+	It("reports all problems at once", func() {
+
+		cfg := config.Default()
+
+		cfg.Server.GrpcPort = cfg.Server.Port
+		cfg.Server.ShutdownTimeout = 0
+		cfg.Database.MinConns = 5
+		cfg.Database.MaxConns = 2
+		cfg.Tls.CertFile = "cert.pem"
+		cfg.Tls.MinVersion = "1.0"
+		cfg.Cors.AllowOrigins = []string{"*", "godo.example.com"}
+		cfg.Cors.AllowCredentials = true
+		cfg.Mailer.SmtpAddr = "smtp"
+		cfg.Storage.Kind = "s3"
+		cfg.Log.Format = "xml"
+
+		Expect(problemsOf(cfg.Validate())).To(ConsistOf(
+			"server.grpc_port: must differ from server.port",
+			"server.shutdown_timeout: must be positive",
+			"database.url: is required (set GODO_DATABASE_URL or --connection)",
+			"database.min_conns: must not exceed database.max_conns (2)",
+			"tls.key_file: is required when tls.cert_file is set",
+			ContainSubstring("tls.cert_file: cannot read cert.pem"),
+			`tls.min_version: "1.0" is not one of 1.2, 1.3`,
+			`cors.allow_origins: cannot be "*" with cors.allow_credentials, as browsers refuse it`,
+			`cors.allow_origins: "godo.example.com" is not an origin like https://godo.example.com`,
+			`mailer.smtp_addr: "smtp" is not host:port`,
+			"mailer.from: is required when mailer.smtp_addr is set",
+			"storage.s3.bucket: is required for s3 storage",
+			`log.format: "xml" is not one of text, json`,
+		))
+	})
+
+	It("redirects to HTTPS only when serving it", func() {
+
+		cfg := config.Default()
+
+		cfg.Database.Url = "postgres://godo@db/godo"
+		cfg.Tls.RedirectPort = 8000
+
+		Expect(problemsOf(cfg.Validate())).To(ConsistOf(
+			"tls.redirect_port: requires tls.cert_file, as there is no HTTPS to redirect to",
+			"tls.redirect_port: must differ from server.port and server.grpc_port",
+		))
+	})
+
+	It("redacts secrets when printed", func() {
+
+		cfg := config.Default()
+
+		cfg.Database.Url = "postgres://godo:hunter2@db/godo?sslmode=disable"
+		cfg.Mailer.Password = "mailpass"
+		cfg.Storage.S3.SecretKey = "s3secret"
+
+		out := &bytes.Buffer{}
+
+		Expect(cfg.Redacted().Write(out, "yaml")).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("postgres://godo:REDACTED@db/godo?sslmode=disable"))
+		Expect(out.String()).To(ContainSubstring("password: REDACTED"))
+		Expect(out.String()).To(ContainSubstring("secret_key: REDACTED"))
+		Expect(out.String()).To(ContainSubstring(`access_key: ""`))
+		Expect(out.String()).NotTo(ContainSubstring("hunter2"))
+
+		Expect(cfg.Mailer.Password).To(Equal("mailpass"))
+
+		cfg.Database.Url = "host=db user=godo password='hunter 2' sslmode=disable"
+
+		Expect(cfg.Redacted().Database.Url).To(Equal("host=db user=godo password=REDACTED sslmode=disable"))
+	})
+
+	It("carries pool settings in connection string", func() {
+
+		database := config.DatabaseConfig{
+			Url: "postgres://godo@db/godo?sslmode=disable",
+			MaxConns: 10,
+			MaxConnIdleTime: time.Minute,
+		}
+
+		Expect(database.ConnectionString()).To(Equal("postgres://godo@db/godo?pool_max_conn_idle_time=1m0s&pool_max_conns=10&sslmode=disable"))
+
+		database.Url = "host=db user=godo"
+
+		Expect(database.ConnectionString()).To(Equal("host=db user=godo pool_max_conns=10 pool_max_conn_idle_time=1m0s"))
+
+		database.MaxConns = 0
+		database.MaxConnIdleTime = 0
+
+		Expect(database.ConnectionString()).To(Equal("host=db user=godo"))
+	})
+})
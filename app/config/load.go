@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// Load defaults overridden by config file, then by environment variables.
+// Path may be empty for no config file. Format of file is told by extension: .yaml, .yml or .toml.
+// Empty environment variables are ignored.
+func Load(path string) (*Config, error) {
+
+	config := Default()
+
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.loadEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *Config) loadFile(path string) error {
+
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+
+	case ".yaml", ".yml":
+
+		decoder := yaml.NewDecoder(bytes.NewReader(b))
+
+		// Typos should not silently leave defaults.
+		decoder.KnownFields(true)
+
+		// Empty file decodes to nothing, leaving defaults.
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+
+	case ".toml":
+
+		meta, err := toml.Decode(string(b), c)
+
+		if err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+
+			keys := make([]string, len(undecoded))
+
+			for i, key := range undecoded {
+				keys[i] = key.String()
+			}
+
+			return fmt.Errorf("config file %s: unknown keys: %s", path, strings.Join(keys, ", "))
+		}
+
+	default:
+		return fmt.Errorf("config file %s: unknown format %q: use .yaml, .yml or .toml", path, filepath.Ext(path))
+	}
+
+	return nil
+}
+
+// Override fields by environment variables of their env tags.
+func (c *Config) loadEnv(lookup func(key string) (string, bool)) error {
+
+	problems := []string{}
+
+	eachField(reflect.ValueOf(c).Elem(), "", func(field reflect.Value, tag reflect.StructField, key string) {
+
+		name := tag.Tag.Get("env")
+
+		if name == "" {
+			return
+		}
+
+		value, ok := lookup(name)
+
+		if !ok || value == "" {
+			return
+		}
+
+		if err := parseInto(field, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	})
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+// Call fn on every field of struct value that is not a struct itself, with key of field in config file.
+func eachField(v reflect.Value, prefix string, fn func(field reflect.Value, tag reflect.StructField, key string)) {
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+
+		tag := t.Field(i)
+
+		key := prefix + tag.Tag.Get("yaml")
+
+		if tag.Type.Kind() == reflect.Struct {
+			eachField(v.Field(i), key + ".", fn)
+			continue
+		}
+
+		fn(v.Field(i), tag, key)
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Set field from text of environment variable or flag. Lists are separated by commas.
+func parseInto(field reflect.Value, value string) error {
+
+	if field.Type() == durationType {
+
+		d, err := time.ParseDuration(value)
+
+		if err != nil {
+			return fmt.Errorf("%q is not a duration like 30s or 5m", value)
+		}
+
+		field.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch field.Kind() {
+
+	case reflect.String:
+		field.SetString(value)
+
+	case reflect.Int:
+
+		n, err := strconv.Atoi(value)
+
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+
+		field.SetInt(int64(n))
+
+	case reflect.Float64:
+
+		f, err := strconv.ParseFloat(value, 64)
+
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+
+		field.SetFloat(f)
+
+	case reflect.Bool:
+
+		b, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+
+		field.SetBool(b)
+
+	case reflect.Slice:
+
+		items := []string{}
+
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		field.Set(reflect.ValueOf(items))
+
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+// Override value at key of config file, e.g. "server.port", by text of flag.
+func (c *Config) Set(key string, value string) error {
+
+	found := false
+
+	var err error
+
+	eachField(reflect.ValueOf(c).Elem(), "", func(field reflect.Value, tag reflect.StructField, k string) {
+
+		if k != key {
+			return
+		}
+
+		found = true
+
+		err = parseInto(field, value)
+	})
+
+	if !found {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+
+	if err != nil {
+		return &ValidationError{Problems: []string{fmt.Sprintf("%s: %v", key, err)}}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"regexp"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// Shown in place of secrets.
+const Redacted = "REDACTED"
+
+// Password of key=value connection string.
+var passwordSetting = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Copy of configuration with values tagged secret redacted.
+// Connection strings keep all but password, as rest helps telling where server connects.
+func (c *Config) Redacted() *Config {
+
+	redacted := *c
+
+	redacted.Admins = append([]string(nil), c.Admins...)
+	redacted.Cors.AllowOrigins = append([]string(nil), c.Cors.AllowOrigins...)
+
+	eachField(reflect.ValueOf(&redacted).Elem(), "", func(field reflect.Value, tag reflect.StructField, key string) {
+
+		if tag.Tag.Get("secret") != "true" || field.String() == "" {
+			return
+		}
+
+		if key == "database.url" {
+			field.SetString(redactConnectionString(field.String()))
+			return
+		}
+
+		field.SetString(Redacted)
+	})
+
+	return &redacted
+}
+
+func redactConnectionString(connectionString string) string {
+
+	if !isDatabaseUrl(connectionString) {
+		return passwordSetting.ReplaceAllString(connectionString, "${1}" + Redacted)
+	}
+
+	u, err := url.Parse(connectionString)
+
+	if err != nil {
+		return Redacted
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), Redacted)
+	}
+
+	query := u.Query()
+
+	if query.Has("password") {
+		query.Set("password", Redacted)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// Write configuration in format yaml or toml, as config file would have it.
+func (c *Config) Write(w io.Writer, format string) error {
+
+	switch format {
+
+	case "yaml":
+
+		encoder := yaml.NewEncoder(w)
+
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(c); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+
+	case "toml":
+		return toml.NewEncoder(w).Encode(c)
+	}
+
+	return fmt.Errorf("unknown format %q: use yaml or toml", format)
+}
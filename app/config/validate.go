@@ -0,0 +1,202 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Problems of configuration, each naming its key.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Check configuration is usable by server, reporting all problems at once.
+func (c *Config) Validate() error {
+
+	v := &validator{}
+
+	v.port("server.port", c.Server.Port, false)
+	v.port("server.grpc_port", c.Server.GrpcPort, true)
+
+	if c.Server.GrpcPort != 0 && c.Server.GrpcPort == c.Server.Port {
+		v.add("server.grpc_port", "must differ from server.port")
+	}
+
+	v.notNegative("server.drain_delay", int(c.Server.DrainDelay))
+
+	if c.Server.ShutdownTimeout <= 0 {
+		v.add("server.shutdown_timeout", "must be positive")
+	}
+
+	if strings.TrimSpace(c.Database.Url) == "" {
+		v.add("database.url", "is required (set GODO_DATABASE_URL or --connection)")
+	}
+
+	v.notNegative("database.max_conns", c.Database.MaxConns)
+	v.notNegative("database.min_conns", c.Database.MinConns)
+
+	if c.Database.MaxConns > 0 && c.Database.MinConns > c.Database.MaxConns {
+		v.add("database.min_conns", fmt.Sprintf("must not exceed database.max_conns (%d)", c.Database.MaxConns))
+	}
+
+	v.notNegative("database.max_conn_lifetime", int(c.Database.MaxConnLifetime))
+	v.notNegative("database.max_conn_idle_time", int(c.Database.MaxConnIdleTime))
+
+	v.filePair("tls.cert_file", c.Tls.CertFile, "tls.key_file", c.Tls.KeyFile)
+	v.oneOf("tls.min_version", c.Tls.MinVersion, "1.2", "1.3")
+	v.port("tls.redirect_port", c.Tls.RedirectPort, true)
+
+	if c.Tls.RedirectPort != 0 {
+
+		if c.Tls.CertFile == "" {
+			v.add("tls.redirect_port", "requires tls.cert_file, as there is no HTTPS to redirect to")
+		}
+
+		if c.Tls.RedirectPort == c.Server.Port || c.Tls.RedirectPort == c.Server.GrpcPort {
+			v.add("tls.redirect_port", "must differ from server.port and server.grpc_port")
+		}
+	}
+	v.filePair("jwt.private_key_file", c.Jwt.PrivateKeyFile, "jwt.public_key_file", c.Jwt.PublicKeyFile)
+
+	for _, origin := range c.Cors.AllowOrigins {
+
+		if origin == "*" {
+
+			if c.Cors.AllowCredentials {
+				v.add("cors.allow_origins", "cannot be \"*\" with cors.allow_credentials, as browsers refuse it")
+			}
+
+			continue
+		}
+
+		u, err := url.Parse(origin)
+
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			v.add("cors.allow_origins", fmt.Sprintf("%q is not an origin like https://godo.example.com", origin))
+		}
+	}
+
+	v.notNegative("cors.max_age", int(c.Cors.MaxAge))
+
+	if c.RateLimit.Rate < 0 {
+		v.add("rate_limit.rate", "must not be negative")
+	}
+
+	if c.RateLimit.Rate > 0 && c.RateLimit.Burst < 1 {
+		v.add("rate_limit.burst", "must be at least 1 when rate_limit.rate is set")
+	}
+
+	if c.Mailer.SmtpAddr != "" {
+
+		if _, _, err := net.SplitHostPort(c.Mailer.SmtpAddr); err != nil {
+			v.add("mailer.smtp_addr", fmt.Sprintf("%q is not host:port", c.Mailer.SmtpAddr))
+		}
+
+		if c.Mailer.From == "" {
+			v.add("mailer.from", "is required when mailer.smtp_addr is set")
+		}
+	}
+
+	switch c.Storage.Kind {
+	case "local":
+		if c.Storage.Dir == "" {
+			v.add("storage.dir", "is required for local storage")
+		}
+	case "s3":
+		if c.Storage.S3.Bucket == "" {
+			v.add("storage.s3.bucket", "is required for s3 storage")
+		}
+	default:
+		v.oneOf("storage.kind", c.Storage.Kind, "local", "s3")
+	}
+
+	v.oneOf("events", c.Events, "memory", "postgres")
+
+	for _, email := range c.Admins {
+		if !strings.Contains(email, "@") {
+			v.add("admins", fmt.Sprintf("%q is not an email", email))
+		}
+	}
+
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	v.oneOf("log.format", c.Log.Format, "text", "json")
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+
+	return nil
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(key string, problem string) {
+	v.problems = append(v.problems, key + ": " + problem)
+}
+
+func (v *validator) port(key string, port int, optional bool) {
+
+	if optional && port == 0 {
+		return
+	}
+
+	if port < 1 || port > 65535 {
+		v.add(key, fmt.Sprintf("%d is not a port between 1 and 65535", port))
+	}
+}
+
+func (v *validator) notNegative(key string, n int) {
+	if n < 0 {
+		v.add(key, "must not be negative")
+	}
+}
+
+func (v *validator) oneOf(key string, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.add(key, fmt.Sprintf("%q is not one of %s", value, strings.Join(allowed, ", ")))
+	}
+}
+
+// Files given together or not at all, and readable.
+func (v *validator) filePair(key string, path string, pairKey string, pairPath string) {
+
+	if (path == "") != (pairPath == "") {
+
+		if path == "" {
+			v.add(key, "is required when " + pairKey + " is set")
+		} else {
+			v.add(pairKey, "is required when " + key + " is set")
+		}
+	}
+
+	v.file(key, path)
+	v.file(pairKey, pairPath)
+}
+
+func (v *validator) file(key string, path string) {
+
+	if path == "" {
+		return
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		v.add(key, fmt.Sprintf("cannot read %s: %v", path, err))
+		return
+	}
+
+	if info.IsDir() {
+		v.add(key, fmt.Sprintf("%s is a directory", path))
+	}
+}
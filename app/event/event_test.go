@@ -0,0 +1,330 @@
+package event_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	eventPort "github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEvent(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Todo event bus test.")
+}
+
+func publish(bus eventPort.TodoEventBus, userId value.UserId, eventType string, title string) {
+
+	todo := entity.NewTodoItem(
+		value.NewTodoItemId(uuid.NewString()),
+		value.NewTodoItemTitle(title),
+		value.NewTodoItemDescription(title + " description"),
+		false,
+		userId,
+		"",
+		nil,
+	)
+
+	Expect(bus.Publish([]*dto.PublishTodoEventCommand{{UserId: userId, Type: eventType, Todo: todo}})).To(Succeed())
+}
+
+func receive(subscription eventPort.TodoEventSubscription) *entity.TodoEvent {
+
+	var e *entity.TodoEvent
+
+	Eventually(subscription.Events()).Should(Receive(&e))
+
+	return e
+}
+
+// Behavior every todo event bus must have.
+func todoEventBusContract(newBus func() eventPort.TodoEventBus, newUser func() value.UserId) {
+
+	var bus eventPort.TodoEventBus
+
+	var userId value.UserId
+
+	BeforeEach(func() {
+		bus = newBus()
+		userId = newUser()
+	})
+
+	It("should deliver events published after subscribing in order", func() {
+
+		subscription, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer subscription.Close()
+
+		publish(bus, userId, entity.TodoEventCreated, "first")
+		publish(bus, userId, entity.TodoEventCompleted, "second")
+
+		first := receive(subscription)
+		second := receive(subscription)
+
+		Expect(first.Type()).To(Equal(entity.TodoEventCreated))
+		Expect(first.Todo().Title().Value()).To(Equal("first"))
+		Expect(first.UserId()).To(Equal(userId))
+		Expect(second.Type()).To(Equal(entity.TodoEventCompleted))
+		Expect(second.Id()).To(BeNumerically(">", first.Id()))
+	})
+
+	It("should resume after last event received", func() {
+
+		subscription, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		publish(bus, userId, entity.TodoEventCreated, "seen")
+
+		seen := receive(subscription)
+
+		subscription.Close()
+
+		publish(bus, userId, entity.TodoEventUpdated, "missed")
+
+		resumed, err := bus.Subscribe(userId, seen.Id())
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resumed.Close()
+
+		publish(bus, userId, entity.TodoEventDeleted, "live")
+
+		Expect(receive(resumed).Todo().Title().Value()).To(Equal("missed"))
+		Expect(receive(resumed).Todo().Title().Value()).To(Equal("live"))
+	})
+
+	It("should fan out to every session of user only", func() {
+
+		otherId := newUser()
+
+		first, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer first.Close()
+
+		second, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer second.Close()
+
+		other, err := bus.Subscribe(otherId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer other.Close()
+
+		publish(bus, userId, entity.TodoEventCreated, "shared")
+
+		Expect(receive(first).Todo().Title().Value()).To(Equal("shared"))
+		Expect(receive(second).Todo().Title().Value()).To(Equal("shared"))
+		Consistently(other.Events(), 100 * time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("should end events when closed", func() {
+
+		subscription, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		subscription.Close()
+		subscription.Close()
+
+		Eventually(subscription.Events()).Should(BeClosed())
+	})
+}
+
+var _ = Describe("Memory todo event bus test", func() {
+
+	todoEventBusContract(
+		func() eventPort.TodoEventBus {
+			return event.NewMemoryTodoEventBus()
+		},
+		func() value.UserId {
+			return value.NewUserId(uuid.NewString())
+		},
+	)
+
+	It("should drop subscriber falling too far behind", func() {
+
+		bus := event.NewMemoryTodoEventBus()
+
+		userId := value.NewUserId(uuid.NewString())
+
+		subscription, err := bus.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		for range 300 {
+			publish(bus, userId, entity.TodoEventUpdated, "flood")
+		}
+
+		received := make([]*entity.TodoEvent, 0)
+
+		for e := range subscription.Events() {
+			received = append(received, e)
+		}
+
+		Expect(len(received)).To(BeNumerically(">", 200))
+		Expect(len(received)).To(BeNumerically("<", 300))
+
+		// Resuming picks up kept history after last event received.
+		last := received[len(received) - 1]
+
+		resumed, err := bus.Subscribe(userId, last.Id())
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resumed.Close()
+
+		next := receive(resumed)
+
+		Expect(next.Type()).To(Equal(entity.TodoEventUpdated))
+		Expect(next.Id()).To(Equal(last.Id() + 1))
+	})
+
+	It("should tell subscriber events were lost", func() {
+
+		previous := event.NewMemoryTodoEventBus()
+
+		userId := value.NewUserId(uuid.NewString())
+
+		subscription, err := previous.Subscribe(userId, 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		publish(previous, userId, entity.TodoEventCreated, "before restart")
+
+		seen := receive(subscription)
+
+		subscription.Close()
+
+		bus := event.NewMemoryTodoEventBus()
+
+		// Resuming from event of earlier process.
+		resumed, err := bus.Subscribe(userId, seen.Id())
+
+		Expect(err).ToNot(HaveOccurred())
+
+		reset := receive(resumed)
+
+		Expect(reset.Type()).To(Equal(entity.TodoEventReset))
+		Expect(reset.Todo()).To(BeNil())
+
+		publish(bus, userId, entity.TodoEventCreated, "after restart")
+
+		Expect(receive(resumed).Todo().Title().Value()).To(Equal("after restart"))
+
+		resumed.Close()
+
+		// Resuming from event of later process, or from reset, does not miss events either.
+		for _, lastEventId := range []int64{reset.Id() + 1_000_000_000, reset.Id()} {
+
+			resumed, err = bus.Subscribe(userId, lastEventId)
+
+			Expect(err).ToNot(HaveOccurred())
+
+			first := receive(resumed)
+
+			resumed.Close()
+
+			if lastEventId > reset.Id() {
+				Expect(first.Type()).To(Equal(entity.TodoEventReset))
+			} else {
+				Expect(first.Todo().Title().Value()).To(Equal("after restart"))
+			}
+		}
+
+		// Resuming from event dropped from history.
+		for range event.MemoryHistorySize + 1 {
+			publish(bus, userId, entity.TodoEventUpdated, "flood")
+		}
+
+		resumed, err = bus.Subscribe(userId, reset.Id() + 1)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resumed.Close()
+
+		Expect(receive(resumed).Type()).To(Equal(entity.TodoEventReset))
+	})
+
+	It("should end all streams when bus is closed", func() {
+
+		bus := event.NewMemoryTodoEventBus()
+
+		first, err := bus.Subscribe(value.NewUserId(uuid.NewString()), 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		second, err := bus.Subscribe(value.NewUserId(uuid.NewString()), 0)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		bus.Close()
+
+		Eventually(first.Events()).Should(BeClosed())
+		Eventually(second.Events()).Should(BeClosed())
+	})
+})
+
+// Runs against database of the dev container when configured.
+var _ = Describe("Postgres todo event bus test", Ordered, func() {
+
+	connectionString := os.Getenv("TEST_DATABASE_URL")
+
+	if connectionString == "" {
+		return
+	}
+
+	bus := event.NewPostgresTodoEventBus(connectionString)
+
+	BeforeAll(func() {
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		DeferCleanup(cancel)
+
+		go bus.Listen(ctx)
+
+		// Listening closes subscriptions made before it started.
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	todoEventBusContract(
+		func() eventPort.TodoEventBus {
+			return bus
+		},
+		func() value.UserId {
+
+			userRepository := postgres.NewUserRepository(connectionString)
+
+			email := value.NewEmail("todo-event-" + uuid.NewString()[:8] + "@example.com")
+
+			Expect(userRepository.Create(&dto.CreateUserCommand{
+				UserName: value.NewUserName("todo_event_user"),
+				Email: email,
+				Password: value.NewPassword("test-pass"),
+			})).To(Succeed())
+
+			user, err := userRepository.GetByEmail(email)
+
+			Expect(err).ToNot(HaveOccurred())
+
+			return user.Id()
+		},
+	)
+})
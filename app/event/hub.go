@@ -0,0 +1,156 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Events a subscriber may fall behind before its subscription is closed.
+const subscriptionBuffer = 256
+
+// Subscribers of this process, grouped by user.
+type hub struct {
+	subscribers map[value.UserId]map[*subscription]bool
+	mu sync.Mutex
+}
+
+func newHub() *hub {
+	return &hub{
+		subscribers: make(map[value.UserId]map[*subscription]bool),
+		mu: sync.Mutex{},
+	}
+}
+
+// Subscription of one session.
+type subscription struct {
+	hub *hub
+	userId value.UserId
+	events chan *entity.TodoEvent
+	// ID of last event delivered, so none is delivered twice.
+	lastId int64
+	// Live events held back while missed ones are loaded. nil once caught up.
+	pending []*entity.TodoEvent
+	closed bool
+}
+
+func (s *subscription) Events() <-chan *entity.TodoEvent {
+	return s.events
+}
+
+func (s *subscription) Close() {
+
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.removeLocked(s)
+}
+
+// Register subscriber. Live events are held back until catchUp is called.
+func (h *hub) subscribe(userId value.UserId, lastEventId int64) *subscription {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := &subscription{
+		hub: h,
+		userId: userId,
+		events: make(chan *entity.TodoEvent, subscriptionBuffer),
+		lastId: lastEventId,
+		pending: make([]*entity.TodoEvent, 0),
+	}
+
+	if h.subscribers[userId] == nil {
+		h.subscribers[userId] = make(map[*subscription]bool)
+	}
+
+	h.subscribers[userId][s] = true
+
+	return s
+}
+
+// Deliver events missed before subscribing followed by those held back, then go live.
+// Subscription is closed when more were missed, so subscriber resumes from where it got.
+func (h *hub) catchUp(s *subscription, missed []*entity.TodoEvent, more bool) {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, e := range missed {
+		s.deliverLocked(e)
+	}
+
+	if more {
+		h.removeLocked(s)
+		return
+	}
+
+	for _, e := range s.pending {
+		s.deliverLocked(e)
+	}
+
+	s.pending = nil
+}
+
+// Pass event to subscribers of its user.
+func (h *hub) dispatch(e *entity.TodoEvent) {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subscribers[e.UserId()] {
+
+		if s.pending != nil {
+			s.pending = append(s.pending, e)
+			continue
+		}
+
+		s.deliverLocked(e)
+	}
+}
+
+// Close all subscriptions, e.g. when events may have been lost, so subscribers resume.
+func (h *hub) closeAll() {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subscriptions := range h.subscribers {
+		for s := range subscriptions {
+			h.removeLocked(s)
+		}
+	}
+}
+
+func (h *hub) removeLocked(s *subscription) {
+
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+
+	close(s.events)
+
+	delete(h.subscribers[s.userId], s)
+
+	if len(h.subscribers[s.userId]) == 0 {
+		delete(h.subscribers, s.userId)
+	}
+}
+
+// Send event without blocking publisher. Subscriber too far behind is dropped to resume later.
+func (s *subscription) deliverLocked(e *entity.TodoEvent) {
+
+	if s.closed || e.Id() <= s.lastId {
+		return
+	}
+
+	select {
+	case s.events <- e:
+		s.lastId = e.Id()
+	default:
+		s.hub.removeLocked(s)
+	}
+}
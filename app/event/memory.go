@@ -0,0 +1,112 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	eventPort "github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+// Recent events kept per user to resume from.
+const MemoryHistorySize = 100
+
+// Todo event bus within one process. Events are lost on restart.
+// IDs start from time process started, so those of earlier processes are older,
+// and subscribers resuming from one are told events were lost.
+type MemoryTodoEventBus struct {
+	hub *hub
+	// ID events of this process follow.
+	epoch int64
+	lastId int64
+	history map[value.UserId][]*entity.TodoEvent
+	// ID of last event dropped from history by user.
+	dropped map[value.UserId]int64
+	mu sync.Mutex
+}
+
+func NewMemoryTodoEventBus() *MemoryTodoEventBus {
+
+	epoch := time.Now().UnixMicro()
+
+	return &MemoryTodoEventBus{
+		hub: newHub(),
+		epoch: epoch,
+		lastId: epoch,
+		history: make(map[value.UserId][]*entity.TodoEvent),
+		dropped: make(map[value.UserId]int64),
+		mu: sync.Mutex{},
+	}
+}
+
+func (b *MemoryTodoEventBus) Publish(events []*dto.PublishTodoEventCommand) error {
+
+	// Dispatch in order of IDs, as subscribers skip events older than last one received.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, command := range events {
+
+		b.lastId++
+
+		e := entity.NewTodoEvent(b.lastId, command.UserId, command.Type, command.Todo.Copy(), time.Now())
+
+		history := append(b.history[e.UserId()], e)
+
+		if len(history) > MemoryHistorySize {
+			b.dropped[e.UserId()] = history[len(history) - MemoryHistorySize - 1].Id()
+			history = history[len(history) - MemoryHistorySize:]
+		}
+
+		b.history[e.UserId()] = history
+
+		b.hub.dispatch(e)
+	}
+
+	return nil
+}
+
+// Subscriber resuming from event not in history, or from one of another process, gets reset event first.
+func (b *MemoryTodoEventBus) Subscribe(userId value.UserId, lastEventId int64) (eventPort.TodoEventSubscription, error) {
+
+	// Nothing is published until caught up, as publishing holds lock too.
+	b.mu.Lock()
+
+	if lastEventId == 0 {
+		s := b.hub.subscribe(userId, b.lastId)
+		b.mu.Unlock()
+		b.hub.catchUp(s, nil, false)
+		return s, nil
+	}
+
+	if lastEventId < b.epoch || lastEventId > b.lastId || lastEventId < b.dropped[userId] {
+		s := b.hub.subscribe(userId, b.lastId - 1)
+		reset := entity.NewTodoEvent(b.lastId, userId, entity.TodoEventReset, nil, time.Now())
+		b.mu.Unlock()
+		b.hub.catchUp(s, []*entity.TodoEvent{reset}, false)
+		return s, nil
+	}
+
+	s := b.hub.subscribe(userId, lastEventId)
+
+	missed := make([]*entity.TodoEvent, 0)
+
+	for _, e := range b.history[userId] {
+		if e.Id() > lastEventId {
+			missed = append(missed, e)
+		}
+	}
+
+	b.mu.Unlock()
+
+	b.hub.catchUp(s, missed, false)
+
+	return s, nil
+}
+
+// Close all subscriptions, ending streams of sessions so they resume on another server.
+func (b *MemoryTodoEventBus) Close() {
+	b.hub.closeAll()
+}
@@ -0,0 +1,322 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	eventPort "github.com/kkatou7209/godo/app/port/out/event"
+	"github.com/kkatou7209/godo/persistence/postgres"
+)
+
+// Channel events are notified on.
+const postgresChannel = "todo_events"
+
+// How long events are kept to resume from.
+const PostgresEventRetention = time.Hour
+
+// Missed events loaded at once. Subscriber resumes for the rest.
+const postgresCatchUpLimit = 200
+
+// Todo event as notified and stored.
+type todoEventJson struct {
+	Id         int64     `json:"id"`
+	UserId     string    `json:"userId"`
+	Type       string    `json:"type"`
+	Todo       todoJson  `json:"todo"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type todoJson struct {
+	Id          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	IsDone      bool    `json:"isDone"`
+	UserId      string  `json:"userId"`
+	ProjectId   string  `json:"projectId"`
+	AssigneeId  *string `json:"assigneeId"`
+}
+
+func toTodoJson(todo *entity.TodoItem) todoJson {
+
+	t := todoJson{
+		Id: todo.Id().Value(),
+		Title: todo.Title().Value(),
+		Description: todo.Description().Value(),
+		IsDone: todo.IsDone(),
+		UserId: todo.UserId().Value(),
+		ProjectId: todo.ProjectId(),
+	}
+
+	if todo.AssigneeId() != nil {
+		assigneeId := todo.AssigneeId().Value()
+		t.AssigneeId = &assigneeId
+	}
+
+	return t
+}
+
+func (t todoJson) toTodoItem() *entity.TodoItem {
+
+	var assigneeId *value.UserId
+
+	if t.AssigneeId != nil {
+		id := value.NewUserId(*t.AssigneeId)
+		assigneeId = &id
+	}
+
+	return entity.NewTodoItem(
+		value.NewTodoItemId(t.Id),
+		value.NewTodoItemTitle(t.Title),
+		value.NewTodoItemDescription(t.Description),
+		t.IsDone,
+		value.NewUserId(t.UserId),
+		t.ProjectId,
+		assigneeId,
+	)
+}
+
+func (e todoEventJson) toTodoEvent() *entity.TodoEvent {
+	return entity.NewTodoEvent(e.Id, value.NewUserId(e.UserId), e.Type, e.Todo.toTodoItem(), e.OccurredAt)
+}
+
+// Todo event bus shared by replicas through Postgres.
+// Events are stored to resume from and notified to every replica listening.
+// Trigger of todo_events numbers and notifies them in order of commit per user.
+// Connections come from pool of repositories using same connection string.
+type PostgresTodoEventBus struct {
+	connectionString string
+	hub *hub
+	// Time old events were last removed.
+	prunedAt time.Time
+	mu sync.Mutex
+}
+
+func NewPostgresTodoEventBus(connectionString string) *PostgresTodoEventBus {
+	return &PostgresTodoEventBus{
+		connectionString: connectionString,
+		hub: newHub(),
+		mu: sync.Mutex{},
+	}
+}
+
+func (b *PostgresTodoEventBus) Publish(events []*dto.PublishTodoEventCommand) (err error) {
+
+	ctx := context.Background()
+
+	pool, err := postgres.Pool(b.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	tran, err := pool.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
+
+	// Trigger locks user of each event until commit, so always lock users in same order.
+	events = append([]*dto.PublishTodoEventCommand(nil), events...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].UserId.Value() < events[j].UserId.Value()
+	})
+
+	for _, command := range events {
+
+		e := todoEventJson{
+			UserId: command.UserId.Value(),
+			Type: command.Type,
+			Todo: toTodoJson(command.Todo),
+		}
+
+		var todo []byte
+
+		todo, err = json.Marshal(e.Todo)
+
+		if err != nil {
+			return err
+		}
+
+		// Notified by trigger.
+		_, err = tran.Exec(ctx, `
+			INSERT INTO todo_events (user_id, type, todo)
+			VALUES ($1, $2, $3)`,
+			e.UserId,
+			e.Type,
+			todo,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.prune(ctx, tran)
+
+	return err
+}
+
+// Remove events too old to resume from, at most once a minute.
+func (b *PostgresTodoEventBus) prune(ctx context.Context, tran pgx.Tx) error {
+
+	b.mu.Lock()
+
+	now := time.Now()
+
+	due := now.Sub(b.prunedAt) > time.Minute
+
+	if due {
+		b.prunedAt = now
+	}
+
+	b.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+
+	_, err := tran.Exec(ctx, `DELETE FROM todo_events WHERE created_at < $1`, now.Add(-PostgresEventRetention))
+
+	return err
+}
+
+func (b *PostgresTodoEventBus) Subscribe(userId value.UserId, lastEventId int64) (eventPort.TodoEventSubscription, error) {
+
+	s := b.hub.subscribe(userId, lastEventId)
+
+	if lastEventId == 0 {
+		b.hub.catchUp(s, nil, false)
+		return s, nil
+	}
+
+	missed, err := b.listAfter(userId, lastEventId)
+
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	more := len(missed) > postgresCatchUpLimit
+
+	if more {
+		missed = missed[:postgresCatchUpLimit]
+	}
+
+	b.hub.catchUp(s, missed, more)
+
+	return s, nil
+}
+
+// List events of user after given ID, one more than catch-up limit to tell if there are more.
+func (b *PostgresTodoEventBus) listAfter(userId value.UserId, lastEventId int64) ([]*entity.TodoEvent, error) {
+
+	ctx := context.Background()
+
+	pool, err := postgres.Pool(b.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT id, type, todo, created_at
+		FROM todo_events
+		WHERE user_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`, userId.Value(), lastEventId, postgresCatchUpLimit + 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]*entity.TodoEvent, 0)
+
+	for rows.Next() {
+
+		e := todoEventJson{UserId: userId.Value()}
+
+		var todo []byte
+
+		if err := rows.Scan(&e.Id, &e.Type, &todo, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(todo, &e.Todo); err != nil {
+			return nil, err
+		}
+
+		events = append(events, e.toTodoEvent())
+	}
+
+	return events, rows.Err()
+}
+
+// Pass events notified by all replicas to subscribers of this one until context is done or connection fails.
+// Subscriptions are closed when listening starts, so subscribers resume past events possibly missed meanwhile.
+func (b *PostgresTodoEventBus) Listen(ctx context.Context) error {
+
+	pool, err := postgres.Pool(b.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	pooled, err := pool.Acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// Taken out of pool, as listening holds it for good.
+	conn := pooled.Hijack()
+
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, `LISTEN `+postgresChannel); err != nil {
+		return err
+	}
+
+	b.hub.closeAll()
+
+	for {
+
+		notification, err := conn.WaitForNotification(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		var e todoEventJson
+
+		if err := json.Unmarshal([]byte(notification.Payload), &e); err != nil {
+			continue
+		}
+
+		b.hub.dispatch(e.toTodoEvent())
+	}
+}
+
+// Close all subscriptions, ending streams of sessions so they resume on another replica.
+// Stop listening by canceling context of Listen.
+func (b *PostgresTodoEventBus) Close() {
+	b.hub.closeAll()
+}
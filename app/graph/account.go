@@ -0,0 +1,291 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+func (r *resolver) RequestAccountDeletion(ctx context.Context, args struct{ Password string }) (*accountDeletionResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := r.app.RequestAccountDeletionUsecase().RequestDeletion(principal.UserId, args.Password)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &accountDeletionResolver{deletion}, nil
+}
+
+func (r *resolver) CancelAccountDeletion(ctx context.Context) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.CancelAccountDeletionUsecase().CancelDeletion(principal.UserId); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) EnrollTwoFactor(ctx context.Context) (*twoFactorEnrollmentResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := r.app.EnrollTwoFactorUsecase().Enroll(principal.UserId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &twoFactorEnrollmentResolver{enrollment}, nil
+}
+
+func (r *resolver) ConfirmTwoFactor(ctx context.Context, args struct{ Code string }) ([]string, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := r.app.ConfirmTwoFactorUsecase().Confirm(principal.UserId, args.Code)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return codes, nil
+}
+
+func (r *resolver) DisableTwoFactor(ctx context.Context, args struct{ Password string }) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.DisableTwoFactorUsecase().Disable(principal.UserId, args.Password); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) CalendarFeed(ctx context.Context) (*calendarFeedResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := r.app.CalendarFeedUsecase().Get(principal.UserId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	if feed == nil {
+		return nil, nil
+	}
+
+	return &calendarFeedResolver{feed}, nil
+}
+
+func (r *resolver) RotateCalendarFeed(ctx context.Context) (*calendarFeedResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := r.app.CalendarFeedUsecase().Rotate(principal.UserId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &calendarFeedResolver{feed}, nil
+}
+
+func (r *resolver) RevokeCalendarFeed(ctx context.Context) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.CalendarFeedUsecase().Revoke(principal.UserId); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) AccessTokens(ctx context.Context) ([]*accessTokenResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := r.app.ListAccessTokensUsecase().List(principal.UserId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	resolvers := make([]*accessTokenResolver, len(tokens))
+
+	for i, token := range tokens {
+		resolvers[i] = &accessTokenResolver{token}
+	}
+
+	return resolvers, nil
+}
+
+func (r *resolver) CreateAccessToken(ctx context.Context, args struct {
+	Name string
+	Scopes []string
+	ExpiresAt *graphql.Time
+}) (*createdAccessTokenResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	command := &dto.CreateAccessTokenCommand{
+		UserId: principal.UserId,
+		Name: args.Name,
+		Scopes: args.Scopes,
+	}
+
+	if args.ExpiresAt != nil {
+		command.ExpiresAt = &args.ExpiresAt.Time
+	}
+
+	created, err := r.app.CreateAccessTokenUsecase().Create(command)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &createdAccessTokenResolver{created}, nil
+}
+
+func (r *resolver) RevokeAccessToken(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return false, err
+	}
+
+	if err := r.app.RevokeAccessTokenUsecase().Revoke(principal.UserId, string(args.Id)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+type accountDeletionResolver struct {
+	deletion *dto.AccountDeletionDto
+}
+
+func (r *accountDeletionResolver) RequestedAt() graphql.Time {
+	return graphql.Time{Time: r.deletion.RequestedAt}
+}
+
+func (r *accountDeletionResolver) ScheduledAt() graphql.Time {
+	return graphql.Time{Time: r.deletion.ScheduledAt}
+}
+
+type twoFactorEnrollmentResolver struct {
+	enrollment *dto.TwoFactorEnrollmentDto
+}
+
+func (r *twoFactorEnrollmentResolver) Secret() string {
+	return r.enrollment.Secret
+}
+
+func (r *twoFactorEnrollmentResolver) Uri() string {
+	return r.enrollment.Uri
+}
+
+type calendarFeedResolver struct {
+	feed *dto.CalendarFeedDto
+}
+
+func (r *calendarFeedResolver) Token() *string {
+	return stringOrNil(r.feed.Token)
+}
+
+func (r *calendarFeedResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.feed.CreatedAt}
+}
+
+type accessTokenResolver struct {
+	token *dto.AccessTokenDto
+}
+
+func (r *accessTokenResolver) Id() graphql.ID {
+	return graphql.ID(r.token.Id)
+}
+
+func (r *accessTokenResolver) Name() string {
+	return r.token.Name
+}
+
+func (r *accessTokenResolver) Scopes() []string {
+	return r.token.Scopes
+}
+
+func (r *accessTokenResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.token.CreatedAt}
+}
+
+func (r *accessTokenResolver) ExpiresAt() *graphql.Time {
+	return timeOf(r.token.ExpiresAt)
+}
+
+func (r *accessTokenResolver) LastUsedAt() *graphql.Time {
+	return timeOf(r.token.LastUsedAt)
+}
+
+type createdAccessTokenResolver struct {
+	created *dto.CreatedAccessTokenDto
+}
+
+func (r *createdAccessTokenResolver) AccessToken() *accessTokenResolver {
+	return &accessTokenResolver{r.created.AccessToken}
+}
+
+func (r *createdAccessTokenResolver) Token() string {
+	return r.created.Token
+}
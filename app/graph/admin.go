@@ -0,0 +1,237 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+func (r *resolver) Users(ctx context.Context, args struct {
+	Search *string
+	Page *int32
+	PerPage *int32
+}) (*userPageResolver, error) {
+
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	query := &dto.ListUsersQuery{Page: pageQueryOf(args.Page, args.PerPage)}
+
+	if args.Search != nil {
+		query.Search = *args.Search
+	}
+
+	users, err := r.app.ListUsersUsecase().List(query)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &userPageResolver{r, users}, nil
+}
+
+func (r *resolver) UserUsage(ctx context.Context, args struct{ Id graphql.ID }) (*userUsageResolver, error) {
+
+	principal, err := requireAdmin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return nil, err
+	}
+
+	usage, err := r.app.InspectUserUsecase().Inspect(principal.UserId, string(args.Id))
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &userUsageResolver{r, usage}, nil
+}
+
+func (r *resolver) AuditLogs(ctx context.Context, args struct{ Page, PerPage *int32 }) (*auditLogPageResolver, error) {
+
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	query := pageQueryOf(args.Page, args.PerPage)
+
+	logs, err := r.app.ListAuditLogsUsecase().List(&query)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &auditLogPageResolver{logs}, nil
+}
+
+func (r *resolver) DisableUser(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	return r.moderate(ctx, args.Id, r.app.DisableUserUsecase().Disable)
+}
+
+func (r *resolver) EnableUser(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	return r.moderate(ctx, args.Id, r.app.EnableUserUsecase().Enable)
+}
+
+func (r *resolver) ForcePasswordReset(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+	return r.moderate(ctx, args.Id, r.app.ForcePasswordResetUsecase().ForceReset)
+}
+
+func (r *resolver) ChangeUserRole(ctx context.Context, args struct {
+	Id graphql.ID
+	Role string
+}) (bool, error) {
+
+	return r.moderate(ctx, args.Id, func(actorId string, userId string) error {
+		return r.app.ChangeUserRoleUsecase().ChangeRole(actorId, userId, args.Role)
+	})
+}
+
+// Run admin action on user on behalf of admin.
+func (r *resolver) moderate(ctx context.Context, userId graphql.ID, action func(actorId string, userId string) error) (bool, error) {
+
+	principal, err := requireAdmin(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("id", string(userId)); err != nil {
+		return false, err
+	}
+
+	if err := action(principal.UserId, string(userId)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+// Page query of arguments. Use cases fill in defaults for missing ones.
+func pageQueryOf(page *int32, perPage *int32) dto.PageQuery {
+
+	query := dto.PageQuery{}
+
+	if page != nil {
+		query.Page = int(*page)
+	}
+
+	if perPage != nil {
+		query.PerPage = int(*perPage)
+	}
+
+	return query
+}
+
+type userPageResolver struct {
+	root *resolver
+	page *dto.PageDto[*dto.UserDto]
+}
+
+func (r *userPageResolver) Items() []*userResolver {
+
+	resolvers := make([]*userResolver, len(r.page.Items))
+
+	for i, user := range r.page.Items {
+		resolvers[i] = &userResolver{r.root.app, user}
+	}
+
+	return resolvers
+}
+
+func (r *userPageResolver) Total() int32 {
+	return int32(r.page.Total)
+}
+
+func (r *userPageResolver) Page() int32 {
+	return int32(r.page.Page)
+}
+
+func (r *userPageResolver) PerPage() int32 {
+	return int32(r.page.PerPage)
+}
+
+type userUsageResolver struct {
+	root *resolver
+	usage *dto.UserUsageDto
+}
+
+func (r *userUsageResolver) User() *userResolver {
+	return &userResolver{r.root.app, r.usage.User}
+}
+
+func (r *userUsageResolver) TodoItems() int32 {
+	return int32(r.usage.TodoItems)
+}
+
+func (r *userUsageResolver) CompletedTodoItems() int32 {
+	return int32(r.usage.CompletedTodoItems)
+}
+
+func (r *userUsageResolver) AccessTokens() int32 {
+	return int32(r.usage.AccessTokens)
+}
+
+func (r *userUsageResolver) TwoFactorEnabled() bool {
+	return r.usage.TwoFactorEnabled
+}
+
+type auditLogPageResolver struct {
+	page *dto.PageDto[*dto.AuditLogDto]
+}
+
+func (r *auditLogPageResolver) Items() []*auditLogResolver {
+
+	resolvers := make([]*auditLogResolver, len(r.page.Items))
+
+	for i, log := range r.page.Items {
+		resolvers[i] = &auditLogResolver{log}
+	}
+
+	return resolvers
+}
+
+func (r *auditLogPageResolver) Total() int32 {
+	return int32(r.page.Total)
+}
+
+func (r *auditLogPageResolver) Page() int32 {
+	return int32(r.page.Page)
+}
+
+func (r *auditLogPageResolver) PerPage() int32 {
+	return int32(r.page.PerPage)
+}
+
+type auditLogResolver struct {
+	log *dto.AuditLogDto
+}
+
+func (r *auditLogResolver) Id() graphql.ID {
+	return graphql.ID(r.log.Id)
+}
+
+func (r *auditLogResolver) ActorId() *graphql.ID {
+	return idOf(r.log.ActorId)
+}
+
+func (r *auditLogResolver) Action() string {
+	return r.log.Action
+}
+
+func (r *auditLogResolver) TargetUserId() graphql.ID {
+	return graphql.ID(r.log.TargetUserId)
+}
+
+func (r *auditLogResolver) Detail() string {
+	return r.log.Detail
+}
+
+func (r *auditLogResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.log.CreatedAt}
+}
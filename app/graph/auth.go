@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+func (r *resolver) SignUp(args struct{ Username, Email, Password string }) (bool, error) {
+
+	err := r.app.AddUserUsecase().Add(&dto.AddUserCommand{
+		UserName: args.Username,
+		Email: args.Email,
+		Password: args.Password,
+	})
+
+	if err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) Login(args struct{ Email, Password string }) (*loginResolver, error) {
+
+	result, err := r.app.LoginUsecase().Login(&dto.LoginCommand{
+		Email: args.Email,
+		Password: args.Password,
+	})
+
+	if err != nil {
+		return nil, loginError(err)
+	}
+
+	return &loginResolver{result}, nil
+}
+
+func (r *resolver) VerifyLogin(args struct{ ChallengeToken, Code string }) (*loginResolver, error) {
+
+	result, err := r.app.VerifyLoginUsecase().Verify(&dto.VerifyLoginCommand{
+		ChallengeToken: args.ChallengeToken,
+		Code: args.Code,
+	})
+
+	if err != nil {
+		return nil, loginError(err)
+	}
+
+	return &loginResolver{result}, nil
+}
+
+func (r *resolver) ForgotPassword(args struct{ Email string }) (bool, error) {
+
+	if err := r.app.RequestPasswordResetUsecase().RequestReset(args.Email); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) ResetPassword(args struct{ Token, Password string }) (bool, error) {
+
+	err := r.app.ResetPasswordUsecase().Reset(&dto.ResetPasswordCommand{
+		Token: args.Token,
+		Password: args.Password,
+	})
+
+	if err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) ConfirmEmailChange(args struct{ Token string }) (bool, error) {
+
+	if err := r.app.ConfirmEmailChangeUsecase().Confirm(args.Token); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) RevertEmailChange(args struct{ Token string }) (bool, error) {
+
+	if err := r.app.RevertEmailChangeUsecase().Revert(args.Token); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+// Bad credentials do not tell which part was wrong.
+func loginError(err error) error {
+
+	if err == validation.ErrUserDisabled {
+		return errorOf(err)
+	}
+
+	if _, ok := err.(*validation.ValidationError); ok {
+		return newError(CodeUnauthenticated, "invalid credentials")
+	}
+
+	return errorOf(err)
+}
+
+type loginResolver struct {
+	result *dto.LoginResultDto
+}
+
+func (r *loginResolver) Token() *string {
+	return stringOrNil(r.result.Token)
+}
+
+func (r *loginResolver) TwoFactorRequired() bool {
+	return r.result.TwoFactorRequired
+}
+
+func (r *loginResolver) ChallengeToken() *string {
+	return stringOrNil(r.result.ChallengeToken)
+}
+
+func (r *loginResolver) UserId() *graphql.ID {
+
+	if r.result.User == nil {
+		return nil
+	}
+
+	return idOf(r.result.User.Id)
+}
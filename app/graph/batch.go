@@ -0,0 +1,29 @@
+package graph
+
+import "sync"
+
+// Values of field for all objects of list, loaded at once when first object asks.
+// Objects of list share batch, so resolving field of each takes one use case call, not one per object.
+type batch[V any] struct {
+	keys []string
+	load func(keys []string) (map[string]V, error)
+	once sync.Once
+	values map[string]V
+	err error
+}
+
+func newBatch[V any](keys []string, load func(keys []string) (map[string]V, error)) *batch[V] {
+	return &batch[V]{keys: keys, load: load}
+}
+
+// Get value of key. Zero value if it was not loaded.
+func (b *batch[V]) get(key string) (V, error) {
+
+	b.once.Do(func() {
+		if len(b.keys) > 0 {
+			b.values, b.err = b.load(b.keys)
+		}
+	})
+
+	return b.values[key], b.err
+}
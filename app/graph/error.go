@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+// Codes of field errors, given in "code" of error extensions.
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden = "FORBIDDEN"
+	CodeNotFound = "NOT_FOUND"
+	CodeConflict = "CONFLICT"
+	CodeBadUserInput = "BAD_USER_INPUT"
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	CodeInternal = "INTERNAL"
+)
+
+// Error of field, telling its code in extensions.
+type fieldError struct {
+	code string
+	message string
+}
+
+func newError(code string, message string) *fieldError {
+	return &fieldError{code, message}
+}
+
+func (e *fieldError) Error() string {
+	return e.message
+}
+
+func (e *fieldError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// Convert error of use case to field error, as REST handlers convert it to HTTP status.
+func errorOf(err error) error {
+
+	switch err {
+	case validation.ErrUserNotFound, validation.ErrTodoNotDound, validation.ErrProjectNotFound, validation.ErrProjectMemberNotFound,
+		validation.ErrTodoCommentNotFound, validation.ErrTodoAttachmentNotFound, validation.ErrCalendarFeedNotFound:
+		return newError(CodeNotFound, err.Error())
+	case validation.ErrPermissionDenied, validation.ErrUserDisabled:
+		return newError(CodeForbidden, err.Error())
+	case validation.ErrUndoConflict:
+		return newError(CodeConflict, err.Error())
+	}
+
+	if _, ok := err.(*validation.ValidationError); ok {
+		return newError(CodeBadUserInput, err.Error())
+	}
+
+	return newError(CodeInternal, "unexpected error")
+}
+
+// Refuse argument missing value, which use cases take as given.
+func required(arg string, value string) error {
+
+	if strings.TrimSpace(value) == "" {
+		return newError(CodeBadUserInput, fmt.Sprintf("%s cannot be empty", arg))
+	}
+
+	return nil
+}
+
+// Report panic of resolver without its detail. Value constructors panic on input use cases take as given.
+type panicHandler struct{}
+
+func (panicHandler) MakePanicError(ctx context.Context, value any) *errors.QueryError {
+	return &errors.QueryError{
+		Message: "unexpected error",
+		Extensions: map[string]any{"code": CodeInternal},
+	}
+}
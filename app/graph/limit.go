@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	// Max nesting of fields in query.
+	MaxQueryDepth = 8
+	// Max cost of query. Each field costs 1, fields under list ListCostFactor times as much.
+	MaxQueryComplexity = 1000
+	// Number of objects list is assumed to have when measuring cost.
+	ListCostFactor = 5
+)
+
+// Check operation of query is within limits. Introspection is not counted, being bound by schema.
+// Returns errors of invalid query too, so it does not run unmeasured.
+func (s *Server) measure(query string, operationName string) []*errors.QueryError {
+
+	doc, errs := gqlparser.LoadQuery(s.limits, query)
+
+	if len(errs) > 0 {
+
+		queryErrors := make([]*errors.QueryError, len(errs))
+
+		for i, err := range errs {
+
+			queryErrors[i] = &errors.QueryError{Message: err.Message}
+
+			for _, loc := range err.Locations {
+				queryErrors[i].Locations = append(queryErrors[i].Locations, errors.Location{Line: loc.Line, Column: loc.Column})
+			}
+		}
+
+		return queryErrors
+	}
+
+	op := doc.Operations.ForName(operationName)
+
+	// Left to executor, which reports missing operation.
+	if op == nil {
+		return nil
+	}
+
+	depth, complexity := measureSelections(op.SelectionSet)
+
+	if depth > MaxQueryDepth {
+		return []*errors.QueryError{limitError(fmt.Sprintf("query depth %d exceeds max depth %d", depth, MaxQueryDepth))}
+	}
+
+	if complexity > MaxQueryComplexity {
+		return []*errors.QueryError{limitError(fmt.Sprintf("query complexity %d exceeds max complexity %d", complexity, MaxQueryComplexity))}
+	}
+
+	return nil
+}
+
+// Depth and cost of selections. Fragment cycles are refused by validation beforehand.
+func measureSelections(selections ast.SelectionSet) (depth int, complexity int) {
+
+	for _, selection := range selections {
+
+		var d, c int
+
+		switch sel := selection.(type) {
+		case *ast.Field:
+
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+
+			d, c = measureSelections(sel.SelectionSet)
+
+			if sel.Definition != nil && sel.Definition.Type.Elem != nil {
+				c *= ListCostFactor
+			}
+
+			d, c = d+1, c+1
+		case *ast.InlineFragment:
+			d, c = measureSelections(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			d, c = measureSelections(sel.Definition.SelectionSet)
+		}
+
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func limitError(message string) *errors.QueryError {
+	return &errors.QueryError{
+		Message: message,
+		Extensions: map[string]any{"code": CodeQueryTooComplex},
+	}
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+type principalKey struct{}
+
+func withPrincipal(ctx context.Context, principal *dto.PrincipalDto) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Require request authenticated by session or personal access token granted all scopes, as REST API does.
+func requireScopes(ctx context.Context, scopes ...string) (*dto.PrincipalDto, error) {
+
+	principal, _ := ctx.Value(principalKey{}).(*dto.PrincipalDto)
+
+	if principal == nil {
+		return nil, newError(CodeUnauthenticated, "authentication required")
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(principal.Scopes, scope) {
+			return nil, newError(CodeForbidden, "insufficient scope: "+scope+" is required")
+		}
+	}
+
+	return principal, nil
+}
+
+// Require request authenticated by session.
+// Personal access tokens are rejected, so they cannot manage account or other tokens.
+func requireSession(ctx context.Context) (*dto.PrincipalDto, error) {
+
+	principal, err := requireScopes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.AccessTokenId != "" {
+		return nil, newError(CodeForbidden, "session required")
+	}
+
+	return principal, nil
+}
+
+// Require request authenticated by session of admin.
+func requireAdmin(ctx context.Context) (*dto.PrincipalDto, error) {
+
+	principal, err := requireScopes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.AccessTokenId != "" || principal.Role != value.RoleAdmin.Value() {
+		return nil, newError(CodeForbidden, "admin session required")
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,343 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+func (r *resolver) Project(ctx context.Context, args struct{ Id graphql.ID }) (*projectResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosRead)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getProject(principal.UserId, args.Id)
+}
+
+func (r *resolver) CreateProject(ctx context.Context, args struct{ Name string }) (*projectResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := r.app.CreateProjectUsecase().Create(&dto.CreateProjectCommand{
+		UserId: principal.UserId,
+		Name: args.Name,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newProjectResolvers(r.app, principal.UserId, []*dto.ProjectDto{project})[0], nil
+}
+
+func (r *resolver) RenameProject(ctx context.Context, args struct {
+	Id graphql.ID
+	Name string
+}) (*projectResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return nil, err
+	}
+
+	err = r.app.RenameProjectUsecase().Rename(&dto.RenameProjectCommand{
+		UserId: principal.UserId,
+		ProjectId: string(args.Id),
+		Name: args.Name,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return r.getProject(principal.UserId, args.Id)
+}
+
+func (r *resolver) DeleteProject(ctx context.Context, args struct{ Id graphql.ID }) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return false, err
+	}
+
+	if err := r.app.DeleteProjectUsecase().Delete(principal.UserId, string(args.Id)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) ChangeProjectMember(ctx context.Context, args struct {
+	ProjectId graphql.ID
+	UserId graphql.ID
+	Permission string
+}) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("userId", string(args.UserId)); err != nil {
+		return false, err
+	}
+
+	err = r.app.ChangeProjectMemberUsecase().Change(&dto.ChangeProjectMemberCommand{
+		UserId: principal.UserId,
+		ProjectId: string(args.ProjectId),
+		MemberId: string(args.UserId),
+		Permission: args.Permission,
+	})
+
+	if err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) RemoveProjectMember(ctx context.Context, args struct{ ProjectId, UserId graphql.ID }) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("userId", string(args.UserId)); err != nil {
+		return false, err
+	}
+
+	if err := r.app.RemoveProjectMemberUsecase().Remove(principal.UserId, string(args.ProjectId), string(args.UserId)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) InviteProjectMember(ctx context.Context, args struct {
+	ProjectId graphql.ID
+	Email string
+	Permission string
+}) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	err = r.app.InviteProjectMemberUsecase().Invite(&dto.InviteProjectMemberCommand{
+		UserId: principal.UserId,
+		ProjectId: string(args.ProjectId),
+		Email: args.Email,
+		Permission: args.Permission,
+	})
+
+	if err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) AcceptProjectInvite(ctx context.Context, args struct{ Token string }) (*projectResolver, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("token", args.Token); err != nil {
+		return nil, err
+	}
+
+	project, err := r.app.AcceptProjectInviteUsecase().Accept(&dto.AcceptProjectInviteCommand{
+		UserId: principal.UserId,
+		Token: args.Token,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newProjectResolvers(r.app, principal.UserId, []*dto.ProjectDto{project})[0], nil
+}
+
+func (r *resolver) getProject(userId string, projectId graphql.ID) (*projectResolver, error) {
+
+	if err := required("id", string(projectId)); err != nil {
+		return nil, err
+	}
+
+	project, err := r.app.GetProjectUsecase().Get(userId, string(projectId))
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newProjectResolvers(r.app, userId, []*dto.ProjectDto{project})[0], nil
+}
+
+type projectResolver struct {
+	project *dto.ProjectDto
+	todos *batch[[]*todoResolver]
+	members *batch[[]*projectMemberResolver]
+}
+
+// Create resolvers of projects sharing batches, so fields of all of them are loaded at once.
+func newProjectResolvers(app *app.Application, userId string, projects []*dto.ProjectDto) []*projectResolver {
+
+	projectIds := make([]string, len(projects))
+
+	for i, project := range projects {
+		projectIds[i] = project.Id
+	}
+
+	// Todo items of all projects share their batches too.
+	todos := newBatch(projectIds, func(keys []string) (map[string][]*todoResolver, error) {
+
+		todos, err := app.ListProjectTodosBatchUsecase().ListMany(userId, keys)
+
+		if err != nil {
+			return nil, err
+		}
+
+		all := make([]*dto.TodoItemDto, 0)
+
+		for _, list := range todos {
+			all = append(all, list...)
+		}
+
+		resolvers := make(map[string][]*todoResolver, len(todos))
+
+		for _, todo := range newTodoResolvers(app, userId, all) {
+			resolvers[todo.todo.ProjectId] = append(resolvers[todo.todo.ProjectId], todo)
+		}
+
+		return resolvers, nil
+	})
+
+	members := newBatch(projectIds, func(keys []string) (map[string][]*projectMemberResolver, error) {
+
+		members, err := app.ListProjectMembersBatchUsecase().ListMany(userId, keys)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers := make(map[string][]*projectMemberResolver, len(members))
+
+		for projectId, list := range members {
+			for _, member := range list {
+				resolvers[projectId] = append(resolvers[projectId], &projectMemberResolver{member})
+			}
+		}
+
+		return resolvers, nil
+	})
+
+	resolvers := make([]*projectResolver, len(projects))
+
+	for i, project := range projects {
+		resolvers[i] = &projectResolver{project, todos, members}
+	}
+
+	return resolvers
+}
+
+func (r *projectResolver) Id() graphql.ID {
+	return graphql.ID(r.project.Id)
+}
+
+func (r *projectResolver) Name() string {
+	return r.project.Name
+}
+
+func (r *projectResolver) Permission() string {
+	return r.project.Permission
+}
+
+func (r *projectResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.project.CreatedAt}
+}
+
+func (r *projectResolver) Todos(ctx context.Context) ([]*todoResolver, error) {
+
+	if _, err := requireScopes(ctx, entity.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	todos, err := r.todos.get(r.project.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	if todos == nil {
+		return []*todoResolver{}, nil
+	}
+
+	return todos, nil
+}
+
+func (r *projectResolver) Members(ctx context.Context) ([]*projectMemberResolver, error) {
+
+	if _, err := requireScopes(ctx, entity.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	members, err := r.members.get(r.project.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	if members == nil {
+		return []*projectMemberResolver{}, nil
+	}
+
+	return members, nil
+}
+
+type projectMemberResolver struct {
+	member *dto.ProjectMemberDto
+}
+
+func (r *projectMemberResolver) UserId() graphql.ID {
+	return graphql.ID(r.member.UserId)
+}
+
+func (r *projectMemberResolver) Username() string {
+	return r.member.UserName
+}
+
+func (r *projectMemberResolver) Email() string {
+	return r.member.Email
+}
+
+func (r *projectMemberResolver) Permission() string {
+	return r.member.Permission
+}
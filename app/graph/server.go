@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// GraphQL schema of use cases.
+//
+//go:embed schema.graphql
+var Schema string
+
+// Body of GraphQL request.
+type Request struct {
+	Query string `json:"query"`
+	OperationName string `json:"operationName"`
+	Variables map[string]any `json:"variables"`
+}
+
+// Runs GraphQL requests against use cases of app.
+type Server struct {
+	schema *graphql.Schema
+	// Same schema for measuring queries before they run.
+	limits *ast.Schema
+}
+
+// Create server of schema resolved by use cases of app.
+func NewServer(app *app.Application) *Server {
+	return &Server{
+		schema: graphql.MustParseSchema(
+			Schema,
+			&resolver{app},
+			graphql.UseStringDescriptions(),
+			graphql.PanicHandler(panicHandler{}),
+		),
+		limits: gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: Schema}),
+	}
+}
+
+// Run request on behalf of principal. Principal is nil if request is anonymous.
+// Queries exceeding MaxQueryDepth or MaxQueryComplexity are refused before running.
+func (s *Server) Exec(ctx context.Context, principal *dto.PrincipalDto, req *Request) *graphql.Response {
+
+	if errs := s.measure(req.Query, req.OperationName); len(errs) > 0 {
+		return &graphql.Response{Errors: errs}
+	}
+
+	return s.schema.Exec(withPrincipal(ctx, principal), req.Query, req.OperationName, req.Variables)
+}
+
+// Root of queries and mutations.
+type resolver struct {
+	app *app.Application
+}
+
+// nil if value is empty, as use cases leave optional values empty.
+func stringOrNil(value string) *string {
+
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+// nil if value is empty, as use cases leave optional IDs empty.
+func idOf(value string) *graphql.ID {
+
+	if value == "" {
+		return nil
+	}
+
+	id := graphql.ID(value)
+
+	return &id
+}
+
+func timeOf(value *time.Time) *graphql.Time {
+
+	if value == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *value}
+}
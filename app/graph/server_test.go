@@ -0,0 +1,443 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/graph"
+	"github.com/kkatou7209/godo/mailer"
+	"github.com/kkatou7209/godo/password"
+	"github.com/kkatou7209/godo/persistence/mock"
+	"github.com/kkatou7209/godo/storage"
+	"github.com/kkatou7209/godo/token"
+	"github.com/kkatou7209/godo/totp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGraph(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GraphQL test.")
+}
+
+// Counts batch loads of comments.
+type countingTodoCommentRepository struct {
+	*mock.MockTodoCommentRepository
+	mu sync.Mutex
+	batches int
+}
+
+func (r *countingTodoCommentRepository) ListByTodos(todoIds []value.TodoItemId) ([]*entity.TodoComment, error) {
+
+	r.mu.Lock()
+	r.batches++
+	r.mu.Unlock()
+
+	return r.MockTodoCommentRepository.ListByTodos(todoIds)
+}
+
+func (r *countingTodoCommentRepository) Batches() int {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.batches
+}
+
+var (
+	application *app.Application
+	server *graph.Server
+	todoCommentRepository *countingTodoCommentRepository
+)
+
+var _ = BeforeSuite(func() {
+
+	application = app.New()
+
+	todoRepository := mock.NewMockTodoItemRepository()
+
+	userRepository := mock.NewMockUserRepository()
+
+	sessionRepository := mock.NewMockSessionRepository()
+
+	passwordResetRepository := mock.NewMockPasswordResetRepository()
+
+	twoFactorRepository := mock.NewMockTwoFactorRepository()
+
+	loginChallengeRepository := mock.NewMockLoginChallengeRepository()
+
+	emailChangeRepository := mock.NewMockEmailChangeRepository()
+
+	accountDeletionRepository := mock.NewMockAccountDeletionRepository()
+
+	accessTokenRepository := mock.NewMockAccessTokenRepository()
+
+	auditLogRepository := mock.NewMockAuditLogRepository()
+
+	projectRepository := mock.NewMockProjectRepository()
+
+	todoAssignmentRepository := mock.NewMockTodoAssignmentRepository()
+
+	todoCommentRepository = &countingTodoCommentRepository{MockTodoCommentRepository: mock.NewMockTodoCommentRepository()}
+
+	todoAttachmentRepository := mock.NewMockTodoAttachmentRepository()
+
+	todoSearchRepository := mock.NewMockTodoSearchRepository(todoRepository, todoCommentRepository.MockTodoCommentRepository)
+
+	todoUndoRepository := mock.NewMockTodoUndoRepository()
+
+	todoSyncRepository := mock.NewMockTodoSyncRepository(todoRepository)
+
+	calendarFeedRepository := mock.NewMockCalendarFeedRepository()
+
+	application.
+		SetCreateTodoPersistence(todoRepository).
+		SetListTodoPersistence(todoRepository).
+		SetListTodoPagePersistence(todoRepository).
+		SetGetTodoPersistence(todoRepository).
+		SetUpdateTodoPersistence(todoRepository).
+		SetDeleteTodoPersistence(todoRepository).
+		SetGetTodoBatchPersistence(todoRepository).
+		SetWriteTodoBatchPersistence(todoRepository).
+		SetCreateTodoBatchPersistence(todoRepository).
+		SetCreateUserPersistence(userRepository).
+		SetGetUserPersistence(userRepository).
+		SetUpdateUserPersistence(userRepository).
+		SetDeleteUserPersistence(userRepository).
+		SetListUserPersistence(userRepository).
+		SetCreateSessionPersistence(sessionRepository).
+		SetGetSessionPersistence(sessionRepository).
+		SetDeleteSessionPersistence(sessionRepository).
+		SetCreatePasswordResetPersistence(passwordResetRepository).
+		SetGetPasswordResetPersistence(passwordResetRepository).
+		SetUpdatePasswordResetPersistence(passwordResetRepository).
+		SetCreateTwoFactorPersistence(twoFactorRepository).
+		SetGetTwoFactorPersistence(twoFactorRepository).
+		SetUpdateTwoFactorPersistence(twoFactorRepository).
+		SetDeleteTwoFactorPersistence(twoFactorRepository).
+		SetCreateLoginChallengePersistence(loginChallengeRepository).
+		SetGetLoginChallengePersistence(loginChallengeRepository).
+		SetUpdateLoginChallengePersistence(loginChallengeRepository).
+		SetDeleteLoginChallengePersistence(loginChallengeRepository).
+		SetCreateEmailChangePersistence(emailChangeRepository).
+		SetGetEmailChangePersistence(emailChangeRepository).
+		SetUpdateEmailChangePersistence(emailChangeRepository).
+		SetCreateAccountDeletionPersistence(accountDeletionRepository).
+		SetGetAccountDeletionPersistence(accountDeletionRepository).
+		SetDeleteAccountDeletionPersistence(accountDeletionRepository).
+		SetCreateAccessTokenPersistence(accessTokenRepository).
+		SetGetAccessTokenPersistence(accessTokenRepository).
+		SetListAccessTokenPersistence(accessTokenRepository).
+		SetUpdateAccessTokenPersistence(accessTokenRepository).
+		SetDeleteAccessTokenPersistence(accessTokenRepository).
+		SetCreateAuditLogPersistence(auditLogRepository).
+		SetListAuditLogPersistence(auditLogRepository).
+		SetCreateProjectPersistence(projectRepository).
+		SetGetProjectPersistence(projectRepository).
+		SetListProjectPersistence(projectRepository).
+		SetUpdateProjectPersistence(projectRepository).
+		SetDeleteProjectPersistence(projectRepository).
+		SetGetProjectMemberPersistence(projectRepository).
+		SetSaveProjectMemberPersistence(projectRepository).
+		SetDeleteProjectMemberPersistence(projectRepository).
+		SetCreateProjectInvitePersistence(projectRepository).
+		SetGetProjectInvitePersistence(projectRepository).
+		SetUpdateProjectInvitePersistence(projectRepository).
+		SetCreateTodoAssignmentPersistence(todoAssignmentRepository).
+		SetListTodoAssignmentPersistence(todoAssignmentRepository).
+		SetCreateTodoCommentPersistence(todoCommentRepository).
+		SetGetTodoCommentPersistence(todoCommentRepository).
+		SetListTodoCommentPersistence(todoCommentRepository).
+		SetUpdateTodoCommentPersistence(todoCommentRepository).
+		SetDeleteTodoCommentPersistence(todoCommentRepository).
+		SetCreateTodoAttachmentPersistence(todoAttachmentRepository).
+		SetGetTodoAttachmentPersistence(todoAttachmentRepository).
+		SetListTodoAttachmentPersistence(todoAttachmentRepository).
+		SetDeleteTodoAttachmentPersistence(todoAttachmentRepository).
+		SetSearchTodoPersistence(todoSearchRepository).
+		SetCreateTodoUndoPersistence(todoUndoRepository).
+		SetGetTodoUndoPersistence(todoUndoRepository).
+		SetUpdateTodoUndoPersistence(todoUndoRepository).
+		SetListTodoChangePersistence(todoSyncRepository).
+		SetGetTodoRevisionPersistence(todoSyncRepository).
+		SetGetTodoSyncOperationPersistence(todoSyncRepository).
+		SetWriteTodoSyncPersistence(todoSyncRepository).
+		SetSaveCalendarFeedPersistence(calendarFeedRepository).
+		SetGetCalendarFeedPersistence(calendarFeedRepository).
+		SetDeleteCalendarFeedPersistence(calendarFeedRepository).
+		SetListTodoBatchPersistence(todoRepository).
+		SetListTodoCommentBatchPersistence(todoCommentRepository).
+		SetGetUserBatchPersistence(userRepository).
+		SetListProjectMemberBatchPersistence(projectRepository).
+		SetPasswordHasher(password.NewBycryptPasswordHasher()).
+		SetTokenGenerator(token.NewRandomTokenGenerator()).
+		SetMailer(mailer.NewMemoryMailer()).
+		SetTotpProvider(totp.NewRfc6238Provider("GoDo")).
+		SetBlobStorage(storage.NewMemoryBlobStorage()).
+		SetTodoEventBus(event.NewMemoryTodoEventBus())
+
+	server = graph.NewServer(application)
+})
+
+// Result of request.
+type result struct {
+	Data map[string]any
+	Errors []struct {
+		Message string
+		Extensions map[string]any
+	}
+}
+
+// Codes of errors in result.
+func (r *result) Codes() []any {
+
+	codes := make([]any, len(r.Errors))
+
+	for i, err := range r.Errors {
+		codes[i] = err.Extensions["code"]
+	}
+
+	return codes
+}
+
+// Run query authenticated by token, anonymous if token is empty.
+func exec(token string, query string, variables map[string]any) *result {
+
+	var principal *dto.PrincipalDto
+
+	if token != "" {
+
+		var err error
+
+		principal, err = application.AuthenticateUsecase().Authenticate(token)
+
+		Expect(err).To(BeNil())
+	}
+
+	res := server.Exec(context.Background(), principal, &graph.Request{Query: query, Variables: variables})
+
+	body, err := json.Marshal(res)
+
+	Expect(err).To(BeNil())
+
+	r := &result{}
+
+	Expect(json.Unmarshal(body, r)).To(Succeed())
+
+	return r
+}
+
+// Sign up and log in, returning user ID and session token.
+func signUpAndLogin(username string, email string) (string, string) {
+
+	res := exec("", `mutation($username: String!, $email: String!) { signUp(username: $username, email: $email, password: "password") }`, map[string]any{
+		"username": username,
+		"email": email,
+	})
+
+	Expect(res.Errors).To(BeEmpty())
+
+	res = exec("", `mutation($email: String!) { login(email: $email, password: "password") { token userId } }`, map[string]any{
+		"email": email,
+	})
+
+	Expect(res.Errors).To(BeEmpty())
+
+	login := res.Data["login"].(map[string]any)
+
+	Expect(login["token"]).ToNot(BeEmpty())
+
+	return login["userId"].(string), login["token"].(string)
+}
+
+var _ = Describe("GraphQL test", Ordered, func() {
+
+	var userId string
+	var session string
+
+	BeforeAll(func() {
+		userId, session = signUpAndLogin("graphql", "graphql@example.com")
+	})
+
+	It("should require authentication", func() {
+
+		res := exec("", `{ me { id } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeUnauthenticated}))
+	})
+
+	It("should refuse bad credentials", func() {
+
+		res := exec("", `mutation { login(email: "graphql@example.com", password: "wrong") { token } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeUnauthenticated}))
+	})
+
+	It("should resolve nested fields", func() {
+
+		res := exec(session, `mutation { createProject(name: "graphql project") { id } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		projectId := res.Data["createProject"].(map[string]any)["id"]
+
+		for _, title := range []string{"first", "second", "third"} {
+
+			res = exec(session, `mutation($title: String!, $projectId: ID) { addTodo(title: $title, description: "in project", projectId: $projectId) { undoToken } }`, map[string]any{
+				"title": title,
+				"projectId": projectId,
+			})
+
+			Expect(res.Errors).To(BeEmpty())
+		}
+
+		res = exec(session, `{ project(id: "` + projectId.(string) + `") { todos { id } } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		for _, todo := range res.Data["project"].(map[string]any)["todos"].([]any) {
+
+			res := exec(session, `mutation($todoId: ID!) { addComment(todoId: $todoId, body: "comment") { id } }`, map[string]any{
+				"todoId": todo.(map[string]any)["id"],
+			})
+
+			Expect(res.Errors).To(BeEmpty())
+		}
+
+		batches := todoCommentRepository.Batches()
+
+		res = exec(session, `{
+			me {
+				id
+				username
+				projects {
+					name
+					members { userId permission }
+					todos {
+						title
+						project { name }
+						comments { authorId authorName body }
+					}
+				}
+			}
+		}`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		me := res.Data["me"].(map[string]any)
+
+		Expect(me["id"]).To(Equal(userId))
+		Expect(me["username"]).To(Equal("graphql"))
+
+		projects := me["projects"].([]any)
+
+		Expect(projects).To(HaveLen(1))
+
+		project := projects[0].(map[string]any)
+
+		Expect(project["name"]).To(Equal("graphql project"))
+		Expect(project["members"]).To(Equal([]any{map[string]any{"userId": userId, "permission": "owner"}}))
+
+		todos := project["todos"].([]any)
+
+		Expect(todos).To(HaveLen(3))
+
+		for _, todo := range todos {
+			Expect(todo.(map[string]any)["project"]).To(Equal(map[string]any{"name": "graphql project"}))
+			Expect(todo.(map[string]any)["comments"]).To(Equal([]any{map[string]any{"authorId": userId, "authorName": "graphql", "body": "comment"}}))
+		}
+
+		// Comments of all todo items are loaded at once.
+		Expect(todoCommentRepository.Batches() - batches).To(Equal(1))
+	})
+
+	It("should hide todo items of other users", func() {
+
+		_, otherSession := signUpAndLogin("graphql-other", "graphql-other@example.com")
+
+		res := exec(otherSession, `mutation { addTodo(title: "other", description: "of other user") { undoToken } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		res = exec(otherSession, `{ me { todos { id } } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		todoId := res.Data["me"].(map[string]any)["todos"].([]any)[0].(map[string]any)["id"].(string)
+
+		res = exec(session, `{ todo(id: "` + todoId + `") { title } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeNotFound}))
+
+		res = exec(session, `mutation { deleteTodo(id: "` + todoId + `") { undoToken } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeNotFound}))
+	})
+
+	It("should refuse bad input", func() {
+
+		res := exec(session, `mutation { addTodo(title: "", description: "no title") { undoToken } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeBadUserInput}))
+
+		res = exec(session, `mutation { addTodo(title: "no description", description: "") { undoToken } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeBadUserInput}))
+	})
+
+	It("should hold personal access tokens to their scopes", func() {
+
+		created, err := application.CreateAccessTokenUsecase().Create(&dto.CreateAccessTokenCommand{
+			UserId: userId,
+			Name: "graphql",
+			Scopes: []string{entity.ScopeProfileRead, entity.ScopeTodosRead},
+		})
+
+		Expect(err).To(BeNil())
+
+		res := exec(created.Token, `{ me { projects { todos { title } } } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+
+		res = exec(created.Token, `mutation { addTodo(title: "by token", description: "by token") { undoToken } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeForbidden}))
+
+		res = exec(created.Token, `{ accessTokens { id } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeForbidden}))
+
+		res = exec(session, `{ users { total } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeForbidden}))
+	})
+
+	It("should refuse too deep queries", func() {
+
+		res := exec(session, `{ me { projects { todos { project { todos { project { todos { project { todos { id } } } } } } } } } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeQueryTooComplex}))
+		Expect(res.Data).To(BeNil())
+	})
+
+	It("should refuse too complex queries", func() {
+
+		projects := `projects { id name todos { id title comments { id todoId authorId authorName body createdAt editedAt } } }`
+
+		res := exec(session, `{ me { a: ` + projects + ` b: ` + projects + ` } }`, nil)
+
+		Expect(res.Codes()).To(Equal([]any{graph.CodeQueryTooComplex}))
+
+		// Introspection does not count.
+		res = exec("", `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`, nil)
+
+		Expect(res.Errors).To(BeEmpty())
+	})
+})
@@ -0,0 +1,512 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+)
+
+func (r *resolver) Todo(ctx context.Context, args struct{ Id graphql.ID }) (*todoResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosRead)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return nil, err
+	}
+
+	todo, err := r.app.GetTodoUsecase().Get(principal.UserId, string(args.Id))
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newTodoResolvers(r.app, principal.UserId, []*dto.TodoItemDto{todo})[0], nil
+}
+
+func (r *resolver) SearchTodos(ctx context.Context, args struct {
+	Query string
+	Limit *int32
+}) ([]*todoSearchResultResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosRead)
+
+	if err != nil {
+		return nil, err
+	}
+
+	command := &dto.SearchTodoCommand{
+		UserId: principal.UserId,
+		Query: args.Query,
+	}
+
+	if args.Limit != nil {
+		command.Limit = int(*args.Limit)
+	}
+
+	results, err := r.app.SearchTodoUsecase().Search(command)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	todos := make([]*dto.TodoItemDto, len(results))
+
+	for i, result := range results {
+		todos[i] = result.Todo
+	}
+
+	todoResolvers := newTodoResolvers(r.app, principal.UserId, todos)
+
+	resolvers := make([]*todoSearchResultResolver, len(results))
+
+	for i, result := range results {
+		resolvers[i] = &todoSearchResultResolver{result, todoResolvers[i]}
+	}
+
+	return resolvers, nil
+}
+
+func (r *resolver) AddTodo(ctx context.Context, args struct {
+	Title string
+	Description string
+	ProjectId *graphql.ID
+}) (*undoableResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("title", args.Title); err != nil {
+		return nil, err
+	}
+
+	command := &dto.AddTodoCommand{
+		UserId: principal.UserId,
+		Title: args.Title,
+		Description: args.Description,
+	}
+
+	if args.ProjectId != nil {
+		command.ProjectId = string(*args.ProjectId)
+	}
+
+	return undoable(r.app.AddTodoUsecase().Add(command))
+}
+
+func (r *resolver) UpdateTodo(ctx context.Context, args struct {
+	Id graphql.ID
+	Title string
+	Description string
+}) (*undoableResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("id", string(args.Id)); err != nil {
+		return nil, err
+	}
+
+	if err := required("title", args.Title); err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.UpdateTodoUsecase().Update(&dto.UpdateTodoCommand{
+		Id: string(args.Id),
+		Title: args.Title,
+		Description: args.Description,
+		UserId: principal.UserId,
+	}))
+}
+
+func (r *resolver) CompleteTodo(ctx context.Context, args struct{ Id graphql.ID }) (*undoableResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.CompleteTodoUsecase().Complete(principal.UserId, string(args.Id)))
+}
+
+func (r *resolver) UncompleteTodo(ctx context.Context, args struct{ Id graphql.ID }) (*undoableResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.UncompleteTodoUsecase().Uncomplete(principal.UserId, string(args.Id)))
+}
+
+func (r *resolver) DeleteTodo(ctx context.Context, args struct{ Id graphql.ID }) (*undoableResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.DeleteTodoUsecase().Delete(principal.UserId, string(args.Id)))
+}
+
+func (r *resolver) AssignTodo(ctx context.Context, args struct{ Id, AssigneeId graphql.ID }) (*undoableResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("assigneeId", string(args.AssigneeId)); err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.AssignTodoUsecase().Assign(&dto.AssignTodoCommand{
+		UserId: principal.UserId,
+		TodoId: string(args.Id),
+		AssigneeId: string(args.AssigneeId),
+	}))
+}
+
+func (r *resolver) UnassignTodo(ctx context.Context, args struct{ Id graphql.ID }) (*undoableResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return undoable(r.app.UnassignTodoUsecase().Unassign(principal.UserId, string(args.Id)))
+}
+
+type batchOperationInput struct {
+	Op string
+	TodoId graphql.ID
+	Title *string
+	Description *string
+	ProjectId *graphql.ID
+}
+
+func (r *resolver) BatchTodos(ctx context.Context, args struct {
+	Atomic bool
+	Operations []*batchOperationInput
+}) (*batchResultResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*dto.BatchTodoOperation, len(args.Operations))
+
+	for i, op := range args.Operations {
+
+		operations[i] = &dto.BatchTodoOperation{
+			Op: op.Op,
+			TodoId: string(op.TodoId),
+			Title: op.Title,
+			Description: op.Description,
+		}
+
+		if op.ProjectId != nil {
+			operations[i].ProjectId = string(*op.ProjectId)
+		}
+	}
+
+	results, undoToken, err := r.app.BatchTodoUsecase().Run(&dto.BatchTodoCommand{
+		UserId: principal.UserId,
+		Atomic: args.Atomic,
+		Operations: operations,
+	})
+
+	if err == validation.ErrBatchAborted {
+		return &batchResultResolver{results, "", false}, nil
+	}
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &batchResultResolver{results, undoToken, true}, nil
+}
+
+func (r *resolver) Undo(ctx context.Context, args struct{ Token string }) (bool, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := required("token", args.Token); err != nil {
+		return false, err
+	}
+
+	if err := r.app.UndoTodoUsecase().Undo(principal.UserId, args.Token); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+// Require todos:write scope and ID of todo item to act on.
+func requireTodoWrite(ctx context.Context, todoId graphql.ID) (*dto.PrincipalDto, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := required("id", string(todoId)); err != nil {
+		return nil, err
+	}
+
+	return principal, nil
+}
+
+type todoResolver struct {
+	todo *dto.TodoItemDto
+	comments *batch[[]*commentResolver]
+	projects *batch[*projectResolver]
+}
+
+// Create resolvers of todo items sharing batches, so fields of all of them are loaded at once.
+func newTodoResolvers(app *app.Application, userId string, todos []*dto.TodoItemDto) []*todoResolver {
+
+	todoIds := make([]string, len(todos))
+
+	projectIds := make([]string, 0)
+
+	for i, todo := range todos {
+
+		todoIds[i] = todo.Id
+
+		if todo.ProjectId != "" {
+			projectIds = append(projectIds, todo.ProjectId)
+		}
+	}
+
+	comments := newBatch(todoIds, func(keys []string) (map[string][]*commentResolver, error) {
+
+		comments, err := app.ListTodoCommentsBatchUsecase().ListMany(userId, keys)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers := make(map[string][]*commentResolver, len(comments))
+
+		for todoId, list := range comments {
+			resolvers[todoId] = newCommentResolvers(list)
+		}
+
+		return resolvers, nil
+	})
+
+	// Projects of user are listed in one call, which covers those of all todo items.
+	projects := newBatch(projectIds, func(keys []string) (map[string]*projectResolver, error) {
+
+		projects, err := app.ListProjectsUsecase().List(userId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers := make(map[string]*projectResolver, len(projects))
+
+		for _, project := range newProjectResolvers(app, userId, projects) {
+			resolvers[project.project.Id] = project
+		}
+
+		return resolvers, nil
+	})
+
+	resolvers := make([]*todoResolver, len(todos))
+
+	for i, todo := range todos {
+		resolvers[i] = &todoResolver{todo, comments, projects}
+	}
+
+	return resolvers
+}
+
+func (r *todoResolver) Id() graphql.ID {
+	return graphql.ID(r.todo.Id)
+}
+
+func (r *todoResolver) Title() string {
+	return r.todo.Title
+}
+
+func (r *todoResolver) Description() string {
+	return r.todo.Description
+}
+
+func (r *todoResolver) Done() bool {
+	return r.todo.IsDone
+}
+
+func (r *todoResolver) UserId() graphql.ID {
+	return graphql.ID(r.todo.UserId)
+}
+
+func (r *todoResolver) AssigneeId() *graphql.ID {
+	return idOf(r.todo.AssigneeId)
+}
+
+func (r *todoResolver) Project(ctx context.Context) (*projectResolver, error) {
+
+	if r.todo.ProjectId == "" {
+		return nil, nil
+	}
+
+	if _, err := requireScopes(ctx, entity.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	project, err := r.projects.get(r.todo.ProjectId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return project, nil
+}
+
+func (r *todoResolver) Comments(ctx context.Context) ([]*commentResolver, error) {
+
+	if _, err := requireScopes(ctx, entity.ScopeTodosRead); err != nil {
+		return nil, err
+	}
+
+	comments, err := r.comments.get(r.todo.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	if comments == nil {
+		return []*commentResolver{}, nil
+	}
+
+	return comments, nil
+}
+
+type todoSearchResultResolver struct {
+	result *dto.TodoSearchResultDto
+	todo *todoResolver
+}
+
+func (r *todoSearchResultResolver) Todo() *todoResolver {
+	return r.todo
+}
+
+func (r *todoSearchResultResolver) Rank() float64 {
+	return r.result.Rank
+}
+
+func (r *todoSearchResultResolver) Snippet() []*snippetFragmentResolver {
+
+	resolvers := make([]*snippetFragmentResolver, len(r.result.Snippet))
+
+	for i, fragment := range r.result.Snippet {
+		resolvers[i] = &snippetFragmentResolver{fragment}
+	}
+
+	return resolvers
+}
+
+type snippetFragmentResolver struct {
+	fragment dto.SnippetFragment
+}
+
+func (r *snippetFragmentResolver) Text() string {
+	return r.fragment.Text
+}
+
+func (r *snippetFragmentResolver) Highlighted() bool {
+	return r.fragment.Highlighted
+}
+
+type undoableResolver struct {
+	undoToken string
+}
+
+// Wrap result of use case returning token to undo it.
+func undoable(undoToken string, err error) (*undoableResolver, error) {
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &undoableResolver{undoToken}, nil
+}
+
+func (r *undoableResolver) UndoToken() *string {
+	return stringOrNil(r.undoToken)
+}
+
+type batchResultResolver struct {
+	results []*dto.BatchTodoResultDto
+	undoToken string
+	applied bool
+}
+
+func (r *batchResultResolver) Applied() bool {
+	return r.applied
+}
+
+func (r *batchResultResolver) Results() []*batchOperationResultResolver {
+
+	resolvers := make([]*batchOperationResultResolver, len(r.results))
+
+	for i, result := range r.results {
+		resolvers[i] = &batchOperationResultResolver{result}
+	}
+
+	return resolvers
+}
+
+func (r *batchResultResolver) UndoToken() *string {
+	return stringOrNil(r.undoToken)
+}
+
+type batchOperationResultResolver struct {
+	result *dto.BatchTodoResultDto
+}
+
+func (r *batchOperationResultResolver) Op() string {
+	return r.result.Op
+}
+
+func (r *batchOperationResultResolver) TodoId() graphql.ID {
+	return graphql.ID(r.result.TodoId)
+}
+
+func (r *batchOperationResultResolver) Error() *string {
+
+	if r.result.Error == nil {
+		return nil
+	}
+
+	message := r.result.Error.Error()
+
+	return &message
+}
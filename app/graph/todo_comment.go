@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+func (r *resolver) AddComment(ctx context.Context, args struct {
+	TodoId graphql.ID
+	Body string
+}) (*commentResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.TodoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := r.app.AddTodoCommentUsecase().Add(&dto.AddTodoCommentCommand{
+		UserId: principal.UserId,
+		TodoId: string(args.TodoId),
+		Body: args.Body,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &commentResolver{comment}, nil
+}
+
+func (r *resolver) EditComment(ctx context.Context, args struct {
+	TodoId graphql.ID
+	CommentId graphql.ID
+	Body string
+}) (*commentResolver, error) {
+
+	principal, err := requireTodoWrite(ctx, args.TodoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := r.app.EditTodoCommentUsecase().Edit(&dto.EditTodoCommentCommand{
+		UserId: principal.UserId,
+		TodoId: string(args.TodoId),
+		CommentId: string(args.CommentId),
+		Body: args.Body,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &commentResolver{comment}, nil
+}
+
+func (r *resolver) DeleteComment(ctx context.Context, args struct{ TodoId, CommentId graphql.ID }) (bool, error) {
+
+	principal, err := requireTodoWrite(ctx, args.TodoId)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.DeleteTodoCommentUsecase().Delete(principal.UserId, string(args.TodoId), string(args.CommentId)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) DeleteAttachment(ctx context.Context, args struct{ TodoId, AttachmentId graphql.ID }) (bool, error) {
+
+	principal, err := requireTodoWrite(ctx, args.TodoId)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.DeleteTodoAttachmentUsecase().Delete(principal.UserId, string(args.TodoId), string(args.AttachmentId)); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+type commentResolver struct {
+	comment *dto.TodoCommentDto
+}
+
+func newCommentResolvers(comments []*dto.TodoCommentDto) []*commentResolver {
+
+	resolvers := make([]*commentResolver, len(comments))
+
+	for i, comment := range comments {
+		resolvers[i] = &commentResolver{comment}
+	}
+
+	return resolvers
+}
+
+func (r *commentResolver) Id() graphql.ID {
+	return graphql.ID(r.comment.Id)
+}
+
+func (r *commentResolver) TodoId() graphql.ID {
+	return graphql.ID(r.comment.TodoId)
+}
+
+func (r *commentResolver) AuthorId() graphql.ID {
+	return graphql.ID(r.comment.AuthorId)
+}
+
+func (r *commentResolver) AuthorName() string {
+	return r.comment.AuthorName
+}
+
+func (r *commentResolver) Body() string {
+	return r.comment.Body
+}
+
+func (r *commentResolver) Mentions() []string {
+
+	if r.comment.Mentions == nil {
+		return []string{}
+	}
+
+	return r.comment.Mentions
+}
+
+func (r *commentResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.comment.CreatedAt}
+}
+
+func (r *commentResolver) EditedAt() *graphql.Time {
+	return timeOf(r.comment.EditedAt)
+}
@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+)
+
+func (r *resolver) Me(ctx context.Context) (*userResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeProfileRead)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.app.GetUserUsecase().Get(principal.UserId)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	if user == nil {
+		return nil, newError(CodeNotFound, "user not found")
+	}
+
+	return &userResolver{r.app, user}, nil
+}
+
+func (r *resolver) UpdateMe(ctx context.Context, args struct{ Username, Email string }) (*updateUserResolver, error) {
+
+	principal, err := requireScopes(ctx, entity.ScopeProfileWrite)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.app.ChangeUserInfoUsecase().ChangeInfo(&dto.UserDto{
+		Id: principal.UserId,
+		UserName: args.Username,
+		Email: args.Email,
+	})
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return &updateUserResolver{result}, nil
+}
+
+func (r *resolver) ChangePassword(ctx context.Context, args struct{ Password, OldPassword string }) (bool, error) {
+
+	principal, err := requireSession(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.app.ChangeUserPasswordUsecase().ChangePassword(principal.UserId, args.Password, args.OldPassword); err != nil {
+		return false, errorOf(err)
+	}
+
+	return true, nil
+}
+
+type userResolver struct {
+	app *app.Application
+	user *dto.UserDto
+}
+
+func (r *userResolver) Id() graphql.ID {
+	return graphql.ID(r.user.Id)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.UserName
+}
+
+func (r *userResolver) Email() string {
+	return r.user.Email
+}
+
+func (r *userResolver) Role() string {
+	return r.user.Role
+}
+
+func (r *userResolver) Disabled() bool {
+	return r.user.IsDisabled
+}
+
+func (r *userResolver) Todos(ctx context.Context) ([]*todoResolver, error) {
+
+	if err := r.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	todos, err := r.app.ListTodoUsecase().List(r.user.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newTodoResolvers(r.app, r.user.Id, todos), nil
+}
+
+func (r *userResolver) AssignedTodos(ctx context.Context) ([]*todoResolver, error) {
+
+	if err := r.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	todos, err := r.app.ListAssignedTodosUsecase().List(r.user.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newTodoResolvers(r.app, r.user.Id, todos), nil
+}
+
+func (r *userResolver) Projects(ctx context.Context) ([]*projectResolver, error) {
+
+	if err := r.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	projects, err := r.app.ListProjectsUsecase().List(r.user.Id)
+
+	if err != nil {
+		return nil, errorOf(err)
+	}
+
+	return newProjectResolvers(r.app, r.user.Id, projects), nil
+}
+
+// Todo items and projects are only listed for authenticated user, as REST API lists them.
+func (r *userResolver) authorize(ctx context.Context) error {
+
+	principal, err := requireScopes(ctx, entity.ScopeTodosRead)
+
+	if err != nil {
+		return err
+	}
+
+	if principal.UserId != r.user.Id {
+		return newError(CodeForbidden, "forbidden")
+	}
+
+	return nil
+}
+
+type updateUserResolver struct {
+	result *dto.ChangeUserInfoResultDto
+}
+
+func (r *updateUserResolver) EmailChangePending() bool {
+	return r.result.EmailChangePending
+}
@@ -0,0 +1,533 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Kinds of calendar components read and written.
+const (
+	KindTodo = "VTODO"
+	KindEvent = "VEVENT"
+)
+
+// Statuses of VTODO.
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusCompleted = "COMPLETED"
+	StatusInProcess = "IN-PROCESS"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	dateFormat = "20060102"
+	dateTimeFormat = "20060102T150405"
+	// Maximum octets of content line before it is folded.
+	lineLength = 75
+)
+
+var ErrInvalidCalendar = errors.New("invalid iCalendar object")
+
+// iCalendar object of RFC 5545.
+type Calendar struct {
+	// Product that created calendar.
+	ProdId string
+	// Display name of calendar. Empty if none.
+	Name string
+	Items []*Item
+}
+
+// VTODO or VEVENT component.
+type Item struct {
+	// KindTodo or KindEvent.
+	Kind string
+	Uid string
+	// Time item was created or last written.
+	Stamp time.Time
+	Summary string
+	Description string
+	// Empty if none.
+	Status string
+	// Start of item. nil if none.
+	Start *DateTime
+	// Due of VTODO or end of VEVENT. nil if none.
+	Due *DateTime
+	// Time VTODO was completed. nil if not completed.
+	Completed *time.Time
+	// Recurrence rule as it is written, e.g. FREQ=WEEKLY;BYDAY=MO. Empty if not recurring.
+	Rrule string
+}
+
+// DATE or DATE-TIME value.
+type DateTime struct {
+	Time time.Time
+	// Date without time of day.
+	AllDay bool
+}
+
+// Write calendar as iCalendar object with CRLF line breaks and folded lines.
+func Write(w io.Writer, cal *Calendar) error {
+
+	out := &writer{w: bufio.NewWriter(w)}
+
+	out.line("BEGIN", nil, "VCALENDAR")
+	out.line("VERSION", nil, "2.0")
+	out.line("PRODID", nil, cal.ProdId)
+	out.line("CALSCALE", nil, "GREGORIAN")
+
+	if cal.Name != "" {
+		out.line("X-WR-CALNAME", nil, escape(cal.Name))
+	}
+
+	for _, item := range cal.Items {
+
+		out.line("BEGIN", nil, item.Kind)
+		out.line("UID", nil, escape(item.Uid))
+		out.line("DTSTAMP", nil, formatUtc(item.Stamp))
+		out.line("SUMMARY", nil, escape(item.Summary))
+
+		if item.Description != "" {
+			out.line("DESCRIPTION", nil, escape(item.Description))
+		}
+
+		if item.Status != "" {
+			out.line("STATUS", nil, item.Status)
+		}
+
+		if item.Start != nil {
+			out.dateTime("DTSTART", item.Start)
+		}
+
+		if item.Due != nil {
+
+			name := "DUE"
+
+			if item.Kind == KindEvent {
+				name = "DTEND"
+			}
+
+			out.dateTime(name, item.Due)
+		}
+
+		if item.Completed != nil {
+			out.line("COMPLETED", nil, formatUtc(*item.Completed))
+		}
+
+		if item.Rrule != "" {
+			out.line("RRULE", nil, item.Rrule)
+		}
+
+		out.line("END", nil, item.Kind)
+	}
+
+	out.line("END", nil, "VCALENDAR")
+
+	if out.err != nil {
+		return out.err
+	}
+
+	return out.w.Flush()
+}
+
+// Write calendar to bytes.
+func Marshal(cal *Calendar) ([]byte, error) {
+
+	var b bytes.Buffer
+
+	if err := Write(&b, cal); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+type writer struct {
+	w *bufio.Writer
+	err error
+}
+
+func (w *writer) dateTime(name string, value *DateTime) {
+
+	if value.AllDay {
+		w.line(name, []string{"VALUE=DATE"}, value.Time.Format(dateFormat))
+		return
+	}
+
+	w.line(name, nil, formatUtc(value.Time))
+}
+
+func (w *writer) line(name string, params []string, value string) {
+
+	if w.err != nil {
+		return
+	}
+
+	line := name
+
+	for _, param := range params {
+		line += ";" + param
+	}
+
+	line += ":" + value
+
+	// Fold at octet boundary without splitting characters.
+	limit := lineLength
+
+	for len(line) > limit {
+
+		cut := limit
+
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		if _, w.err = w.w.WriteString(line[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+
+		line = line[cut:]
+
+		// Leading space of continuation counts to its length.
+		limit = lineLength - 1
+	}
+
+	_, w.err = w.w.WriteString(line + "\r\n")
+}
+
+func formatUtc(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat) + "Z"
+}
+
+// Escape TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// Unescape TEXT value.
+func unescape(s string) string {
+
+	var out strings.Builder
+
+	for i := 0; i < len(s); i++ {
+
+		if s[i] != '\\' || i == len(s) - 1 {
+			out.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 'n', 'N':
+			out.WriteByte('\n')
+		default:
+			out.WriteByte(s[i])
+		}
+	}
+
+	return out.String()
+}
+
+// Property of content line.
+type property struct {
+	name string
+	params map[string]string
+	value string
+}
+
+// Parse first iCalendar object of reader.
+//
+// VTODO and VEVENT components are read with properties this package knows.
+// Other components, including those nested in items such as VALARM, and unknown properties are skipped.
+func Parse(r io.Reader) (*Calendar, error) {
+
+	lines, err := unfold(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cal *Calendar
+	var item *Item
+	// Names of components entered and not yet ended.
+	var open []string
+
+	for n, line := range lines {
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		prop, ok := parseLine(line)
+
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed line %d", ErrInvalidCalendar, n + 1)
+		}
+
+		switch prop.name {
+
+		case "BEGIN":
+
+			component := strings.ToUpper(prop.value)
+
+			if cal == nil {
+
+				if component != "VCALENDAR" {
+					return nil, fmt.Errorf("%w: VCALENDAR expected at line %d", ErrInvalidCalendar, n + 1)
+				}
+
+				cal = &Calendar{Items: make([]*Item, 0)}
+			} else if len(open) == 1 && (component == KindTodo || component == KindEvent) {
+				item = &Item{Kind: component}
+			}
+
+			open = append(open, component)
+
+			continue
+
+		case "END":
+
+			if len(open) == 0 || open[len(open) - 1] != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: unexpected END at line %d", ErrInvalidCalendar, n + 1)
+			}
+
+			open = open[:len(open) - 1]
+
+			if len(open) == 1 && item != nil {
+				cal.Items = append(cal.Items, item)
+				item = nil
+			}
+
+			if len(open) == 0 {
+				return cal, nil
+			}
+
+			continue
+		}
+
+		if cal == nil {
+			return nil, fmt.Errorf("%w: VCALENDAR expected at line %d", ErrInvalidCalendar, n + 1)
+		}
+
+		if len(open) == 1 {
+			switch prop.name {
+			case "PRODID":
+				cal.ProdId = prop.value
+			case "X-WR-CALNAME":
+				cal.Name = unescape(prop.value)
+			}
+			continue
+		}
+
+		// Properties of nested components belong to them, not to item.
+		if item == nil || len(open) != 2 {
+			continue
+		}
+
+		if err := item.set(prop); err != nil {
+			return nil, fmt.Errorf("%w: %s at line %d: %v", ErrInvalidCalendar, prop.name, n + 1, err)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: VCALENDAR not ended", ErrInvalidCalendar)
+}
+
+func (item *Item) set(prop *property) error {
+
+	switch prop.name {
+
+	case "UID":
+		item.Uid = unescape(prop.value)
+
+	case "DTSTAMP":
+
+		stamp, err := parseDateTime(prop)
+
+		if err != nil {
+			return err
+		}
+
+		item.Stamp = stamp.Time
+
+	case "SUMMARY":
+		item.Summary = unescape(prop.value)
+
+	case "DESCRIPTION":
+		item.Description = unescape(prop.value)
+
+	case "STATUS":
+		item.Status = strings.ToUpper(prop.value)
+
+	case "DTSTART":
+
+		start, err := parseDateTime(prop)
+
+		if err != nil {
+			return err
+		}
+
+		item.Start = start
+
+	case "DUE", "DTEND":
+
+		due, err := parseDateTime(prop)
+
+		if err != nil {
+			return err
+		}
+
+		item.Due = due
+
+	case "COMPLETED":
+
+		completed, err := parseDateTime(prop)
+
+		if err != nil {
+			return err
+		}
+
+		item.Completed = &completed.Time
+
+	case "RRULE":
+		item.Rrule = prop.value
+	}
+
+	return nil
+}
+
+func parseDateTime(prop *property) (*DateTime, error) {
+
+	if prop.params["VALUE"] == "DATE" || len(prop.value) == len(dateFormat) {
+
+		t, err := time.ParseInLocation(dateFormat, prop.value, time.UTC)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &DateTime{t, true}, nil
+	}
+
+	if strings.HasSuffix(prop.value, "Z") {
+
+		t, err := time.ParseInLocation(dateTimeFormat, strings.TrimSuffix(prop.value, "Z"), time.UTC)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &DateTime{t, false}, nil
+	}
+
+	// Floating time, or local time of TZID. Unknown zones are read as UTC.
+	location := time.UTC
+
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			location = l
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeFormat, prop.value, location)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DateTime{t, false}, nil
+}
+
+// Read content lines, joining folded ones. Both CRLF and LF line breaks are accepted.
+func unfold(r io.Reader) ([]string, error) {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1 << 20)
+
+	lines := make([]string, 0)
+
+	for scanner.Scan() {
+
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines) - 1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// Split content line into name, parameters and value.
+func parseLine(line string) (*property, bool) {
+
+	quoted := false
+	colon := -1
+
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+
+	if colon <= 0 {
+		return nil, false
+	}
+
+	parts := splitParams(line[:colon])
+
+	prop := &property{
+		name: strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts) - 1),
+		value: line[colon + 1:],
+	}
+
+	for _, param := range parts[1:] {
+
+		name, value, ok := strings.Cut(param, "=")
+
+		if !ok {
+			return nil, false
+		}
+
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return prop, true
+}
+
+// Split name and parameters at semicolons out of quotes.
+func splitParams(s string) []string {
+
+	parts := make([]string, 0)
+	quoted := false
+	start := 0
+
+	for i, c := range s {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ';' && !quoted {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
@@ -0,0 +1,175 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	// Zones of TZID, even where system has none.
+	_ "time/tzdata"
+
+	"github.com/kkatou7209/godo/ical"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIcal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "iCalendar test.")
+}
+
+var _ = Describe("iCalendar test", func() {
+
+	stamp := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	completed := time.Date(2026, 10, 2, 18, 0, 0, 0, time.UTC)
+
+	It("should round trip calendar", func() {
+
+		cal := &ical.Calendar{
+			ProdId: "-//GoDo//GoDo//EN",
+			Name: "GoDo, todos; mine",
+			Items: []*ical.Item{
+				{
+					Kind: ical.KindTodo,
+					Uid: "todo-1",
+					Stamp: stamp,
+					Summary: `Buy milk, eggs; and \ butter`,
+					Description: "first line\nsecond line",
+					Status: ical.StatusCompleted,
+					Due: &ical.DateTime{Time: time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), AllDay: true},
+					Completed: &completed,
+					Rrule: "FREQ=WEEKLY;BYDAY=MO,WE",
+				},
+				{
+					Kind: ical.KindEvent,
+					Uid: "event-1",
+					Stamp: stamp,
+					Summary: "Meeting",
+					Start: &ical.DateTime{Time: time.Date(2026, 10, 4, 10, 0, 0, 0, time.UTC)},
+					Due: &ical.DateTime{Time: time.Date(2026, 10, 4, 11, 0, 0, 0, time.UTC)},
+				},
+			},
+		}
+
+		b, err := ical.Marshal(cal)
+
+		Expect(err).To(BeNil())
+
+		parsed, err := ical.Parse(strings.NewReader(string(b)))
+
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(cal))
+	})
+
+	It("should escape text and use CRLF", func() {
+
+		b, err := ical.Marshal(&ical.Calendar{
+			ProdId: "-//GoDo//GoDo//EN",
+			Items: []*ical.Item{{Kind: ical.KindTodo, Uid: "todo-1", Stamp: stamp, Summary: "a,b;c\\d\ne"}},
+		})
+
+		Expect(err).To(BeNil())
+
+		out := string(b)
+
+		Expect(out).To(HavePrefix("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		Expect(out).To(ContainSubstring("\r\nSUMMARY:a\\,b\\;c\\\\d\\ne\r\n"))
+		Expect(out).To(ContainSubstring("\r\nDTSTAMP:20261001T093000Z\r\n"))
+		Expect(out).To(HaveSuffix("END:VTODO\r\nEND:VCALENDAR\r\n"))
+	})
+
+	It("should fold long lines without splitting characters", func() {
+
+		summary := strings.Repeat("とても長いタイトル", 20)
+
+		b, err := ical.Marshal(&ical.Calendar{
+			ProdId: "-//GoDo//GoDo//EN",
+			Items: []*ical.Item{{Kind: ical.KindTodo, Uid: "todo-1", Stamp: stamp, Summary: summary}},
+		})
+
+		Expect(err).To(BeNil())
+
+		for _, line := range strings.Split(strings.TrimSuffix(string(b), "\r\n"), "\r\n") {
+			Expect(len(line)).To(BeNumerically("<=", 75))
+			Expect(strings.ToValidUTF8(line, "?")).To(Equal(line))
+		}
+
+		parsed, err := ical.Parse(strings.NewReader(string(b)))
+
+		Expect(err).To(BeNil())
+		Expect(parsed.Items[0].Summary).To(Equal(summary))
+	})
+
+	It("should parse calendars of other applications", func() {
+
+		source := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Other//Other//EN",
+			"BEGIN:VTIMEZONE",
+			"TZID:Asia/Tokyo",
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:+0900",
+			"TZOFFSETTO:+0900",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+			"BEGIN:VEVENT",
+			"UID:event-1@other",
+			"DTSTAMP:20261001T000000Z",
+			"DTSTART;TZID=Asia/Tokyo:20261005T090000",
+			"DTEND;TZID=\"Asia/Tokyo\":20261005T100000",
+			"summary:Stand",
+			" up",
+			"DESCRIPTION;LANGUAGE=en:Daily\\Nsync",
+			"X-UNKNOWN;X-PARAM=\"a:b;c\":ignored",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:Reminder",
+			"END:VALARM",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:todo-1@other",
+			"DTSTAMP:20261001T000000Z",
+			"SUMMARY:Pay rent",
+			"DUE;VALUE=DATE:20261031",
+			"STATUS:needs-action",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\n")
+
+		cal, err := ical.Parse(strings.NewReader(source))
+
+		Expect(err).To(BeNil())
+		Expect(cal.ProdId).To(Equal("-//Other//Other//EN"))
+		Expect(cal.Items).To(HaveLen(2))
+
+		event := cal.Items[0]
+
+		Expect(event.Kind).To(Equal(ical.KindEvent))
+		Expect(event.Summary).To(Equal("Standup"))
+		Expect(event.Description).To(Equal("Daily\nsync"))
+		Expect(event.Start.Time).To(BeTemporally("==", time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)))
+		Expect(event.Due.Time).To(BeTemporally("==", time.Date(2026, 10, 5, 1, 0, 0, 0, time.UTC)))
+
+		todo := cal.Items[1]
+
+		Expect(todo.Kind).To(Equal(ical.KindTodo))
+		Expect(todo.Status).To(Equal(ical.StatusNeedsAction))
+		Expect(todo.Due).To(Equal(&ical.DateTime{Time: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), AllDay: true}))
+	})
+
+	It("should refuse malformed calendars", func() {
+
+		for _, source := range []string{
+			"",
+			"BEGIN:VTODO\r\nEND:VTODO\r\n",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n",
+			"BEGIN:VCALENDAR\r\nno colon\r\nEND:VCALENDAR\r\n",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nDUE:tomorrow\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VTODO\r\n",
+		} {
+			_, err := ical.Parse(strings.NewReader(source))
+			Expect(err).To(MatchError(ical.ErrInvalidCalendar), source)
+		}
+	})
+})
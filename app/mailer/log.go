@@ -0,0 +1,21 @@
+package mailer
+
+import (
+	"log"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Mailer writing mails to log instead of sending them. For development.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(to value.Email, subject string, body string) error {
+
+	log.Printf("mail to %s: %s\n%s", to.Value(), subject, body)
+
+	return nil
+}
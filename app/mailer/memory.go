@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Mail sent by MemoryMailer.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer keeping sent mails in memory.
+type MemoryMailer struct {
+	mails []Mail
+	mu    sync.Mutex
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{
+		mails: make([]Mail, 0),
+		mu:    sync.Mutex{},
+	}
+}
+
+func (m *MemoryMailer) Send(to value.Email, subject string, body string) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, Mail{to.Value(), subject, body})
+
+	return nil
+}
+
+// Get last mail sent to address. nil if none.
+func (m *MemoryMailer) LastMailTo(to string) *Mail {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.mails) - 1; i >= 0; i-- {
+		if m.mails[i].To == to {
+			mail := m.mails[i]
+			return &mail
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+// Mailer sending mails through SMTP server.
+type SmtpMailer struct {
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+func NewSmtpMailer(addr string, from string, username string, password string) *SmtpMailer {
+	return &SmtpMailer{addr, from, username, password}
+}
+
+func (m *SmtpMailer) Send(to value.Email, subject string, body string) error {
+
+	var auth smtp.Auth
+
+	if m.username != "" {
+
+		host, _, err := net.SplitHostPort(m.addr)
+
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", m.username, m.password, host)
+	}
+
+	msg := strings.Join([]string{
+		fmt.Sprintf("From: %s", m.from),
+		fmt.Sprintf("To: %s", to.Value()),
+		fmt.Sprintf("Subject: %s", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(m.addr, auth, m.from, []string{to.Value()}, []byte(msg))
+}
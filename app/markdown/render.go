@@ -0,0 +1,149 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	linkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern = regexp.MustCompile(`\*(.+?)\*`)
+	listItemPattern = regexp.MustCompile(`^[-*] `)
+	// Link schemes allowed in rendered HTML.
+	safeSchemes = []string{"http://", "https://", "mailto:"}
+)
+
+// Render Markdown subset to HTML safe to embed in pages.
+//
+// Supported are paragraphs, line breaks, unordered lists, **bold**, *italic*,
+// `code` and [links](https://example.com). Any HTML in source is escaped,
+// and links with schemes other than http, https and mailto are rendered as text.
+func Render(source string) string {
+
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+
+	var out strings.Builder
+
+	for _, block := range strings.Split(source, "\n\n") {
+
+		block = strings.Trim(block, "\n")
+
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		lines := strings.Split(block, "\n")
+
+		if isList(lines) {
+
+			out.WriteString("<ul>")
+
+			for _, line := range lines {
+				out.WriteString("<li>" + renderInline(line[2:]) + "</li>")
+			}
+
+			out.WriteString("</ul>")
+
+			continue
+		}
+
+		rendered := make([]string, len(lines))
+
+		for i, line := range lines {
+			rendered[i] = renderInline(line)
+		}
+
+		out.WriteString("<p>" + strings.Join(rendered, "<br>") + "</p>")
+	}
+
+	return out.String()
+}
+
+func isList(lines []string) bool {
+
+	for _, line := range lines {
+		if !listItemPattern.MatchString(line) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func renderInline(text string) string {
+
+	// Odd parts are inside backticks.
+	parts := strings.Split(text, "`")
+
+	// Unpaired backtick is kept as is.
+	if len(parts) % 2 == 0 {
+		parts[len(parts) - 2] += "`" + parts[len(parts) - 1]
+		parts = parts[:len(parts) - 1]
+	}
+
+	var out strings.Builder
+
+	for i, part := range parts {
+
+		escaped := html.EscapeString(part)
+
+		if i % 2 == 1 {
+			out.WriteString("<code>" + escaped + "</code>")
+			continue
+		}
+
+		out.WriteString(renderEmphasis(escaped))
+	}
+
+	return out.String()
+}
+
+// Links are found first, so emphasis is applied to text around and inside them but never to URLs.
+func renderEmphasis(escaped string) string {
+
+	var out strings.Builder
+
+	last := 0
+
+	for _, m := range linkPattern.FindAllStringSubmatchIndex(escaped, -1) {
+
+		out.WriteString(renderStyle(escaped[last:m[0]]))
+
+		text := renderStyle(escaped[m[2]:m[3]])
+		url := escaped[m[4]:m[5]]
+
+		if isSafeUrl(html.UnescapeString(url)) {
+			out.WriteString(`<a href="` + url + `" rel="nofollow noopener">` + text + `</a>`)
+		} else {
+			out.WriteString(text)
+		}
+
+		last = m[1]
+	}
+
+	out.WriteString(renderStyle(escaped[last:]))
+
+	return out.String()
+}
+
+func renderStyle(escaped string) string {
+
+	escaped = boldPattern.ReplaceAllString(escaped, "<strong>$1</strong>")
+
+	return italicPattern.ReplaceAllString(escaped, "<em>$1</em>")
+}
+
+func isSafeUrl(url string) bool {
+
+	lower := strings.ToLower(url)
+
+	for _, scheme := range safeSchemes {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/kkatou7209/godo/markdown"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMarkdown(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Markdown test.")
+}
+
+var _ = Describe("Markdown renderer test", func() {
+
+	It("should render paragraphs and line breaks", func() {
+		Expect(markdown.Render("first\nline\n\nsecond")).To(Equal("<p>first<br>line</p><p>second</p>"))
+	})
+
+	It("should render emphasis and code", func() {
+		Expect(markdown.Render("**bold** *italic* `*code*`")).
+			To(Equal("<p><strong>bold</strong> <em>italic</em> <code>*code*</code></p>"))
+	})
+
+	It("should render lists", func() {
+		Expect(markdown.Render("- one\n* two")).To(Equal("<ul><li>one</li><li>two</li></ul>"))
+	})
+
+	It("should render safe links only", func() {
+		Expect(markdown.Render("[GoDo](https://example.com/?a=1&b=2)")).
+			To(Equal(`<p><a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener">GoDo</a></p>`))
+		Expect(markdown.Render("[click](javascript:alert(1))")).To(Equal("<p>click)</p>"))
+	})
+
+	It("should not render emphasis inside link URLs", func() {
+		Expect(markdown.Render("[b](https://x.com/*y*) *z*")).
+			To(Equal(`<p><a href="https://x.com/*y*" rel="nofollow noopener">b</a> <em>z</em></p>`))
+		Expect(markdown.Render("[**b**](https://x.com/a**b**c)")).
+			To(Equal(`<p><a href="https://x.com/a**b**c" rel="nofollow noopener"><strong>b</strong></a></p>`))
+	})
+
+	It("should escape HTML", func() {
+		Expect(markdown.Render(`<script>alert("x")</script>`)).
+			To(Equal("<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>"))
+		Expect(markdown.Render("`<b>`")).To(Equal("<p><code>&lt;b&gt;</code></p>"))
+	})
+})
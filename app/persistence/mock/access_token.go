@@ -0,0 +1,107 @@
+package mock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockAccessTokenRepository struct {
+	tokens map[string]*entity.AccessToken
+	mu sync.Mutex
+}
+
+func NewMockAccessTokenRepository() *MockAccessTokenRepository {
+	return &MockAccessTokenRepository{
+		tokens: make(map[string]*entity.AccessToken),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockAccessTokenRepository) Create(token *dto.CreateAccessTokenCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := entity.NewAccessToken(
+		uuid.NewString(),
+		token.UserId,
+		token.Name,
+		token.TokenHash,
+		token.Scopes,
+		time.Now(),
+		token.ExpiresAt,
+		nil,
+	)
+
+	r.tokens[t.Id()] = t
+
+	return nil
+}
+
+func (r *MockAccessTokenRepository) Get(id string) (*entity.AccessToken, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tokens[id], nil
+}
+
+func (r *MockAccessTokenRepository) GetByTokenHash(tokenHash string) (*entity.AccessToken, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.tokens {
+		if t.TokenHash() == tokenHash {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockAccessTokenRepository) List(userId value.UserId) ([]*entity.AccessToken, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.AccessToken, 0)
+
+	for _, t := range r.tokens {
+		if t.UserId() == userId {
+			ts = append(ts, t)
+		}
+	}
+
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i].CreatedAt().Before(ts[j].CreatedAt())
+	})
+
+	return ts, nil
+}
+
+func (r *MockAccessTokenRepository) Update(token *entity.AccessToken) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.Id()] = token
+
+	return nil
+}
+
+func (r *MockAccessTokenRepository) Delete(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tokens, id)
+
+	return nil
+}
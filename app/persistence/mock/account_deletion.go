@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockAccountDeletionRepository struct {
+	deletions map[value.UserId]*entity.AccountDeletion
+	mu sync.Mutex
+}
+
+func NewMockAccountDeletionRepository() *MockAccountDeletionRepository {
+	return &MockAccountDeletionRepository{
+		deletions: make(map[value.UserId]*entity.AccountDeletion),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockAccountDeletionRepository) Create(deletion *dto.CreateAccountDeletionCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deletions[deletion.UserId] = entity.NewAccountDeletion(
+		deletion.UserId,
+		deletion.RequestedAt,
+		deletion.ScheduledAt,
+	)
+
+	return nil
+}
+
+func (r *MockAccountDeletionRepository) Get(userId value.UserId) (*entity.AccountDeletion, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deletions[userId], nil
+}
+
+func (r *MockAccountDeletionRepository) ListDue(now time.Time) ([]*entity.AccountDeletion, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ds := make([]*entity.AccountDeletion, 0)
+
+	for _, d := range r.deletions {
+		if d.IsDue(now) {
+			ds = append(ds, d)
+		}
+	}
+
+	return ds, nil
+}
+
+func (r *MockAccountDeletionRepository) Delete(userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.deletions, userId)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockAuditLogRepository struct {
+	logs []*entity.AuditLog
+	mu sync.Mutex
+}
+
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{
+		logs: make([]*entity.AuditLog, 0),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockAuditLogRepository) Create(log *dto.CreateAuditLogCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, entity.NewAuditLog(
+		uuid.NewString(),
+		log.ActorId,
+		log.Action,
+		log.TargetUserId,
+		log.Detail,
+		time.Now(),
+	))
+
+	return nil
+}
+
+func (r *MockAuditLogRepository) List(page dto.PageQuery) ([]*entity.AuditLog, int, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := len(r.logs)
+
+	ls := make([]*entity.AuditLog, 0)
+
+	// Newest first.
+	for i := total - 1 - page.Offset; i >= 0 && len(ls) < page.Limit; i-- {
+		ls = append(ls, r.logs[i])
+	}
+
+	return ls, total, nil
+}
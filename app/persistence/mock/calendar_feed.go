@@ -0,0 +1,62 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type MockCalendarFeedRepository struct {
+	feeds map[value.UserId]*entity.CalendarFeed
+	mu sync.Mutex
+}
+
+func NewMockCalendarFeedRepository() *MockCalendarFeedRepository {
+	return &MockCalendarFeedRepository{
+		feeds: make(map[value.UserId]*entity.CalendarFeed),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockCalendarFeedRepository) Save(feed *entity.CalendarFeed) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.feeds[feed.UserId()] = feed
+
+	return nil
+}
+
+func (r *MockCalendarFeedRepository) Get(userId value.UserId) (*entity.CalendarFeed, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.feeds[userId], nil
+}
+
+func (r *MockCalendarFeedRepository) GetByTokenHash(tokenHash string) (*entity.CalendarFeed, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.feeds {
+		if f.TokenHash() == tokenHash {
+			return f, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockCalendarFeedRepository) Delete(userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.feeds, userId)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockEmailChangeRepository struct {
+	changes map[string]*entity.EmailChange
+	mu sync.Mutex
+}
+
+func NewMockEmailChangeRepository() *MockEmailChangeRepository {
+	return &MockEmailChangeRepository{
+		changes: make(map[string]*entity.EmailChange),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockEmailChangeRepository) Create(change *dto.CreateEmailChangeCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := entity.NewEmailChange(
+		uuid.NewString(),
+		change.UserId,
+		change.OldEmail,
+		change.NewEmail,
+		change.ConfirmTokenHash,
+		change.RevertTokenHash,
+		change.ExpiresAt,
+		change.RevertibleUntil,
+		nil,
+		nil,
+	)
+
+	r.changes[c.Id()] = c
+
+	return nil
+}
+
+func (r *MockEmailChangeRepository) GetByConfirmTokenHash(tokenHash string) (*entity.EmailChange, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.changes {
+		if c.ConfirmTokenHash() == tokenHash {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockEmailChangeRepository) GetByRevertTokenHash(tokenHash string) (*entity.EmailChange, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.changes {
+		if c.RevertTokenHash() == tokenHash {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockEmailChangeRepository) Update(change *entity.EmailChange) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes[change.Id()] = change
+
+	return nil
+}
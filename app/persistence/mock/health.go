@@ -0,0 +1,32 @@
+package mock
+
+import "sync"
+
+type MockHealthRepository struct {
+	err error
+	mu sync.Mutex
+}
+
+func NewMockHealthRepository() *MockHealthRepository {
+	return &MockHealthRepository{
+		err: nil,
+		mu: sync.Mutex{},
+	}
+}
+
+// Make health checks fail with err, or pass again with nil.
+func (r *MockHealthRepository) SetError(err error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
+
+func (r *MockHealthRepository) CheckHealth() error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
@@ -0,0 +1,79 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockLoginChallengeRepository struct {
+	challenges map[string]*entity.LoginChallenge
+	mu sync.Mutex
+}
+
+func NewMockLoginChallengeRepository() *MockLoginChallengeRepository {
+	return &MockLoginChallengeRepository{
+		challenges: make(map[string]*entity.LoginChallenge),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockLoginChallengeRepository) Create(challenge *dto.CreateLoginChallengeCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.challenges[challenge.TokenHash] = entity.NewLoginChallenge(
+		challenge.TokenHash,
+		challenge.UserId,
+		challenge.ExpiresAt,
+		0,
+	)
+
+	return nil
+}
+
+func (r *MockLoginChallengeRepository) Get(tokenHash string) (*entity.LoginChallenge, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge, ok := r.challenges[tokenHash]
+
+	if !ok {
+		return nil, nil
+	}
+
+	// Copy, so that failing it does not change stored one.
+	return entity.NewLoginChallenge(challenge.TokenHash(), challenge.UserId(), challenge.ExpiresAt(), challenge.FailedAttempts()), nil
+}
+
+func (r *MockLoginChallengeRepository) Fail(tokenHash string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if challenge, ok := r.challenges[tokenHash]; ok {
+		challenge.Fail()
+	}
+
+	return nil
+}
+
+func (r *MockLoginChallengeRepository) Delete(tokenHash string, now time.Time) (bool, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge, ok := r.challenges[tokenHash]
+
+	if !ok || !challenge.IsUsable(now) {
+		return false, nil
+	}
+
+	delete(r.challenges, tokenHash)
+
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockPasswordResetRepository struct {
+	resets map[string]*entity.PasswordReset
+	mu sync.Mutex
+}
+
+func NewMockPasswordResetRepository() *MockPasswordResetRepository {
+	return &MockPasswordResetRepository{
+		resets: make(map[string]*entity.PasswordReset),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockPasswordResetRepository) Create(reset *dto.CreatePasswordResetCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resets[reset.TokenHash] = entity.NewPasswordReset(
+		reset.TokenHash,
+		reset.UserId,
+		reset.ExpiresAt,
+		nil,
+	)
+
+	return nil
+}
+
+func (r *MockPasswordResetRepository) Get(tokenHash string) (*entity.PasswordReset, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reset, ok := r.resets[tokenHash]
+
+	if !ok {
+		return nil, nil
+	}
+
+	// Copy, so that using it does not use stored one before Update.
+	return entity.NewPasswordReset(reset.TokenHash(), reset.UserId(), reset.ExpiresAt(), reset.UsedAt()), nil
+}
+
+func (r *MockPasswordResetRepository) Update(reset *entity.PasswordReset) (bool, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.resets[reset.TokenHash()]
+
+	if !ok || reset.UsedAt() == nil || stored.UsedAt() != nil || !stored.ExpiresAt().After(*reset.UsedAt()) {
+		return false, nil
+	}
+
+	r.resets[reset.TokenHash()] = entity.NewPasswordReset(reset.TokenHash(), reset.UserId(), reset.ExpiresAt(), reset.UsedAt())
+
+	return true, nil
+}
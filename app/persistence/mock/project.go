@@ -0,0 +1,210 @@
+package mock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockProjectRepository struct {
+	projects map[string]*entity.Project
+	members map[string]map[value.UserId]*entity.ProjectMember
+	invites map[string]*entity.ProjectInvite
+	mu sync.Mutex
+}
+
+func NewMockProjectRepository() *MockProjectRepository {
+	return &MockProjectRepository{
+		projects: make(map[string]*entity.Project),
+		members: make(map[string]map[value.UserId]*entity.ProjectMember),
+		invites: make(map[string]*entity.ProjectInvite),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockProjectRepository) Create(project *dto.CreateProjectCommand) (*entity.Project, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := entity.NewProject(uuid.NewString(), project.Name, time.Now())
+
+	r.projects[p.Id()] = p
+
+	r.members[p.Id()] = map[value.UserId]*entity.ProjectMember{
+		project.OwnerId: entity.NewProjectMember(p.Id(), project.OwnerId, value.PermissionOwner),
+	}
+
+	return p, nil
+}
+
+func (r *MockProjectRepository) Get(projectId string) (*entity.Project, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projects[projectId], nil
+}
+
+func (r *MockProjectRepository) List(userId value.UserId) ([]*entity.Project, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ps := make([]*entity.Project, 0)
+
+	for id, members := range r.members {
+		if _, ok := members[userId]; ok {
+			ps = append(ps, r.projects[id])
+		}
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].CreatedAt().Before(ps[j].CreatedAt())
+	})
+
+	return ps, nil
+}
+
+func (r *MockProjectRepository) Update(project *entity.Project) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[project.Id()] = project
+
+	return nil
+}
+
+func (r *MockProjectRepository) Delete(projectId string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.projects, projectId)
+	delete(r.members, projectId)
+
+	for id, invite := range r.invites {
+		if invite.ProjectId() == projectId {
+			delete(r.invites, id)
+		}
+	}
+
+	return nil
+}
+
+func (r *MockProjectRepository) GetMember(projectId string, userId value.UserId) (*entity.ProjectMember, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.members[projectId][userId], nil
+}
+
+func (r *MockProjectRepository) ListMembers(projectId string) ([]*entity.ProjectMember, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ms := make([]*entity.ProjectMember, 0)
+
+	for _, m := range r.members[projectId] {
+		ms = append(ms, m)
+	}
+
+	sort.Slice(ms, func(i, j int) bool {
+		return ms[i].UserId().Value() < ms[j].UserId().Value()
+	})
+
+	return ms, nil
+}
+
+func (r *MockProjectRepository) ListMembersOfProjects(projectIds []string) ([]*entity.ProjectMember, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ms := make([]*entity.ProjectMember, 0)
+
+	for _, projectId := range projectIds {
+		for _, m := range r.members[projectId] {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms, nil
+}
+
+func (r *MockProjectRepository) SaveMember(member *entity.ProjectMember) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[member.ProjectId()]; !ok {
+		r.members[member.ProjectId()] = make(map[value.UserId]*entity.ProjectMember)
+	}
+
+	r.members[member.ProjectId()][member.UserId()] = member
+
+	return nil
+}
+
+func (r *MockProjectRepository) DeleteMember(projectId string, userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members[projectId], userId)
+
+	return nil
+}
+
+func (r *MockProjectRepository) CreateInvite(invite *dto.CreateProjectInviteCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := entity.NewProjectInvite(
+		uuid.NewString(),
+		invite.ProjectId,
+		invite.Email,
+		invite.Permission,
+		invite.TokenHash,
+		invite.InvitedBy,
+		invite.ExpiresAt,
+		nil,
+	)
+
+	r.invites[i.Id()] = i
+
+	return nil
+}
+
+func (r *MockProjectRepository) GetInviteByTokenHash(tokenHash string) (*entity.ProjectInvite, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.invites {
+		if i.TokenHash() == tokenHash {
+			return i, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MockProjectRepository) UpdateInvite(invite *entity.ProjectInvite) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invites[invite.Id()] = invite
+
+	return nil
+}
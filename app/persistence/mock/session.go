@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockSessionRepository struct {
+	sessions map[string]*entity.Session
+	mu sync.Mutex
+}
+
+func NewMockSessionRepository() *MockSessionRepository {
+	return &MockSessionRepository{
+		sessions: make(map[string]*entity.Session),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockSessionRepository) Create(session *dto.CreateSessionCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.TokenHash] = entity.NewSession(
+		session.TokenHash,
+		session.UserId,
+		session.ExpiresAt,
+	)
+
+	return nil
+}
+
+func (r *MockSessionRepository) Get(tokenHash string) (*entity.Session, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.sessions[tokenHash], nil
+}
+
+func (r *MockSessionRepository) Delete(tokenHash string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, tokenHash)
+
+	return nil
+}
+
+func (r *MockSessionRepository) DeleteByUserId(userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, s := range r.sessions {
+		if s.UserId() == userId {
+			delete(r.sessions, hash)
+		}
+	}
+
+	return nil
+}
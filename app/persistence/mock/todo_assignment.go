@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockTodoAssignmentRepository struct {
+	assignments []*entity.TodoAssignment
+	mu sync.Mutex
+}
+
+func NewMockTodoAssignmentRepository() *MockTodoAssignmentRepository {
+	return &MockTodoAssignmentRepository{
+		assignments: make([]*entity.TodoAssignment, 0),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTodoAssignmentRepository) Create(assignment *dto.CreateTodoAssignmentCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.assignments = append(r.assignments, entity.NewTodoAssignment(
+		uuid.NewString(),
+		assignment.TodoId,
+		assignment.AssigneeId,
+		assignment.AssignedBy,
+		time.Now(),
+	))
+
+	return nil
+}
+
+func (r *MockTodoAssignmentRepository) List(todoId value.TodoItemId) ([]*entity.TodoAssignment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	as := make([]*entity.TodoAssignment, 0)
+
+	for _, a := range r.assignments {
+		if a.TodoId() == todoId {
+			as = append(as, a)
+		}
+	}
+
+	return as, nil
+}
@@ -0,0 +1,83 @@
+package mock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockTodoAttachmentRepository struct {
+	attachments map[string]*entity.TodoAttachment
+	mu sync.Mutex
+}
+
+func NewMockTodoAttachmentRepository() *MockTodoAttachmentRepository {
+	return &MockTodoAttachmentRepository{
+		attachments: make(map[string]*entity.TodoAttachment),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTodoAttachmentRepository) Create(attachment *dto.CreateTodoAttachmentCommand) (*entity.TodoAttachment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a := entity.NewTodoAttachment(
+		uuid.NewString(),
+		attachment.TodoId,
+		attachment.UploaderId,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		time.Now(),
+	)
+
+	r.attachments[a.Id()] = a
+
+	return a, nil
+}
+
+func (r *MockTodoAttachmentRepository) Get(id string) (*entity.TodoAttachment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.attachments[id], nil
+}
+
+func (r *MockTodoAttachmentRepository) List(todoId value.TodoItemId) ([]*entity.TodoAttachment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	as := make([]*entity.TodoAttachment, 0)
+
+	for _, a := range r.attachments {
+		if a.TodoId() == todoId {
+			as = append(as, a)
+		}
+	}
+
+	sort.Slice(as, func(i, j int) bool {
+		return as[i].CreatedAt().Before(as[j].CreatedAt())
+	})
+
+	return as, nil
+}
+
+func (r *MockTodoAttachmentRepository) Delete(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attachments, id)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package mock
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockTodoCommentRepository struct {
+	comments map[string]*entity.TodoComment
+	mu sync.Mutex
+}
+
+func NewMockTodoCommentRepository() *MockTodoCommentRepository {
+	return &MockTodoCommentRepository{
+		comments: make(map[string]*entity.TodoComment),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTodoCommentRepository) Create(comment *dto.CreateTodoCommentCommand) (*entity.TodoComment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := entity.NewTodoComment(
+		uuid.NewString(),
+		comment.TodoId,
+		comment.AuthorId,
+		comment.Body,
+		comment.Mentions,
+		time.Now(),
+		nil,
+	)
+
+	r.comments[c.Id()] = c
+
+	return c, nil
+}
+
+func (r *MockTodoCommentRepository) Get(id string) (*entity.TodoComment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.comments[id], nil
+}
+
+func (r *MockTodoCommentRepository) List(todoId value.TodoItemId) ([]*entity.TodoComment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cs := make([]*entity.TodoComment, 0)
+
+	for _, c := range r.comments {
+		if c.TodoId() == todoId {
+			cs = append(cs, c)
+		}
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].CreatedAt().Before(cs[j].CreatedAt())
+	})
+
+	return cs, nil
+}
+
+func (r *MockTodoCommentRepository) ListByTodos(todoIds []value.TodoItemId) ([]*entity.TodoComment, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cs := make([]*entity.TodoComment, 0)
+
+	for _, c := range r.comments {
+		if slices.Contains(todoIds, c.TodoId()) {
+			cs = append(cs, c)
+		}
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].CreatedAt().Before(cs[j].CreatedAt())
+	})
+
+	return cs, nil
+}
+
+func (r *MockTodoCommentRepository) Update(comment *entity.TodoComment) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.comments[comment.Id()] = comment
+
+	return nil
+}
+
+func (r *MockTodoCommentRepository) Delete(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.comments, id)
+
+	return nil
+}
@@ -1,27 +1,45 @@
 package mock
 
 import (
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kkatou7209/godo/app/domain/entity"
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
 )
 
 type MockTodoItemRepository struct {
 	todos map[value.TodoItemId]*entity.TodoItem
+	// Sync state of todo items, deleted ones included.
+	syncs map[value.TodoItemId]*mockTodoSync
+	// Sequence number of last change.
+	seq int64
 	mu sync.Mutex
 }
 
+// Sync state of todo item, kept like database triggers do.
+type mockTodoSync struct {
+	seq int64
+	clocks map[string]entity.TodoClock
+	// Todo item as deleted. nil if not deleted.
+	deleted *entity.TodoItem
+}
+
 func NewMockTodoItemRepository() *MockTodoItemRepository {
 	return &MockTodoItemRepository{
 		todos: make(map[value.TodoItemId]*entity.TodoItem),
+		syncs: make(map[value.TodoItemId]*mockTodoSync),
+		seq: 0,
 		mu: sync.Mutex{},
 	}
 }
 
-func (r *MockTodoItemRepository) Create(todo *dto.CreateTodoCommand) error {
+func (r *MockTodoItemRepository) Create(todo *dto.CreateTodoCommand) (*entity.TodoItem, error) {
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -30,13 +48,17 @@ func (r *MockTodoItemRepository) Create(todo *dto.CreateTodoCommand) error {
 		value.NewTodoItemId(uuid.NewString()),
 		todo.Title,
 		todo.Description,
-		false,
+		todo.IsDone,
 		todo.UserId,
+		todo.ProjectId,
+		nil,
 	)
 
 	r.todos[t.Id()] = t
 
-	return nil
+	r.touchLocked(nil, t)
+
+	return clone(t), nil
 }
 
 func (r *MockTodoItemRepository) Get(todoId value.TodoItemId) (*entity.TodoItem, error) {
@@ -44,7 +66,13 @@ func (r *MockTodoItemRepository) Get(todoId value.TodoItemId) (*entity.TodoItem,
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	return r.todos[todoId], nil
+	t, ok := r.todos[todoId]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return clone(t), nil
 }
 
 func (r *MockTodoItemRepository) List(userId value.UserId) ([]*entity.TodoItem, error) {
@@ -55,7 +83,88 @@ func (r *MockTodoItemRepository) List(userId value.UserId) ([]*entity.TodoItem,
 	ts := make([]*entity.TodoItem, 0)
 
 	for _, t := range r.todos {
-		ts = append(ts, t)
+		if t.UserId() == userId && t.ProjectId() == "" {
+			ts = append(ts, clone(t))
+		}
+	}
+
+	return ts, nil
+}
+
+func (r *MockTodoItemRepository) ListPage(query *dto.ListTodoPageQuery) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.TodoItem, 0)
+
+	for _, t := range r.todos {
+
+		visible := t.UserId() == query.UserId && t.ProjectId() == ""
+
+		if t.ProjectId() != "" {
+			visible = slices.Contains(query.ProjectIds, t.ProjectId())
+		}
+
+		if visible && t.Id().Value() > query.After {
+			ts = append(ts, clone(t))
+		}
+	}
+
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i].Id().Value() < ts[j].Id().Value()
+	})
+
+	if len(ts) > query.Limit {
+		ts = ts[:query.Limit]
+	}
+
+	return ts, nil
+}
+
+func (r *MockTodoItemRepository) ListByProject(projectId string) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.TodoItem, 0)
+
+	for _, t := range r.todos {
+		if t.ProjectId() == projectId {
+			ts = append(ts, clone(t))
+		}
+	}
+
+	return ts, nil
+}
+
+func (r *MockTodoItemRepository) ListByProjects(projectIds []string) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.TodoItem, 0)
+
+	for _, t := range r.todos {
+		if t.ProjectId() != "" && slices.Contains(projectIds, t.ProjectId()) {
+			ts = append(ts, clone(t))
+		}
+	}
+
+	return ts, nil
+}
+
+func (r *MockTodoItemRepository) ListAssigned(userId value.UserId) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.TodoItem, 0)
+
+	for _, t := range r.todos {
+		if t.IsAssignedTo(userId) && !t.IsDone() {
+			ts = append(ts, clone(t))
+		}
 	}
 
 	return ts, nil
@@ -66,7 +175,9 @@ func (r *MockTodoItemRepository) Update(todo *entity.TodoItem) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.todos[todo.Id()] = todo
+	r.touchLocked(r.todos[todo.Id()], todo)
+
+	r.todos[todo.Id()] = clone(todo)
 
 	return nil
 }
@@ -76,7 +187,137 @@ func (r *MockTodoItemRepository) Delete(todoId value.TodoItemId) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if t, ok := r.todos[todoId]; ok {
+		r.touchLocked(t, nil)
+	}
+
 	delete(r.todos, todoId)
 
 	return nil
-}
\ No newline at end of file
+}
+func (r *MockTodoItemRepository) GetMany(todoIds []value.TodoItemId) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := make([]*entity.TodoItem, 0)
+
+	seen := make(map[value.TodoItemId]bool)
+
+	for _, id := range todoIds {
+		if t, ok := r.todos[id]; ok && !seen[id] {
+			ts = append(ts, clone(t))
+			seen[id] = true
+		}
+	}
+
+	return ts, nil
+}
+
+func (r *MockTodoItemRepository) CreateMany(todos []*dto.CreateTodoCommand) ([]*entity.TodoItem, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := make([]*entity.TodoItem, len(todos))
+
+	for i, todo := range todos {
+
+		t := entity.NewTodoItem(
+			value.NewTodoItemId(uuid.NewString()),
+			todo.Title,
+			todo.Description,
+			todo.IsDone,
+			todo.UserId,
+			todo.ProjectId,
+			nil,
+		)
+
+		r.todos[t.Id()] = t
+
+		r.touchLocked(nil, t)
+
+		created[i] = clone(t)
+	}
+
+	return created, nil
+}
+
+func (r *MockTodoItemRepository) WriteBatch(batch *dto.TodoBatchCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, seq := range batch.Seqs {
+
+		current := int64(0)
+
+		if state, ok := r.syncs[id]; ok {
+			current = state.seq
+		}
+
+		if current != seq {
+			return persistence.ErrTodoRevisionChanged
+		}
+	}
+
+	for _, t := range batch.Updates {
+		r.touchLocked(r.todos[t.Id()], t)
+		r.todos[t.Id()] = clone(t)
+	}
+
+	for _, id := range batch.Deletes {
+		if t, ok := r.todos[id]; ok {
+			r.touchLocked(t, nil)
+		}
+		delete(r.todos, id)
+	}
+
+	for _, t := range batch.Restores {
+		r.touchLocked(nil, t)
+		r.todos[t.Id()] = clone(t)
+	}
+
+	return nil
+}
+
+// Record change of todo item written through API for sync.
+// Fields changed get clocks of now, and deleted todo item leaves tombstone.
+func (r *MockTodoItemRepository) touchLocked(before *entity.TodoItem, after *entity.TodoItem) {
+
+	r.seq++
+
+	if after == nil {
+		r.syncs[before.Id()] = &mockTodoSync{seq: r.seq, clocks: nil, deleted: clone(before)}
+		return
+	}
+
+	state, ok := r.syncs[after.Id()]
+
+	if !ok || state.deleted != nil {
+		state = &mockTodoSync{clocks: make(map[string]entity.TodoClock)}
+		r.syncs[after.Id()] = state
+		before = nil
+	}
+
+	state.seq = r.seq
+
+	clock := entity.NewTodoClock(time.Now(), "")
+
+	if before == nil || before.Title() != after.Title() {
+		state.clocks[entity.TodoFieldTitle] = clock
+	}
+
+	if before == nil || before.Description() != after.Description() {
+		state.clocks[entity.TodoFieldDescription] = clock
+	}
+
+	if before == nil || before.IsDone() != after.IsDone() {
+		state.clocks[entity.TodoFieldIsDone] = clock
+	}
+}
+
+// Copy todo item so callers changing it do not change stored one until saved, like a database.
+func clone(t *entity.TodoItem) *entity.TodoItem {
+	return entity.NewTodoItem(t.Id(), t.Title(), t.Description(), t.IsDone(), t.UserId(), t.ProjectId(), t.AssigneeId())
+}
@@ -0,0 +1,232 @@
+package mock
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+// Matches words the same way value.SearchWords splits them.
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{Nd}]+`)
+
+// Words shown before and after first match in snippets.
+const (
+	snippetWordsBefore = 5
+	snippetWordsAfter = 15
+)
+
+// Searches todo items and comments of mock repositories with simple in-memory tokenizer.
+// Matches words exactly or by prefix, no stemming. Ranks title over description over comments.
+type MockTodoSearchRepository struct {
+	todos *MockTodoItemRepository
+	comments *MockTodoCommentRepository
+}
+
+func NewMockTodoSearchRepository(todos *MockTodoItemRepository, comments *MockTodoCommentRepository) *MockTodoSearchRepository {
+	return &MockTodoSearchRepository{todos, comments}
+}
+
+// Searched text of todo item with its rank weight.
+type searchField struct {
+	text string
+	weight float64
+}
+
+func (r *MockTodoSearchRepository) Search(query *dto.SearchTodoQuery) ([]*dto.TodoSearchHit, error) {
+
+	todos, err := r.todos.List(query.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectId := range query.ProjectIds {
+
+		shared, err := r.todos.ListByProject(projectId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, shared...)
+	}
+
+	hits := make([]*dto.TodoSearchHit, 0)
+
+	for _, todo := range todos {
+
+		fields, err := r.fields(todo)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hit := match(todo, fields, query.Query); hit != nil {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Rank > hits[j].Rank
+	})
+
+	if len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+
+	return hits, nil
+}
+
+func (r *MockTodoSearchRepository) fields(todo *entity.TodoItem) ([]searchField, error) {
+
+	comments, err := r.comments.List(todo.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]string, len(comments))
+
+	for i, comment := range comments {
+		bodies[i] = comment.Body()
+	}
+
+	return []searchField{
+		{todo.Title().Value(), 1.0},
+		{todo.Description().Value(), 0.4},
+		{strings.Join(bodies, "\n"), 0.2},
+	}, nil
+}
+
+// Match todo item when every term is found in some field.
+func match(todo *entity.TodoItem, fields []searchField, query value.SearchQuery) *dto.TodoSearchHit {
+
+	rank := 0.0
+
+	// Matched word positions of each field.
+	matched := make([]map[int]bool, len(fields))
+
+	for i := range matched {
+		matched[i] = make(map[int]bool)
+	}
+
+	for _, term := range query.Terms() {
+
+		found := false
+
+		for i, field := range fields {
+
+			positions := findTerm(value.SearchWords(field.text), term)
+
+			if len(positions) == 0 {
+				continue
+			}
+
+			found = true
+
+			rank += field.weight * float64(len(positions))
+
+			for _, p := range positions {
+				for w := range term.Words() {
+					matched[i][p + w] = true
+				}
+			}
+		}
+
+		if !found {
+			return nil
+		}
+	}
+
+	for i, field := range fields {
+		if len(matched[i]) > 0 {
+			return &dto.TodoSearchHit{Todo: todo, Rank: rank, Snippet: snippet(field.text, matched[i])}
+		}
+	}
+
+	return nil
+}
+
+// Find word positions where term starts.
+func findTerm(words []string, term value.SearchTerm) []int {
+
+	termWords := term.Words()
+
+	positions := make([]int, 0)
+
+	for start := 0; start + len(termWords) <= len(words); start++ {
+
+		ok := true
+
+		for w, termWord := range termWords {
+
+			word := words[start + w]
+
+			if term.IsPrefix() && w == len(termWords) - 1 {
+				ok = strings.HasPrefix(word, termWord)
+			} else {
+				ok = word == termWord
+			}
+
+			if !ok {
+				break
+			}
+		}
+
+		if ok {
+			positions = append(positions, start)
+		}
+	}
+
+	return positions
+}
+
+// Cut text around first matched word, enclosing matched words in highlight marks.
+func snippet(text string, matched map[int]bool) string {
+
+	spans := searchWordPattern.FindAllStringIndex(text, -1)
+
+	first := len(spans)
+
+	for p := range matched {
+		first = min(first, p)
+	}
+
+	from := max(0, first - snippetWordsBefore)
+	to := min(len(spans), first + snippetWordsAfter + 1)
+
+	var b strings.Builder
+
+	if from > 0 {
+		b.WriteString("… ")
+	}
+
+	pos := spans[from][0]
+
+	for i := from; i < to; i++ {
+
+		b.WriteString(text[pos:spans[i][0]])
+
+		word := text[spans[i][0]:spans[i][1]]
+
+		if matched[i] {
+			b.WriteString(dto.SnippetHighlightStart + word + dto.SnippetHighlightStop)
+		} else {
+			b.WriteString(word)
+		}
+
+		pos = spans[i][1]
+	}
+
+	if to < len(spans) {
+		b.WriteString(" …")
+	} else {
+		b.WriteString(text[pos:])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package mock
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+)
+
+// Syncs todo items of mock todo item repository.
+type MockTodoSyncRepository struct {
+	todos *MockTodoItemRepository
+	// Outcomes of client operations by user and operation ID.
+	operations map[value.UserId]map[string]*entity.TodoSyncOperation
+	mu sync.Mutex
+}
+
+func NewMockTodoSyncRepository(todos *MockTodoItemRepository) *MockTodoSyncRepository {
+	return &MockTodoSyncRepository{
+		todos: todos,
+		operations: make(map[value.UserId]map[string]*entity.TodoSyncOperation),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTodoSyncRepository) ListChanges(query *dto.ListTodoChangesQuery) ([]*entity.TodoRevision, error) {
+
+	r.todos.mu.Lock()
+	defer r.todos.mu.Unlock()
+
+	visible := func(t *entity.TodoItem) bool {
+
+		if t.ProjectId() == "" {
+			return t.UserId() == query.UserId
+		}
+
+		return slices.Contains(query.ProjectIds, t.ProjectId())
+	}
+
+	revisions := make([]*entity.TodoRevision, 0)
+
+	for id, state := range r.todos.syncs {
+
+		if state.seq <= query.Since {
+			continue
+		}
+
+		if state.deleted != nil {
+			if query.Since > 0 && visible(state.deleted) {
+				revisions = append(revisions, r.todos.revisionLocked(id))
+			}
+			continue
+		}
+
+		if visible(r.todos.todos[id]) {
+			revisions = append(revisions, r.todos.revisionLocked(id))
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Seq() < revisions[j].Seq()
+	})
+
+	if len(revisions) > query.Limit {
+		revisions = revisions[:query.Limit]
+	}
+
+	return revisions, nil
+}
+
+func (r *MockTodoSyncRepository) GetRevisions(todoIds []value.TodoItemId) ([]*entity.TodoRevision, error) {
+
+	r.todos.mu.Lock()
+	defer r.todos.mu.Unlock()
+
+	revisions := make([]*entity.TodoRevision, 0)
+
+	seen := make(map[value.TodoItemId]bool)
+
+	for _, id := range todoIds {
+		if _, ok := r.todos.syncs[id]; ok && !seen[id] {
+			revisions = append(revisions, r.todos.revisionLocked(id))
+			seen[id] = true
+		}
+	}
+
+	return revisions, nil
+}
+
+func (r *MockTodoSyncRepository) GetOperations(userId value.UserId, operationIds []string) ([]*entity.TodoSyncOperation, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operations := make([]*entity.TodoSyncOperation, 0)
+
+	for _, id := range operationIds {
+		if o, ok := r.operations[userId][id]; ok {
+			operations = append(operations, o)
+		}
+	}
+
+	return operations, nil
+}
+
+func (r *MockTodoSyncRepository) WriteSync(command *dto.TodoSyncCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.todos.mu.Lock()
+	defer r.todos.mu.Unlock()
+
+	for _, revision := range append(slices.Clone(command.Saves), command.Deletes...) {
+
+		seq := int64(0)
+
+		if state, ok := r.todos.syncs[revision.TodoId()]; ok {
+			seq = state.seq
+		}
+
+		if seq != revision.Seq() {
+			return persistence.ErrTodoRevisionChanged
+		}
+	}
+
+	for _, revision := range command.Saves {
+
+		r.todos.seq++
+
+		r.todos.todos[revision.TodoId()] = clone(revision.Todo())
+		r.todos.syncs[revision.TodoId()] = &mockTodoSync{seq: r.todos.seq, clocks: maps.Clone(revision.Clocks())}
+	}
+
+	for _, revision := range command.Deletes {
+		r.todos.touchLocked(r.todos.todos[revision.TodoId()], nil)
+		delete(r.todos.todos, revision.TodoId())
+	}
+
+	for _, o := range command.Operations {
+
+		if r.operations[o.UserId()] == nil {
+			r.operations[o.UserId()] = make(map[string]*entity.TodoSyncOperation)
+		}
+
+		r.operations[o.UserId()][o.Id()] = o
+	}
+
+	return nil
+}
+
+// Get revision of todo item as stored.
+func (r *MockTodoItemRepository) revisionLocked(todoId value.TodoItemId) *entity.TodoRevision {
+
+	state := r.syncs[todoId]
+
+	if state.deleted != nil {
+		return entity.NewTodoRevision(todoId, nil, nil, state.seq)
+	}
+
+	return entity.NewTodoRevision(todoId, clone(r.todos[todoId]), maps.Clone(state.clocks), state.seq)
+}
@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockTodoUndoRepository struct {
+	undos map[string]*entity.TodoUndo
+	mu sync.Mutex
+}
+
+func NewMockTodoUndoRepository() *MockTodoUndoRepository {
+	return &MockTodoUndoRepository{
+		undos: make(map[string]*entity.TodoUndo),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTodoUndoRepository) Create(undo *dto.CreateTodoUndoCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := make([]*entity.TodoChange, len(undo.Changes))
+
+	// Keep states as they are now, like a database.
+	for i, change := range undo.Changes {
+		changes[i] = entity.NewTodoChange(cloneOrNil(change.Before()), cloneOrNil(change.After()))
+	}
+
+	r.undos[undo.TokenHash] = entity.NewTodoUndo(
+		undo.TokenHash,
+		undo.UserId,
+		changes,
+		undo.ExpiresAt,
+		nil,
+	)
+
+	return nil
+}
+
+func (r *MockTodoUndoRepository) Get(tokenHash string) (*entity.TodoUndo, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.undos[tokenHash], nil
+}
+
+func (r *MockTodoUndoRepository) Update(undo *entity.TodoUndo) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.undos[undo.TokenHash()] = undo
+
+	return nil
+}
+
+func cloneOrNil(t *entity.TodoItem) *entity.TodoItem {
+
+	if t == nil {
+		return nil
+	}
+
+	return clone(t)
+}
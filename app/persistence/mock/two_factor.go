@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type MockTwoFactorRepository struct {
+	twoFactors map[value.UserId]*entity.TwoFactor
+	mu sync.Mutex
+}
+
+func NewMockTwoFactorRepository() *MockTwoFactorRepository {
+	return &MockTwoFactorRepository{
+		twoFactors: make(map[value.UserId]*entity.TwoFactor),
+		mu: sync.Mutex{},
+	}
+}
+
+func (r *MockTwoFactorRepository) Create(twoFactor *dto.CreateTwoFactorCommand) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.twoFactors[twoFactor.UserId] = entity.NewTwoFactor(
+		twoFactor.UserId,
+		twoFactor.Secret,
+		false,
+		nil,
+		0,
+	)
+
+	return nil
+}
+
+func (r *MockTwoFactorRepository) Get(userId value.UserId) (*entity.TwoFactor, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.twoFactors[userId], nil
+}
+
+func (r *MockTwoFactorRepository) Update(twoFactor *entity.TwoFactor) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.twoFactors[twoFactor.UserId()] = twoFactor
+
+	return nil
+}
+
+func (r *MockTwoFactorRepository) Delete(userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.twoFactors, userId)
+
+	return nil
+}
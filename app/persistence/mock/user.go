@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -31,6 +33,8 @@ func (r *MockUserRepository) Create(user *dto.CreateUserCommand) error {
 		user.UserName,
 		user.Email,
 		user.Password,
+		value.RoleUser,
+		false,
 	)
 
 	r.users[u.Id()] = u
@@ -46,6 +50,25 @@ func (r *MockUserRepository) GetById(userId value.UserId) (*entity.User, error)
 	return r.users[userId], nil
 }
 
+func (r *MockUserRepository) GetMany(userIds []value.UserId) ([]*entity.User, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	us := make([]*entity.User, 0)
+
+	seen := make(map[value.UserId]bool)
+
+	for _, id := range userIds {
+		if u, ok := r.users[id]; ok && !seen[id] {
+			us = append(us, u)
+			seen[id] = true
+		}
+	}
+
+	return us, nil
+}
+
 func (r *MockUserRepository) GetByEmail(email value.Email) (*entity.User, error) {
 
 	r.mu.Lock()
@@ -69,4 +92,42 @@ func (r *MockUserRepository) Update(user *entity.User) error {
 	r.users[user.Id()] = user
 
 	return nil
+}
+
+func (r *MockUserRepository) Delete(userId value.UserId) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, userId)
+
+	return nil
+}
+
+func (r *MockUserRepository) List(query *dto.ListUsersQuery) ([]*entity.User, int, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	search := strings.ToLower(query.Search)
+
+	us := make([]*entity.User, 0)
+
+	for _, u := range r.users {
+		if strings.Contains(strings.ToLower(u.UserName().Value()), search) ||
+			strings.Contains(strings.ToLower(u.Email().Value()), search) {
+			us = append(us, u)
+		}
+	}
+
+	sort.Slice(us, func(i, j int) bool {
+		return us[i].Email().Value() < us[j].Email().Value()
+	})
+
+	total := len(us)
+
+	start := min(query.Page.Offset, total)
+	end := min(start + query.Page.Limit, total)
+
+	return us[start:end], total, nil
 }
\ No newline at end of file
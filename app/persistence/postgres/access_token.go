@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type AccessTokenRepository struct {
+	connectionString string
+}
+
+func NewAccessTokenRepository(connectionString string) *AccessTokenRepository {
+	return &AccessTokenRepository{connectionString}
+}
+
+func (r *AccessTokenRepository) Create(token *dto.CreateAccessTokenCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	scopes := token.Scopes
+
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO access_tokens (
+			id, user_id, name, token_hash, scopes, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		uuid.NewString(),
+		token.UserId.Value(),
+		token.Name,
+		token.TokenHash,
+		scopes,
+		token.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *AccessTokenRepository) Get(id string) (*entity.AccessToken, error) {
+	return r.getBy("id", id)
+}
+
+func (r *AccessTokenRepository) GetByTokenHash(tokenHash string) (*entity.AccessToken, error) {
+	return r.getBy("token_hash", tokenHash)
+}
+
+func (r *AccessTokenRepository) getBy(column string, key string) (*entity.AccessToken, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT id, user_id, name, token_hash, scopes, created_at, expires_at, last_used_at
+		FROM access_tokens
+		WHERE ` + column + ` = $1
+	`, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens, err := scanAccessTokens(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return tokens[0], nil
+}
+
+func (r *AccessTokenRepository) List(userId value.UserId) ([]*entity.AccessToken, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT id, user_id, name, token_hash, scopes, created_at, expires_at, last_used_at
+		FROM access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at
+	`, userId.Value())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanAccessTokens(rows)
+}
+
+func scanAccessTokens(rows pgx.Rows) ([]*entity.AccessToken, error) {
+
+	var (
+		id string
+		userId string
+		name string
+		tokenHash string
+		scopes []string
+		createdAt time.Time
+		expiresAt *time.Time
+		lastUsedAt *time.Time
+	)
+
+	tokens := make([]*entity.AccessToken, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &userId, &name, &tokenHash, &scopes, &createdAt, &expiresAt, &lastUsedAt); err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, entity.NewAccessToken(
+			id,
+			value.NewUserId(userId),
+			name,
+			tokenHash,
+			scopes,
+			createdAt,
+			expiresAt,
+			lastUsedAt,
+		))
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *AccessTokenRepository) Update(token *entity.AccessToken) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE access_tokens
+		SET name = $1, scopes = $2, expires_at = $3, last_used_at = $4
+		WHERE id = $5
+	`,
+		token.Name(),
+		token.Scopes(),
+		token.ExpiresAt(),
+		token.LastUsedAt(),
+		token.Id(),
+	)
+
+	return err
+}
+
+func (r *AccessTokenRepository) Delete(id string) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM access_tokens
+		WHERE id = $1
+	`, id)
+
+	return err
+}
@@ -0,0 +1,107 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("access token repository test", Ordered, func() {
+
+	var accessTokenRepository = postgres.NewAccessTokenRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var accessTokenId string
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("access_token_user"),
+			Email: value.NewEmail("access-token-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("access-token-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should create access token", func() {
+
+		expiresAt := time.Now().Add(time.Hour)
+
+		err := accessTokenRepository.Create(&dto.CreateAccessTokenCommand{
+			UserId: userId,
+			Name: "ci",
+			TokenHash: "access-token-hash",
+			Scopes: []string{"todos:read", "todos:write"},
+			ExpiresAt: &expiresAt,
+		})
+
+		Expect(err).To(BeNil())
+	})
+
+	It("should get access token by hash", func() {
+
+		accessToken, err := accessTokenRepository.GetByTokenHash("access-token-hash")
+
+		Expect(err).To(BeNil())
+		Expect(accessToken).ToNot(BeNil())
+		Expect(accessToken.Name()).To(Equal("ci"))
+		Expect(accessToken.Scopes()).To(Equal([]string{"todos:read", "todos:write"}))
+		Expect(accessToken.ExpiresAt()).ToNot(BeNil())
+		Expect(accessToken.LastUsedAt()).To(BeNil())
+
+		accessTokenId = accessToken.Id()
+	})
+
+	It("should update last use", func() {
+
+		accessToken, err := accessTokenRepository.Get(accessTokenId)
+
+		Expect(err).To(BeNil())
+
+		accessToken.Use(time.Now())
+
+		Expect(accessTokenRepository.Update(accessToken)).To(BeNil())
+
+		tokens, err := accessTokenRepository.List(userId)
+
+		Expect(err).To(BeNil())
+		Expect(tokens).To(HaveLen(1))
+		Expect(tokens[0].LastUsedAt()).ToNot(BeNil())
+	})
+
+	It("should delete access token", func() {
+
+		Expect(accessTokenRepository.Delete(accessTokenId)).To(BeNil())
+
+		accessToken, err := accessTokenRepository.Get(accessTokenId)
+
+		Expect(err).To(BeNil())
+		Expect(accessToken).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
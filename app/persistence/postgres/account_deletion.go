@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type AccountDeletionRepository struct {
+	connectionString string
+}
+
+func NewAccountDeletionRepository(connectionString string) *AccountDeletionRepository {
+	return &AccountDeletionRepository{connectionString}
+}
+
+func (r *AccountDeletionRepository) Create(deletion *dto.CreateAccountDeletionCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO account_deletions (
+			user_id, requested_at, scheduled_at
+		)
+		VALUES ($1, $2, $3)`,
+		deletion.UserId.Value(),
+		deletion.RequestedAt,
+		deletion.ScheduledAt,
+	)
+
+	return err
+}
+
+func (r *AccountDeletionRepository) Get(userId value.UserId) (*entity.AccountDeletion, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		requestedAt time.Time
+		scheduledAt time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT requested_at, scheduled_at
+		FROM account_deletions
+		WHERE user_id = $1
+	`, userId.Value()).Scan(&requestedAt, &scheduledAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewAccountDeletion(userId, requestedAt, scheduledAt), nil
+}
+
+func (r *AccountDeletionRepository) ListDue(now time.Time) ([]*entity.AccountDeletion, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT user_id, requested_at, scheduled_at
+		FROM account_deletions
+		WHERE scheduled_at <= $1
+	`, now)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		userId string
+		requestedAt time.Time
+		scheduledAt time.Time
+	)
+
+	deletions := make([]*entity.AccountDeletion, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&userId, &requestedAt, &scheduledAt); err != nil {
+			return nil, err
+		}
+
+		deletions = append(deletions, entity.NewAccountDeletion(value.NewUserId(userId), requestedAt, scheduledAt))
+	}
+
+	return deletions, rows.Err()
+}
+
+func (r *AccountDeletionRepository) Delete(userId value.UserId) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM account_deletions
+		WHERE user_id = $1
+	`, userId.Value())
+
+	return err
+}
@@ -0,0 +1,93 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("account deletion repository test", Ordered, func() {
+
+	var accountDeletionRepository = postgres.NewAccountDeletionRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("account_deletion_user"),
+			Email: value.NewEmail("account-deletion-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("account-deletion-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should create account deletion", func() {
+
+		err := accountDeletionRepository.Create(&dto.CreateAccountDeletionCommand{
+			UserId: userId,
+			RequestedAt: time.Now(),
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+
+		Expect(err).To(BeNil())
+	})
+
+	It("should get account deletion by user ID", func() {
+
+		deletion, err := accountDeletionRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(deletion).ToNot(BeNil())
+		Expect(deletion.UserId()).To(Equal(userId))
+	})
+
+	It("should list only due deletions", func() {
+
+		due, err := accountDeletionRepository.ListDue(time.Now())
+
+		Expect(err).To(BeNil())
+		Expect(due).To(BeEmpty())
+
+		due, err = accountDeletionRepository.ListDue(time.Now().Add(2 * time.Hour))
+
+		Expect(err).To(BeNil())
+		Expect(due).To(HaveLen(1))
+		Expect(due[0].UserId()).To(Equal(userId))
+	})
+
+	It("should delete account deletion", func() {
+
+		Expect(accountDeletionRepository.Delete(userId)).To(BeNil())
+
+		deletion, err := accountDeletionRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(deletion).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
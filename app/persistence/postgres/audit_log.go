@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type AuditLogRepository struct {
+	connectionString string
+}
+
+func NewAuditLogRepository(connectionString string) *AuditLogRepository {
+	return &AuditLogRepository{connectionString}
+}
+
+func (r *AuditLogRepository) Create(log *dto.CreateAuditLogCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	var actorId *string
+
+	if log.ActorId != nil {
+		id := log.ActorId.Value()
+		actorId = &id
+	}
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO audit_logs (
+			id, actor_id, action, target_user_id, detail
+		)
+		VALUES ($1, $2, $3, $4, $5)`,
+		uuid.NewString(),
+		actorId,
+		log.Action,
+		log.TargetUserId.Value(),
+		log.Detail,
+	)
+
+	return err
+}
+
+func (r *AuditLogRepository) List(page dto.PageQuery) ([]*entity.AuditLog, int, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer conn.Release()
+
+	var total int
+
+	if err := conn.QueryRow(ctx, `SELECT COUNT(*) FROM audit_logs`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT id, actor_id, action, target_user_id, detail, created_at
+		FROM audit_logs
+		ORDER BY created_at DESC, id
+		OFFSET $1
+		LIMIT $2
+	`, page.Offset, page.Limit)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	var (
+		id string
+		actorId *string
+		action string
+		targetUserId string
+		detail string
+		createdAt time.Time
+	)
+
+	logs := make([]*entity.AuditLog, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &actorId, &action, &targetUserId, &detail, &createdAt); err != nil {
+			return nil, 0, err
+		}
+
+		var actor *value.UserId
+
+		if actorId != nil {
+			a := value.NewUserId(*actorId)
+			actor = &a
+		}
+
+		logs = append(logs, entity.NewAuditLog(
+			id,
+			actor,
+			action,
+			value.NewUserId(targetUserId),
+			detail,
+			createdAt,
+		))
+	}
+
+	return logs, total, rows.Err()
+}
@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("audit log repository test", Ordered, func() {
+
+	var auditLogRepository = postgres.NewAuditLogRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var actorId = value.NewUserId("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+
+	var targetUserId = value.NewUserId("9b2e2f1a-3c4d-4e5f-8a6b-7c8d9e0f1a2b")
+
+	It("should create audit logs", func() {
+
+		Expect(auditLogRepository.Create(&dto.CreateAuditLogCommand{
+			Action: "user.role_changed",
+			TargetUserId: targetUserId,
+			Detail: "user -> admin",
+		})).To(BeNil())
+
+		Expect(auditLogRepository.Create(&dto.CreateAuditLogCommand{
+			ActorId: &actorId,
+			Action: "user.disabled",
+			TargetUserId: targetUserId,
+		})).To(BeNil())
+	})
+
+	It("should list newest first", func() {
+
+		logs, total, err := auditLogRepository.List(dto.PageQuery{ Offset: 0, Limit: 1 })
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(2))
+		Expect(logs).To(HaveLen(1))
+		Expect(logs[0].Action()).To(Equal("user.disabled"))
+		Expect(*logs[0].ActorId()).To(Equal(actorId))
+
+		logs, _, err = auditLogRepository.List(dto.PageQuery{ Offset: 1, Limit: 1 })
+
+		Expect(err).To(BeNil())
+		Expect(logs[0].ActorId()).To(BeNil())
+		Expect(logs[0].Detail()).To(Equal("user -> admin"))
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE audit_logs")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+)
+
+type CalendarFeedRepository struct {
+	connectionString string
+}
+
+func NewCalendarFeedRepository(connectionString string) *CalendarFeedRepository {
+	return &CalendarFeedRepository{connectionString}
+}
+
+func (r *CalendarFeedRepository) Save(feed *entity.CalendarFeed) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at`,
+		feed.UserId().Value(),
+		feed.TokenHash(),
+		feed.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *CalendarFeedRepository) Get(userId value.UserId) (*entity.CalendarFeed, error) {
+	return r.getBy("user_id", userId.Value())
+}
+
+func (r *CalendarFeedRepository) GetByTokenHash(tokenHash string) (*entity.CalendarFeed, error) {
+	return r.getBy("token_hash", tokenHash)
+}
+
+func (r *CalendarFeedRepository) getBy(column string, key string) (*entity.CalendarFeed, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		userId string
+		tokenHash string
+		createdAt time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT user_id, token_hash, created_at
+		FROM calendar_feeds
+		WHERE ` + column + ` = $1
+	`, key).Scan(&userId, &tokenHash, &createdAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewCalendarFeed(value.NewUserId(userId), tokenHash, createdAt), nil
+}
+
+func (r *CalendarFeedRepository) Delete(userId value.UserId) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM calendar_feeds
+		WHERE user_id = $1
+	`, userId.Value())
+
+	return err
+}
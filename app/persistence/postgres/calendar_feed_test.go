@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("calendar feed repository test", Ordered, func() {
+
+	var calendarFeedRepository = postgres.NewCalendarFeedRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("calendar_feed_user"),
+			Email: value.NewEmail("calendar-feed-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("calendar-feed-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should save calendar feed", func() {
+
+		Expect(calendarFeedRepository.Save(entity.NewCalendarFeed(userId, "calendar-feed-hash", time.Now()))).To(BeNil())
+
+		feed, err := calendarFeedRepository.GetByTokenHash("calendar-feed-hash")
+
+		Expect(err).To(BeNil())
+		Expect(feed).ToNot(BeNil())
+		Expect(feed.UserId()).To(Equal(userId))
+	})
+
+	It("should replace calendar feed of user", func() {
+
+		Expect(calendarFeedRepository.Save(entity.NewCalendarFeed(userId, "calendar-feed-rotated", time.Now()))).To(BeNil())
+
+		feed, err := calendarFeedRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(feed.TokenHash()).To(Equal("calendar-feed-rotated"))
+
+		old, err := calendarFeedRepository.GetByTokenHash("calendar-feed-hash")
+
+		Expect(err).To(BeNil())
+		Expect(old).To(BeNil())
+	})
+
+	It("should delete calendar feed", func() {
+
+		Expect(calendarFeedRepository.Delete(userId)).To(BeNil())
+
+		feed, err := calendarFeedRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(feed).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE calendar_feeds, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
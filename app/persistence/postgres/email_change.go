@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type EmailChangeRepository struct {
+	connectionString string
+}
+
+func NewEmailChangeRepository(connectionString string) *EmailChangeRepository {
+	return &EmailChangeRepository{connectionString}
+}
+
+func (r *EmailChangeRepository) Create(change *dto.CreateEmailChangeCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO email_changes (
+			id, user_id, old_email, new_email, confirm_token_hash, revert_token_hash, expires_at, revertible_until
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		uuid.NewString(),
+		change.UserId.Value(),
+		change.OldEmail.Value(),
+		change.NewEmail.Value(),
+		change.ConfirmTokenHash,
+		change.RevertTokenHash,
+		change.ExpiresAt,
+		change.RevertibleUntil,
+	)
+
+	return err
+}
+
+func (r *EmailChangeRepository) GetByConfirmTokenHash(tokenHash string) (*entity.EmailChange, error) {
+	return r.getBy("confirm_token_hash", tokenHash)
+}
+
+func (r *EmailChangeRepository) GetByRevertTokenHash(tokenHash string) (*entity.EmailChange, error) {
+	return r.getBy("revert_token_hash", tokenHash)
+}
+
+func (r *EmailChangeRepository) getBy(column string, tokenHash string) (*entity.EmailChange, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		id string
+		userId string
+		oldEmail string
+		newEmail string
+		confirmTokenHash string
+		revertTokenHash string
+		expiresAt time.Time
+		revertibleUntil time.Time
+		confirmedAt *time.Time
+		revertedAt *time.Time
+	)
+
+	// column is one of fixed names above, never user input.
+	err = conn.QueryRow(ctx, fmt.Sprintf(`
+		SELECT id, user_id, old_email, new_email, confirm_token_hash, revert_token_hash,
+			expires_at, revertible_until, confirmed_at, reverted_at
+		FROM email_changes
+		WHERE %s = $1
+	`, column), tokenHash).Scan(
+		&id, &userId, &oldEmail, &newEmail, &confirmTokenHash, &revertTokenHash,
+		&expiresAt, &revertibleUntil, &confirmedAt, &revertedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewEmailChange(
+		id,
+		value.NewUserId(userId),
+		value.NewEmail(oldEmail),
+		value.NewEmail(newEmail),
+		confirmTokenHash,
+		revertTokenHash,
+		expiresAt,
+		revertibleUntil,
+		confirmedAt,
+		revertedAt,
+	), nil
+}
+
+func (r *EmailChangeRepository) Update(change *entity.EmailChange) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE email_changes
+		SET confirmed_at = $1, reverted_at = $2
+		WHERE id = $3`,
+		change.ConfirmedAt(),
+		change.RevertedAt(),
+		change.Id(),
+	)
+
+	return err
+}
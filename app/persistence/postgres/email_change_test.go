@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("email change repository test", Ordered, func() {
+
+	var emailChangeRepository = postgres.NewEmailChangeRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("email_change_user"),
+			Email: value.NewEmail("email-change-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("email-change-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should create email change", func() {
+
+		err := emailChangeRepository.Create(&dto.CreateEmailChangeCommand{
+			UserId: userId,
+			OldEmail: value.NewEmail("email-change-test@example.com"),
+			NewEmail: value.NewEmail("email-change-new@example.com"),
+			ConfirmTokenHash: "confirm-hash",
+			RevertTokenHash: "revert-hash",
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevertibleUntil: time.Now().Add(24 * time.Hour),
+		})
+
+		Expect(err).To(BeNil())
+	})
+
+	It("should get email change by token hashes", func() {
+
+		byConfirm, err := emailChangeRepository.GetByConfirmTokenHash("confirm-hash")
+
+		Expect(err).To(BeNil())
+		Expect(byConfirm).ToNot(BeNil())
+		Expect(byConfirm.NewEmail()).To(Equal(value.NewEmail("email-change-new@example.com")))
+
+		byRevert, err := emailChangeRepository.GetByRevertTokenHash("revert-hash")
+
+		Expect(err).To(BeNil())
+		Expect(byRevert).ToNot(BeNil())
+		Expect(byRevert.Id()).To(Equal(byConfirm.Id()))
+	})
+
+	It("should update email change", func() {
+
+		change, _ := emailChangeRepository.GetByConfirmTokenHash("confirm-hash")
+
+		change.Confirm(time.Now())
+
+		Expect(emailChangeRepository.Update(change)).To(BeNil())
+
+		change, err := emailChangeRepository.GetByConfirmTokenHash("confirm-hash")
+
+		Expect(err).To(BeNil())
+		Expect(change.ConfirmedAt()).ToNot(BeNil())
+		Expect(change.IsConfirmable(time.Now())).To(BeFalse())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// How long health check may take, as probes give up soon.
+const healthCheckTimeout = 3 * time.Second
+
+// Tables and sequences repositories use. Schema is migrated outside of server, so readiness tells whether it was.
+var schemaRelations = []string{
+	"users",
+	"sessions",
+	"password_resets",
+	"user_two_factors",
+	"login_challenges",
+	"email_changes",
+	"account_deletions",
+	"access_tokens",
+	"audit_logs",
+	"projects",
+	"project_members",
+	"project_invites",
+	"todo_items",
+	"todo_assignments",
+	"todo_comments",
+	"todo_attachments",
+	"todo_undos",
+	"todo_events",
+	"todo_tombstones",
+	"todo_sync_operations",
+	"calendar_feeds",
+	"todo_change_seq",
+}
+
+// Columns added to existing tables later, as table.column, so partly migrated schema is not ready either.
+// Triggers maintaining them are not checked.
+var schemaColumns = []string{
+	"todo_items.search_vector",
+	"todo_items.seq",
+	"todo_items.field_clocks",
+}
+
+type HealthRepository struct {
+	connectionString string
+}
+
+func NewHealthRepository(connectionString string) *HealthRepository {
+	return &HealthRepository{connectionString}
+}
+
+func (r *HealthRepository) CheckHealth() error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+
+	defer cancel()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return fmt.Errorf("database is unreachable: %w", err)
+	}
+
+	defer conn.Release()
+
+	if err := conn.Ping(ctx); err != nil {
+		return fmt.Errorf("database is unreachable: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT name
+		FROM unnest($1::text[]) AS name
+		WHERE to_regclass(name) IS NULL
+		UNION ALL
+		SELECT name
+		FROM unnest($2::text[]) AS name
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name || '.' || column_name = name
+		)
+		ORDER BY name`,
+		schemaRelations,
+		schemaColumns,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	missing := []string{}
+
+	for rows.Next() {
+
+		var name string
+
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+
+		missing = append(missing, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("schema is not migrated: missing %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
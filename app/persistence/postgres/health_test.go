@@ -0,0 +1,26 @@
+package postgres_test
+
+import (
+	"os"
+
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("health repository test", func() {
+
+	It("should be healthy with migrated schema", func() {
+
+		healthRepository := postgres.NewHealthRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		Expect(healthRepository.CheckHealth()).To(Succeed())
+	})
+
+	It("should tell database is unreachable", func() {
+
+		healthRepository := postgres.NewHealthRepository("postgres://godo@127.0.0.1:1/godo?connect_timeout=1")
+
+		Expect(healthRepository.CheckHealth()).To(MatchError(ContainSubstring("database is unreachable")))
+	})
+})
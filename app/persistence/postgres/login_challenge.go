@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type LoginChallengeRepository struct {
+	connectionString string
+}
+
+func NewLoginChallengeRepository(connectionString string) *LoginChallengeRepository {
+	return &LoginChallengeRepository{connectionString}
+}
+
+func (r *LoginChallengeRepository) Create(challenge *dto.CreateLoginChallengeCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO login_challenges (
+			token_hash, user_id, expires_at, failed_attempts
+		)
+		VALUES ($1, $2, $3, 0)`,
+		challenge.TokenHash,
+		challenge.UserId.Value(),
+		challenge.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *LoginChallengeRepository) Get(tokenHash string) (*entity.LoginChallenge, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		userId string
+		expiresAt time.Time
+		failedAttempts int
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT user_id, expires_at, failed_attempts
+		FROM login_challenges
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&userId, &expiresAt, &failedAttempts)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewLoginChallenge(tokenHash, value.NewUserId(userId), expiresAt, failedAttempts), nil
+}
+
+func (r *LoginChallengeRepository) Fail(tokenHash string) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE login_challenges
+		SET failed_attempts = failed_attempts + 1
+		WHERE token_hash = $1
+	`, tokenHash)
+
+	return err
+}
+
+func (r *LoginChallengeRepository) Delete(tokenHash string, now time.Time) (bool, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer conn.Release()
+
+	// One statement, so requests racing with same challenge cannot both redeem it.
+	err = conn.QueryRow(ctx, `
+		DELETE FROM login_challenges
+		WHERE token_hash = $1 AND expires_at > $2 AND failed_attempts < $3
+		RETURNING token_hash
+	`, tokenHash, now, entity.MaxLoginChallengeAttempts).Scan(&tokenHash)
+
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import "github.com/kkatou7209/godo/app/domain/value"
+
+// Convert empty string to NULL.
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Convert NULL to empty string.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Convert nil user ID to NULL.
+func nullableUserId(userId *value.UserId) *string {
+	if userId == nil {
+		return nil
+	}
+	return nullable(userId.Value())
+}
+
+// Convert NULL to nil user ID.
+func userIdOf(s *string) *value.UserId {
+	if s == nil {
+		return nil
+	}
+	userId := value.NewUserId(*s)
+	return &userId
+}
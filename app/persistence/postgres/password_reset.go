@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type PasswordResetRepository struct {
+	connectionString string
+}
+
+func NewPasswordResetRepository(connectionString string) *PasswordResetRepository {
+	return &PasswordResetRepository{connectionString}
+}
+
+func (r *PasswordResetRepository) Create(reset *dto.CreatePasswordResetCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO password_resets (
+			token_hash, user_id, expires_at
+		)
+		VALUES ($1, $2, $3)`,
+		reset.TokenHash,
+		reset.UserId.Value(),
+		reset.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *PasswordResetRepository) Get(tokenHash string) (*entity.PasswordReset, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		userId string
+		expiresAt time.Time
+		usedAt *time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT user_id, expires_at, used_at
+		FROM password_resets
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&userId, &expiresAt, &usedAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewPasswordReset(tokenHash, value.NewUserId(userId), expiresAt, usedAt), nil
+}
+
+func (r *PasswordResetRepository) Update(reset *entity.PasswordReset) (bool, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer conn.Release()
+
+	// One statement, so requests racing with same token cannot both use it.
+	tag, err := conn.Exec(ctx, `
+		UPDATE password_resets
+		SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1`,
+		reset.UsedAt(),
+		reset.TokenHash(),
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
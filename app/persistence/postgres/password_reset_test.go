@@ -0,0 +1,142 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("password reset repository test", Ordered, func() {
+
+	var passwordResetRepository = postgres.NewPasswordResetRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("reset_user"),
+			Email: value.NewEmail("reset-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("reset-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should create password reset", func() {
+
+		err := passwordResetRepository.Create(&dto.CreatePasswordResetCommand{
+			TokenHash: "reset-hash",
+			UserId: userId,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		Expect(err).To(BeNil())
+
+		reset, err := passwordResetRepository.Get("reset-hash")
+
+		Expect(err).To(BeNil())
+		Expect(reset).ToNot(BeNil())
+		Expect(reset.UserId()).To(Equal(userId))
+		Expect(reset.IsUsable(time.Now())).To(BeTrue())
+	})
+
+	It("should mark password reset as used", func() {
+
+		reset, err := passwordResetRepository.Get("reset-hash")
+
+		Expect(err).To(BeNil())
+
+		reset.Use(time.Now())
+
+		used, err := passwordResetRepository.Update(reset)
+
+		Expect(err).To(BeNil())
+		Expect(used).To(BeTrue())
+
+		reset, err = passwordResetRepository.Get("reset-hash")
+
+		Expect(err).To(BeNil())
+		Expect(reset.UsedAt()).ToNot(BeNil())
+		Expect(reset.IsUsable(time.Now())).To(BeFalse())
+	})
+
+	It("should refuse to use password reset twice", func() {
+
+		err := passwordResetRepository.Create(&dto.CreatePasswordResetCommand{
+			TokenHash: "race-hash",
+			UserId: userId,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		Expect(err).To(BeNil())
+
+		// Both requests read the token before either used it.
+		first, err := passwordResetRepository.Get("race-hash")
+
+		Expect(err).To(BeNil())
+
+		second, err := passwordResetRepository.Get("race-hash")
+
+		Expect(err).To(BeNil())
+
+		first.Use(time.Now())
+		second.Use(time.Now())
+
+		used, err := passwordResetRepository.Update(first)
+
+		Expect(err).To(BeNil())
+		Expect(used).To(BeTrue())
+
+		used, err = passwordResetRepository.Update(second)
+
+		Expect(err).To(BeNil())
+		Expect(used).To(BeFalse())
+	})
+
+	It("should refuse to use expired password reset", func() {
+
+		err := passwordResetRepository.Create(&dto.CreatePasswordResetCommand{
+			TokenHash: "expired-hash",
+			UserId: userId,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+
+		Expect(err).To(BeNil())
+
+		reset, err := passwordResetRepository.Get("expired-hash")
+
+		Expect(err).To(BeNil())
+
+		reset.Use(time.Now())
+
+		used, err := passwordResetRepository.Update(reset)
+
+		Expect(err).To(BeNil())
+		Expect(used).To(BeFalse())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	poolsMu sync.Mutex
+	// Pools by connection string, shared by repositories.
+	pools = map[string]*pgxpool.Pool{}
+)
+
+// Acquire connection from pool of connection string, created on first use.
+// Pool settings in connection string apply, e.g. pool_max_conns=10.
+// Release connection when done.
+func connect(ctx context.Context, connectionString string) (*pgxpool.Conn, error) {
+
+	pool, err := Pool(connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.Acquire(ctx)
+}
+
+// Get pool of connection string, created on first use.
+// Shared with others using same database, e.g. event bus.
+func Pool(connectionString string) (*pgxpool.Pool, error) {
+
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	if pool, ok := pools[connectionString]; ok {
+		return pool, nil
+	}
+
+	config, err := pgxpool.ParseConfig(connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Connects lazily, so that creating pool never fails for database being down.
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pools[connectionString] = pool
+
+	return pool, nil
+}
+
+// Close pools of all repositories, waiting for acquired connections to be released.
+// Repositories open new pools if used afterwards.
+func Close() {
+
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	for connectionString, pool := range pools {
+		pool.Close()
+		delete(pools, connectionString)
+	}
+}
@@ -0,0 +1,500 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type ProjectRepository struct {
+	connectionString string
+}
+
+func NewProjectRepository(connectionString string) *ProjectRepository {
+	return &ProjectRepository{connectionString}
+}
+
+func (r *ProjectRepository) Create(project *dto.CreateProjectCommand) (p *entity.Project, err error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	tran, err := conn.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
+
+	var createdAt time.Time
+
+	id := uuid.NewString()
+
+	err = tran.QueryRow(ctx, `
+		INSERT INTO projects (id, name)
+		VALUES ($1, $2)
+		RETURNING created_at`,
+		id,
+		project.Name,
+	).Scan(&createdAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tran.Exec(ctx, `
+		INSERT INTO project_members (project_id, user_id, permission)
+		VALUES ($1, $2, $3)`,
+		id,
+		project.OwnerId.Value(),
+		value.PermissionOwner.Value(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewProject(id, project.Name, createdAt), nil
+}
+
+func (r *ProjectRepository) Get(projectId string) (*entity.Project, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		name string
+		createdAt time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT name, created_at
+		FROM projects
+		WHERE id = $1
+	`, projectId).Scan(&name, &createdAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewProject(projectId, name, createdAt), nil
+}
+
+func (r *ProjectRepository) List(userId value.UserId) ([]*entity.Project, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT p.id, p.name, p.created_at
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id
+		WHERE m.user_id = $1
+		ORDER BY p.created_at, p.id
+	`, userId.Value())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		id string
+		name string
+		createdAt time.Time
+	)
+
+	projects := make([]*entity.Project, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &name, &createdAt); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, entity.NewProject(id, name, createdAt))
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepository) Update(project *entity.Project) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE projects
+		SET name = $1
+		WHERE id = $2
+	`, project.Name(), project.Id())
+
+	return err
+}
+
+// Tables holding rows of project, deleted before the project itself.
+var projectOwnedTables = []string{
+	"todo_items",
+	"project_invites",
+	"project_members",
+}
+
+func (r *ProjectRepository) Delete(projectId string) (err error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	tran, err := conn.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
+
+	for _, table := range projectOwnedTables {
+
+		_, err = tran.Exec(ctx, "DELETE FROM " + table + " WHERE project_id = $1", projectId)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tran.Exec(ctx, `
+		DELETE FROM projects
+		WHERE id = $1
+	`, projectId)
+
+	return err
+}
+
+func (r *ProjectRepository) GetMember(projectId string, userId value.UserId) (*entity.ProjectMember, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var permission string
+
+	err = conn.QueryRow(ctx, `
+		SELECT permission
+		FROM project_members
+		WHERE project_id = $1 AND user_id = $2
+	`, projectId, userId.Value()).Scan(&permission)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewProjectMember(projectId, userId, value.NewPermission(permission)), nil
+}
+
+func (r *ProjectRepository) ListMembers(projectId string) ([]*entity.ProjectMember, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT user_id, permission
+		FROM project_members
+		WHERE project_id = $1
+		ORDER BY user_id
+	`, projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		userId string
+		permission string
+	)
+
+	members := make([]*entity.ProjectMember, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&userId, &permission); err != nil {
+			return nil, err
+		}
+
+		members = append(members, entity.NewProjectMember(
+			projectId,
+			value.NewUserId(userId),
+			value.NewPermission(permission),
+		))
+	}
+
+	return members, rows.Err()
+}
+
+func (r *ProjectRepository) ListMembersOfProjects(projectIds []string) ([]*entity.ProjectMember, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT project_id, user_id, permission
+		FROM project_members
+		WHERE project_id = ANY($1::uuid[])
+		ORDER BY project_id, user_id
+	`, projectIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		projectId string
+		userId string
+		permission string
+	)
+
+	members := make([]*entity.ProjectMember, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&projectId, &userId, &permission); err != nil {
+			return nil, err
+		}
+
+		members = append(members, entity.NewProjectMember(
+			projectId,
+			value.NewUserId(userId),
+			value.NewPermission(permission),
+		))
+	}
+
+	return members, rows.Err()
+}
+
+func (r *ProjectRepository) SaveMember(member *entity.ProjectMember) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO project_members (project_id, user_id, permission)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (project_id, user_id)
+		DO UPDATE SET permission = EXCLUDED.permission
+	`,
+		member.ProjectId(),
+		member.UserId().Value(),
+		member.Permission().Value(),
+	)
+
+	return err
+}
+
+func (r *ProjectRepository) DeleteMember(projectId string, userId value.UserId) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM project_members
+		WHERE project_id = $1 AND user_id = $2
+	`, projectId, userId.Value())
+
+	return err
+}
+
+func (r *ProjectRepository) CreateInvite(invite *dto.CreateProjectInviteCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO project_invites (
+			id, project_id, email, permission, token_hash, invited_by, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		uuid.NewString(),
+		invite.ProjectId,
+		invite.Email.Value(),
+		invite.Permission.Value(),
+		invite.TokenHash,
+		invite.InvitedBy.Value(),
+		invite.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *ProjectRepository) GetInviteByTokenHash(tokenHash string) (*entity.ProjectInvite, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		id string
+		projectId string
+		email string
+		permission string
+		invitedBy string
+		expiresAt time.Time
+		acceptedAt *time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT id, project_id, email, permission, invited_by, expires_at, accepted_at
+		FROM project_invites
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&id, &projectId, &email, &permission, &invitedBy, &expiresAt, &acceptedAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewProjectInvite(
+		id,
+		projectId,
+		value.NewEmail(email),
+		value.NewPermission(permission),
+		tokenHash,
+		value.NewUserId(invitedBy),
+		expiresAt,
+		acceptedAt,
+	), nil
+}
+
+func (r *ProjectRepository) UpdateInvite(invite *entity.ProjectInvite) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE project_invites
+		SET accepted_at = $1
+		WHERE id = $2
+	`, invite.AcceptedAt(), invite.Id())
+
+	return err
+}
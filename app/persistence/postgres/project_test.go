@@ -0,0 +1,218 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("project repository test", Ordered, func() {
+
+	var projectRepository = postgres.NewProjectRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var ownerId value.UserId
+
+	var memberId value.UserId
+
+	var projectId string
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		for _, email := range []string{"project-owner@example.com", "project-member@example.com"} {
+			userRepository.Create(&dto.CreateUserCommand{
+				UserName: value.NewUserName("project_user"),
+				Email: value.NewEmail(email),
+				Password: value.NewPassword("test-pass"),
+			})
+		}
+
+		owner, err := userRepository.GetByEmail(value.NewEmail("project-owner@example.com"))
+
+		if err != nil || owner == nil {
+			panic("fail to get user")
+		}
+
+		member, err := userRepository.GetByEmail(value.NewEmail("project-member@example.com"))
+
+		if err != nil || member == nil {
+			panic("fail to get user")
+		}
+
+		ownerId = owner.Id()
+		memberId = member.Id()
+	})
+
+	It("should create project with owner", func() {
+
+		project, err := projectRepository.Create(&dto.CreateProjectCommand{
+			Name: "team",
+			OwnerId: ownerId,
+		})
+
+		Expect(err).To(BeNil())
+		Expect(project.Name()).To(Equal("team"))
+
+		projectId = project.Id()
+
+		owner, err := projectRepository.GetMember(projectId, ownerId)
+
+		Expect(err).To(BeNil())
+		Expect(owner.Permission()).To(Equal(value.PermissionOwner))
+	})
+
+	It("should accept invite and add member", func() {
+
+		err := projectRepository.CreateInvite(&dto.CreateProjectInviteCommand{
+			ProjectId: projectId,
+			Email: value.NewEmail("project-member@example.com"),
+			Permission: value.PermissionEditor,
+			TokenHash: "project-invite-hash",
+			InvitedBy: ownerId,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		Expect(err).To(BeNil())
+
+		invite, err := projectRepository.GetInviteByTokenHash("project-invite-hash")
+
+		Expect(err).To(BeNil())
+		Expect(invite.IsAcceptable(time.Now())).To(BeTrue())
+
+		invite.Accept(time.Now())
+
+		Expect(projectRepository.UpdateInvite(invite)).To(BeNil())
+
+		invite, err = projectRepository.GetInviteByTokenHash("project-invite-hash")
+
+		Expect(err).To(BeNil())
+		Expect(invite.AcceptedAt()).ToNot(BeNil())
+
+		member, err := projectRepository.GetMember(projectId, memberId)
+
+		Expect(err).To(BeNil())
+		Expect(member).To(BeNil())
+
+		err = projectRepository.SaveMember(entity.NewProjectMember(projectId, memberId, value.PermissionEditor))
+
+		Expect(err).To(BeNil())
+
+		members, err := projectRepository.ListMembers(projectId)
+
+		Expect(err).To(BeNil())
+		Expect(members).To(HaveLen(2))
+
+		projects, err := projectRepository.List(memberId)
+
+		Expect(err).To(BeNil())
+		Expect(projects).To(HaveLen(1))
+		Expect(projects[0].Id()).To(Equal(projectId))
+	})
+
+	It("should update member permission", func() {
+
+		member, err := projectRepository.GetMember(projectId, memberId)
+
+		Expect(err).To(BeNil())
+
+		member.ChangePermission(value.PermissionViewer)
+
+		Expect(projectRepository.SaveMember(member)).To(BeNil())
+
+		member, err = projectRepository.GetMember(projectId, memberId)
+
+		Expect(err).To(BeNil())
+		Expect(member.Permission()).To(Equal(value.PermissionViewer))
+	})
+
+	It("should list project todo items apart from personal ones", func() {
+
+		_, err := todoRepository.Create(&dto.CreateTodoCommand{
+			UserId: ownerId,
+			Title: value.NewTodoItemTitle("project todo"),
+			Description: value.NewTodoItemDescription("project todo description"),
+			ProjectId: projectId,
+		})
+
+		Expect(err).To(BeNil())
+
+		todos, err := todoRepository.ListByProject(projectId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(1))
+		Expect(todos[0].ProjectId()).To(Equal(projectId))
+
+		todos, err = todoRepository.List(ownerId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(BeEmpty())
+	})
+
+	It("should list todo items and members of many projects at once", func() {
+
+		todos, err := todoRepository.ListByProjects([]string{projectId, "00000000-0000-0000-0000-000000000000"})
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(1))
+		Expect(todos[0].ProjectId()).To(Equal(projectId))
+
+		members, err := projectRepository.ListMembersOfProjects([]string{projectId})
+
+		Expect(err).To(BeNil())
+		Expect(members).To(HaveLen(2))
+		Expect(members[0].ProjectId()).To(Equal(projectId))
+	})
+
+	It("should hand project over when owner is deleted", func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		Expect(userRepository.Delete(ownerId)).To(BeNil())
+
+		member, err := projectRepository.GetMember(projectId, memberId)
+
+		Expect(err).To(BeNil())
+		Expect(member.Permission()).To(Equal(value.PermissionOwner))
+
+		todos, err := todoRepository.ListByProject(projectId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(1))
+		Expect(todos[0].UserId()).To(Equal(memberId))
+	})
+
+	It("should delete project with its todo items", func() {
+
+		Expect(projectRepository.Delete(projectId)).To(BeNil())
+
+		project, err := projectRepository.Get(projectId)
+
+		Expect(err).To(BeNil())
+		Expect(project).To(BeNil())
+
+		todos, err := todoRepository.ListByProject(projectId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(BeEmpty())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE projects, todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
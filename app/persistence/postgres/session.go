@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type SessionRepository struct {
+	connectionString string
+}
+
+func NewSessionRepository(connectionString string) *SessionRepository {
+	return &SessionRepository{connectionString}
+}
+
+func (r *SessionRepository) Create(session *dto.CreateSessionCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO sessions (
+			token_hash, user_id, expires_at
+		)
+		VALUES ($1, $2, $3)`,
+		session.TokenHash,
+		session.UserId.Value(),
+		session.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *SessionRepository) Get(tokenHash string) (*entity.Session, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		userId string
+		expiresAt time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT user_id, expires_at
+		FROM sessions
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&userId, &expiresAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewSession(tokenHash, value.NewUserId(userId), expiresAt), nil
+}
+
+func (r *SessionRepository) Delete(tokenHash string) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM sessions
+		WHERE token_hash = $1
+	`, tokenHash)
+
+	return err
+}
+
+func (r *SessionRepository) DeleteByUserId(userId value.UserId) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM sessions
+		WHERE user_id = $1
+	`, userId.Value())
+
+	return err
+}
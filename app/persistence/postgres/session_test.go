@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("session repository test", Ordered, func() {
+
+	var sessionRepository = postgres.NewSessionRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("session_user"),
+			Email: value.NewEmail("session-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("session-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	When("create session", func() {
+
+		It("should create session", func() {
+
+			err := sessionRepository.Create(&dto.CreateSessionCommand{
+				TokenHash: "session-hash-1",
+				UserId: userId,
+				ExpiresAt: time.Now().Add(time.Hour),
+			})
+
+			Expect(err).To(BeNil())
+
+			err = sessionRepository.Create(&dto.CreateSessionCommand{
+				TokenHash: "session-hash-2",
+				UserId: userId,
+				ExpiresAt: time.Now().Add(time.Hour),
+			})
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should get session by hash", func() {
+
+			session, err := sessionRepository.Get("session-hash-1")
+
+			Expect(err).To(BeNil())
+			Expect(session).ToNot(BeNil())
+			Expect(session.UserId()).To(Equal(userId))
+		})
+	})
+
+	When("delete session", func() {
+
+		It("should delete single session", func() {
+
+			Expect(sessionRepository.Delete("session-hash-1")).To(BeNil())
+
+			session, err := sessionRepository.Get("session-hash-1")
+
+			Expect(err).To(BeNil())
+			Expect(session).To(BeNil())
+		})
+
+		It("should delete all sessions of user", func() {
+
+			Expect(sessionRepository.DeleteByUserId(userId)).To(BeNil())
+
+			session, err := sessionRepository.Get("session-hash-2")
+
+			Expect(err).To(BeNil())
+			Expect(session).To(BeNil())
+		})
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
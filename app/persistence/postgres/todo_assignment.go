@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type TodoAssignmentRepository struct {
+	connectionString string
+}
+
+func NewTodoAssignmentRepository(connectionString string) *TodoAssignmentRepository {
+	return &TodoAssignmentRepository{connectionString}
+}
+
+func (r *TodoAssignmentRepository) Create(assignment *dto.CreateTodoAssignmentCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO todo_assignments (
+			id, todo_id, assignee_id, assigned_by
+		)
+		VALUES ($1, $2, $3, $4)`,
+		uuid.NewString(),
+		assignment.TodoId.Value(),
+		nullableUserId(assignment.AssigneeId),
+		assignment.AssignedBy.Value(),
+	)
+
+	return err
+}
+
+func (r *TodoAssignmentRepository) List(todoId value.TodoItemId) ([]*entity.TodoAssignment, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT id, assignee_id, assigned_by, created_at
+		FROM todo_assignments
+		WHERE todo_id = $1
+		ORDER BY created_at, id
+	`, todoId.Value())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		id string
+		assigneeId *string
+		assignedBy string
+		createdAt time.Time
+	)
+
+	assignments := make([]*entity.TodoAssignment, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &assigneeId, &assignedBy, &createdAt); err != nil {
+			return nil, err
+		}
+
+		assignments = append(assignments, entity.NewTodoAssignment(
+			id,
+			todoId,
+			userIdOf(assigneeId),
+			value.NewUserId(assignedBy),
+			createdAt,
+		))
+	}
+
+	return assignments, rows.Err()
+}
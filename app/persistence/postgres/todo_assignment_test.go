@@ -0,0 +1,115 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo assignment repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var todoAssignmentRepository = postgres.NewTodoAssignmentRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var todoId value.TodoItemId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_assignment_user"),
+			Email: value.NewEmail("todo-assignment-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-assignment-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		todoRepository.Create(&dto.CreateTodoCommand{
+			UserId: userId,
+			Title: value.NewTodoItemTitle("assigned todo"),
+			Description: value.NewTodoItemDescription("assigned todo description"),
+		})
+
+		todos, err := todoRepository.List(userId)
+
+		if err != nil || len(todos) == 0 {
+			panic("fail to get todo item")
+		}
+
+		todoId = todos[0].Id()
+	})
+
+	It("should list assigned todo items", func() {
+
+		todo, err := todoRepository.Get(todoId)
+
+		Expect(err).To(BeNil())
+
+		todo.AssignTo(userId)
+
+		Expect(todoRepository.Update(todo)).To(BeNil())
+
+		todos, err := todoRepository.ListAssigned(userId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(1))
+		Expect(todos[0].IsAssignedTo(userId)).To(BeTrue())
+	})
+
+	It("should record assignments", func() {
+
+		Expect(todoAssignmentRepository.Create(&dto.CreateTodoAssignmentCommand{
+			TodoId: todoId,
+			AssigneeId: &userId,
+			AssignedBy: userId,
+		})).To(BeNil())
+
+		Expect(todoAssignmentRepository.Create(&dto.CreateTodoAssignmentCommand{
+			TodoId: todoId,
+			AssigneeId: nil,
+			AssignedBy: userId,
+		})).To(BeNil())
+
+		assignments, err := todoAssignmentRepository.List(todoId)
+
+		Expect(err).To(BeNil())
+		Expect(assignments).To(HaveLen(2))
+		Expect(*assignments[0].AssigneeId()).To(Equal(userId))
+		Expect(assignments[1].AssigneeId()).To(BeNil())
+	})
+
+	It("should delete assignments with todo item", func() {
+
+		Expect(todoRepository.Delete(todoId)).To(BeNil())
+
+		assignments, err := todoAssignmentRepository.List(todoId)
+
+		Expect(err).To(BeNil())
+		Expect(assignments).To(BeEmpty())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
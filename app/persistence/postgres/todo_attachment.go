@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type TodoAttachmentRepository struct {
+	connectionString string
+}
+
+func NewTodoAttachmentRepository(connectionString string) *TodoAttachmentRepository {
+	return &TodoAttachmentRepository{connectionString}
+}
+
+func (r *TodoAttachmentRepository) Create(attachment *dto.CreateTodoAttachmentCommand) (*entity.TodoAttachment, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	id := uuid.NewString()
+
+	var createdAt time.Time
+
+	err = conn.QueryRow(ctx, `
+		INSERT INTO todo_attachments (
+			id, todo_id, uploader_id, file_name, content_type, size, storage_key
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`,
+		id,
+		attachment.TodoId.Value(),
+		attachment.UploaderId.Value(),
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+	).Scan(&createdAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTodoAttachment(
+		id,
+		attachment.TodoId,
+		attachment.UploaderId,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		createdAt,
+	), nil
+}
+
+func (r *TodoAttachmentRepository) Get(id string) (*entity.TodoAttachment, error) {
+
+	attachments, err := r.query(`
+		SELECT id, todo_id, uploader_id, file_name, content_type, size, storage_key, created_at
+		FROM todo_attachments
+		WHERE id = $1
+	`, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	return attachments[0], nil
+}
+
+func (r *TodoAttachmentRepository) List(todoId value.TodoItemId) ([]*entity.TodoAttachment, error) {
+	return r.query(`
+		SELECT id, todo_id, uploader_id, file_name, content_type, size, storage_key, created_at
+		FROM todo_attachments
+		WHERE todo_id = $1
+		ORDER BY created_at, id
+	`, todoId.Value())
+}
+
+func (r *TodoAttachmentRepository) query(sql string, args ...any) ([]*entity.TodoAttachment, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, sql, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		id string
+		todoId string
+		uploaderId string
+		fileName string
+		contentType string
+		size int64
+		storageKey string
+		createdAt time.Time
+	)
+
+	attachments := make([]*entity.TodoAttachment, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &todoId, &uploaderId, &fileName, &contentType, &size, &storageKey, &createdAt); err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, entity.NewTodoAttachment(
+			id,
+			value.NewTodoItemId(todoId),
+			value.NewUserId(uploaderId),
+			fileName,
+			contentType,
+			size,
+			storageKey,
+			createdAt,
+		))
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *TodoAttachmentRepository) Delete(id string) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM todo_attachments
+		WHERE id = $1
+	`, id)
+
+	return err
+}
@@ -0,0 +1,107 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo attachment repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var todoAttachmentRepository = postgres.NewTodoAttachmentRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var todoId value.TodoItemId
+
+	var attachmentId string
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_attachment_user"),
+			Email: value.NewEmail("todo-attachment-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-attachment-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		todoRepository.Create(&dto.CreateTodoCommand{
+			UserId: userId,
+			Title: value.NewTodoItemTitle("attached todo"),
+			Description: value.NewTodoItemDescription("attached todo description"),
+		})
+
+		todos, err := todoRepository.List(userId)
+
+		if err != nil || len(todos) == 0 {
+			panic("fail to get todo item")
+		}
+
+		todoId = todos[0].Id()
+	})
+
+	It("should create and get attachment", func() {
+
+		attachment, err := todoAttachmentRepository.Create(&dto.CreateTodoAttachmentCommand{
+			TodoId: todoId,
+			UploaderId: userId,
+			FileName: "shot.png",
+			ContentType: "image/png",
+			Size: 42,
+			StorageKey: "todo-attachments/" + todoId.Value() + "/shot",
+		})
+
+		Expect(err).To(BeNil())
+
+		attachmentId = attachment.Id()
+
+		got, err := todoAttachmentRepository.Get(attachmentId)
+
+		Expect(err).To(BeNil())
+		Expect(got.FileName()).To(Equal("shot.png"))
+		Expect(got.ContentType()).To(Equal("image/png"))
+		Expect(got.Size()).To(Equal(int64(42)))
+		Expect(got.IsUploadedBy(userId)).To(BeTrue())
+
+		attachments, err := todoAttachmentRepository.List(todoId)
+
+		Expect(err).To(BeNil())
+		Expect(attachments).To(HaveLen(1))
+	})
+
+	It("should delete attachments with todo item", func() {
+
+		Expect(todoRepository.Delete(todoId)).To(BeNil())
+
+		attachment, err := todoAttachmentRepository.Get(attachmentId)
+
+		Expect(err).To(BeNil())
+		Expect(attachment).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,164 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo batch repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var todoIds []value.TodoItemId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_batch_user"),
+			Email: value.NewEmail("todo-batch-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-batch-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		for _, title := range []string{"batch one", "batch two", "batch three"} {
+			todoRepository.Create(&dto.CreateTodoCommand{
+				UserId: userId,
+				Title: value.NewTodoItemTitle(title),
+				Description: value.NewTodoItemDescription(title + " description"),
+			})
+		}
+
+		todos, err := todoRepository.List(userId)
+
+		if err != nil || len(todos) != 3 {
+			panic("fail to get todo items")
+		}
+
+		for _, todo := range todos {
+			todoIds = append(todoIds, todo.Id())
+		}
+	})
+
+	It("should get many todo items at once", func() {
+
+		todos, err := todoRepository.GetMany([]value.TodoItemId{todoIds[0], todoIds[2], value.NewTodoItemId("00000000-0000-0000-0000-000000000000")})
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(2))
+	})
+
+	It("should update and delete in one batch", func() {
+
+		todos, err := todoRepository.GetMany(todoIds[:2])
+
+		Expect(err).To(BeNil())
+
+		todos[0].Complete()
+		todos[1].ChangeTitle("batch two renamed")
+
+		Expect(todoRepository.WriteBatch(&dto.TodoBatchCommand{
+			Updates: []*entity.TodoItem{todos[0], todos[1]},
+			Deletes: []value.TodoItemId{todoIds[2]},
+		})).To(BeNil())
+
+		todos, err = todoRepository.List(userId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(2))
+		Expect(todos[0].IsDone()).To(BeTrue())
+		Expect(todos[1].Title().Value()).To(Equal("batch two renamed"))
+	})
+
+	It("should write nothing when todo item changed since read", func() {
+
+		syncRepository := postgres.NewTodoSyncRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		revisions, err := syncRepository.GetRevisions(todoIds)
+
+		Expect(err).To(BeNil())
+		Expect(revisions).To(HaveLen(3))
+
+		seqs := make(map[value.TodoItemId]int64)
+
+		for _, revision := range revisions {
+			seqs[revision.TodoId()] = revision.Seq()
+		}
+
+		todo, err := todoRepository.Get(todoIds[0])
+
+		Expect(err).To(BeNil())
+
+		// Changed by someone else after read.
+		todo.Uncomplete()
+
+		Expect(todoRepository.Update(todo)).To(Succeed())
+
+		todo.ChangeTitle("batch one renamed")
+
+		restored := entity.NewTodoItem(
+			todoIds[2],
+			value.NewTodoItemTitle("batch three"),
+			value.NewTodoItemDescription("batch three description"),
+			false,
+			userId,
+			"",
+			nil,
+		)
+
+		batch := &dto.TodoBatchCommand{
+			Updates: []*entity.TodoItem{todo},
+			Restores: []*entity.TodoItem{restored},
+			Seqs: seqs,
+		}
+
+		Expect(todoRepository.WriteBatch(batch)).To(MatchError(persistence.ErrTodoRevisionChanged))
+
+		todos, err := todoRepository.List(userId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(2))
+		Expect(todos[0].Title().Value()).To(Equal("batch one"))
+
+		revisions, err = syncRepository.GetRevisions(todoIds[:1])
+
+		Expect(err).To(BeNil())
+
+		seqs[todoIds[0]] = revisions[0].Seq()
+
+		Expect(todoRepository.WriteBatch(batch)).To(Succeed())
+
+		todos, err = todoRepository.List(userId)
+
+		Expect(err).To(BeNil())
+		Expect(todos).To(HaveLen(3))
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type TodoCommentRepository struct {
+	connectionString string
+}
+
+func NewTodoCommentRepository(connectionString string) *TodoCommentRepository {
+	return &TodoCommentRepository{connectionString}
+}
+
+func (r *TodoCommentRepository) Create(comment *dto.CreateTodoCommentCommand) (*entity.TodoComment, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	mentions := comment.Mentions
+
+	if mentions == nil {
+		mentions = []string{}
+	}
+
+	id := uuid.NewString()
+
+	var createdAt time.Time
+
+	err = conn.QueryRow(ctx, `
+		INSERT INTO todo_comments (
+			id, todo_id, author_id, body, mentions
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`,
+		id,
+		comment.TodoId.Value(),
+		comment.AuthorId.Value(),
+		comment.Body,
+		mentions,
+	).Scan(&createdAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTodoComment(id, comment.TodoId, comment.AuthorId, comment.Body, mentions, createdAt, nil), nil
+}
+
+func (r *TodoCommentRepository) Get(id string) (*entity.TodoComment, error) {
+
+	comments, err := r.query(`
+		SELECT id, todo_id, author_id, body, mentions, created_at, edited_at
+		FROM todo_comments
+		WHERE id = $1
+	`, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, nil
+	}
+
+	return comments[0], nil
+}
+
+func (r *TodoCommentRepository) List(todoId value.TodoItemId) ([]*entity.TodoComment, error) {
+	return r.query(`
+		SELECT id, todo_id, author_id, body, mentions, created_at, edited_at
+		FROM todo_comments
+		WHERE todo_id = $1
+		ORDER BY created_at, id
+	`, todoId.Value())
+}
+
+func (r *TodoCommentRepository) ListByTodos(todoIds []value.TodoItemId) ([]*entity.TodoComment, error) {
+
+	ids := make([]string, len(todoIds))
+
+	for i, id := range todoIds {
+		ids[i] = id.Value()
+	}
+
+	return r.query(`
+		SELECT id, todo_id, author_id, body, mentions, created_at, edited_at
+		FROM todo_comments
+		WHERE todo_id = ANY($1::uuid[])
+		ORDER BY created_at, id
+	`, ids)
+}
+
+func (r *TodoCommentRepository) query(sql string, args ...any) ([]*entity.TodoComment, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, sql, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		id string
+		todoId string
+		authorId string
+		body string
+		mentions []string
+		createdAt time.Time
+		editedAt *time.Time
+	)
+
+	comments := make([]*entity.TodoComment, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &todoId, &authorId, &body, &mentions, &createdAt, &editedAt); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, entity.NewTodoComment(
+			id,
+			value.NewTodoItemId(todoId),
+			value.NewUserId(authorId),
+			body,
+			mentions,
+			createdAt,
+			editedAt,
+		))
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *TodoCommentRepository) Update(comment *entity.TodoComment) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE todo_comments
+		SET body = $1, mentions = $2, edited_at = $3
+		WHERE id = $4
+	`,
+		comment.Body(),
+		comment.Mentions(),
+		comment.EditedAt(),
+		comment.Id(),
+	)
+
+	return err
+}
+
+func (r *TodoCommentRepository) Delete(id string) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM todo_comments
+		WHERE id = $1
+	`, id)
+
+	return err
+}
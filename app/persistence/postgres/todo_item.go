@@ -8,6 +8,7 @@ import (
 	"github.com/kkatou7209/godo/app/domain/entity"
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
 )
 
 type TodoItemRepository struct {
@@ -18,22 +19,22 @@ func NewTodoItemRepository(connectionString string) *TodoItemRepository {
 	return &TodoItemRepository{connectionString}
 }
 
-func (r *TodoItemRepository) Create(todo *dto.CreateTodoCommand) error {
+func (r *TodoItemRepository) Create(todo *dto.CreateTodoCommand) (created *entity.TodoItem, err error) {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
 	tran, err := conn.Begin(ctx)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer func ()  {
@@ -44,38 +45,126 @@ func (r *TodoItemRepository) Create(todo *dto.CreateTodoCommand) error {
 		err = tran.Commit(ctx)
 	}()
 
+	id := value.NewTodoItemId(uuid.NewString())
+
 	_, err = tran.Exec(ctx, `
 		INSERT INTO todo_items (
-			id, title, description, is_done, user_id
+			id, title, description, is_done, user_id, project_id
 		) 
-		VALUES ($1, $2, $3, $4, $5)`,
-		uuid.NewString(),
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		id.Value(),
 		todo.Title.Value(),
 		todo.Description.Value(),
-		false,
+		todo.IsDone,
 		todo.UserId.Value(),
+		nullable(todo.ProjectId),
 	)
+
+	if err != nil {
+		return nil, err
+	}
 	
-	return err
+	return entity.NewTodoItem(id, todo.Title, todo.Description, todo.IsDone, todo.UserId, todo.ProjectId, nil), nil
 }
 
+// Columns scanned by scanTodoItems.
+const todoItemColumns = "id, title, description, is_done, user_id, project_id, assignee_id"
+
 func (r *TodoItemRepository) Get(todoId value.TodoItemId) (*entity.TodoItem, error) {
 
+	todos, err := r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE id = $1
+	`, todoId.Value())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(todos) == 0 {
+		return nil, nil
+	}
+
+	return todos[0], nil
+}
+
+func (r *TodoItemRepository) List(userId value.UserId) ([]*entity.TodoItem, error) {
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE user_id = $1 AND project_id IS NULL
+		ORDER BY created_at, id
+	`, userId.Value())
+}
+
+func (r *TodoItemRepository) ListPage(query *dto.ListTodoPageQuery) ([]*entity.TodoItem, error) {
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE (user_id = $1 AND project_id IS NULL OR project_id = ANY($2::uuid[]))
+		AND id > COALESCE(NULLIF($3, '')::uuid, '00000000-0000-0000-0000-000000000000')
+		ORDER BY id
+		LIMIT $4
+	`, query.UserId.Value(), query.ProjectIds, query.After, query.Limit)
+}
+
+func (r *TodoItemRepository) GetMany(todoIds []value.TodoItemId) ([]*entity.TodoItem, error) {
+
+	ids := make([]string, len(todoIds))
+
+	for i, id := range todoIds {
+		ids[i] = id.Value()
+	}
+
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE id = ANY($1::uuid[])
+		ORDER BY created_at, id
+	`, ids)
+}
+
+func (r *TodoItemRepository) ListByProject(projectId string) ([]*entity.TodoItem, error) {
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE project_id = $1
+		ORDER BY created_at, id
+	`, projectId)
+}
+
+func (r *TodoItemRepository) ListByProjects(projectIds []string) ([]*entity.TodoItem, error) {
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE project_id = ANY($1::uuid[])
+		ORDER BY created_at, id
+	`, projectIds)
+}
+
+func (r *TodoItemRepository) ListAssigned(userId value.UserId) ([]*entity.TodoItem, error) {
+	return r.query(`
+		SELECT ` + todoItemColumns + `
+		FROM todo_items
+		WHERE assignee_id = $1 AND is_done = false
+		ORDER BY created_at, id
+	`, userId.Value())
+}
+
+func (r *TodoItemRepository) query(sql string, args ...any) ([]*entity.TodoItem, error) {
+
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
-	rows, err := conn.Query(ctx, `
-		SELECT id, title, description, is_done, user_id
-		FROM todo_items
-		WHERE id = $1
-	`, todoId.Value())
+	rows, err := conn.Query(ctx, sql, args...)
 
 	if err != nil {
 		return nil, err
@@ -83,18 +172,44 @@ func (r *TodoItemRepository) Get(todoId value.TodoItemId) (*entity.TodoItem, err
 
 	defer rows.Close()
 
+	return scanTodoItems(rows)
+}
+
+func scanTodoItems(rows pgx.Rows) ([]*entity.TodoItem, error) {
+
+	todos := make([]*entity.TodoItem, 0)
+
+	for rows.Next() {
+
+		todo, err := scanTodoItem(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, todo)
+	}
+
+	return todos, rows.Err()
+}
+
+// Scan row starting with todoItemColumns. Further columns are scanned into extra.
+func scanTodoItem(rows pgx.Rows, extra ...any) (*entity.TodoItem, error) {
+
 	var (
 		id string
 		title string
 		description string
 		isDone bool
 		userId string
+		projectId *string
+		assigneeId *string
 	)
 
-	if rows.Next() {
-		rows.Scan(&id, &title, &description, &isDone, &userId)
-	} else {
-		return nil, nil
+	dest := append([]any{&id, &title, &description, &isDone, &userId, &projectId, &assigneeId}, extra...)
+
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
 	}
 
 	return entity.NewTodoItem(
@@ -103,77 +218,124 @@ func (r *TodoItemRepository) Get(todoId value.TodoItemId) (*entity.TodoItem, err
 		value.NewTodoItemDescription(description),
 		isDone,
 		value.NewUserId(userId),
+		deref(projectId),
+		userIdOf(assigneeId),
 	), nil
 }
 
-func (r *TodoItemRepository) List(userId value.UserId) ([]*entity.TodoItem, error) {
+func (r *TodoItemRepository) Update(todo *entity.TodoItem) error {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
-	rows, err := conn.Query(ctx, `
-		SELECT id, title, description, is_done
-		FROM todo_items
-		WHERE user_id = $1
-	`,
-	userId.Value())
+	tran, err := conn.Begin(ctx)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	defer rows.Close()
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
 
-	var (
-		id string
-		title string
-		description string
-		isDone bool
+	_, err = tran.Exec(ctx, `
+		UPDATE todo_items
+		SET title = $1, description = $2, is_done = $3, project_id = $4, assignee_id = $5
+		WHERE id = $6`,
+		todo.Title().Value(),
+		todo.Description().Value(),
+		todo.IsDone(),
+		nullable(todo.ProjectId()),
+		nullableUserId(todo.AssigneeId()),
+		todo.Id().Value(),
 	)
+	
+	return err
+}
 
-	todos := make([]*entity.TodoItem, 0)
+func (r *TodoItemRepository) CreateMany(todos []*dto.CreateTodoCommand) (created []*entity.TodoItem, err error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
 
-	if rows.Next() {
+	if err != nil {
+		return nil, err
+	}
 
-		err = rows.Scan(&id, &title, &description, &isDone)
+	defer conn.Release()
 
+	tran, err := conn.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func ()  {
 		if err != nil {
-			return nil, err
+			_ = tran.Rollback(ctx)
+			return
 		}
+		err = tran.Commit(ctx)
+	}()
 
-		todos = append(todos, entity.NewTodoItem(
-			value.NewTodoItemId(id),
-			value.NewTodoItemTitle(title),
-			value.NewTodoItemDescription(description),
-			isDone,
-			userId,
-		))
+	queue := &pgx.Batch{}
 
-	} else {
-		return nil, nil
+	created = make([]*entity.TodoItem, len(todos))
+
+	for i, todo := range todos {
+
+		id := value.NewTodoItemId(uuid.NewString())
+
+		queue.Queue(`
+			INSERT INTO todo_items (
+				id, title, description, is_done, user_id, project_id
+			)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			id.Value(),
+			todo.Title.Value(),
+			todo.Description.Value(),
+			todo.IsDone,
+			todo.UserId.Value(),
+			nullable(todo.ProjectId),
+		)
+
+		created[i] = entity.NewTodoItem(id, todo.Title, todo.Description, todo.IsDone, todo.UserId, todo.ProjectId, nil)
 	}
 
-	return todos, nil
+	if queue.Len() == 0 {
+		return created, nil
+	}
+
+	if err = tran.SendBatch(ctx, queue).Close(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
-func (r *TodoItemRepository) Update(todo *entity.TodoItem) error {
+func (r *TodoItemRepository) WriteBatch(batch *dto.TodoBatchCommand) (err error) {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
 		return err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
 	tran, err := conn.Begin(ctx)
 
@@ -189,16 +351,128 @@ func (r *TodoItemRepository) Update(todo *entity.TodoItem) error {
 		err = tran.Commit(ctx)
 	}()
 
-	_, err = tran.Exec(ctx, `
-		UPDATE todo_items
-		SET title = $1, description = $2, is_done = $3
-		WHERE id = $4`,
-		todo.Title().Value(),
-		todo.Description().Value(),
-		todo.IsDone(),
-		todo.Id().Value(),
-	)
-	
+	// Queue all statements to send them in one round trip.
+	queue := &pgx.Batch{}
+
+	// Whether each statement queued must hit one row, or todo item changed since read.
+	guarded := make([]bool, 0)
+
+	for _, todo := range batch.Updates {
+
+		seq, ok := batch.Seqs[todo.Id()]
+
+		if !ok {
+			queue.Queue(`
+				UPDATE todo_items
+				SET title = $1, description = $2, is_done = $3, project_id = $4, assignee_id = $5
+				WHERE id = $6`,
+				todo.Title().Value(),
+				todo.Description().Value(),
+				todo.IsDone(),
+				nullable(todo.ProjectId()),
+				nullableUserId(todo.AssigneeId()),
+				todo.Id().Value(),
+			)
+			guarded = append(guarded, false)
+			continue
+		}
+
+		queue.Queue(`
+			UPDATE todo_items
+			SET title = $1, description = $2, is_done = $3, project_id = $4, assignee_id = $5
+			WHERE id = $6 AND seq = $7`,
+			todo.Title().Value(),
+			todo.Description().Value(),
+			todo.IsDone(),
+			nullable(todo.ProjectId()),
+			nullableUserId(todo.AssigneeId()),
+			todo.Id().Value(),
+			seq,
+		)
+		guarded = append(guarded, true)
+	}
+
+	ids := make([]string, 0, len(batch.Deletes))
+
+	for _, id := range batch.Deletes {
+
+		seq, ok := batch.Seqs[id]
+
+		if !ok {
+			ids = append(ids, id.Value())
+			continue
+		}
+
+		queue.Queue(`DELETE FROM todo_items WHERE id = $1 AND seq = $2`, id.Value(), seq)
+		guarded = append(guarded, true)
+	}
+
+	if len(ids) > 0 {
+		queue.Queue(`DELETE FROM todo_items WHERE id = ANY($1::uuid[])`, ids)
+		guarded = append(guarded, false)
+	}
+
+	for _, todo := range batch.Restores {
+
+		seq, ok := batch.Seqs[todo.Id()]
+
+		if !ok {
+			queue.Queue(`
+				INSERT INTO todo_items (`+todoItemColumns+`)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				todo.Id().Value(),
+				todo.Title().Value(),
+				todo.Description().Value(),
+				todo.IsDone(),
+				todo.UserId().Value(),
+				nullable(todo.ProjectId()),
+				nullableUserId(todo.AssigneeId()),
+			)
+			guarded = append(guarded, false)
+			continue
+		}
+
+		// Deleted todo item is put back only if still deleted as read.
+		queue.Queue(`
+			INSERT INTO todo_items (`+todoItemColumns+`)
+			SELECT $1::uuid, $2::varchar, $3::varchar, $4::boolean, $5::uuid, $6::uuid, $7::uuid
+			WHERE EXISTS (SELECT 1 FROM todo_tombstones WHERE todo_id = $1::uuid AND seq = $8)
+			ON CONFLICT (id) DO NOTHING`,
+			todo.Id().Value(),
+			todo.Title().Value(),
+			todo.Description().Value(),
+			todo.IsDone(),
+			todo.UserId().Value(),
+			nullable(todo.ProjectId()),
+			nullableUserId(todo.AssigneeId()),
+			seq,
+		)
+		guarded = append(guarded, true)
+	}
+
+	if queue.Len() == 0 {
+		return nil
+	}
+
+	results := tran.SendBatch(ctx, queue)
+
+	for _, mustHit := range guarded {
+
+		tag, execErr := results.Exec()
+
+		if execErr != nil {
+			results.Close()
+			return execErr
+		}
+
+		if mustHit && tag.RowsAffected() != 1 {
+			results.Close()
+			return persistence.ErrTodoRevisionChanged
+		}
+	}
+
+	err = results.Close()
+
 	return err
 }
 
@@ -206,13 +480,13 @@ func (r *TodoItemRepository) Delete(todoId value.TodoItemId) error {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
 		return err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
 	tran, err := conn.Begin(ctx)
 
@@ -47,7 +47,7 @@ var _ = Describe("todo item repository test", Ordered, func() {
 		
 		It("should create new todo", func() {
 
-			err := todoItemRepository.Create(&dto.CreateTodoCommand{
+			_, err := todoItemRepository.Create(&dto.CreateTodoCommand{
 				UserId: userId,
 				Title: value.NewTodoItemTitle("todo1"),
 				Description: value.NewTodoItemDescription("todo creation test"),
@@ -117,6 +117,88 @@ var _ = Describe("todo item repository test", Ordered, func() {
 		})
 	})
 
+	When("list todo items by page", func()  {
+
+		It("should list pages ordered by ID", func()  {
+
+			for _, title := range []string{"page1", "page2"} {
+
+				_, err := todoItemRepository.Create(&dto.CreateTodoCommand{
+					UserId: userId,
+					Title: value.NewTodoItemTitle(title),
+					Description: value.NewTodoItemDescription("todo page test " + title),
+					IsDone: true,
+				})
+
+				Expect(err).To(BeNil())
+			}
+
+			first, err := todoItemRepository.ListPage(&dto.ListTodoPageQuery{UserId: userId, Limit: 2})
+
+			Expect(err).To(BeNil())
+			Expect(first).To(HaveLen(2))
+			Expect(first[0].Id().Value() < first[1].Id().Value()).To(BeTrue())
+
+			rest, err := todoItemRepository.ListPage(&dto.ListTodoPageQuery{UserId: userId, After: first[1].Id().Value(), Limit: 2})
+
+			Expect(err).To(BeNil())
+			Expect(rest).To(HaveLen(1))
+
+			created, err := todoItemRepository.ListPage(&dto.ListTodoPageQuery{UserId: userId, Limit: 10})
+
+			Expect(err).To(BeNil())
+
+			for _, todo := range created {
+				Expect(todo.IsDone()).To(BeTrue())
+			}
+		})
+	})
+
+	When("create todo items at once", func()  {
+
+		It("should create todo items with same description", func()  {
+
+			todos := []*dto.CreateTodoCommand{
+				{UserId: userId, Title: value.NewTodoItemTitle("import1"), Description: value.NewTodoItemDescription("imported")},
+				{UserId: userId, Title: value.NewTodoItemTitle("import2"), Description: value.NewTodoItemDescription("imported")},
+			}
+
+			created, err := todoItemRepository.CreateMany(todos)
+
+			Expect(err).To(BeNil())
+			Expect(created).To(HaveLen(2))
+
+			for i, todo := range created {
+
+				got, err := todoItemRepository.Get(todo.Id())
+
+				Expect(err).To(BeNil())
+				Expect(got).ToNot(BeNil())
+				Expect(got.Title()).To(Equal(todos[i].Title))
+				Expect(got.Description().Value()).To(Equal("imported"))
+			}
+		})
+
+		It("should create none when any fails", func()  {
+
+			before, err := todoItemRepository.List(userId)
+
+			Expect(err).To(BeNil())
+
+			_, err = todoItemRepository.CreateMany([]*dto.CreateTodoCommand{
+				{UserId: userId, Title: value.NewTodoItemTitle("import3"), Description: value.NewTodoItemDescription("imported")},
+				{UserId: userId, Title: value.NewTodoItemTitle("import4"), Description: value.NewTodoItemDescription("imported"), ProjectId: "00000000-0000-0000-0000-000000000000"},
+			})
+
+			Expect(err).ToNot(BeNil())
+
+			after, err := todoItemRepository.List(userId)
+
+			Expect(err).To(BeNil())
+			Expect(after).To(HaveLen(len(before)))
+		})
+	})
+
 	When("delete todo item", func()  {
 		
 		It("should delete todo item", func()  {
@@ -137,7 +219,7 @@ var _ = Describe("todo item repository test", Ordered, func() {
 		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
 		Expect(err).To(BeNil())
 		defer conn.Close(ctx)
-		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users")
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
 		Expect(err).To(BeNil())
 	})
 })
\ No newline at end of file
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+// Options of ts_headline. Highlight marks are control characters so
+// user text can be escaped safely after highlighting.
+var headlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" … "`,
+	dto.SnippetHighlightStart,
+	dto.SnippetHighlightStop,
+)
+
+// Search todo items with todo_items.search_vector maintained by triggers.
+type TodoSearchRepository struct {
+	connectionString string
+}
+
+func NewTodoSearchRepository(connectionString string) *TodoSearchRepository {
+	return &TodoSearchRepository{connectionString}
+}
+
+func (r *TodoSearchRepository) Search(query *dto.SearchTodoQuery) ([]*dto.TodoSearchHit, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT `+todoItemColumns+`,
+			ts_rank_cd(search_vector, q) AS rank,
+			ts_headline('english', concat_ws(E'\n', title, description, (
+				SELECT string_agg(body, E'\n' ORDER BY created_at)
+				FROM todo_comments c
+				WHERE c.todo_id = t.id
+			)), q, $2)
+		FROM todo_items t, to_tsquery('english', $1) q
+		WHERE search_vector @@ q
+			AND ((user_id = $3 AND project_id IS NULL) OR project_id = ANY($4::uuid[]))
+		ORDER BY rank DESC, created_at, id
+		LIMIT $5
+	`,
+		toTsQuery(query.Query),
+		headlineOptions,
+		query.UserId.Value(),
+		query.ProjectIds,
+		query.Limit,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	hits := make([]*dto.TodoSearchHit, 0)
+
+	for rows.Next() {
+
+		var rank float64
+		var snippet string
+
+		todo, err := scanTodoItem(rows, &rank, &snippet)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, &dto.TodoSearchHit{Todo: todo, Rank: rank, Snippet: snippet})
+	}
+
+	return hits, rows.Err()
+}
+
+// Convert query to to_tsquery syntax. Words consist of letters and digits
+// only, so they never contain tsquery operators.
+func toTsQuery(query value.SearchQuery) string {
+
+	terms := make([]string, len(query.Terms()))
+
+	for i, term := range query.Terms() {
+
+		terms[i] = strings.Join(term.Words(), " <-> ")
+
+		if term.IsPrefix() {
+			terms[i] += ":*"
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,124 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo search repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var todoCommentRepository = postgres.NewTodoCommentRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var todoSearchRepository = postgres.NewTodoSearchRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	search := func(q string) []*dto.TodoSearchHit {
+
+		hits, err := todoSearchRepository.Search(&dto.SearchTodoQuery{
+			UserId: userId,
+			Query: value.NewSearchQuery(q),
+			Limit: 10,
+		})
+
+		Expect(err).To(BeNil())
+
+		return hits
+	}
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_search_user"),
+			Email: value.NewEmail("todo-search-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-search-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		for _, todo := range [][2]string{
+			{"Deploy release", "ship the release notes to customers"},
+			{"Buy milk", "groceries"},
+		} {
+			todoRepository.Create(&dto.CreateTodoCommand{
+				UserId: userId,
+				Title: value.NewTodoItemTitle(todo[0]),
+				Description: value.NewTodoItemDescription(todo[1]),
+			})
+		}
+	})
+
+	It("should rank title over description with stemming", func() {
+
+		hits := search("releases")
+
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].Todo.Title().Value()).To(Equal("Deploy release"))
+		Expect(hits[0].Snippet).To(ContainSubstring(dto.SnippetHighlightStart + "release" + dto.SnippetHighlightStop))
+	})
+
+	It("should match prefix and phrase", func() {
+
+		Expect(search("depl*")).To(HaveLen(1))
+		Expect(search(`"release notes"`)).To(HaveLen(1))
+		Expect(search(`"notes release"`)).To(BeEmpty())
+	})
+
+	It("should match comments kept up to date by trigger", func() {
+
+		todos, err := todoRepository.List(userId)
+
+		Expect(err).To(BeNil())
+
+		var milk value.TodoItemId
+
+		for _, todo := range todos {
+			if todo.Title().Value() == "Buy milk" {
+				milk = todo.Id()
+			}
+		}
+
+		comment, err := todoCommentRepository.Create(&dto.CreateTodoCommentCommand{
+			TodoId: milk,
+			AuthorId: userId,
+			Body: "oat milk please",
+		})
+
+		Expect(err).To(BeNil())
+
+		hits := search("oat")
+
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].Todo.Id()).To(Equal(milk))
+
+		Expect(todoCommentRepository.Delete(comment.Id())).To(BeNil())
+
+		Expect(search("oat")).To(BeEmpty())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,393 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+)
+
+// Field clock as stored in field_clocks column.
+type todoClockJson struct {
+	At time.Time `json:"at"`
+	By string    `json:"by"`
+}
+
+func toFieldClocksJson(clocks map[string]entity.TodoClock) ([]byte, error) {
+
+	j := make(map[string]todoClockJson, len(clocks))
+
+	for field, clock := range clocks {
+		j[field] = todoClockJson{clock.ChangedAt(), clock.Origin()}
+	}
+
+	return json.Marshal(j)
+}
+
+func fieldClocksOf(b []byte) (map[string]entity.TodoClock, error) {
+
+	var j map[string]todoClockJson
+
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+
+	clocks := make(map[string]entity.TodoClock, len(j))
+
+	for field, clock := range j {
+		clocks[field] = entity.NewTodoClock(clock.At, clock.By)
+	}
+
+	return clocks, nil
+}
+
+// Sync of todo items, numbered and clocked by triggers on todo_items.
+type TodoSyncRepository struct {
+	connectionString string
+}
+
+func NewTodoSyncRepository(connectionString string) *TodoSyncRepository {
+	return &TodoSyncRepository{connectionString}
+}
+
+// Columns scanned by scanTodoRevisions.
+const todoRevisionColumns = todoItemColumns + ", seq, field_clocks"
+
+func (r *TodoSyncRepository) ListChanges(query *dto.ListTodoChangesQuery) ([]*entity.TodoRevision, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT ` + todoRevisionColumns + `
+		FROM todo_items
+		WHERE seq > $1
+		AND (user_id = $2 AND project_id IS NULL OR project_id = ANY($3::uuid[]))
+		ORDER BY seq
+		LIMIT $4
+	`, query.Since, query.UserId.Value(), query.ProjectIds, query.Limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := scanTodoRevisions(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients syncing from start have nothing to delete.
+	if query.Since > 0 {
+
+		rows, err := conn.Query(ctx, `
+			SELECT todo_id, seq
+			FROM todo_tombstones
+			WHERE seq > $1
+			AND (user_id = $2 AND project_id IS NULL OR project_id = ANY($3::uuid[]))
+			ORDER BY seq
+			LIMIT $4
+		`, query.Since, query.UserId.Value(), query.ProjectIds, query.Limit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		deleted, err := scanTombstones(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, deleted...)
+
+		sort.Slice(revisions, func(i, j int) bool {
+			return revisions[i].Seq() < revisions[j].Seq()
+		})
+	}
+
+	if len(revisions) > query.Limit {
+		revisions = revisions[:query.Limit]
+	}
+
+	return revisions, nil
+}
+
+func (r *TodoSyncRepository) GetRevisions(todoIds []value.TodoItemId) ([]*entity.TodoRevision, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	ids := make([]string, len(todoIds))
+
+	for i, id := range todoIds {
+		ids[i] = id.Value()
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT ` + todoRevisionColumns + `
+		FROM todo_items
+		WHERE id = ANY($1::uuid[])
+	`, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := scanTodoRevisions(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = conn.Query(ctx, `
+		SELECT todo_id, seq
+		FROM todo_tombstones
+		WHERE todo_id = ANY($1::uuid[])
+	`, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := scanTombstones(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(revisions, deleted...), nil
+}
+
+func scanTodoRevisions(rows pgx.Rows) ([]*entity.TodoRevision, error) {
+
+	defer rows.Close()
+
+	revisions := make([]*entity.TodoRevision, 0)
+
+	for rows.Next() {
+
+		var seq int64
+		var fieldClocks []byte
+
+		todo, err := scanTodoItem(rows, &seq, &fieldClocks)
+
+		if err != nil {
+			return nil, err
+		}
+
+		clocks, err := fieldClocksOf(fieldClocks)
+
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, entity.NewTodoRevision(todo.Id(), todo, clocks, seq))
+	}
+
+	return revisions, rows.Err()
+}
+
+func scanTombstones(rows pgx.Rows) ([]*entity.TodoRevision, error) {
+
+	defer rows.Close()
+
+	revisions := make([]*entity.TodoRevision, 0)
+
+	for rows.Next() {
+
+		var todoId string
+		var seq int64
+
+		if err := rows.Scan(&todoId, &seq); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, entity.NewTodoRevision(value.NewTodoItemId(todoId), nil, nil, seq))
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *TodoSyncRepository) GetOperations(userId value.UserId, operationIds []string) ([]*entity.TodoSyncOperation, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT operation_id, todo_id, status, message
+		FROM todo_sync_operations
+		WHERE user_id = $1 AND operation_id = ANY($2)
+	`, userId.Value(), operationIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	operations := make([]*entity.TodoSyncOperation, 0)
+
+	for rows.Next() {
+
+		var (
+			id string
+			todoId string
+			status string
+			message string
+		)
+
+		if err := rows.Scan(&id, &todoId, &status, &message); err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, entity.NewTodoSyncOperation(id, userId, todoId, status, message))
+	}
+
+	return operations, rows.Err()
+}
+
+func (r *TodoSyncRepository) WriteSync(command *dto.TodoSyncCommand) (err error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	tran, err := conn.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
+
+	// Queue all statements to send them in one round trip.
+	// Writes of todo items must each hit one row, or todo item changed since read.
+	queue := &pgx.Batch{}
+
+	for _, revision := range command.Saves {
+
+		todo := revision.Todo()
+
+		var fieldClocks []byte
+
+		fieldClocks, err = toFieldClocksJson(revision.Clocks())
+
+		if err != nil {
+			return err
+		}
+
+		if revision.Seq() == 0 {
+			queue.Queue(`
+				INSERT INTO todo_items (` + todoItemColumns + `, field_clocks)
+				SELECT $1::uuid, $2::varchar, $3::varchar, $4::boolean, $5::uuid, $6::uuid, $7::uuid, $8::jsonb
+				WHERE NOT EXISTS (SELECT 1 FROM todo_tombstones WHERE todo_id = $1::uuid)
+				ON CONFLICT (id) DO NOTHING`,
+				todo.Id().Value(),
+				todo.Title().Value(),
+				todo.Description().Value(),
+				todo.IsDone(),
+				todo.UserId().Value(),
+				nullable(todo.ProjectId()),
+				nullableUserId(todo.AssigneeId()),
+				fieldClocks,
+			)
+			continue
+		}
+
+		queue.Queue(`
+			UPDATE todo_items
+			SET title = $1, description = $2, is_done = $3, project_id = $4, assignee_id = $5, field_clocks = $6
+			WHERE id = $7 AND seq = $8`,
+			todo.Title().Value(),
+			todo.Description().Value(),
+			todo.IsDone(),
+			nullable(todo.ProjectId()),
+			nullableUserId(todo.AssigneeId()),
+			fieldClocks,
+			todo.Id().Value(),
+			revision.Seq(),
+		)
+	}
+
+	for _, revision := range command.Deletes {
+		queue.Queue(`DELETE FROM todo_items WHERE id = $1 AND seq = $2`, revision.TodoId().Value(), revision.Seq())
+	}
+
+	for _, o := range command.Operations {
+		queue.Queue(`
+			INSERT INTO todo_sync_operations (user_id, operation_id, todo_id, status, message)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, operation_id) DO NOTHING`,
+			o.UserId().Value(),
+			o.Id(),
+			o.TodoId(),
+			o.Status(),
+			o.Message(),
+		)
+	}
+
+	if queue.Len() == 0 {
+		return nil
+	}
+
+	results := tran.SendBatch(ctx, queue)
+
+	for range len(command.Saves) + len(command.Deletes) {
+
+		tag, execErr := results.Exec()
+
+		if execErr != nil {
+			results.Close()
+			return execErr
+		}
+
+		if tag.RowsAffected() != 1 {
+			results.Close()
+			return persistence.ErrTodoRevisionChanged
+		}
+	}
+
+	err = results.Close()
+
+	return err
+}
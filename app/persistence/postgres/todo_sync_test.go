@@ -0,0 +1,179 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/app/port/out/persistence"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo sync repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var syncRepository = postgres.NewTodoSyncRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var todo *entity.TodoItem
+
+	revisionOf := func(todoId value.TodoItemId) *entity.TodoRevision {
+
+		revisions, err := syncRepository.GetRevisions([]value.TodoItemId{todoId})
+
+		Expect(err).To(BeNil())
+		Expect(revisions).To(HaveLen(1))
+
+		return revisions[0]
+	}
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_sync_user"),
+			Email: value.NewEmail("todo-sync-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-sync-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		todo, err = todoRepository.Create(&dto.CreateTodoCommand{
+			UserId: userId,
+			Title: value.NewTodoItemTitle("sync todo"),
+			Description: value.NewTodoItemDescription("sync todo description"),
+		})
+
+		if err != nil {
+			panic("fail to create todo item")
+		}
+	})
+
+	It("should number and clock changes made through API", func() {
+
+		created := revisionOf(todo.Id())
+
+		Expect(created.Seq()).To(BeNumerically(">", 0))
+		Expect(created.Clocks()).To(HaveKey(entity.TodoFieldTitle))
+		Expect(created.Clocks()).To(HaveKey(entity.TodoFieldIsDone))
+
+		todo.Complete()
+
+		Expect(todoRepository.Update(todo)).To(BeNil())
+
+		completed := revisionOf(todo.Id())
+
+		Expect(completed.Seq()).To(BeNumerically(">", created.Seq()))
+		Expect(completed.Clocks()[entity.TodoFieldIsDone].ChangedAt()).To(BeTemporally(">", created.Clocks()[entity.TodoFieldIsDone].ChangedAt()))
+		Expect(completed.Clocks()[entity.TodoFieldTitle]).To(Equal(created.Clocks()[entity.TodoFieldTitle]))
+
+		changes, err := syncRepository.ListChanges(&dto.ListTodoChangesQuery{UserId: userId, Since: created.Seq(), Limit: 10})
+
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Todo().IsDone()).To(BeTrue())
+	})
+
+	It("should write sync unless todo item changed since read", func() {
+
+		revision := revisionOf(todo.Id())
+
+		stale := entity.NewTodoRevision(todo.Id(), revision.Todo(), revision.Clocks(), revision.Seq() - 1)
+
+		Expect(syncRepository.WriteSync(&dto.TodoSyncCommand{Saves: []*entity.TodoRevision{stale}})).To(Equal(persistence.ErrTodoRevisionChanged))
+
+		revision.Todo().ChangeTitle("synced title")
+		revision.Claim(entity.TodoFieldTitle, entity.NewTodoClock(time.Now(), "op-title"))
+
+		clientTodo := entity.NewTodoItem(
+			value.NewTodoItemId(uuid.NewString()),
+			value.NewTodoItemTitle("client todo"),
+			value.NewTodoItemDescription("client todo description"),
+			false,
+			userId,
+			"",
+			nil,
+		)
+
+		created := entity.NewTodoRevision(clientTodo.Id(), clientTodo, nil, 0)
+		created.Claim(entity.TodoFieldTitle, entity.NewTodoClock(time.Now(), "op-create"))
+
+		Expect(syncRepository.WriteSync(&dto.TodoSyncCommand{
+			Saves: []*entity.TodoRevision{revision, created},
+			Operations: []*entity.TodoSyncOperation{
+				entity.NewTodoSyncOperation("op-title", userId, todo.Id().Value(), entity.TodoSyncApplied, ""),
+				entity.NewTodoSyncOperation("op-create", userId, created.TodoId().Value(), entity.TodoSyncApplied, ""),
+			},
+		})).To(BeNil())
+
+		synced := revisionOf(todo.Id())
+
+		Expect(synced.Todo().Title().Value()).To(Equal("synced title"))
+		Expect(synced.Clocks()[entity.TodoFieldTitle].Origin()).To(Equal("op-title"))
+		Expect(synced.Seq()).To(BeNumerically(">", revision.Seq()))
+
+		Expect(revisionOf(created.TodoId()).Clocks()[entity.TodoFieldTitle].Origin()).To(Equal("op-create"))
+
+		operations, err := syncRepository.GetOperations(userId, []string{"op-title", "op-create", "op-unknown"})
+
+		Expect(err).To(BeNil())
+		Expect(operations).To(HaveLen(2))
+	})
+
+	It("should leave tombstone of deleted todo item", func() {
+
+		before := revisionOf(todo.Id())
+
+		Expect(todoRepository.Delete(todo.Id())).To(BeNil())
+
+		deleted := revisionOf(todo.Id())
+
+		Expect(deleted.IsDeleted()).To(BeTrue())
+		Expect(deleted.Seq()).To(BeNumerically(">", before.Seq()))
+
+		changes, err := syncRepository.ListChanges(&dto.ListTodoChangesQuery{UserId: userId, Since: before.Seq(), Limit: 10})
+
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].IsDeleted()).To(BeTrue())
+
+		// Listing from start leaves deleted todo items out.
+		changes, err = syncRepository.ListChanges(&dto.ListTodoChangesQuery{UserId: userId, Since: 0, Limit: 10})
+
+		Expect(err).To(BeNil())
+
+		for _, change := range changes {
+			Expect(change.IsDeleted()).To(BeFalse())
+		}
+
+		// Re-creating deleted todo item is refused.
+		recreated := entity.NewTodoRevision(todo.Id(), todo, nil, 0)
+
+		Expect(syncRepository.WriteSync(&dto.TodoSyncCommand{Saves: []*entity.TodoRevision{recreated}})).To(Equal(persistence.ErrTodoRevisionChanged))
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, todo_tombstones, todo_sync_operations, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+// Todo item state as stored in changes column.
+type todoStateJson struct {
+	Id          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	IsDone      bool    `json:"isDone"`
+	UserId      string  `json:"userId"`
+	ProjectId   string  `json:"projectId"`
+	AssigneeId  *string `json:"assigneeId"`
+}
+
+// Todo change as stored in changes column. nil states are null.
+type todoChangeJson struct {
+	Before *todoStateJson `json:"before"`
+	After  *todoStateJson `json:"after"`
+}
+
+func toTodoStateJson(todo *entity.TodoItem) *todoStateJson {
+
+	if todo == nil {
+		return nil
+	}
+
+	return &todoStateJson{
+		Id: todo.Id().Value(),
+		Title: todo.Title().Value(),
+		Description: todo.Description().Value(),
+		IsDone: todo.IsDone(),
+		UserId: todo.UserId().Value(),
+		ProjectId: todo.ProjectId(),
+		AssigneeId: nullableUserId(todo.AssigneeId()),
+	}
+}
+
+func (s *todoStateJson) toTodoItem() *entity.TodoItem {
+
+	if s == nil {
+		return nil
+	}
+
+	return entity.NewTodoItem(
+		value.NewTodoItemId(s.Id),
+		value.NewTodoItemTitle(s.Title),
+		value.NewTodoItemDescription(s.Description),
+		s.IsDone,
+		value.NewUserId(s.UserId),
+		s.ProjectId,
+		userIdOf(s.AssigneeId),
+	)
+}
+
+type TodoUndoRepository struct {
+	connectionString string
+}
+
+func NewTodoUndoRepository(connectionString string) *TodoUndoRepository {
+	return &TodoUndoRepository{connectionString}
+}
+
+func (r *TodoUndoRepository) Create(undo *dto.CreateTodoUndoCommand) error {
+
+	changes := make([]todoChangeJson, len(undo.Changes))
+
+	for i, change := range undo.Changes {
+		changes[i] = todoChangeJson{toTodoStateJson(change.Before()), toTodoStateJson(change.After())}
+	}
+
+	changesJson, err := json.Marshal(changes)
+
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO todo_undos (
+			token_hash, user_id, changes, expires_at
+		)
+		VALUES ($1, $2, $3, $4)`,
+		undo.TokenHash,
+		undo.UserId.Value(),
+		changesJson,
+		undo.ExpiresAt,
+	)
+
+	return err
+}
+
+func (r *TodoUndoRepository) Get(tokenHash string) (*entity.TodoUndo, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		userId string
+		changesJson []byte
+		expiresAt time.Time
+		usedAt *time.Time
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT user_id, changes, expires_at, used_at
+		FROM todo_undos
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&userId, &changesJson, &expiresAt, &usedAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []todoChangeJson
+
+	if err := json.Unmarshal(changesJson, &stored); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*entity.TodoChange, len(stored))
+
+	for i, change := range stored {
+		changes[i] = entity.NewTodoChange(change.Before.toTodoItem(), change.After.toTodoItem())
+	}
+
+	return entity.NewTodoUndo(tokenHash, value.NewUserId(userId), changes, expiresAt, usedAt), nil
+}
+
+func (r *TodoUndoRepository) Update(undo *entity.TodoUndo) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		UPDATE todo_undos
+		SET used_at = $1
+		WHERE token_hash = $2`,
+		undo.UsedAt(),
+		undo.TokenHash(),
+	)
+
+	return err
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo undo repository test", Ordered, func() {
+
+	var todoRepository = postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var undoRepository = postgres.NewTodoUndoRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	var todo *entity.TodoItem
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("todo_undo_user"),
+			Email: value.NewEmail("todo-undo-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("todo-undo-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+
+		todo, err = todoRepository.Create(&dto.CreateTodoCommand{
+			UserId: userId,
+			Title: value.NewTodoItemTitle("undo todo"),
+			Description: value.NewTodoItemDescription("undo todo description"),
+		})
+
+		if err != nil {
+			panic("fail to create todo item")
+		}
+	})
+
+	It("should keep todo states of undo", func() {
+
+		after := todo.Copy()
+		after.Complete()
+		after.AssignTo(userId)
+
+		Expect(undoRepository.Create(&dto.CreateTodoUndoCommand{
+			TokenHash: "todo-undo-hash",
+			UserId: userId,
+			Changes: []*entity.TodoChange{entity.NewTodoChange(todo, after), entity.NewTodoChange(todo, nil)},
+			ExpiresAt: time.Now().Add(time.Minute),
+		})).To(BeNil())
+
+		undo, err := undoRepository.Get("todo-undo-hash")
+
+		Expect(err).To(BeNil())
+		Expect(undo.UserId()).To(Equal(userId))
+		Expect(undo.Changes()).To(HaveLen(2))
+		Expect(undo.Changes()[0].Before().Equals(todo)).To(BeTrue())
+		Expect(undo.Changes()[0].After().Equals(after)).To(BeTrue())
+		Expect(undo.Changes()[1].After()).To(BeNil())
+		Expect(undo.IsUsable(time.Now())).To(BeTrue())
+
+		undo.Use(time.Now())
+
+		Expect(undoRepository.Update(undo)).To(BeNil())
+
+		undo, err = undoRepository.Get("todo-undo-hash")
+
+		Expect(err).To(BeNil())
+		Expect(undo.IsUsable(time.Now())).To(BeFalse())
+	})
+
+	It("should restore deleted todo item with its ID", func() {
+
+		Expect(todoRepository.Delete(todo.Id())).To(BeNil())
+
+		Expect(todoRepository.WriteBatch(&dto.TodoBatchCommand{
+			Restores: []*entity.TodoItem{todo},
+		})).To(BeNil())
+
+		restored, err := todoRepository.Get(todo.Id())
+
+		Expect(err).To(BeNil())
+		Expect(restored.Equals(todo)).To(BeTrue())
+	})
+
+	It("should not find unknown undo", func() {
+
+		undo, err := undoRepository.Get("unknown-hash")
+
+		Expect(err).To(BeNil())
+		Expect(undo).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+)
+
+type TwoFactorRepository struct {
+	connectionString string
+}
+
+func NewTwoFactorRepository(connectionString string) *TwoFactorRepository {
+	return &TwoFactorRepository{connectionString}
+}
+
+func (r *TwoFactorRepository) Create(twoFactor *dto.CreateTwoFactorCommand) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO user_two_factors (
+			user_id, secret, is_enabled, recovery_code_hashes, last_used_step
+		)
+		VALUES ($1, $2, false, '{}', 0)`,
+		twoFactor.UserId.Value(),
+		twoFactor.Secret,
+	)
+
+	return err
+}
+
+func (r *TwoFactorRepository) Get(userId value.UserId) (*entity.TwoFactor, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	var (
+		secret string
+		isEnabled bool
+		recoveryCodeHashes []string
+		lastUsedStep int64
+	)
+
+	err = conn.QueryRow(ctx, `
+		SELECT secret, is_enabled, recovery_code_hashes, last_used_step
+		FROM user_two_factors
+		WHERE user_id = $1
+	`, userId.Value()).Scan(&secret, &isEnabled, &recoveryCodeHashes, &lastUsedStep)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTwoFactor(userId, secret, isEnabled, recoveryCodeHashes, lastUsedStep), nil
+}
+
+func (r *TwoFactorRepository) Update(twoFactor *entity.TwoFactor) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	recoveryCodeHashes := twoFactor.RecoveryCodeHashes()
+
+	if recoveryCodeHashes == nil {
+		recoveryCodeHashes = []string{}
+	}
+
+	_, err = conn.Exec(ctx, `
+		UPDATE user_two_factors
+		SET secret = $1, is_enabled = $2, recovery_code_hashes = $3, last_used_step = $4
+		WHERE user_id = $5`,
+		twoFactor.Secret(),
+		twoFactor.IsEnabled(),
+		recoveryCodeHashes,
+		twoFactor.LastUsedStep(),
+		twoFactor.UserId().Value(),
+	)
+
+	return err
+}
+
+func (r *TwoFactorRepository) Delete(userId value.UserId) error {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `
+		DELETE FROM user_two_factors
+		WHERE user_id = $1
+	`, userId.Value())
+
+	return err
+}
@@ -0,0 +1,153 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/persistence/postgres"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("two-factor repository test", Ordered, func() {
+
+	var twoFactorRepository = postgres.NewTwoFactorRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var loginChallengeRepository = postgres.NewLoginChallengeRepository(os.Getenv("TEST_DATABASE_URL"))
+
+	var userId value.UserId
+
+	BeforeAll(func() {
+
+		userRepository := postgres.NewUserRepository(os.Getenv("TEST_DATABASE_URL"))
+
+		userRepository.Create(&dto.CreateUserCommand{
+			UserName: value.NewUserName("two_factor_user"),
+			Email: value.NewEmail("two-factor-test@example.com"),
+			Password: value.NewPassword("test-pass"),
+		})
+
+		user, err := userRepository.GetByEmail(value.NewEmail("two-factor-test@example.com"))
+
+		if err != nil || user == nil {
+			panic("fail to get user")
+		}
+
+		userId = user.Id()
+	})
+
+	It("should create pending two-factor", func() {
+
+		err := twoFactorRepository.Create(&dto.CreateTwoFactorCommand{
+			UserId: userId,
+			Secret: "JBSWY3DPEHPK3PXP",
+		})
+
+		Expect(err).To(BeNil())
+
+		twoFactor, err := twoFactorRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(twoFactor).ToNot(BeNil())
+		Expect(twoFactor.IsEnabled()).To(BeFalse())
+		Expect(twoFactor.Secret()).To(Equal("JBSWY3DPEHPK3PXP"))
+	})
+
+	It("should update two-factor", func() {
+
+		twoFactor, _ := twoFactorRepository.Get(userId)
+
+		twoFactor.Enable([]string{"hash-a", "hash-b"})
+		twoFactor.UseStep(100)
+
+		Expect(twoFactorRepository.Update(twoFactor)).To(BeNil())
+
+		twoFactor, err := twoFactorRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(twoFactor.IsEnabled()).To(BeTrue())
+		Expect(twoFactor.RecoveryCodeHashes()).To(Equal([]string{"hash-a", "hash-b"}))
+		Expect(twoFactor.LastUsedStep()).To(Equal(int64(100)))
+	})
+
+	It("should store login challenge", func() {
+
+		err := loginChallengeRepository.Create(&dto.CreateLoginChallengeCommand{
+			TokenHash: "challenge-hash",
+			UserId: userId,
+			ExpiresAt: time.Now().Add(time.Minute),
+		})
+
+		Expect(err).To(BeNil())
+
+		challenge, err := loginChallengeRepository.Get("challenge-hash")
+
+		Expect(err).To(BeNil())
+		Expect(challenge.UserId()).To(Equal(userId))
+
+		Expect(loginChallengeRepository.Fail("challenge-hash")).To(BeNil())
+
+		challenge, _ = loginChallengeRepository.Get("challenge-hash")
+
+		Expect(challenge.FailedAttempts()).To(Equal(1))
+
+		deleted, err := loginChallengeRepository.Delete("challenge-hash", time.Now())
+
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeTrue())
+
+		challenge, err = loginChallengeRepository.Get("challenge-hash")
+
+		Expect(err).To(BeNil())
+		Expect(challenge).To(BeNil())
+
+		deleted, err = loginChallengeRepository.Delete("challenge-hash", time.Now())
+
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should refuse to redeem login challenge failed too often", func() {
+
+		err := loginChallengeRepository.Create(&dto.CreateLoginChallengeCommand{
+			TokenHash: "failed-hash",
+			UserId: userId,
+			ExpiresAt: time.Now().Add(time.Minute),
+		})
+
+		Expect(err).To(BeNil())
+
+		for range entity.MaxLoginChallengeAttempts {
+			Expect(loginChallengeRepository.Fail("failed-hash")).To(BeNil())
+		}
+
+		deleted, err := loginChallengeRepository.Delete("failed-hash", time.Now())
+
+		Expect(err).To(BeNil())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should delete two-factor", func() {
+
+		Expect(twoFactorRepository.Delete(userId)).To(BeNil())
+
+		twoFactor, err := twoFactorRepository.Get(userId)
+
+		Expect(err).To(BeNil())
+		Expect(twoFactor).To(BeNil())
+	})
+
+	AfterAll(func() {
+		ctx := context.Background()
+		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
+		Expect(err).To(BeNil())
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
+		Expect(err).To(BeNil())
+	})
+})
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,13 +24,13 @@ func (r *UserRepository) Create(user *dto.CreateUserCommand) error {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
 		return err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
 	tran, err := conn.Begin(ctx)
 
@@ -61,93 +63,180 @@ func (r *UserRepository) Create(user *dto.CreateUserCommand) error {
 }
 
 func (r *UserRepository) GetById(userId value.UserId) (*entity.User, error) {
+	return r.getBy("id", userId.Value())
+}
+
+func (r *UserRepository) GetByEmail(email value.Email) (*entity.User, error) {
+	return r.getBy("email", email.Value())
+}
+
+func (r *UserRepository) GetMany(userIds []value.UserId) ([]*entity.User, error) {
+
+	ids := make([]string, len(userIds))
+
+	for i, id := range userIds {
+		ids[i] = id.Value()
+	}
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		SELECT id, username, email, password, role, is_disabled
+		FROM users
+		WHERE id = ANY($1::uuid[])
+		ORDER BY id
+	`, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanUsers(rows)
+}
+
+func (r *UserRepository) getBy(column string, key string) (*entity.User, error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil { return nil, err }
 
+	defer conn.Release()
+
 	rows, err := conn.Query(ctx, `
-		SELECT id, username, email, password
+		SELECT id, username, email, password, role, is_disabled
 		FROM users
-		WHERE id = $1
-	`, userId.Value())
+		WHERE ` + column + ` = $1
+	`, key)
 
 	if err != nil { 
 		return nil, err
 	}
 
-	var (
-		id string
-		username string
-		email string
-		password string
-	)
+	defer rows.Close()
 
-	if rows.Next() {
-		rows.Scan(&id, &username, &email, &password)
-		return entity.NewUser(
-			value.NewUserId(id),
-			value.NewUserName(username),
-			value.NewEmail(email),
-			value.NewPassword(password),
-		), nil
+	users, err := scanUsers(rows)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return users[0], nil
 }
 
-func (r *UserRepository) GetByEmail(email value.Email) (*entity.User, error) {
+func (r *UserRepository) List(query *dto.ListUsersQuery) ([]*entity.User, int, error) {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
-	if err != nil { return nil, err }
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer conn.Release()
+
+	pattern := "%" + escapeLike(query.Search) + "%"
 
-	defer conn.Close(ctx)
+	var total int
+
+	err = conn.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM users
+		WHERE username ILIKE $1 OR email ILIKE $1
+	`, pattern).Scan(&total)
+
+	if err != nil {
+		return nil, 0, err
+	}
 
 	rows, err := conn.Query(ctx, `
-		SELECT id, username, password
+		SELECT id, username, email, password, role, is_disabled
 		FROM users
-		WHERE email = $1
-	`, email.Value())
+		WHERE username ILIKE $1 OR email ILIKE $1
+		ORDER BY email
+		OFFSET $2
+		LIMIT $3
+	`, pattern, query.Page.Offset, query.Page.Limit)
 
-	if err != nil { 
-		return nil, err
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	users, err := scanUsers(rows)
+
+	if err != nil {
+		return nil, 0, err
 	}
 
+	return users, total, nil
+}
+
+func scanUsers(rows pgx.Rows) ([]*entity.User, error) {
+
 	var (
 		id string
 		username string
+		email string
 		password string
+		role string
+		isDisabled bool
 	)
 
-	if rows.Next() {
-		rows.Scan(&id, &username, &password)
-		return entity.NewUser(
+	users := make([]*entity.User, 0)
+
+	for rows.Next() {
+
+		if err := rows.Scan(&id, &username, &email, &password, &role, &isDisabled); err != nil {
+			return nil, err
+		}
+
+		users = append(users, entity.NewUser(
 			value.NewUserId(id),
 			value.NewUserName(username),
-			email,
+			value.NewEmail(email),
 			value.NewPassword(password),
-		), nil
+			value.NewRole(role),
+			isDisabled,
+		))
 	}
 
-	return nil, nil
+	return users, rows.Err()
+}
+
+// Escape wildcards of LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 func (r *UserRepository) Update(user *entity.User) error {
 
 	ctx := context.Background()
 
-	conn, err := pgx.Connect(ctx, r.connectionString)
+	conn, err := connect(ctx, r.connectionString)
 
 	if err != nil {
 		return err
 	}
 
-	defer conn.Close(ctx)
+	defer conn.Release()
 
 	tran, err := conn.Begin(ctx)
 
@@ -165,12 +254,138 @@ func (r *UserRepository) Update(user *entity.User) error {
 
 	_, err = tran.Exec(context.Background(), `
 		UPDATE users
-		SET username = $1, email = $2, password = $3
+		SET username = $1, email = $2, password = $3, role = $4, is_disabled = $5
+		WHERE id = $6
 		`,
 		user.UserName().Value(),
 		user.Email().Value(),
 		user.Password().Value(),
+		user.Role().Value(),
+		user.IsDisabled(),
+		user.Id().Value(),
 	)
 
+	return err
+}
+
+// Tables holding rows owned by user, deleted before the user itself.
+var userOwnedTables = []string{
+	"todo_items",
+	"sessions",
+	"password_resets",
+	"user_two_factors",
+	"login_challenges",
+	"email_changes",
+	"account_deletions",
+	"access_tokens",
+	"todo_undos",
+	"todo_events",
+	"todo_tombstones",
+	"todo_sync_operations",
+	"calendar_feeds",
+}
+
+// Statements handing shared projects over to remaining members before user leaves them.
+// Assignments to user and comments by user are dropped, and projects left without members are deleted.
+var projectHandoverStatements = []string{
+	`UPDATE todo_items SET assignee_id = NULL WHERE assignee_id = $1`,
+	`DELETE FROM todo_comments WHERE author_id = $1`,
+	// Give todo items of user in shared projects to the highest ranked remaining member.
+	`UPDATE todo_items t
+	SET user_id = (
+		SELECT m.user_id FROM project_members m
+		WHERE m.project_id = t.project_id AND m.user_id <> $1
+		ORDER BY m.permission = 'owner' DESC, m.permission = 'editor' DESC, m.user_id
+		LIMIT 1
+	)
+	WHERE t.user_id = $1 AND EXISTS (
+		SELECT 1 FROM project_members m
+		WHERE m.project_id = t.project_id AND m.user_id <> $1
+	)`,
+	// Files user attached to todo items that survive go to owner of the todo item.
+	`UPDATE todo_attachments a
+	SET uploader_id = t.user_id
+	FROM todo_items t
+	WHERE a.todo_id = t.id AND a.uploader_id = $1 AND t.user_id <> $1`,
+	// Promote the highest ranked remaining member where user is the last owner.
+	`UPDATE project_members m
+	SET permission = 'owner'
+	WHERE m.user_id = (
+		SELECT o.user_id FROM project_members o
+		WHERE o.project_id = m.project_id AND o.user_id <> $1
+		ORDER BY o.permission = 'editor' DESC, o.user_id
+		LIMIT 1
+	)
+	AND m.project_id IN (
+		SELECT project_id FROM project_members
+		WHERE user_id = $1 AND permission = 'owner'
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM project_members o
+		WHERE o.project_id = m.project_id AND o.user_id <> $1 AND o.permission = 'owner'
+	)`,
+	`DELETE FROM project_invites WHERE invited_by = $1`,
+	`DELETE FROM project_members WHERE user_id = $1`,
+	`DELETE FROM todo_items WHERE project_id IN (
+		SELECT id FROM projects p
+		WHERE NOT EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = p.id)
+	)`,
+	`DELETE FROM project_invites WHERE project_id IN (
+		SELECT id FROM projects p
+		WHERE NOT EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = p.id)
+	)`,
+	`DELETE FROM projects p
+	WHERE NOT EXISTS (SELECT 1 FROM project_members m WHERE m.project_id = p.id)`,
+}
+
+func (r *UserRepository) Delete(userId value.UserId) (err error) {
+
+	ctx := context.Background()
+
+	conn, err := connect(ctx, r.connectionString)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	tran, err := conn.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func ()  {
+		if err != nil {
+			_ = tran.Rollback(ctx)
+			return
+		}
+		err = tran.Commit(ctx)
+	}()
+
+	for _, statement := range projectHandoverStatements {
+
+		_, err = tran.Exec(ctx, statement, userId.Value())
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, table := range userOwnedTables {
+
+		_, err = tran.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE user_id = $1", table), userId.Value())
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tran.Exec(ctx, `
+		DELETE FROM users
+		WHERE id = $1
+	`, userId.Value())
+
 	return err
 }
\ No newline at end of file
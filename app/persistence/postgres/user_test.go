@@ -53,6 +53,13 @@ var _ = Describe("postgres repository test", Ordered, func() {
 			Expect(fetched.Password()).To(Equal(value.NewPassword("test-password-01")))
 		})
 
+		It("can get many users by ids", func() {
+			users, err := userRepository.GetMany([]value.UserId{userId, value.NewUserId("00000000-0000-0000-0000-000000000000")})
+			Expect(err).To(BeNil())
+			Expect(users).To(HaveLen(1))
+			Expect(users[0].Id()).To(Equal(userId))
+		})
+
 		When("updating user", func() {
 			
 			It("should have updated values", func() {
@@ -65,6 +72,69 @@ var _ = Describe("postgres repository test", Ordered, func() {
 				Expect(updatedUser.Email()).To(Equal(value.NewEmail("another@example.com")))
 				Expect(updatedUser.Password()).To(Equal(value.NewPassword("test-password-02")))
 			})
+
+			It("should have updated role and disabled state", func() {
+				Expect(user.Role()).To(Equal(value.RoleUser))
+				Expect(user.IsDisabled()).To(BeFalse())
+
+				user.ChangeRole(value.RoleAdmin)
+				user.Disable()
+				err = userRepository.Update(user)
+
+				Expect(err).To(BeNil())
+				updatedUser, _ := userRepository.GetById(userId)
+				Expect(updatedUser.Role()).To(Equal(value.RoleAdmin))
+				Expect(updatedUser.IsDisabled()).To(BeTrue())
+			})
+		})
+
+		When("listing users", func() {
+
+			It("should search by part of email", func() {
+				users, total, err := userRepository.List(&dto.ListUsersQuery{
+					Search: "ANOTHER@",
+					Page: dto.PageQuery{ Offset: 0, Limit: 10 },
+				})
+				Expect(err).To(BeNil())
+				Expect(total).To(Equal(1))
+				Expect(users).To(HaveLen(1))
+				Expect(users[0].Id()).To(Equal(userId))
+			})
+
+			It("should treat wildcards literally", func() {
+				users, total, err := userRepository.List(&dto.ListUsersQuery{
+					Search: "%",
+					Page: dto.PageQuery{ Offset: 0, Limit: 10 },
+				})
+				Expect(err).To(BeNil())
+				Expect(total).To(Equal(0))
+				Expect(users).To(BeEmpty())
+			})
+		})
+
+		When("deleting user", func() {
+
+			It("should delete user with owned todo items", func() {
+				todoItemRepository := postgres.NewTodoItemRepository(os.Getenv("TEST_DATABASE_URL"))
+
+				_, err = todoItemRepository.Create(&dto.CreateTodoCommand{
+					UserId: userId,
+					Title: value.NewTodoItemTitle("owned todo"),
+					Description: value.NewTodoItemDescription("deleted with user"),
+				})
+				Expect(err).To(BeNil())
+
+				err = userRepository.Delete(userId)
+				Expect(err).To(BeNil())
+
+				deletedUser, err := userRepository.GetById(userId)
+				Expect(err).To(BeNil())
+				Expect(deletedUser).To(BeNil())
+
+				todos, err := todoItemRepository.List(userId)
+				Expect(err).To(BeNil())
+				Expect(todos).To(BeEmpty())
+			})
 		})
 	})
 	
@@ -73,7 +143,7 @@ var _ = Describe("postgres repository test", Ordered, func() {
 		conn, err := pgx.Connect(ctx, os.Getenv("TEST_DATABASE_URL"))
 		Expect(err).To(BeNil())
 		defer conn.Close(ctx)
-		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users")
+		_, err = conn.Exec(ctx, "TRUNCATE todo_items, users CASCADE")
 		Expect(err).To(BeNil())
 	})
 })
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type authServer struct {
+	godopb.UnimplementedAuthServiceServer
+	app *app.Application
+}
+
+func (s *authServer) SignUp(ctx context.Context, req *godopb.SignUpRequest) (*godopb.SignUpResponse, error) {
+
+	err := s.app.AddUserUsecase().Add(&dto.AddUserCommand{
+		UserName: req.Username,
+		Email: req.Email,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.SignUpResponse{}, nil
+}
+
+func (s *authServer) Login(ctx context.Context, req *godopb.LoginRequest) (*godopb.LoginResponse, error) {
+
+	result, err := s.app.LoginUsecase().Login(&dto.LoginCommand{
+		Email: req.Email,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		return nil, loginError(err)
+	}
+
+	return toLoginResponse(result), nil
+}
+
+func (s *authServer) VerifyLogin(ctx context.Context, req *godopb.VerifyLoginRequest) (*godopb.LoginResponse, error) {
+
+	result, err := s.app.VerifyLoginUsecase().Verify(&dto.VerifyLoginCommand{
+		ChallengeToken: req.ChallengeToken,
+		Code: req.Code,
+	})
+
+	if err != nil {
+		return nil, loginError(err)
+	}
+
+	return toLoginResponse(result), nil
+}
+
+func (s *authServer) ForgotPassword(ctx context.Context, req *godopb.ForgotPasswordRequest) (*godopb.ForgotPasswordResponse, error) {
+
+	if err := s.app.RequestPasswordResetUsecase().RequestReset(req.Email); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.ForgotPasswordResponse{}, nil
+}
+
+func (s *authServer) ResetPassword(ctx context.Context, req *godopb.ResetPasswordRequest) (*godopb.ResetPasswordResponse, error) {
+
+	err := s.app.ResetPasswordUsecase().Reset(&dto.ResetPasswordCommand{
+		Token: req.Token,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.ResetPasswordResponse{}, nil
+}
+
+// Bad credentials do not tell which part was wrong.
+func loginError(err error) error {
+
+	if err == validation.ErrUserDisabled {
+		return statusOf(err)
+	}
+
+	if _, ok := err.(*validation.ValidationError); ok {
+		return status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	return statusOf(err)
+}
+
+func toLoginResponse(result *dto.LoginResultDto) *godopb.LoginResponse {
+
+	res := &godopb.LoginResponse{
+		Token: result.Token,
+		TwoFactorRequired: result.TwoFactorRequired,
+		ChallengeToken: result.ChallengeToken,
+	}
+
+	if result.User != nil {
+		res.UserId = result.User.Id
+	}
+
+	return res
+}
@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"strings"
+
+	"github.com/kkatou7209/godo/app/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Convert error of use case to status, as REST handlers convert it to HTTP status.
+func statusOf(err error) error {
+
+	switch err {
+	case validation.ErrUserNotFound, validation.ErrTodoNotDound, validation.ErrProjectNotFound, validation.ErrTodoCommentNotFound, validation.ErrTodoAttachmentNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case validation.ErrPermissionDenied, validation.ErrUserDisabled:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case validation.ErrUndoConflict:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if _, ok := err.(*validation.ValidationError); ok {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, "unexpected error")
+}
+
+// Refuse request missing field, which use cases take as given.
+func required(field string, value string) error {
+
+	if strings.TrimSpace(value) == "" {
+		return status.Errorf(codes.InvalidArgument, "%s cannot be empty", field)
+	}
+
+	return nil
+}
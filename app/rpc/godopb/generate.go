@@ -0,0 +1,4 @@
+// Package godopb holds protobuf messages and gRPC services of GoDo generated from .proto files of directory.
+package godopb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative auth.proto user.proto todo.proto
@@ -0,0 +1,217 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Who may call method.
+type policy struct {
+	// Called without token.
+	public bool
+	// Personal access tokens are refused.
+	session bool
+	// Scopes required of token.
+	scopes []string
+}
+
+// Policies of methods by full method name. Methods without policy are refused.
+var policies = map[string]policy{
+	godopb.AuthService_SignUp_FullMethodName: {public: true},
+	godopb.AuthService_Login_FullMethodName: {public: true},
+	godopb.AuthService_VerifyLogin_FullMethodName: {public: true},
+	godopb.AuthService_ForgotPassword_FullMethodName: {public: true},
+	godopb.AuthService_ResetPassword_FullMethodName: {public: true},
+	godopb.UserService_GetUser_FullMethodName: {scopes: []string{entity.ScopeProfileRead}},
+	godopb.UserService_UpdateUser_FullMethodName: {scopes: []string{entity.ScopeProfileWrite}},
+	godopb.UserService_ChangePassword_FullMethodName: {session: true},
+	godopb.TodoService_ListTodos_FullMethodName: {scopes: []string{entity.ScopeTodosRead}},
+	godopb.TodoService_AddTodo_FullMethodName: {scopes: []string{entity.ScopeTodosWrite}},
+	godopb.TodoService_UpdateTodo_FullMethodName: {scopes: []string{entity.ScopeTodosWrite}},
+	godopb.TodoService_CompleteTodo_FullMethodName: {scopes: []string{entity.ScopeTodosWrite}},
+	godopb.TodoService_UncompleteTodo_FullMethodName: {scopes: []string{entity.ScopeTodosWrite}},
+	godopb.TodoService_DeleteTodo_FullMethodName: {scopes: []string{entity.ScopeTodosWrite}},
+	godopb.TodoService_WatchTodos_FullMethodName: {scopes: []string{entity.ScopeTodosRead}},
+}
+
+type principalKey struct{}
+
+// Get authenticated user of call. nil if method is public.
+func Principal(ctx context.Context) *dto.PrincipalDto {
+
+	principal, _ := ctx.Value(principalKey{}).(*dto.PrincipalDto)
+
+	return principal
+}
+
+// Turn panic of unary call into Internal status, so one bad request cannot stop server.
+func UnaryRecoverInterceptor() grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("panic in %s: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "unexpected error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// Turn panic of streaming call into Internal status.
+func StreamRecoverInterceptor() grpc.StreamServerInterceptor {
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("panic in %s: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "unexpected error")
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+// Authenticate unary calls by session token or personal access token in "authorization: Bearer <token>" metadata.
+// Requests carrying user ID can only act on authenticated user.
+func UnaryAuthInterceptor(app *app.Application) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+
+		ctx, err := authenticate(app, ctx, info.FullMethod)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Authenticate streaming calls like UnaryAuthInterceptor. User ID is checked as requests are received.
+func StreamAuthInterceptor(app *app.Application) grpc.StreamServerInterceptor {
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		ctx, err := authenticate(app, stream.Context(), info.FullMethod)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// Stream with authenticated context.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return authorize(s.ctx, m)
+}
+
+// Check token of call against policy of method, storing principal in context.
+func authenticate(app *app.Application, ctx context.Context, method string) (context.Context, error) {
+
+	policy, ok := policies[method]
+
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method is not allowed")
+	}
+
+	if policy.public {
+		return ctx, nil
+	}
+
+	principal, err := app.AuthenticateUsecase().Authenticate(tokenOf(ctx))
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unexpected error")
+	}
+
+	if principal == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if policy.session && principal.AccessTokenId != "" {
+		return nil, status.Error(codes.PermissionDenied, "session required, personal access token cannot be used here")
+	}
+
+	for _, scope := range policy.scopes {
+		if !slices.Contains(principal.Scopes, scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "insufficient scope, %s is required", scope)
+		}
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// Refuse request acting on other user than authenticated one.
+func authorize(ctx context.Context, req any) error {
+
+	principal := Principal(ctx)
+
+	r, ok := req.(interface{ GetUserId() string })
+
+	if principal == nil || !ok {
+		return nil
+	}
+
+	if r.GetUserId() != principal.UserId {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	return nil
+}
+
+// Get bearer token of authorization metadata.
+func tokenOf(ctx context.Context) string {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ""
+	}
+
+	for _, auth := range md.Get("authorization") {
+
+		scheme, token, found := strings.Cut(auth, " ")
+
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"google.golang.org/grpc"
+)
+
+// Create gRPC server of services calling use cases of app, authenticated like REST API.
+func NewServer(app *app.Application, opts ...grpc.ServerOption) *grpc.Server {
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(UnaryRecoverInterceptor(), UnaryAuthInterceptor(app)),
+		grpc.ChainStreamInterceptor(StreamRecoverInterceptor(), StreamAuthInterceptor(app)),
+	)
+
+	server := grpc.NewServer(opts...)
+
+	godopb.RegisterAuthServiceServer(server, &authServer{app: app})
+	godopb.RegisterUserServiceServer(server, &userServer{app: app})
+	godopb.RegisterTodoServiceServer(server, &todoServer{app: app})
+
+	return server
+}
@@ -0,0 +1,387 @@
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/mailer"
+	"github.com/kkatou7209/godo/password"
+	"github.com/kkatou7209/godo/persistence/mock"
+	"github.com/kkatou7209/godo/rpc"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"github.com/kkatou7209/godo/storage"
+	"github.com/kkatou7209/godo/token"
+	"github.com/kkatou7209/godo/totp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRpc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "gRPC test.")
+}
+
+var (
+	application *app.Application
+	server *grpc.Server
+	conn *grpc.ClientConn
+	authClient godopb.AuthServiceClient
+	userClient godopb.UserServiceClient
+	todoClient godopb.TodoServiceClient
+)
+
+var _ = BeforeSuite(func() {
+
+	application = app.New()
+
+	todoRepository := mock.NewMockTodoItemRepository()
+
+	userRepository := mock.NewMockUserRepository()
+
+	sessionRepository := mock.NewMockSessionRepository()
+
+	passwordResetRepository := mock.NewMockPasswordResetRepository()
+
+	twoFactorRepository := mock.NewMockTwoFactorRepository()
+
+	loginChallengeRepository := mock.NewMockLoginChallengeRepository()
+
+	emailChangeRepository := mock.NewMockEmailChangeRepository()
+
+	accountDeletionRepository := mock.NewMockAccountDeletionRepository()
+
+	accessTokenRepository := mock.NewMockAccessTokenRepository()
+
+	auditLogRepository := mock.NewMockAuditLogRepository()
+
+	projectRepository := mock.NewMockProjectRepository()
+
+	todoAssignmentRepository := mock.NewMockTodoAssignmentRepository()
+
+	todoCommentRepository := mock.NewMockTodoCommentRepository()
+
+	todoAttachmentRepository := mock.NewMockTodoAttachmentRepository()
+
+	todoSearchRepository := mock.NewMockTodoSearchRepository(todoRepository, todoCommentRepository)
+
+	todoUndoRepository := mock.NewMockTodoUndoRepository()
+
+	todoSyncRepository := mock.NewMockTodoSyncRepository(todoRepository)
+
+	calendarFeedRepository := mock.NewMockCalendarFeedRepository()
+
+	
+	
+	application.
+		SetCreateTodoPersistence(todoRepository).
+		SetListTodoPersistence(todoRepository).
+		SetListTodoPagePersistence(todoRepository).
+		SetGetTodoPersistence(todoRepository).
+		SetUpdateTodoPersistence(todoRepository).
+		SetDeleteTodoPersistence(todoRepository).
+		SetGetTodoBatchPersistence(todoRepository).
+		SetWriteTodoBatchPersistence(todoRepository).
+		SetCreateTodoBatchPersistence(todoRepository).
+		SetCreateUserPersistence(userRepository).
+		SetGetUserPersistence(userRepository).
+		SetUpdateUserPersistence(userRepository).
+		SetDeleteUserPersistence(userRepository).
+		SetListUserPersistence(userRepository).
+		SetCreateSessionPersistence(sessionRepository).
+		SetGetSessionPersistence(sessionRepository).
+		SetDeleteSessionPersistence(sessionRepository).
+		SetCreatePasswordResetPersistence(passwordResetRepository).
+		SetGetPasswordResetPersistence(passwordResetRepository).
+		SetUpdatePasswordResetPersistence(passwordResetRepository).
+		SetCreateTwoFactorPersistence(twoFactorRepository).
+		SetGetTwoFactorPersistence(twoFactorRepository).
+		SetUpdateTwoFactorPersistence(twoFactorRepository).
+		SetDeleteTwoFactorPersistence(twoFactorRepository).
+		SetCreateLoginChallengePersistence(loginChallengeRepository).
+		SetGetLoginChallengePersistence(loginChallengeRepository).
+		SetUpdateLoginChallengePersistence(loginChallengeRepository).
+		SetDeleteLoginChallengePersistence(loginChallengeRepository).
+		SetCreateEmailChangePersistence(emailChangeRepository).
+		SetGetEmailChangePersistence(emailChangeRepository).
+		SetUpdateEmailChangePersistence(emailChangeRepository).
+		SetCreateAccountDeletionPersistence(accountDeletionRepository).
+		SetGetAccountDeletionPersistence(accountDeletionRepository).
+		SetDeleteAccountDeletionPersistence(accountDeletionRepository).
+		SetCreateAccessTokenPersistence(accessTokenRepository).
+		SetGetAccessTokenPersistence(accessTokenRepository).
+		SetListAccessTokenPersistence(accessTokenRepository).
+		SetUpdateAccessTokenPersistence(accessTokenRepository).
+		SetDeleteAccessTokenPersistence(accessTokenRepository).
+		SetCreateAuditLogPersistence(auditLogRepository).
+		SetListAuditLogPersistence(auditLogRepository).
+		SetCreateProjectPersistence(projectRepository).
+		SetGetProjectPersistence(projectRepository).
+		SetListProjectPersistence(projectRepository).
+		SetUpdateProjectPersistence(projectRepository).
+		SetDeleteProjectPersistence(projectRepository).
+		SetGetProjectMemberPersistence(projectRepository).
+		SetSaveProjectMemberPersistence(projectRepository).
+		SetDeleteProjectMemberPersistence(projectRepository).
+		SetCreateProjectInvitePersistence(projectRepository).
+		SetGetProjectInvitePersistence(projectRepository).
+		SetUpdateProjectInvitePersistence(projectRepository).
+		SetCreateTodoAssignmentPersistence(todoAssignmentRepository).
+		SetListTodoAssignmentPersistence(todoAssignmentRepository).
+		SetCreateTodoCommentPersistence(todoCommentRepository).
+		SetGetTodoCommentPersistence(todoCommentRepository).
+		SetListTodoCommentPersistence(todoCommentRepository).
+		SetUpdateTodoCommentPersistence(todoCommentRepository).
+		SetDeleteTodoCommentPersistence(todoCommentRepository).
+		SetCreateTodoAttachmentPersistence(todoAttachmentRepository).
+		SetGetTodoAttachmentPersistence(todoAttachmentRepository).
+		SetListTodoAttachmentPersistence(todoAttachmentRepository).
+		SetDeleteTodoAttachmentPersistence(todoAttachmentRepository).
+		SetSearchTodoPersistence(todoSearchRepository).
+		SetCreateTodoUndoPersistence(todoUndoRepository).
+		SetGetTodoUndoPersistence(todoUndoRepository).
+		SetUpdateTodoUndoPersistence(todoUndoRepository).
+		SetListTodoChangePersistence(todoSyncRepository).
+		SetGetTodoRevisionPersistence(todoSyncRepository).
+		SetGetTodoSyncOperationPersistence(todoSyncRepository).
+		SetWriteTodoSyncPersistence(todoSyncRepository).
+		SetSaveCalendarFeedPersistence(calendarFeedRepository).
+		SetGetCalendarFeedPersistence(calendarFeedRepository).
+		SetDeleteCalendarFeedPersistence(calendarFeedRepository).
+		SetListTodoBatchPersistence(todoRepository).
+		SetListTodoCommentBatchPersistence(todoCommentRepository).
+		SetGetUserBatchPersistence(userRepository).
+		SetListProjectMemberBatchPersistence(projectRepository).
+		SetPasswordHasher(password.NewBycryptPasswordHasher()).
+		SetTokenGenerator(token.NewRandomTokenGenerator()).
+		SetMailer(mailer.NewMemoryMailer()).
+		SetTotpProvider(totp.NewRfc6238Provider("GoDo")).
+		SetBlobStorage(storage.NewMemoryBlobStorage()).
+		SetTodoEventBus(event.NewMemoryTodoEventBus())
+
+	listener := bufconn.Listen(1 << 20)
+
+	server = rpc.NewServer(application)
+
+	go server.Serve(listener)
+
+	var err error
+
+	conn, err = grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	Expect(err).To(BeNil())
+
+	authClient = godopb.NewAuthServiceClient(conn)
+	userClient = godopb.NewUserServiceClient(conn)
+	todoClient = godopb.NewTodoServiceClient(conn)
+})
+
+var _ = AfterSuite(func() {
+	conn.Close()
+	server.Stop()
+})
+
+// Context sending token as bearer.
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer " + token)
+}
+
+// Sign up and log in, returning user ID and session token.
+func signUpAndLogin(username string, email string) (string, string) {
+
+	_, err := authClient.SignUp(context.Background(), &godopb.SignUpRequest{Username: username, Email: email, Password: "password"})
+
+	Expect(err).To(BeNil())
+
+	res, err := authClient.Login(context.Background(), &godopb.LoginRequest{Email: email, Password: "password"})
+
+	Expect(err).To(BeNil())
+	Expect(res.Token).ToNot(BeEmpty())
+
+	return res.UserId, res.Token
+}
+
+var _ = Describe("gRPC test", Ordered, func() {
+
+	var userId string
+	var session context.Context
+
+	BeforeAll(func() {
+
+		var sessionToken string
+
+		userId, sessionToken = signUpAndLogin("grpc", "grpc@example.com")
+
+		session = withToken(sessionToken)
+	})
+
+	It("should refuse bad credentials", func() {
+
+		_, err := authClient.Login(context.Background(), &godopb.LoginRequest{Email: "grpc@example.com", Password: "wrong"})
+
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+	})
+
+	It("should require token", func() {
+
+		_, err := userClient.GetUser(context.Background(), &godopb.GetUserRequest{UserId: userId})
+
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+
+		_, err = userClient.GetUser(withToken("not a token"), &godopb.GetUserRequest{UserId: userId})
+
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+	})
+
+	It("should get authenticated user only", func() {
+
+		res, err := userClient.GetUser(session, &godopb.GetUserRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+		Expect(res.User.Username).To(Equal("grpc"))
+		Expect(res.User.Email).To(Equal("grpc@example.com"))
+
+		otherId, _ := signUpAndLogin("grpc-other", "grpc-other@example.com")
+
+		_, err = userClient.GetUser(session, &godopb.GetUserRequest{UserId: otherId})
+
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+
+	It("should refuse to add invalid todo items", func() {
+
+		before, err := todoClient.ListTodos(session, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+
+		_, err = todoClient.AddTodo(session, &godopb.AddTodoRequest{UserId: userId, Title: "", Description: "no title"})
+
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		_, err = todoClient.AddTodo(session, &godopb.AddTodoRequest{UserId: userId, Title: "no description", Description: ""})
+
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		after, err := todoClient.ListTodos(session, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+		Expect(after.Todos).To(HaveLen(len(before.Todos)))
+	})
+
+	It("should change todo items", func() {
+
+		add, err := todoClient.AddTodo(session, &godopb.AddTodoRequest{UserId: userId, Title: "grpc todo", Description: "added over gRPC"})
+
+		Expect(err).To(BeNil())
+		Expect(add.UndoToken).ToNot(BeEmpty())
+
+		list, err := todoClient.ListTodos(session, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+		Expect(list.Todos).To(HaveLen(1))
+		Expect(list.Todos[0].Title).To(Equal("grpc todo"))
+
+		todoId := list.Todos[0].Id
+
+		_, err = todoClient.CompleteTodo(session, &godopb.CompleteTodoRequest{UserId: userId, TodoId: todoId})
+
+		Expect(err).To(BeNil())
+
+		_, err = todoClient.UpdateTodo(session, &godopb.UpdateTodoRequest{UserId: userId, TodoId: todoId, Title: "", Description: "no title"})
+
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		list, _ = todoClient.ListTodos(session, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(list.Todos[0].IsDone).To(BeTrue())
+
+		_, err = todoClient.DeleteTodo(session, &godopb.DeleteTodoRequest{UserId: userId, TodoId: todoId})
+
+		Expect(err).To(BeNil())
+
+		list, _ = todoClient.ListTodos(session, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(list.Todos).To(BeEmpty())
+	})
+
+	It("should hold personal access tokens to their scopes", func() {
+
+		created, err := application.CreateAccessTokenUsecase().Create(&dto.CreateAccessTokenCommand{
+			UserId: userId,
+			Name: "grpc",
+			Scopes: []string{entity.ScopeTodosRead},
+		})
+
+		Expect(err).To(BeNil())
+
+		ctx := withToken(created.Token)
+
+		_, err = todoClient.ListTodos(ctx, &godopb.ListTodosRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+
+		_, err = todoClient.AddTodo(ctx, &godopb.AddTodoRequest{UserId: userId, Title: "by token", Description: "by token"})
+
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+		_, err = userClient.ChangePassword(ctx, &godopb.ChangePasswordRequest{UserId: userId, OldPassword: "password", NewPassword: "new password"})
+
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+
+	It("should stream todo changes", func() {
+
+		ctx, cancel := context.WithTimeout(session, 5 * time.Second)
+
+		defer cancel()
+
+		stream, err := todoClient.WatchTodos(ctx, &godopb.WatchTodosRequest{UserId: userId})
+
+		Expect(err).To(BeNil())
+
+		// Server sends headers once subscribed.
+		_, err = stream.Header()
+
+		Expect(err).To(BeNil())
+
+		_, err = todoClient.AddTodo(session, &godopb.AddTodoRequest{UserId: userId, Title: "watched", Description: "watched todo"})
+
+		Expect(err).To(BeNil())
+
+		event, err := stream.Recv()
+
+		Expect(err).To(BeNil())
+		Expect(event.Type).To(Equal(entity.TodoEventCreated))
+		Expect(event.Todo.Title).To(Equal("watched"))
+		Expect(event.Id).ToNot(BeEmpty())
+		Expect(event.OccurredAt.AsTime()).To(BeTemporally("~", time.Now(), time.Minute))
+	})
+
+	It("should refuse watching other user", func() {
+
+		stream, err := todoClient.WatchTodos(session, &godopb.WatchTodosRequest{UserId: "someone else"})
+
+		Expect(err).To(BeNil())
+
+		_, err = stream.Recv()
+
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+})
@@ -0,0 +1,178 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type todoServer struct {
+	godopb.UnimplementedTodoServiceServer
+	app *app.Application
+}
+
+func (s *todoServer) ListTodos(ctx context.Context, req *godopb.ListTodosRequest) (*godopb.ListTodosResponse, error) {
+
+	todos, err := s.app.ListTodoUsecase().List(req.UserId)
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	res := &godopb.ListTodosResponse{Todos: make([]*godopb.Todo, len(todos))}
+
+	for i, todo := range todos {
+		res.Todos[i] = toTodo(todo)
+	}
+
+	return res, nil
+}
+
+func (s *todoServer) AddTodo(ctx context.Context, req *godopb.AddTodoRequest) (*godopb.AddTodoResponse, error) {
+
+	undoToken, err := s.app.AddTodoUsecase().Add(&dto.AddTodoCommand{
+		UserId: req.UserId,
+		Title: req.Title,
+		Description: req.Description,
+		ProjectId: req.ProjectId,
+	})
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.AddTodoResponse{UndoToken: undoToken}, nil
+}
+
+func (s *todoServer) UpdateTodo(ctx context.Context, req *godopb.UpdateTodoRequest) (*godopb.UpdateTodoResponse, error) {
+
+	if err := required("todo_id", req.TodoId); err != nil {
+		return nil, err
+	}
+
+	if err := required("title", req.Title); err != nil {
+		return nil, err
+	}
+
+	undoToken, err := s.app.UpdateTodoUsecase().Update(&dto.UpdateTodoCommand{
+		Id: req.TodoId,
+		Title: req.Title,
+		Description: req.Description,
+		UserId: req.UserId,
+	})
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.UpdateTodoResponse{UndoToken: undoToken}, nil
+}
+
+func (s *todoServer) CompleteTodo(ctx context.Context, req *godopb.CompleteTodoRequest) (*godopb.CompleteTodoResponse, error) {
+
+	if err := required("todo_id", req.TodoId); err != nil {
+		return nil, err
+	}
+
+	undoToken, err := s.app.CompleteTodoUsecase().Complete(req.UserId, req.TodoId)
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.CompleteTodoResponse{UndoToken: undoToken}, nil
+}
+
+func (s *todoServer) UncompleteTodo(ctx context.Context, req *godopb.UncompleteTodoRequest) (*godopb.UncompleteTodoResponse, error) {
+
+	if err := required("todo_id", req.TodoId); err != nil {
+		return nil, err
+	}
+
+	undoToken, err := s.app.UncompleteTodoUsecase().Uncomplete(req.UserId, req.TodoId)
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.UncompleteTodoResponse{UndoToken: undoToken}, nil
+}
+
+func (s *todoServer) DeleteTodo(ctx context.Context, req *godopb.DeleteTodoRequest) (*godopb.DeleteTodoResponse, error) {
+
+	if err := required("todo_id", req.TodoId); err != nil {
+		return nil, err
+	}
+
+	undoToken, err := s.app.DeleteTodoUsecase().Delete(req.UserId, req.TodoId)
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.DeleteTodoResponse{UndoToken: undoToken}, nil
+}
+
+// Stream todo events until client cancels.
+// Closed subscription ends stream with Unavailable, so client watches again with last event ID.
+func (s *todoServer) WatchTodos(req *godopb.WatchTodosRequest, stream grpc.ServerStreamingServer[godopb.WatchTodosResponse]) error {
+
+	events, cancel, err := s.app.SubscribeTodoEventsUsecase().Subscribe(req.UserId, req.LastEventId)
+
+	if err != nil {
+		return statusOf(err)
+	}
+
+	defer cancel()
+
+	// Headers tell client that changes made from now on are streamed.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription closed, watch again with last event ID")
+			}
+
+			response := &godopb.WatchTodosResponse{
+				Id: e.Id,
+				Type: e.Type,
+				OccurredAt: timestamppb.New(e.OccurredAt),
+			}
+
+			if e.Todo != nil {
+				response.Todo = toTodo(e.Todo)
+			}
+
+			err := stream.Send(response)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toTodo(todo *dto.TodoItemDto) *godopb.Todo {
+	return &godopb.Todo{
+		Id: todo.Id,
+		Title: todo.Title,
+		Description: todo.Description,
+		IsDone: todo.IsDone,
+		ProjectId: todo.ProjectId,
+		AssigneeId: todo.AssigneeId,
+	}
+}
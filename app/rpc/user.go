@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/rpc/godopb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type userServer struct {
+	godopb.UnimplementedUserServiceServer
+	app *app.Application
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *godopb.GetUserRequest) (*godopb.GetUserResponse, error) {
+
+	user, err := s.app.GetUserUsecase().Get(req.UserId)
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return &godopb.GetUserResponse{
+		User: &godopb.User{
+			Id: user.Id,
+			Username: user.UserName,
+			Email: user.Email,
+			Role: user.Role,
+		},
+	}, nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *godopb.UpdateUserRequest) (*godopb.UpdateUserResponse, error) {
+
+	result, err := s.app.ChangeUserInfoUsecase().ChangeInfo(&dto.UserDto{
+		Id: req.UserId,
+		UserName: req.Username,
+		Email: req.Email,
+	})
+
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.UpdateUserResponse{EmailChangePending: result.EmailChangePending}, nil
+}
+
+func (s *userServer) ChangePassword(ctx context.Context, req *godopb.ChangePasswordRequest) (*godopb.ChangePasswordResponse, error) {
+
+	if err := s.app.ChangeUserPasswordUsecase().ChangePassword(req.UserId, req.NewPassword, req.OldPassword); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &godopb.ChangePasswordResponse{}, nil
+}
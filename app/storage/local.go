@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kkatou7209/godo/app/port/out/storage"
+)
+
+// Blob storage keeping blobs as files under root directory.
+type LocalBlobStorage struct {
+	root string
+}
+
+func NewLocalBlobStorage(root string) *LocalBlobStorage {
+	return &LocalBlobStorage{root}
+}
+
+// Resolve key to file path, refusing keys escaping root.
+func (s *LocalBlobStorage) path(key string) (string, error) {
+
+	if key == "" || strings.HasPrefix(key, "/") || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalBlobStorage) Put(key string, content io.Reader, size int64, contentType string) error {
+
+	p, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+
+	// Write to temporary file first so readers never see partial blobs.
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, content)
+
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if written != size {
+		return fmt.Errorf("blob %q: expected %d bytes, got %d", key, size, written)
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *LocalBlobStorage) Get(key string) (io.ReadCloser, error) {
+
+	p, err := s.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, storage.ErrBlobNotFound
+	}
+
+	return f, err
+}
+
+func (s *LocalBlobStorage) Delete(key string) error {
+
+	p, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/kkatou7209/godo/app/port/out/storage"
+)
+
+// Blob storage keeping blobs in memory. For testing.
+type MemoryBlobStorage struct {
+	blobs map[string][]byte
+	mu    sync.Mutex
+}
+
+func NewMemoryBlobStorage() *MemoryBlobStorage {
+	return &MemoryBlobStorage{
+		blobs: make(map[string][]byte),
+		mu:    sync.Mutex{},
+	}
+}
+
+func (s *MemoryBlobStorage) Put(key string, content io.Reader, size int64, contentType string) error {
+
+	b, err := io.ReadAll(content)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = b
+
+	return nil
+}
+
+func (s *MemoryBlobStorage) Get(key string) (io.ReadCloser, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.blobs[key]
+
+	if !ok {
+		return nil, storage.ErrBlobNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (s *MemoryBlobStorage) Delete(key string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+
+	return nil
+}
+
+// Count stored blobs.
+func (s *MemoryBlobStorage) Len() int {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.blobs)
+}
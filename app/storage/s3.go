@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app/port/out/storage"
+)
+
+// Payload hash sent instead of hashing streamed uploads.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// Blob storage on S3 compatible object storage such as AWS S3 or MinIO.
+// Requests use path-style URLs and AWS Signature Version 4.
+type S3BlobStorage struct {
+	// Endpoint URL, e.g. https://s3.ap-northeast-1.amazonaws.com or http://minio:9000.
+	endpoint *url.URL
+	region string
+	bucket string
+	accessKey string
+	secretKey string
+	client *http.Client
+	// Clock for signing. Replaced in tests.
+	now func() time.Time
+}
+
+func NewS3BlobStorage(endpoint string, region string, bucket string, accessKey string, secretKey string) (*S3BlobStorage, error) {
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, fmt.Errorf("S3 bucket must be specified")
+	}
+
+	return &S3BlobStorage{u, region, bucket, accessKey, secretKey, http.DefaultClient, time.Now}, nil
+}
+
+func (s *S3BlobStorage) Put(key string, content io.Reader, size int64, contentType string) error {
+
+	req, err := s.request(http.MethodPut, key, content)
+
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return s.error(res)
+	}
+
+	return nil
+}
+
+func (s *S3BlobStorage) Get(key string) (io.ReadCloser, error) {
+
+	req, err := s.request(http.MethodGet, key, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, storage.ErrBlobNotFound
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, s.error(res)
+	}
+
+	return res.Body, nil
+}
+
+func (s *S3BlobStorage) Delete(key string) error {
+
+	req, err := s.request(http.MethodDelete, key, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	// S3 answers 204 even for missing keys, some compatible stores answer 404.
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return s.error(res)
+	}
+
+	return nil
+}
+
+func (s *S3BlobStorage) request(method string, key string, body io.Reader) (*http.Request, error) {
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = ""
+
+	return http.NewRequest(method, u.String(), body)
+}
+
+func (s *S3BlobStorage) do(req *http.Request) (*http.Response, error) {
+
+	signV4(req, s.accessKey, s.secretKey, s.region, s.now())
+
+	return s.client.Do(req)
+}
+
+func (s *S3BlobStorage) error(res *http.Response) error {
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return fmt.Errorf("S3 %s %s: %s: %s", res.Request.Method, res.Request.URL.Path, res.Status, strings.TrimSpace(string(body)))
+}
+
+// Sign request with AWS Signature Version 4, leaving payload unsigned.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signV4(req *http.Request, accessKey string, secretKey string, region string, at time.Time) {
+
+	at = at.UTC()
+	amzDate := at.Format("20060102T150405Z")
+	date := at.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	headers := map[string]string{"host": req.URL.Host}
+
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + secretKey)
+
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSha256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+
+	mac := hmac.New(sha256.New, key)
+
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,216 @@
+package storage_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	storagePort "github.com/kkatou7209/godo/app/port/out/storage"
+	"github.com/kkatou7209/godo/storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStorage(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Blob storage test.")
+}
+
+// Behavior every blob storage must have.
+func blobStorageContract(newStorage func() storagePort.BlobStorage) {
+
+	var blobStorage storagePort.BlobStorage
+
+	BeforeEach(func() {
+		blobStorage = newStorage()
+	})
+
+	It("should store and read blob", func() {
+
+		key := "todo-attachments/" + uuid.NewString() + "/blob"
+
+		Expect(blobStorage.Put(key, strings.NewReader("hello blob"), 10, "text/plain")).To(Succeed())
+
+		content, err := blobStorage.Get(key)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		defer content.Close()
+
+		b, err := io.ReadAll(content)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(b)).To(Equal("hello blob"))
+	})
+
+	It("should report missing blob", func() {
+
+		_, err := blobStorage.Get("todo-attachments/" + uuid.NewString())
+
+		Expect(err).To(Equal(storagePort.ErrBlobNotFound))
+	})
+
+	It("should delete blob", func() {
+
+		key := "todo-attachments/" + uuid.NewString()
+
+		Expect(blobStorage.Put(key, strings.NewReader("x"), 1, "text/plain")).To(Succeed())
+		Expect(blobStorage.Delete(key)).To(Succeed())
+
+		_, err := blobStorage.Get(key)
+
+		Expect(err).To(Equal(storagePort.ErrBlobNotFound))
+
+		// Deleting twice is fine.
+		Expect(blobStorage.Delete(key)).To(Succeed())
+	})
+}
+
+var _ = Describe("Local blob storage test", func() {
+
+	blobStorageContract(func() storagePort.BlobStorage {
+		return storage.NewLocalBlobStorage(GinkgoT().TempDir())
+	})
+
+	It("should refuse keys escaping root", func() {
+
+		blobStorage := storage.NewLocalBlobStorage(GinkgoT().TempDir())
+
+		Expect(blobStorage.Put("../escape", strings.NewReader("x"), 1, "text/plain")).ToNot(Succeed())
+		Expect(blobStorage.Put("/absolute", strings.NewReader("x"), 1, "text/plain")).ToNot(Succeed())
+	})
+
+	It("should refuse content shorter than declared", func() {
+
+		blobStorage := storage.NewLocalBlobStorage(GinkgoT().TempDir())
+
+		Expect(blobStorage.Put("short", strings.NewReader("x"), 2, "text/plain")).ToNot(Succeed())
+
+		_, err := blobStorage.Get("short")
+
+		Expect(err).To(Equal(storagePort.ErrBlobNotFound))
+	})
+})
+
+var _ = Describe("Memory blob storage test", func() {
+
+	blobStorageContract(func() storagePort.BlobStorage {
+		return storage.NewMemoryBlobStorage()
+	})
+})
+
+// Minimal in-process stand-in for S3 checking requests are signed.
+type fakeS3 struct {
+	objects map[string][]byte
+	mu sync.Mutex
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	auth := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test-access-key/") ||
+		!strings.Contains(auth, "/test-region/s3/aws4_request") ||
+		!strings.Contains(auth, "SignedHeaders=") ||
+		r.Header.Get("X-Amz-Date") == "" ||
+		r.Header.Get("X-Amz-Content-Sha256") != "UNSIGNED-PAYLOAD" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/test-bucket/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		b, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = b
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		b, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+var _ = Describe("S3 blob storage test", func() {
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+		DeferCleanup(server.Close)
+	})
+
+	blobStorageContract(func() storagePort.BlobStorage {
+
+		blobStorage, err := storage.NewS3BlobStorage(server.URL, "test-region", "test-bucket", "test-access-key", "test-secret-key")
+
+		Expect(err).ToNot(HaveOccurred())
+
+		return blobStorage
+	})
+
+	It("should surface errors of storage", func() {
+
+		blobStorage, err := storage.NewS3BlobStorage(server.URL, "test-region", "test-bucket", "wrong-access-key", "test-secret-key")
+
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(blobStorage.Put("key", strings.NewReader("x"), 1, "text/plain")).To(MatchError(ContainSubstring("403")))
+	})
+
+	It("should refuse invalid configuration", func() {
+
+		_, err := storage.NewS3BlobStorage("minio:9000", "test-region", "test-bucket", "key", "secret")
+
+		Expect(err).To(HaveOccurred())
+
+		_, err = storage.NewS3BlobStorage("http://minio:9000", "test-region", "", "key", "secret")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// Runs against real S3 compatible storage, e.g. MinIO of the dev container, when configured.
+var _ = Describe("S3 blob storage integration test", func() {
+
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+
+	if endpoint == "" {
+		return
+	}
+
+	blobStorageContract(func() storagePort.BlobStorage {
+
+		blobStorage, err := storage.NewS3BlobStorage(
+			endpoint,
+			os.Getenv("TEST_S3_REGION"),
+			os.Getenv("TEST_S3_BUCKET"),
+			os.Getenv("TEST_S3_ACCESS_KEY"),
+			os.Getenv("TEST_S3_SECRET_KEY"),
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+
+		return blobStorage
+	})
+})
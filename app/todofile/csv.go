@@ -0,0 +1,152 @@
+package todofile
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"id", "title", "description", "isDone", "projectId"}
+
+// Column names read as each field, lower cased.
+var (
+	titleColumns = []string{"title", "content", "name", "task", "summary"}
+	descriptionColumns = []string{"description", "notes", "note", "desc", "details"}
+	doneColumns = []string{"isdone", "done", "completed", "checked", "status"}
+)
+
+type csvWriter struct {
+	w *csv.Writer
+	headed bool
+}
+
+func newCsvWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) head() error {
+
+	if w.headed {
+		return nil
+	}
+
+	w.headed = true
+
+	return w.w.Write(csvHeader)
+}
+
+func (w *csvWriter) Write(todo *Todo) error {
+
+	if err := w.head(); err != nil {
+		return err
+	}
+
+	return w.w.Write([]string{todo.Id, todo.Title, todo.Description, strconv.FormatBool(todo.IsDone), todo.ProjectId})
+}
+
+func (w *csvWriter) Close() error {
+
+	if err := w.head(); err != nil {
+		return err
+	}
+
+	w.w.Flush()
+
+	return w.w.Error()
+}
+
+func readCsv(r io.Reader) ([]*Row, error) {
+
+	reader := csv.NewReader(r)
+	// Rows are checked one by one below.
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: header row expected", ErrInvalidFile)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		// Spreadsheet apps may start file with byte order mark.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	column := func(names []string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	title := column(titleColumns)
+	description := column(descriptionColumns)
+	done := column(doneColumns)
+	// Todoist puts sections and notes between tasks.
+	kind := column([]string{"type"})
+
+	if title < 0 {
+		return nil, fmt.Errorf("%w: title column expected", ErrInvalidFile)
+	}
+
+	rows := make([]*Row, 0)
+
+	for {
+
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+
+		if errors.As(err, &parseErr) {
+			rows = append(rows, &Row{Number: parseErr.Line, Err: parseErr.Err})
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		cell := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		if kind >= 0 && !strings.EqualFold(cell(kind), "task") {
+			continue
+		}
+
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		isDone, err := parseDone(cell(done))
+
+		if err != nil {
+			rows = append(rows, &Row{Number: line, Err: err})
+			continue
+		}
+
+		rows = append(rows, &Row{
+			Number: line,
+			Todo: &Todo{Title: cell(title), Description: cell(description), IsDone: isDone},
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package todofile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Todo item of JSON files written by package.
+type jsonTodo struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	IsDone      bool   `json:"isDone"`
+	ProjectId   string `json:"projectId,omitempty"`
+}
+
+// Keys of objects holding tasks in JSON exports of other apps.
+var listKeys = []string{"items", "tasks", "todos", "cards"}
+
+type jsonWriter struct {
+	w io.Writer
+	count int
+}
+
+func (w *jsonWriter) Write(todo *Todo) error {
+
+	b, err := json.Marshal(jsonTodo{todo.Id, todo.Title, todo.Description, todo.IsDone, todo.ProjectId})
+
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+
+	if w.count == 0 {
+		sep = "[\n"
+	}
+
+	w.count++
+
+	_, err = io.WriteString(w.w, sep + string(b))
+
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+
+	if w.count == 0 {
+		_, err := io.WriteString(w.w, "[]\n")
+		return err
+	}
+
+	_, err := io.WriteString(w.w, "\n]\n")
+
+	return err
+}
+
+func readJson(r io.Reader) ([]*Row, error) {
+
+	var root any
+
+	if err := json.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	tasks, ok := taskList(root)
+
+	if !ok {
+		return nil, fmt.Errorf("%w: list of todo items expected", ErrInvalidFile)
+	}
+
+	rows := make([]*Row, len(tasks))
+
+	for i, task := range tasks {
+
+		rows[i] = &Row{Number: i + 1}
+
+		object, ok := task.(map[string]any)
+
+		if !ok {
+			rows[i].Err = fmt.Errorf("object expected")
+			continue
+		}
+
+		todo, err := jsonTask(object)
+
+		if err != nil {
+			rows[i].Err = err
+			continue
+		}
+
+		rows[i].Todo = todo
+	}
+
+	return rows, nil
+}
+
+// Find tasks in array of tasks, or object holding them as Trello boards do.
+// Lists of lists, as of Google Tasks, are flattened.
+func taskList(root any) ([]any, bool) {
+
+	list, ok := root.([]any)
+
+	if !ok {
+
+		object, ok := root.(map[string]any)
+
+		if !ok {
+			return nil, false
+		}
+
+		for _, key := range listKeys {
+			if l, ok := object[key].([]any); ok {
+				list = l
+				break
+			}
+		}
+
+		if list == nil {
+			return nil, false
+		}
+	}
+
+	tasks := make([]any, 0, len(list))
+
+	for _, item := range list {
+
+		if object, ok := item.(map[string]any); ok {
+			if nested, ok := taskList(object); ok {
+				tasks = append(tasks, nested...)
+				continue
+			}
+		}
+
+		tasks = append(tasks, item)
+	}
+
+	return tasks, true
+}
+
+// Read task object with field names of this package or other apps.
+func jsonTask(object map[string]any) (*Todo, error) {
+
+	text := func(keys ...string) (string, error) {
+		for _, key := range keys {
+			if v, ok := object[key]; ok && v != nil {
+				s, ok := v.(string)
+				if !ok {
+					return "", fmt.Errorf("%s must be string", key)
+				}
+				return s, nil
+			}
+		}
+		return "", nil
+	}
+
+	title, err := text("title", "content", "name", "task", "summary")
+
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := text("description", "notes", "note", "desc", "details")
+
+	if err != nil {
+		return nil, err
+	}
+
+	todo := &Todo{Title: title, Description: description}
+
+	for _, key := range []string{"isDone", "done", "completed", "checked", "dueComplete", "status"} {
+
+		v, ok := object[key]
+
+		if !ok || v == nil {
+			continue
+		}
+
+		switch v := v.(type) {
+		case bool:
+			todo.IsDone = v
+		case float64:
+			todo.IsDone = v != 0
+		case string:
+			if todo.IsDone, err = parseDone(v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%s must be boolean", key)
+		}
+
+		break
+	}
+
+	return todo, nil
+}
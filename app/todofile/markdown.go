@@ -0,0 +1,103 @@
+package todofile
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// List item, checked or not, e.g. "- [x] title" or "* title".
+var checklistPattern = regexp.MustCompile(`^[-*+]\s+(?:\[([ xX])\]\s+)?(.*)$`)
+
+// Indent of description lines under list item.
+const markdownIndent = "  "
+
+type markdownWriter struct {
+	w io.Writer
+}
+
+// Write todo item as checklist item with description indented under it.
+func (w *markdownWriter) Write(todo *Todo) error {
+
+	check := "[ ]"
+
+	if todo.IsDone {
+		check = "[x]"
+	}
+
+	var out strings.Builder
+
+	out.WriteString("- " + check + " " + strings.Join(strings.Fields(todo.Title), " ") + "\n")
+
+	if todo.Description != "" {
+		for _, line := range strings.Split(strings.ReplaceAll(todo.Description, "\r\n", "\n"), "\n") {
+			out.WriteString(markdownIndent + line + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w.w, out.String())
+
+	return err
+}
+
+func (w *markdownWriter) Close() error {
+	return nil
+}
+
+func readMarkdown(r io.Reader) ([]*Row, error) {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1 << 20)
+
+	rows := make([]*Row, 0)
+
+	// Item description lines are added to. nil outside item.
+	var current *Row
+	// Whether current item has description lines, which may be blank.
+	var described bool
+
+	for number := 1; scanner.Scan(); number++ {
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.HasPrefix(line, markdownIndent) || strings.HasPrefix(line, "\t") {
+
+			if current != nil && current.Todo != nil {
+
+				text := strings.TrimPrefix(strings.TrimPrefix(line, "\t"), markdownIndent)
+
+				if described {
+					text = "\n" + text
+				}
+
+				current.Todo.Description += text
+				described = true
+			}
+
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			current = nil
+			continue
+		}
+
+		match := checklistPattern.FindStringSubmatch(line)
+
+		if match == nil {
+			rows = append(rows, &Row{Number: number, Err: errors.New("checklist item expected")})
+			current = nil
+			continue
+		}
+
+		described = false
+
+		current = &Row{Number: number, Todo: &Todo{Title: match[2], IsDone: strings.EqualFold(match[1], "x")}}
+
+		rows = append(rows, current)
+	}
+
+	return rows, scanner.Err()
+}
@@ -0,0 +1,117 @@
+package todofile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Formats of todo list files.
+const (
+	FormatCsv = "csv"
+	FormatJson = "json"
+	FormatMarkdown = "md"
+)
+
+// Formats known to package.
+var Formats = []string{FormatCsv, FormatJson, FormatMarkdown}
+
+var (
+	ErrUnknownFormat = errors.New("unknown todo file format")
+	ErrInvalidFile = errors.New("invalid todo file")
+)
+
+// Todo item as written to file.
+type Todo struct {
+	// Empty when read.
+	Id string
+	Title string
+	Description string
+	IsDone bool
+	// Empty if personal. Empty when read.
+	ProjectId string
+}
+
+// Todo item read from file, or why it could not be read.
+type Row struct {
+	// Line of CSV and Markdown files, or position of item in JSON files, starting from 1.
+	Number int
+	// nil if row could not be read.
+	Todo *Todo
+	// nil if row was read.
+	Err error
+}
+
+// Writes todo items to file one by one, so long lists are never held in memory.
+type Writer interface {
+	Write(todo *Todo) error
+	// Finish file. Does not close underlying writer.
+	Close() error
+}
+
+// Check if format is known to package.
+func IsFormat(format string) bool {
+	return slices.Contains(Formats, format)
+}
+
+// Get content type of format.
+func ContentType(format string) string {
+
+	switch format {
+	case FormatCsv:
+		return "text/csv; charset=utf-8"
+	case FormatJson:
+		return "application/json; charset=utf-8"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+
+	switch format {
+	case FormatCsv:
+		return newCsvWriter(w), nil
+	case FormatJson:
+		return &jsonWriter{w: w}, nil
+	case FormatMarkdown:
+		return &markdownWriter{w: w}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Read todo items of file.
+//
+// Besides files written by this package, common exports of other apps are read:
+// CSV with columns such as content and notes, e.g. Todoist,
+// and JSON of Google Tasks, Trello boards and lists of task objects.
+// Error is returned only when file as a whole cannot be read. Bad rows are returned with their errors.
+func Read(format string, r io.Reader) ([]*Row, error) {
+
+	switch format {
+	case FormatCsv:
+		return readCsv(r)
+	case FormatJson:
+		return readJson(r)
+	case FormatMarkdown:
+		return readMarkdown(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Read boolean cell or field written by people or other apps.
+func parseDone(s string) (bool, error) {
+
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "false", "0", "no", "n", "open", "needs-action", "needsaction", "todo":
+		return false, nil
+	case "true", "1", "yes", "y", "x", "done", "completed", "complete":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%q is not done flag", s)
+	}
+}
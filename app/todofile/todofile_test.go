@@ -0,0 +1,175 @@
+package todofile_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kkatou7209/godo/todofile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTodofile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Todo file test.")
+}
+
+// Todo items of rows, failing on bad rows.
+func todosOf(rows []*todofile.Row) []todofile.Todo {
+
+	todos := make([]todofile.Todo, len(rows))
+
+	for i, row := range rows {
+		Expect(row.Err).To(BeNil())
+		todos[i] = *row.Todo
+	}
+
+	return todos
+}
+
+var _ = Describe("Todo file test", func() {
+
+	todos := []*todofile.Todo{
+		{Id: "todo-1", Title: "Buy milk, eggs", Description: "from \"the\" shop\nbefore 6pm", IsDone: false},
+		{Id: "todo-2", Title: "Write report", Description: "quarterly", IsDone: true, ProjectId: "project-1"},
+	}
+
+	for _, format := range todofile.Formats {
+
+		It("should round trip " + format, func() {
+
+			var b bytes.Buffer
+
+			w, err := todofile.NewWriter(format, &b)
+
+			Expect(err).To(BeNil())
+
+			for _, todo := range todos {
+				Expect(w.Write(todo)).To(BeNil())
+			}
+
+			Expect(w.Close()).To(BeNil())
+
+			rows, err := todofile.Read(format, &b)
+
+			Expect(err).To(BeNil())
+			Expect(todosOf(rows)).To(Equal([]todofile.Todo{
+				{Title: todos[0].Title, Description: todos[0].Description},
+				{Title: todos[1].Title, Description: todos[1].Description, IsDone: true},
+			}))
+		})
+
+		It("should write empty " + format, func() {
+
+			var b bytes.Buffer
+
+			w, _ := todofile.NewWriter(format, &b)
+
+			Expect(w.Close()).To(BeNil())
+
+			rows, err := todofile.Read(format, &b)
+
+			Expect(err).To(BeNil())
+			Expect(rows).To(BeEmpty())
+		})
+	}
+
+	It("should write Markdown checklist", func() {
+
+		var b bytes.Buffer
+
+		w, _ := todofile.NewWriter(todofile.FormatMarkdown, &b)
+
+		for _, todo := range todos {
+			w.Write(todo)
+		}
+
+		Expect(b.String()).To(Equal("- [ ] Buy milk, eggs\n  from \"the\" shop\n  before 6pm\n- [x] Write report\n  quarterly\n"))
+	})
+
+	It("should report bad rows with their numbers", func() {
+
+		rows, err := todofile.Read(todofile.FormatCsv, strings.NewReader("title,done\nfirst,yes\nsecond,maybe\n\"broken,no\n"))
+
+		Expect(err).To(BeNil())
+		Expect(rows).To(HaveLen(3))
+		Expect(rows[0].Todo.IsDone).To(BeTrue())
+		Expect(rows[1].Number).To(Equal(3))
+		Expect(rows[1].Err).ToNot(BeNil())
+		Expect(rows[2].Number).To(Equal(4))
+		Expect(rows[2].Err).ToNot(BeNil())
+
+		rows, err = todofile.Read(todofile.FormatMarkdown, strings.NewReader("# List\n\n- [x] done\nloose text\n* plain\n"))
+
+		Expect(err).To(BeNil())
+		Expect(rows).To(HaveLen(3))
+		Expect(*rows[0].Todo).To(Equal(todofile.Todo{Title: "done", IsDone: true}))
+		Expect(rows[1].Number).To(Equal(4))
+		Expect(rows[1].Err).ToNot(BeNil())
+		Expect(*rows[2].Todo).To(Equal(todofile.Todo{Title: "plain"}))
+
+		rows, err = todofile.Read(todofile.FormatJson, strings.NewReader(`[{"title": "ok"}, "text", {"title": 1}]`))
+
+		Expect(err).To(BeNil())
+		Expect(rows[0].Err).To(BeNil())
+		Expect(rows[1].Err).ToNot(BeNil())
+		Expect(rows[2].Number).To(Equal(3))
+		Expect(rows[2].Err).ToNot(BeNil())
+	})
+
+	It("should refuse unreadable files", func() {
+
+		_, err := todofile.Read(todofile.FormatJson, strings.NewReader(`{"title": `))
+		Expect(err).To(MatchError(todofile.ErrInvalidFile))
+
+		_, err = todofile.Read(todofile.FormatJson, strings.NewReader(`{"title": "not a list"}`))
+		Expect(err).To(MatchError(todofile.ErrInvalidFile))
+
+		_, err = todofile.Read(todofile.FormatCsv, strings.NewReader("id,when\n1,today\n"))
+		Expect(err).To(MatchError(todofile.ErrInvalidFile))
+
+		_, err = todofile.Read("xlsx", strings.NewReader(""))
+		Expect(err).To(MatchError(todofile.ErrUnknownFormat))
+	})
+
+	It("should read exports of other apps", func() {
+
+		// Todoist CSV.
+		rows, err := todofile.Read(todofile.FormatCsv, strings.NewReader(
+			"\uFEFFTYPE,CONTENT,DESCRIPTION,PRIORITY\nsection,Work,,\ntask,Call Bob,about lunch,4\n\ntask,Pay bills,,1\n",
+		))
+
+		Expect(err).To(BeNil())
+		Expect(todosOf(rows)).To(Equal([]todofile.Todo{{Title: "Call Bob", Description: "about lunch"}, {Title: "Pay bills"}}))
+
+		// Google Tasks.
+		rows, err = todofile.Read(todofile.FormatJson, strings.NewReader(`{
+			"kind": "tasks#taskLists",
+			"items": [
+				{"kind": "tasks#taskList", "title": "My Tasks", "items": [
+					{"kind": "tasks#task", "title": "Renew passport", "notes": "photo", "status": "needsAction"},
+					{"kind": "tasks#task", "title": "Book hotel", "status": "completed"}
+				]}
+			]
+		}`))
+
+		Expect(err).To(BeNil())
+		Expect(todosOf(rows)).To(Equal([]todofile.Todo{{Title: "Renew passport", Description: "photo"}, {Title: "Book hotel", IsDone: true}}))
+
+		// Trello board.
+		rows, err = todofile.Read(todofile.FormatJson, strings.NewReader(`{
+			"name": "Board",
+			"cards": [{"name": "Fix bug", "desc": "in login", "dueComplete": true}]
+		}`))
+
+		Expect(err).To(BeNil())
+		Expect(todosOf(rows)).To(Equal([]todofile.Todo{{Title: "Fix bug", Description: "in login", IsDone: true}}))
+
+		// Todoist API items.
+		rows, err = todofile.Read(todofile.FormatJson, strings.NewReader(`{"items": [{"content": "Water plants", "checked": 1}]}`))
+
+		Expect(err).To(BeNil())
+		Expect(todosOf(rows)).To(Equal([]todofile.Todo{{Title: "Water plants", IsDone: true}}))
+	})
+})
@@ -0,0 +1,49 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type RandomTokenGenerator struct{}
+
+func NewRandomTokenGenerator() *RandomTokenGenerator {
+	return &RandomTokenGenerator{}
+}
+
+func (g *RandomTokenGenerator) Generate() (string, error) {
+
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (g *RandomTokenGenerator) GenerateCode() (string, error) {
+
+	b := make([]byte, 10)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	// 10 bytes = 16 base32 chars, shown as xxxx-xxxx-xxxx-xxxx.
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+
+	return fmt.Sprintf("%s-%s-%s-%s", code[0:4], code[4:8], code[8:12], code[12:16]), nil
+}
+
+func (g *RandomTokenGenerator) Hash(token string) string {
+
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,108 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Length of time step in seconds.
+	period = 30
+	// Number of digits of code.
+	digits = 6
+	// Number of steps accepted before and after current step for clock drift.
+	skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// RFC 6238 TOTP with SHA-1, 6 digits and 30 seconds period,
+// which is what common authenticator apps expect.
+type Rfc6238Provider struct {
+	issuer string
+}
+
+func NewRfc6238Provider(issuer string) *Rfc6238Provider {
+	return &Rfc6238Provider{issuer}
+}
+
+func (p *Rfc6238Provider) GenerateSecret() (string, error) {
+
+	secret := make([]byte, 20)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+func (p *Rfc6238Provider) Uri(secret string, account string) string {
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", p.issuer, account))
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", p.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(period))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+func (p *Rfc6238Provider) Verify(secret string, code string, at time.Time) (int64, bool) {
+
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.TrimSpace(code)
+
+	if len(code) != digits {
+		return 0, false
+	}
+
+	current := at.Unix() / period
+
+	for step := current - skew; step <= current + skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(Code(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// Compute code of given time step (RFC 4226 HOTP).
+func Code(key []byte, step int64) string {
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum) - 1] & 0x0f
+
+	truncated := binary.BigEndian.Uint32(sum[offset:offset + 4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, truncated % mod)
+}
@@ -0,0 +1,77 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/totp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTotp(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TOTP test.")
+}
+
+var _ = Describe("RFC 6238 provider test", func() {
+
+	// Key of RFC 6238 Appendix B test vectors for SHA-1.
+	key := []byte("12345678901234567890")
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key)
+
+	provider := totp.NewRfc6238Provider("GoDo")
+
+	It("should match RFC 6238 test vectors", func() {
+		// Last 6 digits of the 8 digit values in the RFC.
+		Expect(totp.Code(key, 59 / 30)).To(Equal("287082"))
+		Expect(totp.Code(key, 1111111109 / 30)).To(Equal("081804"))
+		Expect(totp.Code(key, 1234567890 / 30)).To(Equal("005924"))
+		Expect(totp.Code(key, 2000000000 / 30)).To(Equal("279037"))
+	})
+
+	It("should verify code within clock skew", func() {
+		at := time.Unix(1111111109, 0)
+
+		step, ok := provider.Verify(secret, "081804", at.Add(30 * time.Second))
+
+		Expect(ok).To(BeTrue())
+		Expect(step).To(Equal(int64(1111111109 / 30)))
+	})
+
+	It("should reject wrong or stale code", func() {
+		at := time.Unix(1111111109, 0)
+
+		_, ok := provider.Verify(secret, "000000", at)
+		Expect(ok).To(BeFalse())
+
+		_, ok = provider.Verify(secret, "081804", at.Add(5 * time.Minute))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should build otpauth URI", func() {
+		uri := provider.Uri(secret, "user@example.com")
+
+		Expect(strings.HasPrefix(uri, "otpauth://totp/GoDo:user@example.com?")).To(BeTrue())
+		Expect(uri).To(ContainSubstring("secret=" + secret))
+		Expect(uri).To(ContainSubstring("issuer=GoDo"))
+	})
+
+	It("should generate secret usable for verification", func() {
+		generated, err := provider.GenerateSecret()
+
+		Expect(err).To(BeNil())
+
+		raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(generated)
+
+		Expect(err).To(BeNil())
+		Expect(raw).To(HaveLen(20))
+
+		now := time.Now()
+		_, ok := provider.Verify(generated, totp.Code(raw, now.Unix() / 30), now)
+
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type AccessTokenData struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+type CreatedAccessTokenData struct {
+	AccessTokenData
+	// Plain token. Shown only once.
+	Token string `json:"token"`
+}
+
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name"      validate:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func CreateAccessToken(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		req := new(CreateAccessTokenRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		created, err := app.CreateAccessTokenUsecase().Create(&dto.CreateAccessTokenCommand{
+			UserId: userId,
+			Name: req.Name,
+			Scopes: req.Scopes,
+			ExpiresAt: req.ExpiresAt,
+		})
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("validation error").
+						WithErrors("token", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			data.NewPayload(data.StatusSuccess, &CreatedAccessTokenData{
+				AccessTokenData: *toAccessTokenData(created.AccessToken),
+				Token: created.Token,
+			}).
+				WithMessage("access token created. store it now, it will not be shown again"),
+		)
+	}
+}
+
+func ListAccessTokens(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		tokens, err := app.ListAccessTokensUsecase().List(userId)
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		tokensJson := make([]*AccessTokenData, len(tokens))
+
+		for i, t := range tokens {
+			tokensJson[i] = toAccessTokenData(t)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, tokensJson),
+		)
+	}
+}
+
+func RevokeAccessToken(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+		tokenId := c.Param("tokenId")
+
+		if strings.TrimSpace(userId) == "" || strings.TrimSpace(tokenId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("userId", "userId and tokenId cannot be empty"),
+			)
+		}
+
+		if err := app.RevokeAccessTokenUsecase().Revoke(userId, tokenId); err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusNotFound,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("access token revoked"),
+		)
+	}
+}
+
+func toAccessTokenData(t *dto.AccessTokenDto) *AccessTokenData {
+	return &AccessTokenData{
+		Id: t.Id,
+		Name: t.Name,
+		Scopes: t.Scopes,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type UserExportData struct {
+	User       *UserData   `json:"user"`
+	TodoItems  []*TodoData `json:"todoItems"`
+	ExportedAt time.Time   `json:"exportedAt"`
+}
+
+type AccountDeletionData struct {
+	RequestedAt time.Time `json:"requestedAt"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+func ExportUserData(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		export, err := app.ExportUserDataUsecase().Export(userId)
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		todos := make([]*TodoData, len(export.TodoItems))
+
+		for i, todo := range export.TodoItems {
+			todos[i] = &TodoData{
+				Id: todo.Id,
+				Title: todo.Title,
+				Description: todo.Description,
+				IsDone: todo.IsDone,
+			}
+		}
+
+		c.Response().Header().Set(
+			echo.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="godo-export-%s.json"`, export.ExportedAt.UTC().Format("20060102T150405Z")),
+		)
+
+		return c.JSON(
+			http.StatusOK,
+			&UserExportData{
+				User: &UserData{
+					Id: export.User.Id,
+					Username: export.User.UserName,
+					Email: export.User.Email,
+					Role: export.User.Role,
+				},
+				TodoItems: todos,
+				ExportedAt: export.ExportedAt,
+			},
+		)
+	}
+}
+
+// Password confirming action on account.
+type PasswordRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func RequestAccountDeletion(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		req := new(PasswordRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		deletion, err := app.RequestAccountDeletionUsecase().RequestDeletion(userId, req.Password)
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("validation error").
+						WithErrors("password", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusAccepted,
+			data.NewPayload(data.StatusSuccess, &AccountDeletionData{
+				RequestedAt: deletion.RequestedAt,
+				ScheduledAt: deletion.ScheduledAt,
+			}).
+				WithMessage("account is scheduled for deletion"),
+		)
+	}
+}
+
+func CancelAccountDeletion(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		if err := app.CancelAccountDeletionUsecase().CancelDeletion(userId); err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("account deletion cancelled"),
+		)
+	}
+}
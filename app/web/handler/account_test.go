@@ -0,0 +1,223 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	outDto "github.com/kkatou7209/godo/app/port/out/dto"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/handler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("account handler test", Ordered, func() {
+
+	var accountUserId string
+
+	BeforeAll(func() {
+
+		err := app.AddUserUsecase().Add(&dto.AddUserCommand{
+			UserName: "account-test-user",
+			Email: "account-test@example.com",
+			Password: "account-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		login, err := app.LoginUsecase().Login(&dto.LoginCommand{
+			Email: "account-test@example.com",
+			Password: "account-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		accountUserId = login.User.Id
+
+		_, err = app.AddTodoUsecase().Add(&dto.AddTodoCommand{
+			UserId: accountUserId,
+			Title: "account-test-todo",
+			Description: "exported todo",
+		})
+		Expect(err).To(BeNil())
+
+		project, err := app.CreateProjectUsecase().Create(&dto.CreateProjectCommand{
+			UserId: accountUserId,
+			Name: "account-test-project",
+		})
+		Expect(err).To(BeNil())
+
+		_, err = app.AddTodoUsecase().Add(&dto.AddTodoCommand{
+			UserId: accountUserId,
+			Title: "account-test-project-todo",
+			Description: "exported project todo",
+			ProjectId: project.Id,
+		})
+		Expect(err).To(BeNil())
+	})
+
+	When("export user data", func() {
+
+		It("should return profile and own todo items including those in projects as attachment", func() {
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/user/%s/export", accountUserId), nil)
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetParamNames("userId")
+			c.SetParamValues(accountUserId)
+
+			Expect(handler.ExportUserData(app)(c)).To(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Disposition")).To(HavePrefix("attachment;"))
+
+			var res handler.UserExportData
+
+			Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+			Expect(res.User.Id).To(Equal(accountUserId))
+			Expect(res.User.Email).To(Equal("account-test@example.com"))
+			Expect(res.TodoItems).To(HaveLen(2))
+			Expect(res.TodoItems[0].Title).To(Equal("account-test-todo"))
+			Expect(res.TodoItems[1].Title).To(Equal("account-test-project-todo"))
+			Expect(res.ExportedAt.IsZero()).To(BeFalse())
+		})
+	})
+
+	When("request account deletion", func() {
+
+		requestDeletion := func(password string) *httptest.ResponseRecorder {
+
+			jreq, err := json.Marshal(map[string]any{ "password": password })
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/user/%s", accountUserId), bytes.NewBuffer(jreq))
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetParamNames("userId")
+			c.SetParamValues(accountUserId)
+
+			Expect(handler.RequestAccountDeletion(app)(c)).To(BeNil())
+
+			return rec
+		}
+
+		It("should reject wrong password", func() {
+
+			rec := requestDeletion("wrong-pass")
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should schedule deletion after grace period", func() {
+
+			rec := requestDeletion("account-test-pass")
+
+			Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+			res := new(data.Payload[handler.AccountDeletionData])
+
+			Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+			Expect(res.Data.ScheduledAt.Sub(res.Data.RequestedAt)).To(Equal(14 * 24 * time.Hour))
+			Expect(memoryMailer.LastMailTo("account-test@example.com").Subject).To(ContainSubstring("deleted"))
+		})
+
+		It("should reject second request", func() {
+
+			rec := requestDeletion("account-test-pass")
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should keep account until grace period ends", func() {
+
+			purged, err := app.PurgeDeletedAccountsUsecase().Purge()
+
+			Expect(err).To(BeNil())
+			Expect(purged).To(Equal(0))
+
+			user, err := app.GetUserUsecase().Get(accountUserId)
+
+			Expect(err).To(BeNil())
+			Expect(user).ToNot(BeNil())
+		})
+	})
+
+	When("cancel account deletion", func() {
+
+		cancelDeletion := func() *httptest.ResponseRecorder {
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/user/%s/deletion", accountUserId), nil)
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetParamNames("userId")
+			c.SetParamValues(accountUserId)
+
+			Expect(handler.CancelAccountDeletion(app)(c)).To(BeNil())
+
+			return rec
+		}
+
+		It("should cancel pending deletion", func() {
+
+			Expect(cancelDeletion().Code).To(Equal(http.StatusOK))
+		})
+
+		It("should fail when nothing is pending", func() {
+
+			Expect(cancelDeletion().Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("grace period passed", func() {
+
+		It("should hard delete user", func() {
+
+			err := accountDeletionRepository.Create(&outDto.CreateAccountDeletionCommand{
+				UserId: value.NewUserId(accountUserId),
+				RequestedAt: time.Now().Add(-15 * 24 * time.Hour),
+				ScheduledAt: time.Now().Add(-24 * time.Hour),
+			})
+
+			Expect(err).To(BeNil())
+
+			purged, err := app.PurgeDeletedAccountsUsecase().Purge()
+
+			Expect(err).To(BeNil())
+			Expect(purged).To(Equal(1))
+
+			user, err := app.GetUserUsecase().Get(accountUserId)
+
+			Expect(err).To(BeNil())
+			Expect(user).To(BeNil())
+		})
+	})
+})
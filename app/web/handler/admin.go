@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type PageData[T any] struct {
+	Items   []T `json:"items"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+}
+
+type AdminUserData struct {
+	UserData
+	IsDisabled bool `json:"isDisabled"`
+}
+
+type UserUsageData struct {
+	User               *AdminUserData `json:"user"`
+	TodoItems          int            `json:"todoItems"`
+	CompletedTodoItems int            `json:"completedTodoItems"`
+	AccessTokens       int            `json:"accessTokens"`
+	TwoFactorEnabled   bool           `json:"twoFactorEnabled"`
+}
+
+type AuditLogData struct {
+	Id           string    `json:"id"`
+	ActorId      string    `json:"actorId"`
+	Action       string    `json:"action"`
+	TargetUserId string    `json:"targetUserId"`
+	Detail       string    `json:"detail"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func ListUsers(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		users, err := app.ListUsersUsecase().List(&dto.ListUsersQuery{
+			Search: c.QueryParam("q"),
+			Page: pageQueryOf(c),
+		})
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		usersJson := make([]*AdminUserData, len(users.Items))
+
+		for i, user := range users.Items {
+			usersJson[i] = toAdminUserData(user)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &PageData[*AdminUserData]{
+				Items: usersJson,
+				Total: users.Total,
+				Page: users.Page,
+				PerPage: users.PerPage,
+			}),
+		)
+	}
+}
+
+func InspectUser(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		usage, err := app.InspectUserUsecase().Inspect(middleware.Principal(c).UserId, c.Param("id"))
+
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &UserUsageData{
+				User: toAdminUserData(usage.User),
+				TodoItems: usage.TodoItems,
+				CompletedTodoItems: usage.CompletedTodoItems,
+				AccessTokens: usage.AccessTokens,
+				TwoFactorEnabled: usage.TwoFactorEnabled,
+			}),
+		)
+	}
+}
+
+func DisableUser(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.DisableUserUsecase().Disable(middleware.Principal(c).UserId, c.Param("id")); err != nil {
+			return adminError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("user disabled"),
+		)
+	}
+}
+
+func EnableUser(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.EnableUserUsecase().Enable(middleware.Principal(c).UserId, c.Param("id")); err != nil {
+			return adminError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("user enabled"),
+		)
+	}
+}
+
+func ForcePasswordReset(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.ForcePasswordResetUsecase().ForceReset(middleware.Principal(c).UserId, c.Param("id")); err != nil {
+			return adminError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("password reset token sent to user"),
+		)
+	}
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+func ChangeUserRole(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ChangeRoleRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if err := app.ChangeUserRoleUsecase().ChangeRole(middleware.Principal(c).UserId, c.Param("id"), req.Role); err != nil {
+			return adminError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("role changed"),
+		)
+	}
+}
+
+func ListAuditLogs(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		query := pageQueryOf(c)
+
+		logs, err := app.ListAuditLogsUsecase().List(&query)
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		logsJson := make([]*AuditLogData, len(logs.Items))
+
+		for i, log := range logs.Items {
+			logsJson[i] = &AuditLogData{
+				Id: log.Id,
+				ActorId: log.ActorId,
+				Action: log.Action,
+				TargetUserId: log.TargetUserId,
+				Detail: log.Detail,
+				CreatedAt: log.CreatedAt,
+			}
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &PageData[*AuditLogData]{
+				Items: logsJson,
+				Total: logs.Total,
+				Page: logs.Page,
+				PerPage: logs.PerPage,
+			}),
+		)
+	}
+}
+
+// Read "page" and "perPage" query parameters. Invalid values fall back to defaults.
+func pageQueryOf(c echo.Context) dto.PageQuery {
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("perPage"))
+
+	return dto.PageQuery{
+		Page: page,
+		PerPage: perPage,
+	}
+}
+
+func adminError(c echo.Context, err error) error {
+
+	if err == validation.ErrUserNotFound {
+		return c.JSON(
+			http.StatusNotFound,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if e, ok := err.(*validation.ValidationError); ok {
+		return c.JSON(
+			http.StatusBadRequest,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(e.Error()),
+		)
+	}
+
+	return c.JSON(
+		http.StatusInternalServerError,
+		data.NewPayload[any](data.StatusFail, nil).
+			WithMessage("unexpected error").
+			WithErrors("couse", err.Error()),
+	)
+}
+
+func toAdminUserData(user *dto.UserDto) *AdminUserData {
+	return &AdminUserData{
+		UserData: UserData{
+			Id: user.Id,
+			Username: user.UserName,
+			Email: user.Email,
+			Role: user.Role,
+		},
+		IsDisabled: user.IsDisabled,
+	}
+}
@@ -2,23 +2,38 @@ package handler
 
 import (
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/kkatou7209/godo/app"
 	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/service"
 	"github.com/kkatou7209/godo/app/validation"
 	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/middleware"
 	"github.com/labstack/echo/v4"
 )
 
+type LoginChallengeData struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+}
+
+// Same pattern as value.Email, checked up front so that malformed input gets a 400.
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+type SignUpRequest struct {
+	Username string `json:"username"  validate:"required"`
+	Email    string `json:"email"     validate:"required,email"`
+	Password string `json:"password"  validate:"required"`
+}
+
 func SignUp(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error {
 
-		user := new(struct {
-			Username string `json:"username"  validate:"required"`
-			Email    string `json:"email"     validate:"required,email"`
-			Password string `json:"password"  validate:"required"`
-		})
+		user := new(SignUpRequest)
 	
 		if err := c.Bind(&user); err != nil {
 			return c.JSON(
@@ -62,14 +77,16 @@ func SignUp(app *app.Application) (func(c echo.Context) error) {
 	}
 }
 
+type LoginRequest struct {
+	Email    string `json:"email"    validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
 func Login(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error  {
 		
-		cred := new(struct {
-			Email    string `json:"email"    validate:"required,email"`
-			Password string `json:"password" validate:"required"`
-		})
+		cred := new(LoginRequest)
 
 		if err := c.Bind(&cred); err != nil {
 
@@ -86,10 +103,18 @@ func Login(app *app.Application) (func(c echo.Context) error) {
 			Password: cred.Password,
 		}
 
-		_, err := app.LoginUsecase().Login(credDto)
+		result, err := app.LoginUsecase().Login(credDto)
 
 		if err != nil {
 
+			if err == validation.ErrUserDisabled {
+				return c.JSON(
+					http.StatusForbidden,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("account is disabled"),
+				)
+			}
+
 			if _, ok := err.(*validation.ValidationError); ok {
 				return c.JSON(
 					http.StatusBadRequest,
@@ -107,17 +132,206 @@ func Login(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		c.SetCookie(&http.Cookie{
-			SameSite: http.SameSiteLaxMode,
-			HttpOnly: true,
-			Name: "x-api-token",
-			Value: "test-token",
+		if result.TwoFactorRequired {
+			return c.JSON(
+				http.StatusAccepted,
+				data.NewPayload(data.StatusSuccess, &LoginChallengeData{
+					TwoFactorRequired: true,
+					ChallengeToken: result.ChallengeToken,
+				}).
+					WithMessage("two-factor authentication required"),
+			)
+		}
+
+		setSessionCookie(c, result.Token)
+
+		// Clients not keeping cookies learn which user they act as.
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &UserData{
+				Id: result.User.Id,
+				Username: result.User.UserName,
+				Email: result.User.Email,
+				Role: result.User.Role,
+			}).
+				WithMessage("user loged in successdully"),
+		)
+	}
+}
+
+type VerifyLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code"           validate:"required"`
+}
+
+func VerifyLogin(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(VerifyLoginRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("errors", err.Error()),
+			)
+		}
+
+		result, err := app.VerifyLoginUsecase().Verify(&dto.VerifyLoginCommand{
+			ChallengeToken: req.ChallengeToken,
+			Code: req.Code,
 		})
 
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("invalid two-factor code").
+						WithErrors("code", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error occured").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		setSessionCookie(c, result.Token)
+
 		return c.JSON(
 			http.StatusOK,
-			data.NewPayload[any](data.StatusSuccess, nil).
+			data.NewPayload(data.StatusSuccess, &UserData{
+				Id: result.User.Id,
+				Username: result.User.UserName,
+				Email: result.User.Email,
+				Role: result.User.Role,
+			}).
 				WithMessage("user loged in successdully"),
 		)
 	}
+}
+
+func setSessionCookie(c echo.Context, token string) {
+	c.SetCookie(&http.Cookie{
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Name: middleware.SessionCookieName,
+		Value: token,
+		Path: "/",
+		Expires: time.Now().Add(service.SessionLifetime),
+	})
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func ForgotPassword(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ForgotPasswordRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("errors", err.Error()),
+			)
+		}
+
+		if !emailPattern.MatchString(strings.TrimSpace(req.Email)) {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("email", "invalid email"),
+			)
+		}
+
+		if err := app.RequestPasswordResetUsecase().RequestReset(req.Email); err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error occured").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		// Same response whether or not the email is registered.
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("if the email is registered, a reset token has been sent"),
+		)
+	}
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"    validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func ResetPassword(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ResetPasswordRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("errors", err.Error()),
+			)
+		}
+
+		if strings.TrimSpace(req.Token) == "" || strings.TrimSpace(req.Password) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid request").
+					WithErrors("errors", "token and password are required"),
+			)
+		}
+
+		err := app.ResetPasswordUsecase().Reset(&dto.ResetPasswordCommand{
+			Token: req.Token,
+			Password: req.Password,
+		})
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("validation error").
+						WithErrors("token", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error occured").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("password reset successfully"),
+		)
+	}
 }
\ No newline at end of file
@@ -6,7 +6,9 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 
+	"github.com/kkatou7209/godo/token"
 	"github.com/kkatou7209/godo/web/data"
 	"github.com/kkatou7209/godo/web/handler"
 	. "github.com/onsi/ginkgo/v2"
@@ -108,4 +110,141 @@ var _ = Describe("auth handler test", Ordered, func() {
 			Expect(tokenCookie.Value).ToNot(BeEmpty())
 		})
 	})
+	When("password forgotten", func() {
+
+		var resetToken string
+
+		forgot := func(email string) *httptest.ResponseRecorder {
+
+			jreq, err := json.Marshal(map[string]any{ "email": email })
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/password/forgot", bytes.NewBuffer(jreq))
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			Expect(handler.ForgotPassword(app)(e.NewContext(req, rec))).To(BeNil())
+
+			return rec
+		}
+
+		reset := func(token string, password string) *httptest.ResponseRecorder {
+
+			jreq, err := json.Marshal(map[string]any{ "token": token, "password": password })
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/password/reset", bytes.NewBuffer(jreq))
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			Expect(handler.ResetPassword(app)(e.NewContext(req, rec))).To(BeNil())
+
+			return rec
+		}
+
+		It("should send reset token to registered email", func() {
+
+			rec := forgot("auth-api@example.com")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			mail := memoryMailer.LastMailTo("auth-api@example.com")
+
+			Expect(mail).ToNot(BeNil())
+
+			matches := regexp.MustCompile(`Reset token: (\S+)`).FindStringSubmatch(mail.Body)
+
+			Expect(matches).To(HaveLen(2))
+
+			resetToken = matches[1]
+		})
+
+		It("should answer same way for unknown email", func() {
+
+			known := forgot("auth-api@example.com")
+			unknown := forgot("unknown-user@example.com")
+
+			Expect(unknown.Code).To(Equal(known.Code))
+			Expect(unknown.Body.String()).To(Equal(known.Body.String()))
+			Expect(memoryMailer.LastMailTo("unknown-user@example.com")).To(BeNil())
+		})
+
+		It("should reset password and invalidate sessions", func() {
+
+			jcred, err := json.Marshal(map[string]any{
+				"email": "auth-api@example.com",
+				"password": "auth-api-test-pass",
+			})
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(jcred))
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			loginRec := httptest.NewRecorder()
+
+			Expect(handler.Login(app)(e.NewContext(req, loginRec))).To(BeNil())
+
+			var sessionToken string
+
+			for _, cookie := range loginRec.Result().Cookies() {
+				if cookie.Name == "x-api-token" {
+					sessionToken = cookie.Value
+				}
+			}
+
+			sessionHash := token.NewRandomTokenGenerator().Hash(sessionToken)
+
+			session, _ := sessionRepository.Get(sessionHash)
+
+			Expect(session).ToNot(BeNil())
+
+			rec := reset(resetToken, "auth-api-test-pass-reset")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			session, _ = sessionRepository.Get(sessionHash)
+
+			Expect(session).To(BeNil())
+		})
+
+		It("should not reuse reset token", func() {
+
+			rec := reset(resetToken, "auth-api-test-pass-again")
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject unknown reset token", func() {
+
+			rec := reset("unknown-token", "auth-api-test-pass-again")
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
\ No newline at end of file
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/ical"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+const calendarProdId = "-//GoDo//GoDo//EN"
+
+type CalendarFeedData struct {
+	// Only returned when feed is created.
+	Token     string    `json:"token,omitempty"`
+	// URL to subscribe to in calendar apps. Only returned when feed is created.
+	Url       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func GetCalendarFeed(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		feed, err := app.CalendarFeedUsecase().Get(c.Param("userId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		if feed == nil {
+			return c.JSON(
+				http.StatusNotFound,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("calendar feed not found"),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, CalendarFeedData{CreatedAt: feed.CreatedAt}),
+		)
+	}
+}
+
+// Create calendar feed, replacing URL of previous one.
+func RotateCalendarFeed(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		feed, err := app.CalendarFeedUsecase().Rotate(c.Param("userId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			data.NewPayload(data.StatusSuccess, CalendarFeedData{
+				Token: feed.Token,
+				Url: c.Scheme() + "://" + c.Request().Host + "/calendar/" + feed.Token + ".ics",
+				CreatedAt: feed.CreatedAt,
+			}).WithMessage("calendar feed created"),
+		)
+	}
+}
+
+func RevokeCalendarFeed(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.CalendarFeedUsecase().Revoke(c.Param("userId")); err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("calendar feed revoked"),
+		)
+	}
+}
+
+// Serve todo items as VTODO components. Token in path stands in for login.
+func ServeCalendarFeed(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		feed, err := app.CalendarFeedUsecase().ListTodos(strings.TrimSuffix(c.Param("token"), ".ics"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		now := time.Now()
+
+		cal := &ical.Calendar{ProdId: calendarProdId, Name: "GoDo", Items: make([]*ical.Item, len(feed.Todos))}
+
+		for i, todo := range feed.Todos {
+
+			status := ical.StatusNeedsAction
+
+			if todo.IsDone {
+				status = ical.StatusCompleted
+			}
+
+			cal.Items[i] = &ical.Item{
+				Kind: ical.KindTodo,
+				Uid: todo.Id,
+				Stamp: now,
+				Summary: todo.Title,
+				Description: todo.Description,
+				Status: status,
+			}
+		}
+
+		b, err := ical.Marshal(cal)
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		c.Response().Header().Set(echo.HeaderCacheControl, "private, no-cache")
+
+		return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", b)
+	}
+}
+
+// Accepts iCalendar file as request body. VTODO and VEVENT components become todo items.
+// Query is same as of ImportTodoItems.
+func ImportCalendar(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImportSize)
+
+		cal, err := ical.Parse(c.Request().Body)
+
+		if err != nil {
+			return importFileError(c, err)
+		}
+
+		rows := make([]*importRow, 0, len(cal.Items))
+
+		for i, item := range cal.Items {
+
+			if strings.TrimSpace(item.Summary) == "" || item.Status == ical.StatusCancelled {
+				continue
+			}
+
+			// Todo items need description, so summary stands in for missing one.
+			description := item.Description
+
+			if strings.TrimSpace(description) == "" {
+				description = item.Summary
+			}
+
+			rows = append(rows, &importRow{
+				number: i + 1,
+				todo: &dto.AddTodoCommand{
+					UserId: c.Param("userId"),
+					Title: item.Summary,
+					Description: description,
+					ProjectId: c.QueryParam("projectId"),
+					IsDone: item.Status == ical.StatusCompleted,
+				},
+			})
+		}
+
+		return importTodos(c, app, rows, len(cal.Items) - len(rows))
+	}
+}
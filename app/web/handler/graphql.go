@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kkatou7209/godo/graph"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Run GraphQL request on behalf of authenticated user, or anonymously.
+// Response is GraphQL response, not payload envelope, as GraphQL clients expect.
+func ExecGraphQL(server *graph.Server) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(graph.Request)
+
+		if err := c.Bind(req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if strings.TrimSpace(req.Query) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("query", "empty cannot be set"),
+			)
+		}
+
+		return c.JSON(http.StatusOK, server.Exec(c.Request().Context(), middleware.Principal(c), req))
+	}
+}
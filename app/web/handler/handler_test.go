@@ -7,8 +7,13 @@ import (
 	ap "github.com/kkatou7209/godo/app"
 	"github.com/kkatou7209/godo/app/domain/value"
 	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/mailer"
 	"github.com/kkatou7209/godo/password"
 	"github.com/kkatou7209/godo/persistence/mock"
+	"github.com/kkatou7209/godo/storage"
+	"github.com/kkatou7209/godo/token"
+	"github.com/kkatou7209/godo/totp"
 	"github.com/labstack/echo/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -18,23 +23,118 @@ var (
 	app *ap.Application
 	e *echo.Echo = echo.New()
 	userId value.UserId
+	sessionRepository *mock.MockSessionRepository
+	memoryMailer *mailer.MemoryMailer
+	accountDeletionRepository *mock.MockAccountDeletionRepository
 )
 
 var _ = BeforeSuite(func() {
 
 	todoRepository := mock.NewMockTodoItemRepository()
 	userRepository := mock.NewMockUserRepository()
+	sessionRepository = mock.NewMockSessionRepository()
+	passwordResetRepository := mock.NewMockPasswordResetRepository()
+	twoFactorRepository := mock.NewMockTwoFactorRepository()
+	loginChallengeRepository := mock.NewMockLoginChallengeRepository()
+	emailChangeRepository := mock.NewMockEmailChangeRepository()
+	accountDeletionRepository = mock.NewMockAccountDeletionRepository()
+	accessTokenRepository := mock.NewMockAccessTokenRepository()
+	auditLogRepository := mock.NewMockAuditLogRepository()
+	projectRepository := mock.NewMockProjectRepository()
+	todoAssignmentRepository := mock.NewMockTodoAssignmentRepository()
+	todoCommentRepository := mock.NewMockTodoCommentRepository()
+	todoAttachmentRepository := mock.NewMockTodoAttachmentRepository()
+	todoSearchRepository := mock.NewMockTodoSearchRepository(todoRepository, todoCommentRepository)
+	todoUndoRepository := mock.NewMockTodoUndoRepository()
+	todoSyncRepository := mock.NewMockTodoSyncRepository(todoRepository)
+	calendarFeedRepository := mock.NewMockCalendarFeedRepository()
+	memoryMailer = mailer.NewMemoryMailer()
 
 	app = ap.New().
 		SetCreateTodoPersistence(todoRepository).
 		SetListTodoPersistence(todoRepository).
+		SetListTodoPagePersistence(todoRepository).
 		SetGetTodoPersistence(todoRepository).
 		SetUpdateTodoPersistence(todoRepository).
 		SetDeleteTodoPersistence(todoRepository).
+		SetGetTodoBatchPersistence(todoRepository).
+		SetWriteTodoBatchPersistence(todoRepository).
+		SetCreateTodoBatchPersistence(todoRepository).
 		SetCreateUserPersistence(userRepository).
 		SetGetUserPersistence(userRepository).
 		SetUpdateUserPersistence(userRepository).
-		SetPasswordHasher(password.NewBycryptPasswordHasher())
+		SetDeleteUserPersistence(userRepository).
+		SetListUserPersistence(userRepository).
+		SetCreateSessionPersistence(sessionRepository).
+		SetGetSessionPersistence(sessionRepository).
+		SetDeleteSessionPersistence(sessionRepository).
+		SetCreatePasswordResetPersistence(passwordResetRepository).
+		SetGetPasswordResetPersistence(passwordResetRepository).
+		SetUpdatePasswordResetPersistence(passwordResetRepository).
+		SetCreateTwoFactorPersistence(twoFactorRepository).
+		SetGetTwoFactorPersistence(twoFactorRepository).
+		SetUpdateTwoFactorPersistence(twoFactorRepository).
+		SetDeleteTwoFactorPersistence(twoFactorRepository).
+		SetCreateLoginChallengePersistence(loginChallengeRepository).
+		SetGetLoginChallengePersistence(loginChallengeRepository).
+		SetUpdateLoginChallengePersistence(loginChallengeRepository).
+		SetDeleteLoginChallengePersistence(loginChallengeRepository).
+		SetCreateEmailChangePersistence(emailChangeRepository).
+		SetGetEmailChangePersistence(emailChangeRepository).
+		SetUpdateEmailChangePersistence(emailChangeRepository).
+		SetCreateAccountDeletionPersistence(accountDeletionRepository).
+		SetGetAccountDeletionPersistence(accountDeletionRepository).
+		SetDeleteAccountDeletionPersistence(accountDeletionRepository).
+		SetCreateAccessTokenPersistence(accessTokenRepository).
+		SetGetAccessTokenPersistence(accessTokenRepository).
+		SetListAccessTokenPersistence(accessTokenRepository).
+		SetUpdateAccessTokenPersistence(accessTokenRepository).
+		SetDeleteAccessTokenPersistence(accessTokenRepository).
+		SetCreateAuditLogPersistence(auditLogRepository).
+		SetListAuditLogPersistence(auditLogRepository).
+		SetCreateProjectPersistence(projectRepository).
+		SetGetProjectPersistence(projectRepository).
+		SetListProjectPersistence(projectRepository).
+		SetUpdateProjectPersistence(projectRepository).
+		SetDeleteProjectPersistence(projectRepository).
+		SetGetProjectMemberPersistence(projectRepository).
+		SetSaveProjectMemberPersistence(projectRepository).
+		SetDeleteProjectMemberPersistence(projectRepository).
+		SetCreateProjectInvitePersistence(projectRepository).
+		SetGetProjectInvitePersistence(projectRepository).
+		SetUpdateProjectInvitePersistence(projectRepository).
+		SetCreateTodoAssignmentPersistence(todoAssignmentRepository).
+		SetListTodoAssignmentPersistence(todoAssignmentRepository).
+		SetCreateTodoCommentPersistence(todoCommentRepository).
+		SetGetTodoCommentPersistence(todoCommentRepository).
+		SetListTodoCommentPersistence(todoCommentRepository).
+		SetUpdateTodoCommentPersistence(todoCommentRepository).
+		SetDeleteTodoCommentPersistence(todoCommentRepository).
+		SetCreateTodoAttachmentPersistence(todoAttachmentRepository).
+		SetGetTodoAttachmentPersistence(todoAttachmentRepository).
+		SetListTodoAttachmentPersistence(todoAttachmentRepository).
+		SetDeleteTodoAttachmentPersistence(todoAttachmentRepository).
+		SetSearchTodoPersistence(todoSearchRepository).
+		SetCreateTodoUndoPersistence(todoUndoRepository).
+		SetGetTodoUndoPersistence(todoUndoRepository).
+		SetUpdateTodoUndoPersistence(todoUndoRepository).
+		SetListTodoChangePersistence(todoSyncRepository).
+		SetGetTodoRevisionPersistence(todoSyncRepository).
+		SetGetTodoSyncOperationPersistence(todoSyncRepository).
+		SetWriteTodoSyncPersistence(todoSyncRepository).
+		SetSaveCalendarFeedPersistence(calendarFeedRepository).
+		SetGetCalendarFeedPersistence(calendarFeedRepository).
+		SetDeleteCalendarFeedPersistence(calendarFeedRepository).
+		SetListTodoBatchPersistence(todoRepository).
+		SetListTodoCommentBatchPersistence(todoCommentRepository).
+		SetGetUserBatchPersistence(userRepository).
+		SetListProjectMemberBatchPersistence(projectRepository).
+		SetPasswordHasher(password.NewBycryptPasswordHasher()).
+		SetTokenGenerator(token.NewRandomTokenGenerator()).
+		SetMailer(memoryMailer).
+		SetTotpProvider(totp.NewRfc6238Provider("GoDo")).
+		SetBlobStorage(storage.NewMemoryBlobStorage()).
+		SetTodoEventBus(event.NewMemoryTodoEventBus())
 
 	if err := app.AddUserUsecase().Add(&dto.AddUserCommand{
 		UserName: "handler-test-user",
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+// Whether server still takes traffic. Once draining, it does not again.
+type Readiness struct {
+	draining atomic.Bool
+}
+
+// Fail readiness from now on, so load balancers stop sending requests before server stops.
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+func (r *Readiness) Draining() bool {
+	return r.draining.Load()
+}
+
+// Liveness of process. Succeeds as long as server answers.
+func GetHealth() (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, data.NewPayload[any](data.StatusSuccess, nil).WithMessage("ok"))
+	}
+}
+
+// Readiness to serve requests: not draining, database reachable and schema migrated.
+func GetReadiness(app *app.Application, readiness *Readiness) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if readiness.Draining() {
+			return c.JSON(
+				http.StatusServiceUnavailable,
+				data.NewPayload[any](data.StatusFail, nil).WithMessage("draining"),
+			)
+		}
+
+		// Probe is unauthenticated, so cause is only logged.
+		if err := app.CheckReadinessUsecase().Check(); err != nil {
+			slog.Error("not ready", "error", err)
+			return c.JSON(
+				http.StatusServiceUnavailable,
+				data.NewPayload[any](data.StatusFail, nil).WithMessage("not ready"),
+			)
+		}
+
+		return c.JSON(http.StatusOK, data.NewPayload[any](data.StatusSuccess, nil).WithMessage("ready"))
+	}
+}
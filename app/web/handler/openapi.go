@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/kkatou7209/godo/web/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+func GetOpenApiDocument(doc *openapi.Document) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc)
+	}
+}
+
+// Page browsing document of GetOpenApiDocument.
+func GetApiDocs() (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+		return c.HTMLBlob(http.StatusOK, openapi.Page)
+	}
+}
@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectData struct {
+	Id         string    `json:"id"`
+	Name       string    `json:"name"`
+	// Permission of requesting user.
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type ProjectMemberData struct {
+	UserId     string `json:"userId"`
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	Permission string `json:"permission"`
+}
+
+func ListProjects(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		projects, err := app.ListProjectsUsecase().List(c.Param("userId"))
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		projectsJson := make([]*ProjectData, len(projects))
+
+		for i, p := range projects {
+			projectsJson[i] = toProjectData(p)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, projectsJson),
+		)
+	}
+}
+
+type ProjectNameRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func CreateProject(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ProjectNameRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		project, err := app.CreateProjectUsecase().Create(&dto.CreateProjectCommand{
+			UserId: c.Param("userId"),
+			Name: req.Name,
+		})
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			data.NewPayload(data.StatusSuccess, toProjectData(project)).
+				WithMessage("project created"),
+		)
+	}
+}
+
+func GetProject(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		project, err := app.GetProjectUsecase().Get(c.Param("userId"), c.Param("projectId"))
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, toProjectData(project)),
+		)
+	}
+}
+
+func RenameProject(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ProjectNameRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		err := app.RenameProjectUsecase().Rename(&dto.RenameProjectCommand{
+			UserId: c.Param("userId"),
+			ProjectId: c.Param("projectId"),
+			Name: req.Name,
+		})
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("project renamed"),
+		)
+	}
+}
+
+func DeleteProject(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.DeleteProjectUsecase().Delete(c.Param("userId"), c.Param("projectId")); err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("project deleted"),
+		)
+	}
+}
+
+func ListProjectTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		todos, err := app.ListProjectTodosUsecase().List(c.Param("userId"), c.Param("projectId"))
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		todoJsons := make([]TodoData, len(todos))
+
+		for i, todo := range todos {
+			todoJsons[i] = toTodoData(todo)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, todoJsons),
+		)
+	}
+}
+
+func ListProjectMembers(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		members, err := app.ListProjectMembersUsecase().List(c.Param("userId"), c.Param("projectId"))
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		membersJson := make([]*ProjectMemberData, len(members))
+
+		for i, m := range members {
+			membersJson[i] = &ProjectMemberData{
+				UserId: m.UserId,
+				Username: m.UserName,
+				Email: m.Email,
+				Permission: m.Permission,
+			}
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, membersJson),
+		)
+	}
+}
+
+type ChangeMemberRequest struct {
+	Permission string `json:"permission" validate:"required"`
+}
+
+func ChangeProjectMember(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(ChangeMemberRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		err := app.ChangeProjectMemberUsecase().Change(&dto.ChangeProjectMemberCommand{
+			UserId: c.Param("userId"),
+			ProjectId: c.Param("projectId"),
+			MemberId: c.Param("memberId"),
+			Permission: req.Permission,
+		})
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("member permission changed"),
+		)
+	}
+}
+
+func RemoveProjectMember(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		err := app.RemoveProjectMemberUsecase().Remove(c.Param("userId"), c.Param("projectId"), c.Param("memberId"))
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("member removed"),
+		)
+	}
+}
+
+type InviteMemberRequest struct {
+	Email      string `json:"email"      validate:"required"`
+	Permission string `json:"permission" validate:"required"`
+}
+
+func InviteProjectMember(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(InviteMemberRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		err := app.InviteProjectMemberUsecase().Invite(&dto.InviteProjectMemberCommand{
+			UserId: c.Param("userId"),
+			ProjectId: c.Param("projectId"),
+			Email: req.Email,
+			Permission: req.Permission,
+		})
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusAccepted,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("invite sent"),
+		)
+	}
+}
+
+// Token sent by mail.
+type TokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+func AcceptProjectInvite(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(TokenRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		project, err := app.AcceptProjectInviteUsecase().Accept(&dto.AcceptProjectInviteCommand{
+			UserId: c.Param("userId"),
+			Token: req.Token,
+		})
+
+		if err != nil {
+			return projectError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, toProjectData(project)).
+				WithMessage("joined project"),
+		)
+	}
+}
+
+func toProjectData(p *dto.ProjectDto) *ProjectData {
+	return &ProjectData{
+		Id: p.Id,
+		Name: p.Name,
+		Permission: p.Permission,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func projectError(c echo.Context, err error) error {
+
+	if err == validation.ErrProjectNotFound || err == validation.ErrProjectMemberNotFound {
+		return c.JSON(
+			http.StatusNotFound,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if err == validation.ErrPermissionDenied {
+		return c.JSON(
+			http.StatusForbidden,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if e, ok := err.(*validation.ValidationError); ok {
+		return c.JSON(
+			http.StatusBadRequest,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(e.Error()),
+		)
+	}
+
+	return c.JSON(
+		http.StatusInternalServerError,
+		data.NewPayload[any](data.StatusFail, nil).
+			WithMessage("unexpected error").
+			WithErrors("couse", err.Error()),
+	)
+}
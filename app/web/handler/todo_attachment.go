@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/service"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+// Room for multipart headers around uploaded file.
+const multipartOverhead = 1 << 20
+
+type TodoAttachmentData struct {
+	Id          string    `json:"id"`
+	TodoId      string    `json:"todoId"`
+	UploaderId  string    `json:"uploaderId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func ListTodoAttachments(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		attachments, err := app.ListTodoAttachmentsUsecase().List(c.Param("userId"), c.Param("todoItemId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		attachmentsJson := make([]*TodoAttachmentData, len(attachments))
+
+		for i, attachment := range attachments {
+			attachmentsJson[i] = toTodoAttachmentData(attachment)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, attachmentsJson),
+		)
+	}
+}
+
+// Accepts multipart form with file in "file" field.
+func UploadTodoAttachment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, service.MaxTodoAttachmentSize + multipartOverhead)
+
+		header, err := c.FormFile("file")
+
+		var tooLarge *http.MaxBytesError
+
+		if errors.As(err, &tooLarge) {
+			return todoError(c, validation.ErrTodoAttachmentTooLarge)
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		file, err := header.Open()
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		defer file.Close()
+
+		attachment, err := app.UploadTodoAttachmentUsecase().Upload(&dto.UploadTodoAttachmentCommand{
+			UserId: c.Param("userId"),
+			TodoId: c.Param("todoItemId"),
+			FileName: header.Filename,
+			Size: header.Size,
+			Content: file,
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			data.NewPayload(data.StatusSuccess, toTodoAttachmentData(attachment)).
+				WithMessage("file attached"),
+		)
+	}
+}
+
+// Streams file content. Served as download so browsers never render uploaded HTML inline.
+func DownloadTodoAttachment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		download, err := app.DownloadTodoAttachmentUsecase().Download(c.Param("userId"), c.Param("todoItemId"), c.Param("attachmentId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		defer download.Content.Close()
+
+		attachment := download.Attachment
+
+		header := c.Response().Header()
+
+		header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set(echo.HeaderLastModified, attachment.CreatedAt.UTC().Format(http.TimeFormat))
+
+		header.Set(echo.HeaderContentType, attachment.ContentType)
+		c.Response().WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(c.Response(), download.Content)
+
+		return err
+	}
+}
+
+func DeleteTodoAttachment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		err := app.DeleteTodoAttachmentUsecase().Delete(c.Param("userId"), c.Param("todoItemId"), c.Param("attachmentId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("attachment deleted"),
+		)
+	}
+}
+
+func toTodoAttachmentData(attachment *dto.TodoAttachmentDto) *TodoAttachmentData {
+	return &TodoAttachmentData{
+		Id: attachment.Id,
+		TodoId: attachment.TodoId,
+		UploaderId: attachment.UploaderId,
+		FileName: attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size: attachment.Size,
+		CreatedAt: attachment.CreatedAt,
+	}
+}
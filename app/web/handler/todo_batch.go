@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+// Modes of todo batch.
+const (
+	// Apply nothing if any operation fails.
+	BatchModeAtomic = "atomic"
+	// Apply operations that succeed and report each result.
+	BatchModePartial = "partial"
+)
+
+type BatchOperationData struct {
+	Op          string  `json:"op"`
+	TodoId      string  `json:"todoId"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	ProjectId   string  `json:"projectId"`
+}
+
+type BatchResultData struct {
+	Op     string `json:"op"`
+	TodoId string `json:"todoId"`
+	Ok     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BatchData struct {
+	Results   []BatchResultData `json:"results"`
+	// Token to undo applied operations. Empty if nothing was applied.
+	UndoToken string            `json:"undoToken,omitempty"`
+}
+
+type BatchRequest struct {
+	// BatchModeAtomic when empty.
+	Mode       string                `json:"mode"`
+	Operations []*BatchOperationData `json:"operations"`
+}
+
+func BatchTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(BatchRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if req.Mode == "" {
+			req.Mode = BatchModeAtomic
+		}
+
+		if req.Mode != BatchModeAtomic && req.Mode != BatchModePartial {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("invalid batch mode").
+					WithErrors("mode", "mode must be atomic or partial"),
+			)
+		}
+
+		operations := make([]*dto.BatchTodoOperation, len(req.Operations))
+
+		for i, op := range req.Operations {
+			operations[i] = &dto.BatchTodoOperation{
+				Op: op.Op,
+				TodoId: op.TodoId,
+				Title: op.Title,
+				Description: op.Description,
+				ProjectId: op.ProjectId,
+			}
+		}
+
+		results, undoToken, err := app.BatchTodoUsecase().Run(&dto.BatchTodoCommand{
+			UserId: c.Param("userId"),
+			Atomic: req.Mode == BatchModeAtomic,
+			Operations: operations,
+		})
+
+		if err == validation.ErrBatchAborted {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload(data.StatusFail, BatchData{Results: toBatchResultData(results)}).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, BatchData{toBatchResultData(results), undoToken}).
+				WithMessage("batch applied"),
+		)
+	}
+}
+
+func toBatchResultData(results []*dto.BatchTodoResultDto) []BatchResultData {
+
+	resultsJson := make([]BatchResultData, len(results))
+
+	for i, result := range results {
+
+		resultsJson[i] = BatchResultData{
+			Op: result.Op,
+			TodoId: result.TodoId,
+			Ok: result.Error == nil,
+		}
+
+		if result.Error != nil {
+			resultsJson[i].Error = result.Error.Error()
+		}
+	}
+
+	return resultsJson
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/markdown"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type TodoCommentData struct {
+	Id         string     `json:"id"`
+	TodoId     string     `json:"todoId"`
+	AuthorId   string     `json:"authorId"`
+	AuthorName string     `json:"authorName"`
+	// Body in Markdown as written.
+	Body       string     `json:"body"`
+	// Body rendered to sanitized HTML.
+	BodyHtml   string     `json:"bodyHtml"`
+	Mentions   []string   `json:"mentions"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	EditedAt   *time.Time `json:"editedAt"`
+}
+
+func ListTodoComments(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		comments, err := app.ListTodoCommentsUsecase().List(c.Param("userId"), c.Param("todoItemId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		commentsJson := make([]*TodoCommentData, len(comments))
+
+		for i, comment := range comments {
+			commentsJson[i] = toTodoCommentData(comment)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, commentsJson),
+		)
+	}
+}
+
+type CommentRequest struct {
+	Body string `json:"body" validate:"required"`
+}
+
+func AddTodoComment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(CommentRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		comment, err := app.AddTodoCommentUsecase().Add(&dto.AddTodoCommentCommand{
+			UserId: c.Param("userId"),
+			TodoId: c.Param("todoItemId"),
+			Body: req.Body,
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			data.NewPayload(data.StatusSuccess, toTodoCommentData(comment)).
+				WithMessage("comment added"),
+		)
+	}
+}
+
+func EditTodoComment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(CommentRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		comment, err := app.EditTodoCommentUsecase().Edit(&dto.EditTodoCommentCommand{
+			UserId: c.Param("userId"),
+			TodoId: c.Param("todoItemId"),
+			CommentId: c.Param("commentId"),
+			Body: req.Body,
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, toTodoCommentData(comment)).
+				WithMessage("comment edited"),
+		)
+	}
+}
+
+func DeleteTodoComment(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		err := app.DeleteTodoCommentUsecase().Delete(c.Param("userId"), c.Param("todoItemId"), c.Param("commentId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("comment deleted"),
+		)
+	}
+}
+
+func toTodoCommentData(comment *dto.TodoCommentDto) *TodoCommentData {
+	return &TodoCommentData{
+		Id: comment.Id,
+		TodoId: comment.TodoId,
+		AuthorId: comment.AuthorId,
+		AuthorName: comment.AuthorName,
+		Body: comment.Body,
+		BodyHtml: markdown.Render(comment.Body),
+		Mentions: comment.Mentions,
+		CreatedAt: comment.CreatedAt,
+		EditedAt: comment.EditedAt,
+	}
+}
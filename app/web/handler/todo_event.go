@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// Interval of comments sent to keep idle event streams open through proxies.
+const todoEventHeartbeat = 15 * time.Second
+
+type TodoEventData struct {
+	// Pass as Last-Event-ID to resume after this event.
+	Id         string    `json:"id"`
+	Type       string    `json:"type"`
+	// Absent if reset, when todo items should be reloaded.
+	Todo       *TodoData `json:"todo,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+func toTodoEventData(e *dto.TodoEventDto) TodoEventData {
+
+	data := TodoEventData{
+		Id: e.Id,
+		Type: e.Type,
+		OccurredAt: e.OccurredAt,
+	}
+
+	if e.Todo != nil {
+		todo := toTodoData(e.Todo)
+		data.Todo = &todo
+	}
+
+	return data
+}
+
+// Stream todo events as server-sent events.
+// Stream ends when subscriber falls behind, so client reconnects with Last-Event-ID.
+func StreamTodoEvents(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		lastEventId := c.Request().Header.Get("Last-Event-ID")
+
+		if lastEventId == "" {
+			lastEventId = c.QueryParam("lastEventId")
+		}
+
+		events, cancel, err := app.SubscribeTodoEventsUsecase().Subscribe(c.Param("userId"), lastEventId)
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		defer cancel()
+
+		res := c.Response()
+
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set(echo.HeaderConnection, "keep-alive")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		res.Flush()
+
+		heartbeat := time.NewTicker(todoEventHeartbeat)
+
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-c.Request().Context().Done():
+				return nil
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+					return nil
+				}
+				res.Flush()
+			case e, ok := <-events:
+
+				if !ok {
+					return nil
+				}
+
+				body, err := json.Marshal(toTodoEventData(e))
+
+				if err != nil {
+					return err
+				}
+
+				if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", e.Id, e.Type, body); err != nil {
+					return nil
+				}
+
+				res.Flush()
+			}
+		}
+	}
+}
+
+// Stream todo events over WebSocket as JSON messages.
+// Connection is closed when subscriber falls behind, so client reconnects with lastEventId.
+func StreamTodoEventsWebSocket(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		events, cancel, err := app.SubscribeTodoEventsUsecase().Subscribe(c.Param("userId"), c.QueryParam("lastEventId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		defer cancel()
+
+		server := websocket.Server{
+			// Browsers send cookies cross-site on WebSocket handshake, so only same origin is accepted.
+			Handshake: func(config *websocket.Config, req *http.Request) error {
+
+				origin, err := websocket.Origin(config, req)
+
+				if err != nil {
+					return err
+				}
+
+				if origin != nil && origin.Host != req.Host {
+					return fmt.Errorf("origin %s not allowed", origin)
+				}
+
+				config.Origin = origin
+
+				return nil
+			},
+			Handler: func(ws *websocket.Conn) {
+
+				defer ws.Close()
+
+				// Incoming messages are ignored. Reading only tells when client leaves.
+				go func() {
+					for {
+						var message string
+						if err := websocket.Message.Receive(ws, &message); err != nil {
+							cancel()
+							return
+						}
+					}
+				}()
+
+				for e := range events {
+					if err := websocket.JSON.Send(ws, toTodoEventData(e)); err != nil {
+						return
+					}
+				}
+			},
+		}
+
+		server.ServeHTTP(c.Response(), c.Request())
+
+		return nil
+	}
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/todofile"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+// Maximum size of file imported at once.
+const maxImportSize = 1 << 20
+
+type ImportedTodosData struct {
+	// Number of todo items imported, or that would be on dry run.
+	Count   int               `json:"count"`
+	// Empty on dry run or errors.
+	TodoIds []string          `json:"todoIds"`
+	// Number of items left out, e.g. cancelled calendar events.
+	Skipped int               `json:"skipped"`
+	// Nothing is imported if there are any.
+	Errors  []ImportErrorData `json:"errors"`
+	// nil on dry run or errors.
+	Undo    *UndoData         `json:"undo"`
+}
+
+type ImportErrorData struct {
+	// Line of CSV and Markdown files, or position of item in JSON and iCalendar files, starting from 1.
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// Todo item read from uploaded file, or why it could not be read.
+type importRow struct {
+	number int
+	todo *dto.AddTodoCommand
+	err error
+}
+
+// Stream todo items as file of format given by query, without holding them all in memory.
+func ExportTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		format := c.QueryParam("format")
+
+		if !todofile.IsFormat(format) {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("format must be csv, json or md"),
+			)
+		}
+
+		w, err := todofile.NewWriter(format, c.Response())
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		header := c.Response().Header()
+
+		header.Set(echo.HeaderContentType, todofile.ContentType(format))
+		header.Set(
+			echo.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="godo-todos-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), format),
+		)
+
+		err = app.ExportTodosUsecase().Export(c.Param("userId"), func(todo *dto.TodoItemDto) error {
+			return w.Write(&todofile.Todo{
+				Id: todo.Id,
+				Title: todo.Title,
+				Description: todo.Description,
+				IsDone: todo.IsDone,
+				ProjectId: todo.ProjectId,
+			})
+		})
+
+		if err == nil {
+			err = w.Close()
+		}
+
+		// Status cannot change once file started. Error only cuts it short.
+		if err != nil && !c.Response().Committed {
+			header.Del(echo.HeaderContentDisposition)
+			return todoError(c, err)
+		}
+
+		return err
+	}
+}
+
+// Accepts file of format given by query as request body.
+// With dryRun=true rows are only checked. With projectId todo items are added to project.
+func ImportTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		format := c.QueryParam("format")
+
+		if !todofile.IsFormat(format) {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("format must be csv, json or md"),
+			)
+		}
+
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImportSize)
+
+		rows, err := todofile.Read(format, c.Request().Body)
+
+		if err != nil {
+			return importFileError(c, err)
+		}
+
+		imports := make([]*importRow, len(rows))
+
+		for i, row := range rows {
+
+			imports[i] = &importRow{number: row.Number, err: row.Err}
+
+			if row.Err == nil {
+				imports[i].todo = &dto.AddTodoCommand{
+					UserId: c.Param("userId"),
+					Title: row.Todo.Title,
+					Description: row.Todo.Description,
+					ProjectId: c.QueryParam("projectId"),
+					IsDone: row.Todo.IsDone,
+				}
+			}
+		}
+
+		return importTodos(c, app, imports, 0)
+	}
+}
+
+// Respond to file that could not be read as a whole.
+func importFileError(c echo.Context, err error) error {
+
+	var tooLarge *http.MaxBytesError
+
+	if errors.As(err, &tooLarge) {
+		return c.JSON(
+			http.StatusRequestEntityTooLarge,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage("file is too large"),
+		)
+	}
+
+	return c.JSON(
+		http.StatusBadRequest,
+		data.NewPayload[any](data.StatusFail, nil).
+			WithMessage(err.Error()),
+	)
+}
+
+// Import rows read from file and report errors of rows that were not read or are not valid.
+func importTodos(c echo.Context, app *app.Application, rows []*importRow, skipped int) error {
+
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dryRun"))
+
+	command := &dto.ImportTodosCommand{UserId: c.Param("userId"), DryRun: dryRun}
+
+	// Row numbers of todo items of command.
+	numbers := make([]int, 0, len(rows))
+
+	result := ImportedTodosData{TodoIds: make([]string, 0), Skipped: skipped, Errors: make([]ImportErrorData, 0)}
+
+	for _, row := range rows {
+
+		if row.err != nil {
+			result.Errors = append(result.Errors, ImportErrorData{row.number, row.err.Error()})
+			continue
+		}
+
+		command.Todos = append(command.Todos, row.todo)
+		numbers = append(numbers, row.number)
+	}
+
+	// Valid rows are still checked, so every error is reported at once.
+	if len(result.Errors) > 0 {
+		command.DryRun = true
+	}
+
+	if len(command.Todos) > 0 || len(result.Errors) == 0 {
+
+		imported, err := app.ImportTodosUsecase().Import(command)
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		for _, e := range imported.Errors {
+			result.Errors = append(result.Errors, ImportErrorData{numbers[e.Index], e.Error.Error()})
+		}
+
+		result.TodoIds = imported.TodoIds
+		result.Undo = toUndoData(imported.UndoToken)
+	}
+
+	if len(result.Errors) > 0 {
+
+		sort.SliceStable(result.Errors, func(i, j int) bool {
+			return result.Errors[i].Row < result.Errors[j].Row
+		})
+
+		return c.JSON(
+			http.StatusBadRequest,
+			data.NewPayload(data.StatusFail, result).
+				WithMessage("import has invalid rows, nothing imported"),
+		)
+	}
+
+	result.Count = len(command.Todos)
+
+	if dryRun {
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, result).
+				WithMessage("import checked"),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		data.NewPayload(data.StatusSuccess, result).
+			WithMessage("todo items imported"),
+	)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/kkatou7209/godo/app"
 	"github.com/kkatou7209/godo/app/port/in/dto"
@@ -16,6 +17,18 @@ type TodoData struct {
 	Title 		string `json:"title"`
 	Description string `json:"description"`
 	IsDone 		bool   `json:"isDone"`
+	// Empty if personal.
+	ProjectId   string `json:"projectId"`
+	// Empty if unassigned.
+	AssigneeId  string `json:"assigneeId"`
+}
+
+type TodoAssignmentData struct {
+	Id         string    `json:"id"`
+	// Empty if unassigned.
+	AssigneeId string    `json:"assigneeId"`
+	AssignedBy string    `json:"assignedBy"`
+	CreatedAt  time.Time `json:"createdAt"`
 }
 
 func ListTodoItems(app *app.Application) (func(c echo.Context) error) {
@@ -55,12 +68,7 @@ func ListTodoItems(app *app.Application) (func(c echo.Context) error) {
 		todoJsons := make([]TodoData, len(todos))
 
 		for i, todo := range todos {
-			todoJsons[i] = TodoData{
-				Id: todo.Id,
-				Title: todo.Title,
-				Description: todo.Description,
-				IsDone: todo.IsDone,
-			}
+			todoJsons[i] = toTodoData(todo)
 		}
 
 		return c.JSON(
@@ -71,6 +79,12 @@ func ListTodoItems(app *app.Application) (func(c echo.Context) error) {
 	}
 }
 
+type AddTodoRequest struct {
+	Title string       `json:"title"       validate:"required"`
+	Description string `json:"description"`
+	ProjectId string   `json:"projectId"`
+}
+
 func AddTodoItem(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error {
@@ -85,10 +99,7 @@ func AddTodoItem(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		todo := new(struct {
-			Title string       `json:"title"       validate:"required"`
-			Description string `json:"description"`
-		})
+		todo := new(AddTodoRequest)
 
 		if err := c.Bind(&todo); err != nil {
 			return c.JSON(
@@ -102,34 +113,28 @@ func AddTodoItem(app *app.Application) (func(c echo.Context) error) {
 			UserId: 	 userId,
 			Title: 		 todo.Title,
 			Description: todo.Description,
+			ProjectId: 	 todo.ProjectId,
 		}
 
-		if err := app.AddTodoUsecase().Add(todoDto); err != nil {
+		undoToken, err := app.AddTodoUsecase().Add(todoDto)
 
-			if e, ok := err.(*validation.ValidationError); ok {
-				return c.JSON(
-					http.StatusBadRequest,
-					data.NewPayload[any](data.StatusFail, nil).
-						WithMessage(e.Error()),
-				)
-			}
-
-			return c.JSON(
-				http.StatusInternalServerError,
-				data.NewPayload[any](data.StatusFail, nil).
-					WithMessage("unexpected error").
-					WithErrors("couse", err.Error()),
-			)
+		if err != nil {
+			return todoError(c, err)
 		}
 
 		return c.JSON(
 			http.StatusCreated,
-			data.NewPayload[any](data.StatusSuccess, nil).
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
 				WithMessage("todo item created"),
 		)
 	}
 }
 
+type UpdateTodoRequest struct {
+	Title 		string `json:"title"       validate:"required"`
+	Description string `json:"description" validate:"required"`
+}
+
 func UpdateTodoItem(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error {
@@ -154,10 +159,7 @@ func UpdateTodoItem(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		todo := new(struct {
-			Title 		string `json:"title"       validate:"required"`
-			Description string `json:"description" validate:"required"`
-		})
+		todo := new(UpdateTodoRequest)
 
 		if err := c.Bind(&todo); err != nil {
 			return c.JSON(
@@ -183,27 +185,15 @@ func UpdateTodoItem(app *app.Application) (func(c echo.Context) error) {
 			UserId: userId,
 		}
 
-		if err := app.UpdateTodoUsecase().Update(todoDto); err != nil {
+		undoToken, err := app.UpdateTodoUsecase().Update(todoDto)
 
-			if e, ok := err.(*validation.ValidationError); ok {
-				return c.JSON(
-					http.StatusBadRequest,
-					data.NewPayload[any](data.StatusFail, nil).
-						WithMessage(e.Error()),
-				)
-			}
-
-			return c.JSON(
-				http.StatusInternalServerError,
-				data.NewPayload[any](data.StatusFail, nil).
-					WithMessage("unexpected error").
-					WithErrors("couse", err.Error()),
-			)
+		if err != nil {
+			return todoError(c, err)
 		}
 
 		return c.JSON(
 			http.StatusOK,
-			data.NewPayload[any](data.StatusSuccess, nil).
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
 				WithMessage("todo item udated"),
 		)
 	}
@@ -233,27 +223,15 @@ func CompleteTodoItem(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		if err := app.CompleteTodoUsecase().Complete(userId, todoItemId); err != nil {
-		
-			if e, ok := err.(*validation.ValidationError); ok {
-				return c.JSON(
-					http.StatusBadRequest,
-					data.NewPayload[any](data.StatusFail, nil).
-						WithMessage(e.Error()),
-				)
-			}
+		undoToken, err := app.CompleteTodoUsecase().Complete(userId, todoItemId)
 
-			return c.JSON(
-				http.StatusInternalServerError,
-				data.NewPayload[any](data.StatusFail, nil).
-					WithMessage("unexpected error").
-					WithErrors("couse", err.Error()),
-			)
+		if err != nil {
+			return todoError(c, err)
 		}
 
 		return c.JSON(
 			http.StatusOK,
-			data.NewPayload[any](data.StatusSuccess, nil).
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
 				WithMessage("todo item completed"),
 		)
 	}
@@ -283,27 +261,15 @@ func UncompleteTodoItem(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		if err := app.UncompleteTodoUsecase().Uncomplete(userId, todoItemId); err != nil {
-
-			if e, ok := err.(*validation.ValidationError); ok {
-				return c.JSON(
-					http.StatusBadRequest,
-					data.NewPayload[any](data.StatusFail, nil).
-						WithMessage(e.Error()),
-				)
-			}
+		undoToken, err := app.UncompleteTodoUsecase().Uncomplete(userId, todoItemId)
 
-			return c.JSON(
-				http.StatusInternalServerError,
-				data.NewPayload[any](data.StatusFail, nil).
-					WithMessage("unexpected error").
-					WithErrors("couse", err.Error()),
-			)
+		if err != nil {
+			return todoError(c, err)
 		}
 
 		return c.JSON(
 			http.StatusOK,
-			data.NewPayload[any](data.StatusSuccess, nil).
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
 				WithMessage("todo item uncompleted"),
 		)
 	}
@@ -333,28 +299,182 @@ func DeleteTodoItem(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		if err := app.DeleteTodoUsecase().Delete(userId, todoItemId); err != nil {
+		undoToken, err := app.DeleteTodoUsecase().Delete(userId, todoItemId)
 
-			if e, ok := err.(*validation.ValidationError); ok {
-				return c.JSON(
-					http.StatusBadRequest,
-					data.NewPayload[any](data.StatusFail, nil).
-						WithMessage(e.Error()),
-				)
-			}
+		if err != nil {
+			return todoError(c, err)
+		}
 
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
+				WithMessage("todo item deleted"),
+		)
+	}
+}
+
+type AssignTodoRequest struct {
+	AssigneeId string `json:"assigneeId" validate:"required"`
+}
+
+func AssignTodoItem(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(AssignTodoRequest)
+
+		if err := c.Bind(&req); err != nil {
 			return c.JSON(
-				http.StatusInternalServerError,
+				http.StatusBadRequest,
 				data.NewPayload[any](data.StatusFail, nil).
-					WithMessage("unexpected error").
-					WithErrors("couse", err.Error()),
+					WithMessage(err.Error()),
 			)
 		}
 
+		undoToken, err := app.AssignTodoUsecase().Assign(&dto.AssignTodoCommand{
+			UserId: c.Param("userId"),
+			TodoId: c.Param("todoItemId"),
+			AssigneeId: strings.TrimSpace(req.AssigneeId),
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
 		return c.JSON(
 			http.StatusOK,
-			data.NewPayload[any](data.StatusSuccess, nil).
-				WithMessage("todo item deleted"),
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
+				WithMessage("todo item assigned"),
 		)
 	}
-}
\ No newline at end of file
+}
+
+func UnassignTodoItem(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		undoToken, err := app.UnassignTodoUsecase().Unassign(c.Param("userId"), c.Param("todoItemId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, toUndoData(undoToken)).
+				WithMessage("todo item unassigned"),
+		)
+	}
+}
+
+func ListAssignedTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		todos, err := app.ListAssignedTodosUsecase().List(c.Param("userId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		todoJsons := make([]TodoData, len(todos))
+
+		for i, todo := range todos {
+			todoJsons[i] = toTodoData(todo)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, todoJsons),
+		)
+	}
+}
+
+func ListTodoAssignments(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		assignments, err := app.ListTodoAssignmentsUsecase().List(c.Param("userId"), c.Param("todoItemId"))
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		assignmentsJson := make([]TodoAssignmentData, len(assignments))
+
+		for i, a := range assignments {
+			assignmentsJson[i] = TodoAssignmentData{
+				Id: a.Id,
+				AssigneeId: a.AssigneeId,
+				AssignedBy: a.AssignedBy,
+				CreatedAt: a.CreatedAt,
+			}
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, assignmentsJson),
+		)
+	}
+}
+
+func toTodoData(todo *dto.TodoItemDto) TodoData {
+	return TodoData{
+		Id: todo.Id,
+		Title: todo.Title,
+		Description: todo.Description,
+		IsDone: todo.IsDone,
+		ProjectId: todo.ProjectId,
+		AssigneeId: todo.AssigneeId,
+	}
+}
+
+func todoError(c echo.Context, err error) error {
+
+	if err == validation.ErrTodoCommentNotFound || err == validation.ErrTodoAttachmentNotFound || err == validation.ErrCalendarFeedNotFound {
+		return c.JSON(
+			http.StatusNotFound,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if err == validation.ErrUndoConflict {
+		return c.JSON(
+			http.StatusConflict,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if err == validation.ErrPermissionDenied {
+		return c.JSON(
+			http.StatusForbidden,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if err == validation.ErrTodoAttachmentTooLarge {
+		return c.JSON(
+			http.StatusRequestEntityTooLarge,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(err.Error()),
+		)
+	}
+
+	if e, ok := err.(*validation.ValidationError); ok {
+		return c.JSON(
+			http.StatusBadRequest,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage(e.Error()),
+		)
+	}
+
+	return c.JSON(
+		http.StatusInternalServerError,
+		data.NewPayload[any](data.StatusFail, nil).
+			WithMessage("unexpected error").
+			WithErrors("couse", err.Error()),
+	)
+}
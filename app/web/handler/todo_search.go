@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type TodoSearchResultData struct {
+	Todo    TodoData `json:"todo"`
+	Rank    float64  `json:"rank"`
+	// Excerpt of matched text as HTML. Matches are enclosed in <mark>, everything else is escaped.
+	Snippet string   `json:"snippet"`
+}
+
+// Search with "q" query parameter, see value.SearchQuery for syntax. "limit" caps results.
+func SearchTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		results, err := app.SearchTodoUsecase().Search(&dto.SearchTodoCommand{
+			UserId: c.Param("userId"),
+			Query: c.QueryParam("q"),
+			Limit: limit,
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		resultsJson := make([]TodoSearchResultData, len(results))
+
+		for i, result := range results {
+			resultsJson[i] = TodoSearchResultData{
+				Todo: toTodoData(result.Todo),
+				Rank: result.Rank,
+				Snippet: snippetHtml(result.Snippet),
+			}
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, resultsJson),
+		)
+	}
+}
+
+func snippetHtml(fragments []dto.SnippetFragment) string {
+
+	var b strings.Builder
+
+	for _, fragment := range fragments {
+		if fragment.Highlighted {
+			b.WriteString("<mark>" + html.EscapeString(fragment.Text) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(fragment.Text))
+		}
+	}
+
+	return b.String()
+}
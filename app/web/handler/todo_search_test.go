@@ -0,0 +1,180 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/handler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("todo search handler test", Ordered, func() {
+
+	var searchUserId string
+
+	search := func(q string) (*httptest.ResponseRecorder, []handler.TodoSearchResultData) {
+
+		req, err := http.NewRequest(http.MethodGet, "/user/:userId/search?q=" + url.QueryEscape(q), nil)
+
+		Expect(err).To(BeNil())
+
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("userId")
+		c.SetParamValues(searchUserId)
+
+		Expect(handler.SearchTodoItems(app)(c)).To(BeNil())
+
+		var res data.Payload[[]handler.TodoSearchResultData]
+
+		Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+
+		if res.Data == nil {
+			return rec, nil
+		}
+
+		return rec, *res.Data
+	}
+
+	titles := func(results []handler.TodoSearchResultData) []string {
+
+		ts := make([]string, len(results))
+
+		for i, result := range results {
+			ts[i] = result.Todo.Title
+		}
+
+		return ts
+	}
+
+	BeforeAll(func() {
+
+		Expect(app.AddUserUsecase().Add(&dto.AddUserCommand{
+			UserName: "search-test-user",
+			Email: "search-test@example.com",
+			Password: "search-test-pass",
+		})).To(BeNil())
+
+		login, err := app.LoginUsecase().Login(&dto.LoginCommand{
+			Email: "search-test@example.com",
+			Password: "search-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		searchUserId = login.User.Id
+
+		for _, todo := range [][2]string{
+			{"Deploy release", "ship the release notes to customers"},
+			{"Write notes", "meeting notes about deployment <b>"},
+			{"Buy milk", "groceries"},
+		} {
+			_, err := app.AddTodoUsecase().Add(&dto.AddTodoCommand{
+				UserId: searchUserId,
+				Title: todo[0],
+				Description: todo[1],
+			})
+			Expect(err).To(BeNil())
+		}
+
+		todos, err := app.ListTodoUsecase().List(searchUserId)
+
+		Expect(err).To(BeNil())
+
+		for _, todo := range todos {
+			if todo.Title == "Buy milk" {
+				_, err := app.AddTodoCommentUsecase().Add(&dto.AddTodoCommentCommand{
+					UserId: searchUserId,
+					TodoId: todo.Id,
+					Body: "remember the oat release",
+				})
+				Expect(err).To(BeNil())
+			}
+		}
+
+		// Todo item of another user must never be found.
+		Expect(app.AddUserUsecase().Add(&dto.AddUserCommand{
+			UserName: "search-test-other",
+			Email: "search-test-other@example.com",
+			Password: "search-test-pass",
+		})).To(BeNil())
+
+		other, err := app.LoginUsecase().Login(&dto.LoginCommand{
+			Email: "search-test-other@example.com",
+			Password: "search-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		_, err = app.AddTodoUsecase().Add(&dto.AddTodoCommand{
+			UserId: other.User.Id,
+			Title: "Foreign release",
+			Description: "foreign-search-description",
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It("should rank matches in title first", func() {
+
+		rec, results := search("release")
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(titles(results)).To(Equal([]string{"Deploy release", "Buy milk"}))
+		Expect(results[0].Rank).To(BeNumerically(">", results[1].Rank))
+		Expect(results[0].Snippet).To(Equal("Deploy <mark>release</mark>"))
+	})
+
+	It("should match comments", func() {
+
+		_, results := search("oat")
+
+		Expect(titles(results)).To(Equal([]string{"Buy milk"}))
+		Expect(results[0].Snippet).To(Equal("remember the <mark>oat</mark> release"))
+	})
+
+	It("should match prefix", func() {
+
+		_, results := search("deploy*")
+
+		Expect(titles(results)).To(ConsistOf("Deploy release", "Write notes"))
+	})
+
+	It("should match phrase", func() {
+
+		_, results := search(`"release notes"`)
+
+		Expect(titles(results)).To(Equal([]string{"Deploy release"}))
+		Expect(results[0].Snippet).To(Equal("ship the <mark>release</mark> <mark>notes</mark> to customers"))
+
+		_, results = search(`"notes release"`)
+
+		Expect(results).To(BeEmpty())
+	})
+
+	It("should require all terms", func() {
+
+		_, results := search("notes meeting")
+
+		Expect(titles(results)).To(Equal([]string{"Write notes"}))
+	})
+
+	It("should escape snippet", func() {
+
+		_, results := search("deployment")
+
+		Expect(results[0].Snippet).To(Equal("meeting notes about <mark>deployment</mark> &lt;b&gt;"))
+	})
+
+	It("should reject query without words", func() {
+
+		rec, _ := search(" * ")
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+})
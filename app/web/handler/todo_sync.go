@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type SyncOperationData struct {
+	// Generated by client, so retried operation applies once.
+	Id          string    `json:"id"`
+	// upsert or delete.
+	Op          string    `json:"op"`
+	// Generated by client when it created todo item.
+	TodoId      string    `json:"todoId"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	IsDone      *bool     `json:"isDone"`
+	// Only used when todo item is created.
+	ProjectId   string    `json:"projectId"`
+	// Time change was made on client.
+	ChangedAt   time.Time `json:"changedAt"`
+}
+
+type SyncResultData struct {
+	Id      string `json:"id"`
+	TodoId  string `json:"todoId"`
+	// applied or rejected.
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type SyncChangeData struct {
+	TodoId  string    `json:"todoId"`
+	Deleted bool      `json:"deleted"`
+	// nil if deleted.
+	Todo    *TodoData `json:"todo"`
+}
+
+type SyncData struct {
+	Results    []SyncResultData `json:"results"`
+	Changes    []SyncChangeData `json:"changes"`
+	// Pass as since on next sync.
+	Token      string           `json:"token"`
+	// Sync again with token to get the rest of changes.
+	HasMore    bool             `json:"hasMore"`
+	// Projects user is member of. Local todo items of other projects are no longer accessible.
+	ProjectIds []string         `json:"projectIds"`
+}
+
+type SyncRequest struct {
+	// Token of last sync. Empty syncs from start.
+	Since      string               `json:"since"`
+	Operations []*SyncOperationData `json:"operations"`
+}
+
+func SyncTodoItems(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(SyncRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		operations := make([]*dto.SyncTodoOperation, len(req.Operations))
+
+		for i, op := range req.Operations {
+			operations[i] = &dto.SyncTodoOperation{
+				Id: op.Id,
+				Op: op.Op,
+				TodoId: op.TodoId,
+				Title: op.Title,
+				Description: op.Description,
+				IsDone: op.IsDone,
+				ProjectId: op.ProjectId,
+				ChangedAt: op.ChangedAt,
+			}
+		}
+
+		sync, err := app.SyncTodoUsecase().Sync(&dto.SyncTodoCommand{
+			UserId: c.Param("userId"),
+			Since: req.Since,
+			Operations: operations,
+		})
+
+		if err != nil {
+			return todoError(c, err)
+		}
+
+		results := make([]SyncResultData, len(sync.Results))
+
+		for i, result := range sync.Results {
+			results[i] = SyncResultData{
+				Id: result.Id,
+				TodoId: result.TodoId,
+				Status: result.Status,
+				Message: result.Message,
+			}
+		}
+
+		changes := make([]SyncChangeData, len(sync.Changes))
+
+		for i, change := range sync.Changes {
+
+			changes[i] = SyncChangeData{TodoId: change.TodoId, Deleted: change.Todo == nil}
+
+			if change.Todo != nil {
+				todo := toTodoData(change.Todo)
+				changes[i].Todo = &todo
+			}
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, SyncData{results, changes, sync.Token, sync.HasMore, sync.ProjectIds}).
+				WithMessage("todo items synced"),
+		)
+	}
+}
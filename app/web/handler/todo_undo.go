@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type UndoData struct {
+	// Token to pass to undo endpoint within its short lifetime.
+	UndoToken string `json:"undoToken"`
+}
+
+// Wrap undo token for response. nil if mutation changed nothing.
+func toUndoData(undoToken string) *UndoData {
+
+	if undoToken == "" {
+		return nil
+	}
+
+	return &UndoData{undoToken}
+}
+
+func UndoTodoMutation(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		if err := app.UndoTodoUsecase().Undo(c.Param("userId"), c.Param("token")); err != nil {
+			return todoError(c, err)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("todo mutation undone"),
+		)
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+type TwoFactorEnrollmentData struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+type RecoveryCodesData struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func EnrollTwoFactor(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		enrollment, err := app.EnrollTwoFactorUsecase().Enroll(userId)
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &TwoFactorEnrollmentData{
+				Secret: enrollment.Secret,
+				Uri: enrollment.Uri,
+			}).
+				WithMessage("confirm enrollment with code from authenticator app"),
+		)
+	}
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+func ConfirmTwoFactor(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		req := new(TwoFactorCodeRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		codes, err := app.ConfirmTwoFactorUsecase().Confirm(userId, req.Code)
+
+		if err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload(data.StatusSuccess, &RecoveryCodesData{codes}).
+				WithMessage("two-factor authentication enabled. store recovery codes safely"),
+		)
+	}
+}
+
+func DisableTwoFactor(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		userId := c.Param("userId")
+
+		if strings.TrimSpace(userId) == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithErrors("userId", "empty cannot be set"),
+			)
+		}
+
+		req := new(PasswordRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if err := app.DisableTwoFactorUsecase().Disable(userId, req.Password); err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage(e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage("unexpected error").
+					WithErrors("couse", err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("two-factor authentication disabled"),
+		)
+	}
+}
@@ -0,0 +1,258 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/totp"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/handler"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("two-factor handler test", Ordered, func() {
+
+	var (
+		twoFactorUserId string
+		secret          string
+		recoveryCodes   []string
+		challengeToken  string
+	)
+
+	request := func(method string, body map[string]any, h func(c echo.Context) error) *httptest.ResponseRecorder {
+
+		jbody, err := json.Marshal(body)
+
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		req, err := http.NewRequest(method, "/", bytes.NewBuffer(jbody))
+
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("userId")
+		c.SetParamValues(twoFactorUserId)
+
+		Expect(h(c)).To(BeNil())
+
+		return rec
+	}
+
+	login := func() *httptest.ResponseRecorder {
+		return request(http.MethodPost, map[string]any{
+			"email": "two-factor-test@example.com",
+			"password": "two-factor-test-pass",
+		}, handler.Login(app))
+	}
+
+	codeAt := func(step int64) string {
+		key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+		Expect(err).To(BeNil())
+		return totp.Code(key, step)
+	}
+
+	BeforeAll(func() {
+
+		err := app.AddUserUsecase().Add(&dto.AddUserCommand{
+			UserName: "two-factor-test-user",
+			Email: "two-factor-test@example.com",
+			Password: "two-factor-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		result, err := app.LoginUsecase().Login(&dto.LoginCommand{
+			Email: "two-factor-test@example.com",
+			Password: "two-factor-test-pass",
+		})
+
+		Expect(err).To(BeNil())
+
+		twoFactorUserId = result.User.Id
+	})
+
+	It("should start enrollment", func() {
+
+		rec := request(http.MethodPost, map[string]any{}, handler.EnrollTwoFactor(app))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var res data.Payload[handler.TwoFactorEnrollmentData]
+
+		Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+		Expect(res.Data.Uri).To(HavePrefix("otpauth://totp/"))
+
+		secret = res.Data.Secret
+	})
+
+	It("should not enable with invalid code", func() {
+
+		rec := request(http.MethodPost, map[string]any{ "code": "000000" }, handler.ConfirmTwoFactor(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+
+		Expect(login().Code).To(Equal(http.StatusOK))
+	})
+
+	It("should enable with valid code", func() {
+
+		code := codeAt(time.Now().Unix() / 30)
+
+		rec := request(http.MethodPost, map[string]any{ "code": code }, handler.ConfirmTwoFactor(app))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var res data.Payload[handler.RecoveryCodesData]
+
+		Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+		Expect(res.Data.RecoveryCodes).To(HaveLen(10))
+
+		recoveryCodes = res.Data.RecoveryCodes
+	})
+
+	It("should require second factor on login", func() {
+
+		rec := login()
+
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+		Expect(rec.Result().Cookies()).To(BeEmpty())
+
+		var res data.Payload[handler.LoginChallengeData]
+
+		Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(BeNil())
+		Expect(res.Data.TwoFactorRequired).To(BeTrue())
+
+		challengeToken = res.Data.ChallengeToken
+	})
+
+	It("should reject invalid code", func() {
+
+		rec := request(http.MethodPost, map[string]any{
+			"challengeToken": challengeToken,
+			"code": "000000",
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should login with TOTP code", func() {
+
+		// Code of current step was used on confirmation; next step is within skew.
+		rec := request(http.MethodPost, map[string]any{
+			"challengeToken": challengeToken,
+			"code": codeAt(time.Now().Unix() / 30 + 1),
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Result().Cookies()).ToNot(BeEmpty())
+	})
+
+	It("should not reuse challenge", func() {
+
+		rec := request(http.MethodPost, map[string]any{
+			"challengeToken": challengeToken,
+			"code": recoveryCodes[0],
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should login with recovery code only once", func() {
+
+		var res data.Payload[handler.LoginChallengeData]
+
+		Expect(json.Unmarshal(login().Body.Bytes(), &res)).To(BeNil())
+
+		rec := request(http.MethodPost, map[string]any{
+			"challengeToken": res.Data.ChallengeToken,
+			"code": recoveryCodes[0],
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		Expect(json.Unmarshal(login().Body.Bytes(), &res)).To(BeNil())
+
+		rec = request(http.MethodPost, map[string]any{
+			"challengeToken": res.Data.ChallengeToken,
+			"code": recoveryCodes[0],
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should redeem challenge only once when verified in parallel", func() {
+
+		var res data.Payload[handler.LoginChallengeData]
+
+		Expect(json.Unmarshal(login().Body.Bytes(), &res)).To(BeNil())
+
+		codes := make(chan int, 3)
+
+		for _, code := range recoveryCodes[1:4] {
+			go func() {
+				defer GinkgoRecover()
+				codes <- request(http.MethodPost, map[string]any{
+					"challengeToken": res.Data.ChallengeToken,
+					"code": code,
+				}, handler.VerifyLogin(app)).Code
+			}()
+		}
+
+		ok := 0
+
+		for range 3 {
+			if <-codes == http.StatusOK {
+				ok++
+			}
+		}
+
+		Expect(ok).To(Equal(1))
+	})
+
+	It("should reject user disabled after password", func() {
+
+		var res data.Payload[handler.LoginChallengeData]
+
+		Expect(json.Unmarshal(login().Body.Bytes(), &res)).To(BeNil())
+
+		Expect(app.DisableUserUsecase().Disable(userId.Value(), twoFactorUserId)).To(BeNil())
+
+		rec := request(http.MethodPost, map[string]any{
+			"challengeToken": res.Data.ChallengeToken,
+			"code": recoveryCodes[4],
+		}, handler.VerifyLogin(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Result().Cookies()).To(BeEmpty())
+
+		Expect(app.EnableUserUsecase().Enable(userId.Value(), twoFactorUserId)).To(BeNil())
+	})
+
+	It("should disable with password", func() {
+
+		rec := request(http.MethodDelete, map[string]any{ "password": "wrong-pass" }, handler.DisableTwoFactor(app))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+
+		rec = request(http.MethodDelete, map[string]any{ "password": "two-factor-test-pass" }, handler.DisableTwoFactor(app))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(login().Code).To(Equal(http.StatusOK))
+	})
+})
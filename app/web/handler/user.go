@@ -15,6 +15,7 @@ type UserData struct {
 	Id       string `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 }
 
 func GetUserById(app *app.Application) (func(c echo.Context) error) {
@@ -55,6 +56,7 @@ func GetUserById(app *app.Application) (func(c echo.Context) error) {
 			Id:       user.Id,
 			Username: user.UserName,
 			Email:    user.Email,
+			Role:     user.Role,
 		}
 
 		return c.JSON(http.StatusOK, 
@@ -64,6 +66,11 @@ func GetUserById(app *app.Application) (func(c echo.Context) error) {
 	}
 }
 
+type UpdateUserRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
 func UpdateUser(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error {
@@ -78,10 +85,7 @@ func UpdateUser(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		userInfo := new(struct{
-			Username string `json:"username"`
-			Email    string `json:"email"`
-		})
+		userInfo := new(UpdateUserRequest)
 
 		err := c.Bind(&userInfo)
 
@@ -93,7 +97,7 @@ func UpdateUser(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
-		err = app.ChangeUserInfoUsecase().ChangeInfo(&dto.UserDto{
+		result, err := app.ChangeUserInfoUsecase().ChangeInfo(&dto.UserDto{
 			Id: userId,
 			UserName: userInfo.Username,
 			Email: userInfo.Email,
@@ -107,6 +111,14 @@ func UpdateUser(app *app.Application) (func(c echo.Context) error) {
 			)
 		}
 
+		if result.EmailChangePending {
+			return c.JSON(
+				http.StatusOK,
+				data.NewPayload[any](data.StatusSuccess, nil).
+					WithMessage("user updated successfully. new email takes effect after confirmation"),
+			)
+		}
+
 		return c.JSON(
 			http.StatusOK,
 			data.NewPayload[any](data.StatusSuccess, nil).
@@ -115,16 +127,18 @@ func UpdateUser(app *app.Application) (func(c echo.Context) error) {
 	}
 }
 
+type ChangePasswordRequest struct {
+	NewPassword string `json:"newPassword" validate:"required"`
+	OldPassword string `json:"oldPassword" validate:"required"`
+}
+
 func ChangeUserPassword(app *app.Application) (func(c echo.Context) error) {
 
 	return func(c echo.Context) error {
 
 		userId := c.Param("userId")
 
-		passwords := new(struct {
-			NewPassword string `json:"newPassword" validate:"required"`
-			OldPassword string `json:"oldPassword" validate:"required"`
-		})
+		passwords := new(ChangePasswordRequest)
 
 		if err := c.Bind(&passwords); err != nil {
 			return c.JSON(
@@ -158,4 +172,84 @@ func ChangeUserPassword(app *app.Application) (func(c echo.Context) error) {
 				WithMessage("password successfully changed"),
 		)
 	}
+}
+
+func ConfirmEmailChange(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(TokenRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if err := app.ConfirmEmailChangeUsecase().Confirm(req.Token); err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("validation error").
+						WithErrors("token", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("email changed successfully"),
+		)
+	}
+}
+
+func RevertEmailChange(app *app.Application) (func(c echo.Context) error) {
+
+	return func(c echo.Context) error {
+
+		req := new(TokenRequest)
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		if err := app.RevertEmailChangeUsecase().Revert(req.Token); err != nil {
+
+			if e, ok := err.(*validation.ValidationError); ok {
+				return c.JSON(
+					http.StatusBadRequest,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("validation error").
+						WithErrors("token", e.Error()),
+				)
+			}
+
+			return c.JSON(
+				http.StatusInternalServerError,
+				data.NewPayload[any](data.StatusFail, nil).
+					WithMessage(err.Error()),
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			data.NewPayload[any](data.StatusSuccess, nil).
+				WithMessage("email change reverted and all sessions signed out"),
+		)
+	}
 }
\ No newline at end of file
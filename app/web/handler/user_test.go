@@ -7,7 +7,10 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/token"
 	"github.com/kkatou7209/godo/web/data"
 	"github.com/kkatou7209/godo/web/handler"
 	. "github.com/onsi/ginkgo/v2"
@@ -120,9 +123,127 @@ var _ = Describe("user handler test", func() {
 				Expect(err).To(BeNil())
 				Expect(res.Status).To(Equal(data.StatusSuccess))
 				Expect(res.Data.Id).To(Equal(userId.Value()))
-				Expect(res.Data.Email).To(Equal("handler-test-updated@example.com"))
+				Expect(res.Data.Email).To(Equal("handler-test@example.com"))
 				Expect(res.Data.Username).To(Equal("handler-test-user-updated"))
 			})
+
+			It("should notify old email with revert token", func() {
+
+				mail := memoryMailer.LastMailTo("handler-test@example.com")
+
+				Expect(mail).ToNot(BeNil())
+				Expect(mail.Body).To(ContainSubstring("handler-test-updated@example.com"))
+				Expect(mail.Body).To(MatchRegexp(`Revert token: \S+`))
+			})
+
+			It("should change email after confirmation", func() {
+
+				mail := memoryMailer.LastMailTo("handler-test-updated@example.com")
+
+				Expect(mail).ToNot(BeNil())
+
+				confirmToken := regexp.MustCompile(`Confirm token: (\S+)`).FindStringSubmatch(mail.Body)[1]
+
+				jreq, err := json.Marshal(map[string]any{ "token": confirmToken })
+
+				if err != nil {
+					log.Fatalln(err)
+				}
+
+				req, err := http.NewRequest(http.MethodPost, "/auth/email/confirm", bytes.NewBuffer(jreq))
+
+				if err != nil {
+					log.Fatalln(err)
+				}
+
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+
+				err = handler.ConfirmEmailChange(app)(e.NewContext(req, rec))
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+
+				user, err := app.GetUserUsecase().Get(userId.Value())
+
+				Expect(err).To(BeNil())
+				Expect(user.Email).To(Equal("handler-test-updated@example.com"))
+
+				// Token is single-use.
+				req, _ = http.NewRequest(http.MethodPost, "/auth/email/confirm", bytes.NewBuffer(jreq))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec = httptest.NewRecorder()
+
+				Expect(handler.ConfirmEmailChange(app)(e.NewContext(req, rec))).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	When("email change reverted", func() {
+
+		It("should restore old email and sign out sessions", func() {
+
+			err := app.AddUserUsecase().Add(&dto.AddUserCommand{
+				UserName: "revert-test-user",
+				Email: "revert-test@example.com",
+				Password: "revert-test-pass",
+			})
+
+			Expect(err).To(BeNil())
+
+			login, err := app.LoginUsecase().Login(&dto.LoginCommand{
+				Email: "revert-test@example.com",
+				Password: "revert-test-pass",
+			})
+
+			Expect(err).To(BeNil())
+
+			_, err = app.ChangeUserInfoUsecase().ChangeInfo(&dto.UserDto{
+				Id: login.User.Id,
+				UserName: "revert-test-user",
+				Email: "attacker@example.com",
+			})
+
+			Expect(err).To(BeNil())
+
+			confirmMail := memoryMailer.LastMailTo("attacker@example.com")
+			confirmToken := regexp.MustCompile(`Confirm token: (\S+)`).FindStringSubmatch(confirmMail.Body)[1]
+
+			Expect(app.ConfirmEmailChangeUsecase().Confirm(confirmToken)).To(BeNil())
+
+			revertMail := memoryMailer.LastMailTo("revert-test@example.com")
+			revertToken := regexp.MustCompile(`Revert token: (\S+)`).FindStringSubmatch(revertMail.Body)[1]
+
+			jreq, err := json.Marshal(map[string]any{ "token": revertToken })
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/email/revert", bytes.NewBuffer(jreq))
+
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			Expect(handler.RevertEmailChange(app)(e.NewContext(req, rec))).To(BeNil())
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			user, err := app.GetUserUsecase().Get(login.User.Id)
+
+			Expect(err).To(BeNil())
+			Expect(user.Email).To(Equal("revert-test@example.com"))
+
+			session, _ := sessionRepository.Get(token.NewRandomTokenGenerator().Hash(login.Token))
+
+			Expect(session).To(BeNil())
 		})
 	})
 
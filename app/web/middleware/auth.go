@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/port/in/dto"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+)
+
+// Cookie holding session token.
+const SessionCookieName = "x-api-token"
+
+const principalKey = "principal"
+
+// Get authenticated user of request. nil if request is not authenticated.
+func Principal(c echo.Context) *dto.PrincipalDto {
+
+	principal, ok := c.Get(principalKey).(*dto.PrincipalDto)
+
+	if !ok {
+		return nil
+	}
+
+	return principal
+}
+
+// Require request authenticated by session or personal access token granted all scopes.
+// Request can only act on user given by "userId" path parameter.
+func RequireScopes(app *app.Application, scopes ...string) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			principal, err := authenticate(app, c)
+
+			if err != nil || principal == nil {
+				return err
+			}
+
+			for _, scope := range scopes {
+				if !slices.Contains(principal.Scopes, scope) {
+					c.Response().Header().Set(
+						echo.HeaderWWWAuthenticate,
+						fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(scopes, " ")),
+					)
+					return c.JSON(
+						http.StatusForbidden,
+						data.NewPayload[any](data.StatusFail, nil).
+							WithMessage("insufficient scope").
+							WithErrors("scope", scope + " is required"),
+					)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Require request authenticated by session.
+// Personal access tokens are rejected, so they cannot manage account or other tokens.
+// Request can only act on user given by "userId" path parameter.
+func RequireSession(app *app.Application) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			principal, err := authenticate(app, c)
+
+			if err != nil || principal == nil {
+				return err
+			}
+
+			if principal.AccessTokenId != "" {
+				return c.JSON(
+					http.StatusForbidden,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("session required").
+						WithErrors("token", "personal access token cannot be used here"),
+				)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Require request authenticated by session of admin.
+func RequireAdmin(app *app.Application) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			principal, err := authenticate(app, c)
+
+			if err != nil || principal == nil {
+				return err
+			}
+
+			if principal.AccessTokenId != "" || principal.Role != value.RoleAdmin.Value() {
+				return c.JSON(
+					http.StatusForbidden,
+					data.NewPayload[any](data.StatusFail, nil).
+						WithMessage("admin session required"),
+				)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Authenticate request carrying token, letting request without one through anonymously.
+// Handler decides what anonymous request can do, Principal being nil for it.
+func AllowAnonymous(app *app.Application) echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			if tokenOf(c) == "" {
+				return next(c)
+			}
+
+			principal, err := authenticate(app, c)
+
+			if err != nil || principal == nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Authenticate request and store principal in context.
+// Returns nil principal when response is already written.
+func authenticate(app *app.Application, c echo.Context) (*dto.PrincipalDto, error) {
+
+	principal, err := app.AuthenticateUsecase().Authenticate(tokenOf(c))
+
+	if err != nil {
+		return nil, c.JSON(
+			http.StatusInternalServerError,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage("unexpected error").
+				WithErrors("couse", err.Error()),
+		)
+	}
+
+	if principal == nil {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+		return nil, c.JSON(
+			http.StatusUnauthorized,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage("authentication required"),
+		)
+	}
+
+	if userId := c.Param("userId"); userId != "" && userId != principal.UserId {
+		return nil, c.JSON(
+			http.StatusForbidden,
+			data.NewPayload[any](data.StatusFail, nil).
+				WithMessage("forbidden"),
+		)
+	}
+
+	c.Set(principalKey, principal)
+
+	return principal, nil
+}
+
+// Get token from Authorization header, falling back to session cookie.
+func tokenOf(c echo.Context) string {
+
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); auth != "" {
+
+		scheme, token, found := strings.Cut(auth, " ")
+
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return ""
+		}
+
+		return strings.TrimSpace(token)
+	}
+
+	cookie, err := c.Cookie(SessionCookieName)
+
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
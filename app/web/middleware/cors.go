@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// Allow cross-origin requests from origins, "*" for any.
+// Credentials lets browsers send session cookie along.
+func Cors(origins []string, credentials bool, maxAge time.Duration) echo.MiddlewareFunc {
+
+	return echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		AllowOrigins: origins,
+		AllowCredentials: credentials,
+		AllowHeaders: []string{
+			echo.HeaderAuthorization,
+			echo.HeaderContentType,
+		},
+		ExposeHeaders: []string{
+			echo.HeaderRetryAfter,
+		},
+		MaxAge: int(maxAge.Seconds()),
+	})
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Paths of liveness and readiness probes.
+var probePaths = []string{"/healthz", "/readyz"}
+
+func isProbe(c echo.Context) bool {
+	return slices.Contains(probePaths, c.Request().URL.Path)
+}
+
+// Limit requests per client IP to rate per second, allowing burst at once.
+// Limited requests are answered 429 with Retry-After.
+func RateLimit(requestsPerSecond float64, burst int) echo.MiddlewareFunc {
+
+	store := echoMiddleware.NewRateLimiterMemoryStoreWithConfig(echoMiddleware.RateLimiterMemoryStoreConfig{
+		Rate: rate.Limit(requestsPerSecond),
+		Burst: burst,
+		ExpiresIn: 3 * time.Minute,
+	})
+
+	retryAfter := fmt.Sprint(int(math.Ceil(1 / requestsPerSecond)))
+
+	return echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		// Load balancers probe from few addresses.
+		Skipper: isProbe,
+		Store: store,
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfter)
+
+			return c.JSON(
+				http.StatusTooManyRequests,
+				data.NewPayload[any](data.StatusFail, nil).WithMessage("too many requests"),
+			)
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return c.JSON(
+				http.StatusForbidden,
+				data.NewPayload[any](data.StatusFail, nil).WithMessage("client cannot be identified"),
+			)
+		},
+	})
+}
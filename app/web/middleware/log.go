@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// Log every request but probes to logger, failed ones as errors.
+func LogRequests(logger *slog.Logger) echo.MiddlewareFunc {
+
+	return echoMiddleware.RequestLoggerWithConfig(echoMiddleware.RequestLoggerConfig{
+		Skipper: isProbe,
+		LogMethod: true,
+		LogURI: true,
+		LogStatus: true,
+		LogLatency: true,
+		LogRemoteIP: true,
+		LogError: true,
+		HandleError: true,
+		LogValuesFunc: func(c echo.Context, v echoMiddleware.RequestLoggerValues) error {
+
+			level := slog.LevelInfo
+
+			if v.Error != nil || v.Status >= 500 {
+				level = slog.LevelError
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("uri", v.URI),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+				slog.String("remote_ip", v.RemoteIP),
+			}
+
+			if v.Error != nil {
+				attrs = append(attrs, slog.String("error", v.Error.Error()))
+			}
+
+			logger.LogAttrs(context.Background(), level, "request", attrs...)
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version of OpenAPI documents built by package.
+const Version = "3.1.0"
+
+// Content type of JSON bodies.
+const ContentTypeJson = "application/json"
+
+// Document as defined by OpenAPI 3.1. Only parts used by this API are modeled.
+type Document struct {
+	OpenApi    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]PathItem  `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Operations of path keyed by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	// Empty list makes operation public.
+	Security    []map[string][]string `json:"security"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	// Keyed by status code.
+	Responses   map[string]*Response  `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	// path, query or header.
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	// http or apiKey.
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	// Location of API key, e.g. cookie.
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Route of API and what it accepts and responds with.
+type Route struct {
+	Method      string
+	// Path as routed by echo, e.g. /user/:userId.
+	Path        string
+	Summary     string
+	Tag         string
+	// Names of security schemes accepted, any of them. Public if empty.
+	Security    []string
+	// Whether requests without credentials are accepted as well.
+	Optional    bool
+	// Scopes required of token schemes.
+	Scopes      []string
+	Query       []*Parameter
+	Headers     []*Parameter
+	// Value of type of request body, e.g. handler.LoginRequest{}. nil if there is none.
+	Body        any
+	// Content type of body. JSON when empty.
+	BodyType    string
+	Replies     []*Reply
+}
+
+// Response of route.
+type Reply struct {
+	Status      int
+	// Defaults to status text.
+	Description string
+	// Value of type of response body, e.g. data.Payload[handler.TodoData]{}. nil if there is none.
+	Body        any
+	// Content type of body. JSON when empty.
+	ContentType string
+}
+
+// Path parameter of echo route, e.g. ":userId".
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// Path parameter of OpenAPI path, e.g. "{userId}".
+var templatePattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Convert echo route path to OpenAPI path, e.g. /user/:userId to /user/{userId}.
+func PathOf(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// Build document describing routes. Path parameters are read from route paths.
+func Build(info Info, schemes map[string]*SecurityScheme, routes []*Route) *Document {
+
+	schemas := NewSchemas()
+
+	doc := &Document{
+		OpenApi: Version,
+		Info: info,
+		Paths: map[string]PathItem{},
+		Components: Components{SecuritySchemes: schemes},
+	}
+
+	for _, route := range routes {
+
+		path := PathOf(route.Path)
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+
+		doc.Paths[path][strings.ToLower(route.Method)] = operationOf(schemas, route)
+	}
+
+	doc.Components.Schemas = schemas.Components()
+
+	return doc
+}
+
+func operationOf(schemas *Schemas, route *Route) *Operation {
+
+	op := &Operation{
+		OperationId: operationIdOf(route),
+		Summary: route.Summary,
+		Security: make([]map[string][]string, 0, len(route.Security)),
+		Responses: map[string]*Response{},
+	}
+
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, scheme := range route.Security {
+
+		scopes := route.Scopes
+
+		if scopes == nil {
+			scopes = make([]string, 0)
+		}
+
+		op.Security = append(op.Security, map[string][]string{scheme: scopes})
+	}
+
+	// Empty requirement lets request go without any scheme.
+	if route.Optional && len(route.Security) > 0 {
+		op.Security = append(op.Security, map[string][]string{})
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name: match[1],
+			In: "path",
+			Required: true,
+			Schema: &Schema{Type: "string"},
+		})
+	}
+
+	for _, param := range route.Query {
+		param.In = "query"
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	for _, param := range route.Headers {
+		param.In = "header"
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	if route.Body != nil || route.BodyType != "" {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: contentOf(schemas, route.Body, route.BodyType),
+		}
+	}
+
+	for _, reply := range route.Replies {
+
+		res := &Response{Description: reply.Description}
+
+		if res.Description == "" {
+			res.Description = http.StatusText(reply.Status)
+		}
+
+		if reply.Body != nil || reply.ContentType != "" {
+			res.Content = contentOf(schemas, reply.Body, reply.ContentType)
+		}
+
+		op.Responses[strconv.Itoa(reply.Status)] = res
+	}
+
+	return op
+}
+
+// Content of body of type of v. Bodies of other than JSON are described as binary.
+func contentOf(schemas *Schemas, v any, contentType string) map[string]*MediaType {
+
+	if contentType == "" {
+		contentType = ContentTypeJson
+	}
+
+	schema := &Schema{Type: "string", Format: "binary"}
+
+	if v != nil {
+		schema = schemas.Of(v)
+	}
+
+	return map[string]*MediaType{contentType: {Schema: schema}}
+}
+
+// Unique id of operation, e.g. "get /user/:userId/todo-items" becomes getUserTodoItems.
+func operationIdOf(route *Route) string {
+
+	var id strings.Builder
+
+	id.WriteString(strings.ToLower(route.Method))
+
+	for _, part := range strings.FieldsFunc(route.Path, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '_'
+	}) {
+
+		if strings.HasPrefix(part, ":") {
+			part = "By" + strings.ToUpper(part[1:2]) + part[2:]
+		}
+
+		id.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return id.String()
+}
+
+// Operations of document as "METHOD path" pairs in echo form, e.g. "GET /user/:userId", sorted.
+func (doc *Document) Operations() []string {
+
+	ops := make([]string, 0)
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			ops = append(ops, fmt.Sprintf("%s %s", strings.ToUpper(method), templatePattern.ReplaceAllString(path, ":$1")))
+		}
+	}
+
+	sort.Strings(ops)
+
+	return ops
+}
+
+// Page browsing document served next to it as "openapi.json", and trying its operations.
+//
+//go:embed ui.html
+var Page []byte
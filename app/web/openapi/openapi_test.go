@@ -0,0 +1,91 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kkatou7209/godo/web/openapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOpenApi(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OpenAPI test.")
+}
+
+type Base struct {
+	Id string `json:"id"`
+}
+
+type Node struct {
+	Base
+	Name     string            `json:"name"     validate:"required"`
+	Email    string            `json:"email"    validate:"required,email"`
+	Parent   *Node             `json:"parent"`
+	Children []Node            `json:"children"`
+	Labels   map[string]string `json:"labels"`
+	Count    *int              `json:"count"`
+	At       time.Time         `json:"at"`
+	Skipped  string            `json:"-"`
+	hidden   string
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+var _ = Describe("OpenAPI test", func() {
+
+	It("should read schemas of types", func() {
+
+		schemas := openapi.NewSchemas()
+
+		Expect(schemas.Of(Node{}).Ref).To(Equal("#/components/schemas/Node"))
+
+		node := schemas.Components()["Node"]
+
+		Expect(node.Properties).To(HaveLen(8))
+		Expect(node.Properties).To(HaveKey("id"))
+		Expect(node.Required).To(Equal([]string{"email", "name"}))
+		Expect(node.Properties["email"].Format).To(Equal("email"))
+		Expect(node.Properties["parent"].AnyOf[0].Ref).To(Equal("#/components/schemas/Node"))
+		Expect(node.Properties["children"].Items.Ref).To(Equal("#/components/schemas/Node"))
+		Expect(node.Properties["labels"].AdditionalProperties.Type).To(Equal("string"))
+		Expect(node.Properties["count"].Type).To(Equal([]string{"integer", "null"}))
+		Expect(node.Properties["at"].Format).To(Equal("date-time"))
+
+		page := schemas.Of(Page[Node]{})
+
+		Expect(page.Ref).To(BeEmpty())
+		Expect(page.Properties["items"].Items.Ref).To(Equal("#/components/schemas/Node"))
+		Expect(schemas.Components()).To(HaveLen(1))
+	})
+
+	It("should build operations of routes", func() {
+
+		doc := openapi.Build(openapi.Info{Title: "test", Version: "1"}, nil, []*openapi.Route{
+			{Method: http.MethodGet, Path: "/user/:userId/items", Replies: []*openapi.Reply{{Status: http.StatusOK, Body: Node{}}}},
+			{Method: http.MethodPost, Path: "/user/:userId/items", Body: Node{}, Security: []string{"bearer"}},
+			{Method: http.MethodPost, Path: "/query", Security: []string{"bearer"}, Optional: true},
+		})
+
+		Expect(doc.Operations()).To(Equal([]string{"GET /user/:userId/items", "POST /query", "POST /user/:userId/items"}))
+
+		get := doc.Paths["/user/{userId}/items"]["get"]
+
+		Expect(get.OperationId).To(Equal("getUserByUserIdItems"))
+		Expect(get.Security).To(BeEmpty())
+		Expect(get.Parameters[0].Name).To(Equal("userId"))
+		Expect(get.Responses["200"].Description).To(Equal("OK"))
+
+		post := doc.Paths["/user/{userId}/items"]["post"]
+
+		Expect(post.Security).To(Equal([]map[string][]string{{"bearer": {}}}))
+		Expect(post.RequestBody.Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/Node"))
+		Expect(doc.Components.Schemas).To(HaveKey("Node"))
+
+		Expect(doc.Paths["/query"]["post"].Security).To(Equal([]map[string][]string{{"bearer": {}}, {}}))
+	})
+})
@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JSON Schema of value as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	// Name of type, or list of names when null is allowed.
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schemas of Go types read by reflection.
+// Named structs become components referred to by name. Anonymous and generic ones are inlined.
+type Schemas struct {
+	components map[string]*Schema
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{components: map[string]*Schema{}}
+}
+
+// Schemas of named structs referred to so far, keyed by name.
+func (s *Schemas) Components() map[string]*Schema {
+	return s.components
+}
+
+// Schema of type of v as encoded by encoding/json.
+func (s *Schemas) Of(v any) *Schema {
+	return s.of(reflect.TypeOf(v))
+}
+
+func (s *Schemas) of(t reflect.Type) *Schema {
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(s.of(t.Elem()))
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		return s.structOf(t)
+	default:
+		// Interfaces may hold anything.
+		return &Schema{}
+	}
+}
+
+func (s *Schemas) structOf(t reflect.Type) *Schema {
+
+	// Instances of generic types are named after their type arguments, so they are inlined.
+	if t.Name() == "" || strings.Contains(t.Name(), "[") {
+		return s.objectOf(t)
+	}
+
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+
+	if _, ok := s.components[t.Name()]; ok {
+		return ref
+	}
+
+	// Registered before fields are read, so recursive types refer to themselves.
+	s.components[t.Name()] = &Schema{}
+
+	*s.components[t.Name()] = *s.objectOf(t)
+
+	return ref
+}
+
+// Object with properties of exported fields. Fields of embedded structs are promoted.
+func (s *Schemas) objectOf(t reflect.Type) *Schema {
+
+	object := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+
+			embedded := s.objectOf(field.Type)
+
+			for key, property := range embedded.Properties {
+				object.Properties[key] = property
+			}
+
+			object.Required = append(object.Required, embedded.Required...)
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.of(field.Type)
+
+		// Validator rules tell format and presence of fields.
+		validate := strings.Split(field.Tag.Get("validate"), ",")
+
+		if slices.Contains(validate, "email") {
+			property.Format = "email"
+		}
+
+		if slices.Contains(validate, "required") {
+			object.Required = append(object.Required, name)
+		}
+
+		object.Properties[name] = property
+	}
+
+	slices.Sort(object.Required)
+
+	return object
+}
+
+// Allow null besides schema.
+func nullable(schema *Schema) *Schema {
+
+	if schema.Ref != "" {
+		return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+	}
+
+	if name, ok := schema.Type.(string); ok {
+		schema.Type = []string{name, "null"}
+	}
+
+	return schema
+}
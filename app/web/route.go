@@ -2,31 +2,167 @@ package web
 
 import (
 	"github.com/kkatou7209/godo/app"
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/graph"
 	"github.com/kkatou7209/godo/web/handler"
+	"github.com/kkatou7209/godo/web/middleware"
 	"github.com/labstack/echo/v4"
 )
 
-func MapRoutes(e *echo.Echo, app *app.Application) {
+// Readiness is failed by /readyz once server starts draining.
+func MapRoutes(e *echo.Echo, app *app.Application, readiness *handler.Readiness) {
+
+	session := middleware.RequireSession(app)
+
+	admin := middleware.RequireAdmin(app)
+
+	scopes := func(scopes ...string) echo.MiddlewareFunc {
+		return middleware.RequireScopes(app, scopes...)
+	}
+
+	e.GET("/openapi.json", handler.GetOpenApiDocument(Spec()))
+
+	e.GET("/docs", handler.GetApiDocs())
+
+	e.GET("/healthz", handler.GetHealth())
+
+	e.GET("/readyz", handler.GetReadiness(app, readiness))
 
 	e.POST("/auth/signup", handler.SignUp(app))
 
 	e.POST("/auth/login", handler.Login(app));
 
-	e.GET("/user/:userId", handler.GetUserById(app))
+	e.POST("/auth/login/verify", handler.VerifyLogin(app))
+
+	e.POST("/auth/password/forgot", handler.ForgotPassword(app))
+
+	e.POST("/auth/password/reset", handler.ResetPassword(app))
+
+	e.POST("/auth/email/confirm", handler.ConfirmEmailChange(app))
+
+	e.POST("/auth/email/revert", handler.RevertEmailChange(app))
+
+	e.GET("/user/:userId", handler.GetUserById(app), scopes(entity.ScopeProfileRead))
+
+	e.PUT("/user/:userId", handler.UpdateUser(app), scopes(entity.ScopeProfileWrite))
+
+	e.PATCH("/user/:userId/password", handler.ChangeUserPassword(app), session)
+
+	e.DELETE("/user/:userId", handler.RequestAccountDeletion(app), session)
+
+	e.DELETE("/user/:userId/deletion", handler.CancelAccountDeletion(app), session)
+
+	e.GET("/user/:userId/export", handler.ExportUserData(app), scopes(entity.ScopeProfileRead, entity.ScopeTodosRead))
 
-	e.PUT("/user/:userId", handler.UpdateUser(app))
+	e.POST("/user/:userId/two-factor", handler.EnrollTwoFactor(app), session)
 
-	e.PATCH("/user/:userId/password", handler.ChangeUserPassword(app))
+	e.POST("/user/:userId/two-factor/confirm", handler.ConfirmTwoFactor(app), session)
+
+	e.DELETE("/user/:userId/two-factor", handler.DisableTwoFactor(app), session)
+
+	e.GET("/user/:userId/calendar-feed", handler.GetCalendarFeed(app), session)
+
+	e.POST("/user/:userId/calendar-feed", handler.RotateCalendarFeed(app), session)
+
+	e.DELETE("/user/:userId/calendar-feed", handler.RevokeCalendarFeed(app), session)
+
+	e.GET("/calendar/:token", handler.ServeCalendarFeed(app))
+
+	e.GET("/user/:userId/tokens", handler.ListAccessTokens(app), session)
+
+	e.POST("/user/:userId/tokens", handler.CreateAccessToken(app), session)
+
+	e.DELETE("/user/:userId/tokens/:tokenId", handler.RevokeAccessToken(app), session)
 	
-	e.GET("/user/:userId/todo-items", handler.ListTodoItems(app))
+	e.GET("/user/:userId/todo-items", handler.ListTodoItems(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/todo-items/batch", handler.BatchTodoItems(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/todo-items/export", handler.ExportTodoItems(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/todo-items/import", handler.ImportTodoItems(app), scopes(entity.ScopeTodosWrite))
+
+	e.POST("/user/:userId/todo-items/import/ical", handler.ImportCalendar(app), scopes(entity.ScopeTodosWrite))
+
+	e.POST("/user/:userId/undo/:token", handler.UndoTodoMutation(app), scopes(entity.ScopeTodosWrite))
+
+	e.POST("/user/:userId/sync", handler.SyncTodoItems(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/events", handler.StreamTodoEvents(app), scopes(entity.ScopeTodosRead))
+
+	e.GET("/user/:userId/events/ws", handler.StreamTodoEventsWebSocket(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/todo-item", handler.AddTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.PUT("/user/:userId/todo-item/:todoItemId", handler.UpdateTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.PATCH("/user/:userId/todo-item/:todoItemId/complete", handler.CompleteTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.PATCH("/user/:userId/todo-item/:todoItemId/uncomplete", handler.UncompleteTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.DELETE("/user/:userId/todo-item/:todoItemId", handler.DeleteTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.PUT("/user/:userId/todo-item/:todoItemId/assignee", handler.AssignTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.DELETE("/user/:userId/todo-item/:todoItemId/assignee", handler.UnassignTodoItem(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/todo-item/:todoItemId/assignments", handler.ListTodoAssignments(app), scopes(entity.ScopeTodosRead))
+
+	e.GET("/user/:userId/assigned", handler.ListAssignedTodoItems(app), scopes(entity.ScopeTodosRead))
+
+	e.GET("/user/:userId/search", handler.SearchTodoItems(app), scopes(entity.ScopeTodosRead))
+
+	e.GET("/user/:userId/todo-item/:todoItemId/comments", handler.ListTodoComments(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/todo-item/:todoItemId/comments", handler.AddTodoComment(app), scopes(entity.ScopeTodosWrite))
+
+	e.PUT("/user/:userId/todo-item/:todoItemId/comments/:commentId", handler.EditTodoComment(app), scopes(entity.ScopeTodosWrite))
+
+	e.DELETE("/user/:userId/todo-item/:todoItemId/comments/:commentId", handler.DeleteTodoComment(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/todo-item/:todoItemId/attachments", handler.ListTodoAttachments(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/todo-item/:todoItemId/attachments", handler.UploadTodoAttachment(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/todo-item/:todoItemId/attachments/:attachmentId", handler.DownloadTodoAttachment(app), scopes(entity.ScopeTodosRead))
+
+	e.DELETE("/user/:userId/todo-item/:todoItemId/attachments/:attachmentId", handler.DeleteTodoAttachment(app), scopes(entity.ScopeTodosWrite))
+
+	e.GET("/user/:userId/projects", handler.ListProjects(app), scopes(entity.ScopeTodosRead))
+
+	e.POST("/user/:userId/projects", handler.CreateProject(app), scopes(entity.ScopeTodosWrite))
+
+	e.POST("/user/:userId/projects/invites/accept", handler.AcceptProjectInvite(app), session)
+
+	e.GET("/user/:userId/projects/:projectId", handler.GetProject(app), scopes(entity.ScopeTodosRead))
+
+	e.PATCH("/user/:userId/projects/:projectId", handler.RenameProject(app), scopes(entity.ScopeTodosWrite))
+
+	e.DELETE("/user/:userId/projects/:projectId", handler.DeleteProject(app), session)
+
+	e.GET("/user/:userId/projects/:projectId/todo-items", handler.ListProjectTodoItems(app), scopes(entity.ScopeTodosRead))
+
+	e.GET("/user/:userId/projects/:projectId/members", handler.ListProjectMembers(app), scopes(entity.ScopeTodosRead))
+
+	e.PATCH("/user/:userId/projects/:projectId/members/:memberId", handler.ChangeProjectMember(app), session)
+
+	e.DELETE("/user/:userId/projects/:projectId/members/:memberId", handler.RemoveProjectMember(app), session)
+
+	e.POST("/user/:userId/projects/:projectId/invites", handler.InviteProjectMember(app), session)
+
+	e.GET("/admin/users", handler.ListUsers(app), admin)
+
+	e.GET("/admin/users/:id", handler.InspectUser(app), admin)
+
+	e.POST("/admin/users/:id/disable", handler.DisableUser(app), admin)
 
-	e.POST("/user/:userId/todo-item", handler.AddTodoItem(app))
+	e.POST("/admin/users/:id/enable", handler.EnableUser(app), admin)
 
-	e.PUT("/user/:userId/todo-item/:todoItemId", handler.UpdateTodoItem(app))
+	e.POST("/admin/users/:id/password-reset", handler.ForcePasswordReset(app), admin)
 
-	e.PATCH("/user/:userId/todo-item/:todoItemId/complete", handler.CompleteTodoItem(app))
+	e.PATCH("/admin/users/:id/role", handler.ChangeUserRole(app), admin)
 
-	e.PATCH("/user/:userId/todo-item/:todoItemId/uncomplete", handler.UncompleteTodoItem(app))
+	e.GET("/admin/audit-logs", handler.ListAuditLogs(app), admin)
 
-	e.DELETE("/user/:userId/todo-item/:todoItemId", handler.DeleteTodoItem(app))
-}
\ No newline at end of file
+	e.POST("/graphql", handler.ExecGraphQL(graph.NewServer(app)), middleware.AllowAnonymous(app))
+}
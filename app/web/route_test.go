@@ -1,24 +1,46 @@
 package web_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/kkatou7209/godo/app"
 	"github.com/kkatou7209/godo/app/domain/value"
+	"github.com/kkatou7209/godo/app/validation"
+	"github.com/kkatou7209/godo/client"
+	"github.com/kkatou7209/godo/event"
+	"github.com/kkatou7209/godo/ical"
+	"github.com/kkatou7209/godo/mailer"
 	"github.com/kkatou7209/godo/password"
 	"github.com/kkatou7209/godo/persistence/mock"
+	"github.com/kkatou7209/godo/todofile"
+	"github.com/kkatou7209/godo/storage"
+	"github.com/kkatou7209/godo/token"
+	"github.com/kkatou7209/godo/totp"
 	"github.com/kkatou7209/godo/web"
 	"github.com/kkatou7209/godo/web/data"
 	"github.com/kkatou7209/godo/web/handler"
+	"github.com/kkatou7209/godo/web/openapi"
 	"github.com/labstack/echo/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/net/websocket"
 )
 
 func TestServer(t *testing.T) {
@@ -30,10 +52,15 @@ func TestServer(t *testing.T) {
 
 var (
 	ts         *httptest.Server
+	api        *client.Client
 	userId 	   string
 	todoItemId string
 	todoRepository *mock.MockTodoItemRepository
 	userRepository *mock.MockUserRepository
+	memoryMailer *mailer.MemoryMailer
+	blobStorage *storage.MemoryBlobStorage
+	healthRepository *mock.MockHealthRepository
+	readiness *handler.Readiness
 )
 
 var _ = BeforeSuite(func() {
@@ -44,393 +71,2581 @@ var _ = BeforeSuite(func() {
 
 	userRepository = mock.NewMockUserRepository()
 
+	sessionRepository := mock.NewMockSessionRepository()
+
+	passwordResetRepository := mock.NewMockPasswordResetRepository()
+
+	twoFactorRepository := mock.NewMockTwoFactorRepository()
+
+	loginChallengeRepository := mock.NewMockLoginChallengeRepository()
+
+	emailChangeRepository := mock.NewMockEmailChangeRepository()
+
+	accountDeletionRepository := mock.NewMockAccountDeletionRepository()
+
+	accessTokenRepository := mock.NewMockAccessTokenRepository()
+
+	auditLogRepository := mock.NewMockAuditLogRepository()
+
+	projectRepository := mock.NewMockProjectRepository()
+
+	todoAssignmentRepository := mock.NewMockTodoAssignmentRepository()
+
+	todoCommentRepository := mock.NewMockTodoCommentRepository()
+
+	todoAttachmentRepository := mock.NewMockTodoAttachmentRepository()
+
+	todoSearchRepository := mock.NewMockTodoSearchRepository(todoRepository, todoCommentRepository)
+
+	todoUndoRepository := mock.NewMockTodoUndoRepository()
+
+	todoSyncRepository := mock.NewMockTodoSyncRepository(todoRepository)
+
+	calendarFeedRepository := mock.NewMockCalendarFeedRepository()
+
+	memoryMailer = mailer.NewMemoryMailer()
+
+	blobStorage = storage.NewMemoryBlobStorage()
+
 	app.
 		SetCreateTodoPersistence(todoRepository).
 		SetListTodoPersistence(todoRepository).
+		SetListTodoPagePersistence(todoRepository).
 		SetGetTodoPersistence(todoRepository).
 		SetUpdateTodoPersistence(todoRepository).
 		SetDeleteTodoPersistence(todoRepository).
+		SetGetTodoBatchPersistence(todoRepository).
+		SetWriteTodoBatchPersistence(todoRepository).
+		SetCreateTodoBatchPersistence(todoRepository).
 		SetCreateUserPersistence(userRepository).
 		SetGetUserPersistence(userRepository).
 		SetUpdateUserPersistence(userRepository).
-		SetPasswordHasher(password.NewBycryptPasswordHasher())
+		SetDeleteUserPersistence(userRepository).
+		SetListUserPersistence(userRepository).
+		SetCreateSessionPersistence(sessionRepository).
+		SetGetSessionPersistence(sessionRepository).
+		SetDeleteSessionPersistence(sessionRepository).
+		SetCreatePasswordResetPersistence(passwordResetRepository).
+		SetGetPasswordResetPersistence(passwordResetRepository).
+		SetUpdatePasswordResetPersistence(passwordResetRepository).
+		SetCreateTwoFactorPersistence(twoFactorRepository).
+		SetGetTwoFactorPersistence(twoFactorRepository).
+		SetUpdateTwoFactorPersistence(twoFactorRepository).
+		SetDeleteTwoFactorPersistence(twoFactorRepository).
+		SetCreateLoginChallengePersistence(loginChallengeRepository).
+		SetGetLoginChallengePersistence(loginChallengeRepository).
+		SetUpdateLoginChallengePersistence(loginChallengeRepository).
+		SetDeleteLoginChallengePersistence(loginChallengeRepository).
+		SetCreateEmailChangePersistence(emailChangeRepository).
+		SetGetEmailChangePersistence(emailChangeRepository).
+		SetUpdateEmailChangePersistence(emailChangeRepository).
+		SetCreateAccountDeletionPersistence(accountDeletionRepository).
+		SetGetAccountDeletionPersistence(accountDeletionRepository).
+		SetDeleteAccountDeletionPersistence(accountDeletionRepository).
+		SetCreateAccessTokenPersistence(accessTokenRepository).
+		SetGetAccessTokenPersistence(accessTokenRepository).
+		SetListAccessTokenPersistence(accessTokenRepository).
+		SetUpdateAccessTokenPersistence(accessTokenRepository).
+		SetDeleteAccessTokenPersistence(accessTokenRepository).
+		SetCreateAuditLogPersistence(auditLogRepository).
+		SetListAuditLogPersistence(auditLogRepository).
+		SetCreateProjectPersistence(projectRepository).
+		SetGetProjectPersistence(projectRepository).
+		SetListProjectPersistence(projectRepository).
+		SetUpdateProjectPersistence(projectRepository).
+		SetDeleteProjectPersistence(projectRepository).
+		SetGetProjectMemberPersistence(projectRepository).
+		SetSaveProjectMemberPersistence(projectRepository).
+		SetDeleteProjectMemberPersistence(projectRepository).
+		SetCreateProjectInvitePersistence(projectRepository).
+		SetGetProjectInvitePersistence(projectRepository).
+		SetUpdateProjectInvitePersistence(projectRepository).
+		SetCreateTodoAssignmentPersistence(todoAssignmentRepository).
+		SetListTodoAssignmentPersistence(todoAssignmentRepository).
+		SetCreateTodoCommentPersistence(todoCommentRepository).
+		SetGetTodoCommentPersistence(todoCommentRepository).
+		SetListTodoCommentPersistence(todoCommentRepository).
+		SetUpdateTodoCommentPersistence(todoCommentRepository).
+		SetDeleteTodoCommentPersistence(todoCommentRepository).
+		SetCreateTodoAttachmentPersistence(todoAttachmentRepository).
+		SetGetTodoAttachmentPersistence(todoAttachmentRepository).
+		SetListTodoAttachmentPersistence(todoAttachmentRepository).
+		SetDeleteTodoAttachmentPersistence(todoAttachmentRepository).
+		SetSearchTodoPersistence(todoSearchRepository).
+		SetCreateTodoUndoPersistence(todoUndoRepository).
+		SetGetTodoUndoPersistence(todoUndoRepository).
+		SetUpdateTodoUndoPersistence(todoUndoRepository).
+		SetListTodoChangePersistence(todoSyncRepository).
+		SetGetTodoRevisionPersistence(todoSyncRepository).
+		SetGetTodoSyncOperationPersistence(todoSyncRepository).
+		SetWriteTodoSyncPersistence(todoSyncRepository).
+		SetSaveCalendarFeedPersistence(calendarFeedRepository).
+		SetGetCalendarFeedPersistence(calendarFeedRepository).
+		SetDeleteCalendarFeedPersistence(calendarFeedRepository).
+		SetListTodoBatchPersistence(todoRepository).
+		SetListTodoCommentBatchPersistence(todoCommentRepository).
+		SetGetUserBatchPersistence(userRepository).
+		SetListProjectMemberBatchPersistence(projectRepository).
+		SetPasswordHasher(password.NewBycryptPasswordHasher()).
+		SetTokenGenerator(token.NewRandomTokenGenerator()).
+		SetMailer(memoryMailer).
+		SetTotpProvider(totp.NewRfc6238Provider("GoDo")).
+		SetBlobStorage(blobStorage).
+		SetTodoEventBus(event.NewMemoryTodoEventBus())
+
+	healthRepository = mock.NewMockHealthRepository()
+
+	app.SetCheckHealthPersistence(healthRepository)
+
+	readiness = &handler.Readiness{}
 
 	e := echo.New()
 	e.HideBanner = true
 
-	web.MapRoutes(e, app)
+	web.MapRoutes(e, app, readiness)
 
 	ts = httptest.NewServer(e)
+
+	api = client.New(ts.URL)
 })
 
 var _ = AfterSuite(func() {
 	ts.Close()
 })
 
-var _ = Describe("API integration test", Ordered, func() {
+var _ = Describe("API health test", Ordered, func() {
 
-	It("should create user", func() {
+	probe := func(path string) (int, data.Payload[any]) {
 
-		u := map[string]any{
-			"username": "http-api-test-user",
-			"email": "http-api@example.com",
-			"password": "http-api-test-pass",
-		}
+		res, err := http.Get(ts.URL + path)
 
-		ju, err := json.Marshal(u)
+		Expect(err).To(BeNil())
 
-		if err != nil {
-			log.Fatalln(err)
-		}
+		defer res.Body.Close()
 
-		res, err := http.Post(ts.URL + "/auth/signup", "application/json", bytes.NewBuffer(ju))
+		var payload data.Payload[any]
 
-		Expect(err).To(BeNil())
-		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		Expect(json.NewDecoder(res.Body).Decode(&payload)).To(Succeed())
 
-		defer res.Body.Close()
+		return res.StatusCode, payload
+	}
+
+	It("should be alive and ready", func() {
+
+		status, _ := probe("/healthz")
+
+		Expect(status).To(Equal(http.StatusOK))
+
+		status, payload := probe("/readyz")
+
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(payload.Message).To(Equal("ready"))
 	})
 
-	Context("after user created", func() {
+	It("should not be ready while database is unhealthy", func() {
 
-		It("should login", func() {
+		healthRepository.SetError(errors.New("schema is not migrated: missing users"))
 
-			cred := map[string]any{
-				"email": "http-api@example.com",
-				"password": "http-api-test-pass",
-			}
+		defer healthRepository.SetError(nil)
 
-			jcred, err := json.Marshal(cred)
+		status, payload := probe("/readyz")
 
-			if err != nil {
-				log.Fatalln(err)
-			}
+		Expect(status).To(Equal(http.StatusServiceUnavailable))
+		Expect(payload.Message).To(Equal("not ready"))
+		Expect(payload.Errors).To(BeEmpty())
 
-			res, err := http.Post(ts.URL + "/auth/login", "application/json", bytes.NewBuffer(jcred))
+		status, _ = probe("/healthz")
 
-			Expect(err).To(BeNil())
+		Expect(status).To(Equal(http.StatusOK))
+	})
 
-			defer res.Body.Close()
-			
-			Expect(res.StatusCode).To(Equal(http.StatusOK))
+	It("should not be ready once draining", func() {
 
-			var tokenCookie *http.Cookie
+		readiness.Drain()
 
-			for _, c := range res.Cookies() {
-				if c.Name == "x-api-token" {
-					tokenCookie = c
-				}
-			}
+		status, payload := probe("/readyz")
 
-			Expect(tokenCookie).ToNot(BeNil())
+		Expect(status).To(Equal(http.StatusServiceUnavailable))
+		Expect(payload.Message).To(Equal("draining"))
 
-			body, _ := io.ReadAll(res.Body)
+		status, _ = probe("/healthz")
 
-			var payload data.Payload[handler.UserData]
+		Expect(status).To(Equal(http.StatusOK))
+	})
+})
 
-			_ = json.Unmarshal(body, &payload)
+var _ = Describe("API integration test", Ordered, func() {
 
-			Expect(payload).ToNot(BeNil())
-			Expect(payload.Status).To(Equal(data.StatusSuccess))
-		})
+	ctx := context.Background()
+
+	It("should create user", func() {
+
+		err := api.SignUp(ctx, "http-api-test-user", "http-api@example.com", "http-api-test-pass")
+
+		Expect(err).To(BeNil())
 	})
 
-	Context("after login", func() {
+	Context("after user created", func() {
+
+		It("should refuse existing email", func() {
 
-		BeforeAll(func() {
-			u, _ := userRepository.GetByEmail(value.NewEmail("http-api@example.com"))
-			userId = u.Id().Value()
+			err := api.SignUp(ctx, "http-api-test-user-2", "http-api@example.com", "http-api-test-pass")
+
+			Expect(errors.Is(err, validation.ErrEmailAlreadyExists)).To(BeTrue())
 		})
 
-		It("should get user by ID", func() {
+		It("should login", func() {
 
-			res, err := http.Get(ts.URL + "/user/" + userId)
+			result, err := api.Login(ctx, "http-api@example.com", "http-api-test-pass")
 
 			Expect(err).To(BeNil())
-			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(result.Token).ToNot(BeEmpty())
+			Expect(result.User.Email).To(Equal("http-api@example.com"))
+			Expect(api.Token()).To(Equal(result.Token))
 
-			defer res.Body.Close()
+			userId = result.User.Id
+		})
+	})
 
-			body, _ := io.ReadAll(res.Body)
+	Context("after login", func() {
 
-			var payload data.Payload[handler.UserData]
+		It("should get user by ID", func() {
 
-			_ = json.Unmarshal(body, &payload)
+			user, err := api.GetUser(ctx, userId)
 
-			Expect(payload).ToNot(BeNil())
-			Expect(payload.Status).To(Equal(data.StatusSuccess))
-			Expect(payload.Data.Email).To(Equal("http-api@example.com"))
-			Expect(payload.Data.Username).To(Equal("http-api-test-user"))
-			Expect(payload.Data.Id).To(Equal(userId))
+			Expect(err).To(BeNil())
+			Expect(user.Email).To(Equal("http-api@example.com"))
+			Expect(user.Username).To(Equal("http-api-test-user"))
+			Expect(user.Id).To(Equal(userId))
 		})
 
 		It("should update user", func() {
 
-			u := map[string]any{
-				"id": userId,
-				"email": "http-api-test-updated@example.com",
-				"username": "http-api-test-user-updated",
-			}
+			err := api.UpdateUser(ctx, userId, "http-api-test-user-updated", "http-api-test-updated@example.com")
 
-			ju, err := json.Marshal(u)
+			Expect(err).To(BeNil())
+		})
 
-			if err != nil {
-				panic(err)
-			}
+		It("should confirm email change", func() {
 
-			req, _ := http.NewRequest(http.MethodPut, ts.URL + "/user/" + userId, bytes.NewReader(ju))
-			req.Header.Set("Content-Type", "application/json")
+			mail := memoryMailer.LastMailTo("http-api-test-updated@example.com")
 
-			res, err := http.DefaultClient.Do(req)
+			Expect(mail).ToNot(BeNil())
 
-			Expect(err).To(BeNil())
-			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			confirmToken := regexp.MustCompile(`Confirm token: (\S+)`).FindStringSubmatch(mail.Body)[1]
 
-			defer res.Body.Close()
+			Expect(api.ConfirmEmailChange(ctx, confirmToken)).To(Succeed())
 		})
 
 		It("should change password", func() {
 
-			p := map[string]any{
-				"oldPassword": "http-api-test-pass",
-				"newPassword": "http-api-test-pass-updated",
-			}
-
-			jp, err := json.Marshal(p)
+			err := api.ChangePassword(ctx, userId, "wrong-pass", "http-api-test-pass-updated")
 
-			if err != nil {
-				log.Fatalln(err)
-			}
-			
-			req, _ := http.NewRequest(http.MethodPatch, ts.URL + "/user/" + userId + "/password", bytes.NewReader(jp))
-			req.Header.Set("Content-Type", "application/json")
+			Expect(errors.Is(err, validation.ErrInvalidPassword)).To(BeTrue())
 
-			res, err := http.DefaultClient.Do(req)
+			err = api.ChangePassword(ctx, userId, "http-api-test-pass", "http-api-test-pass-updated")
 
 			Expect(err).To(BeNil())
-			Expect(res.StatusCode).To(Equal(http.StatusOK))
-			
-			defer res.Body.Close()
 		})
 
-		It("should add todo item", func()  {
-			
-			todo := map[string]any{
-				"title":       "todo-test-title",
-				"description": "todo-test-description",
-			}
+		It("should reset forgotten password", func() {
 
-			jtodo, err := json.Marshal(todo)
+			Expect(api.ForgotPassword(ctx, "http-api-test-updated@example.com")).To(Succeed())
 
-			if err != nil {
-				log.Fatal(err)
-			}
+			mail := memoryMailer.LastMailTo("http-api-test-updated@example.com")
+
+			Expect(mail).ToNot(BeNil())
+
+			resetToken := regexp.MustCompile(`Reset token: (\S+)`).FindStringSubmatch(mail.Body)[1]
 
-			res, err := http.Post(ts.URL + "/user/" + userId + "/todo-item", "application/json", bytes.NewBuffer(jtodo))
+			Expect(api.ResetPassword(ctx, resetToken, "http-api-test-pass-reset")).To(Succeed())
+
+			_, err := api.Login(ctx, "http-api-test-updated@example.com", "http-api-test-pass-reset")
 
 			Expect(err).To(BeNil())
-			Expect(res.StatusCode).To(Equal(http.StatusCreated))
-			
-			defer res.Body.Close()
+		})
+
+		It("should add todo item", func()  {
+
+			undoToken, err := api.AddTodo(ctx, userId, "todo-test-title", "todo-test-description", "")
+
+			Expect(err).To(BeNil())
+			Expect(undoToken).ToNot(BeEmpty())
 		})
 
 		Context("after todo item added", func() {
 
 			It("should list todo items", func() {
 
-				res, err := http.Get(ts.URL + "/user/" + userId + "/todo-items")
+				todos, err := api.ListTodos(ctx, userId)
 
 				Expect(err).To(BeNil())
-				Expect(res.StatusCode).To(Equal(http.StatusOK))
+				Expect(todos).To(HaveLen(1))
 
-				defer res.Body.Close()
-
-				var payload data.Payload[[]handler.TodoData]
+				todoItemId = todos[0].Id
+			})
 
-				body, _ := io.ReadAll(res.Body)
+			It("should update todo item", func() {
 
-				err = json.Unmarshal(body, &payload)
+				_, err := api.UpdateTodo(ctx, userId, todoItemId, "todo-test-title-updated", "todo-test-description-updated")
 
 				Expect(err).To(BeNil())
-				Expect(payload.Status).To(Equal(data.StatusSuccess))
-				Expect(payload.Data).ToNot(BeNil())
-
-				todoItemId = (*payload.Data)[0].Id
 			})
 
-			It("should update todo item", func() {
+			Context("after todo item updated", func() {
 
-				todo := map[string]any{
-					"title":       "todo-test-title-updated",
-					"description": "todo-test-description-updated",
-				}
-	
-				jtodo, err := json.Marshal(todo)
-	
-				if err != nil {
-					log.Fatal(err)
-				}
+				It("should todo item updated", func() {
 
-				req, err := http.NewRequest(http.MethodPut, ts.URL + "/user/" + userId + "/todo-item/" + todoItemId, bytes.NewBuffer(jtodo))
-				req.Header.Set("Content-Type", "application/json")
+					todos, err := api.ListTodos(ctx, userId)
 
-				if err != nil {
-					log.Fatal(err)
-				}
+					Expect(err).To(BeNil())
+					Expect(todos[0].Title).To(Equal("todo-test-title-updated"))
+					Expect(todos[0].Description).To(Equal("todo-test-description-updated"))
+				})
+			})
+
+			It("should complete todo item", func() {
 
-				res, err := http.DefaultClient.Do(req)
+				_, err := api.CompleteTodo(ctx, userId, todoItemId)
 
 				Expect(err).To(BeNil())
-				// Expect(res.StatusCode).To(Equal(http.StatusOK))
-				
-				defer res.Body.Close()
+			})
+
+			Context("after todo item completed", func() {
 
-				body, _ := io.ReadAll(res.Body)
+				It("should todo item completed", func() {
+
+					todos, err := api.ListTodos(ctx, userId)
+
+					Expect(err).To(BeNil())
+					Expect(todos[0].IsDone).To(BeTrue())
+				})
+			})
 
-				var payload data.Payload[any]
+			It("should uncomplete todo item", func() {
 
-				_ = json.Unmarshal(body, &payload)
+				_, err := api.UncompleteTodo(ctx, userId, todoItemId)
 
-				log.Print(payload.Message, payload.Errors)
+				Expect(err).To(BeNil())
 			})
 
-			Context("after todo item updated", func() {
+			Context("after todo item uncompleted", func() {
 
-				It("should todo item updated", func() {
+				It("should todo item uncompleted", func() {
 
-					res, err := http.Get(ts.URL + "/user/" + userId + "/todo-items")
+					todos, err := api.ListTodos(ctx, userId)
 
 					Expect(err).To(BeNil())
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
+					Expect(todos[0].IsDone).To(BeFalse())
+				})
+			})
+
+			It("should delete todo item", func() {
 
-					defer res.Body.Close()
+				_, err := api.DeleteTodo(ctx, userId, todoItemId)
 
-					var payload data.Payload[[]handler.TodoData]
+				Expect(err).To(BeNil())
+			})
+
+			Context("after todo item deleted", func() {
 
-					body, _ := io.ReadAll(res.Body)
+				It("should todo item deleted", func() {
 
-					err = json.Unmarshal(body, &payload)
+					todos, err := api.ListTodos(ctx, userId)
 
 					Expect(err).To(BeNil())
-					Expect(payload.Status).To(Equal(data.StatusSuccess))
-					Expect(payload.Data).ToNot(BeNil())
-					Expect((*payload.Data)[0].Title).To(Equal("todo-test-title-updated"))
-					Expect((*payload.Data)[0].Description).To(Equal("todo-test-description-updated"))
+					Expect(todos).To(BeEmpty())
+				})
+
+				It("should not find todo item", func() {
+
+					_, err := api.CompleteTodo(ctx, userId, todoItemId)
+
+					Expect(errors.Is(err, validation.ErrTodoNotDound)).To(BeTrue())
 				})
 			})
+		})
+	})
+})
+var _ = Describe("API authentication test", Ordered, func() {
 
-			It("should complete todo item", func() {
+	var sessionClient *http.Client
+	var tokenUserId string
+	var accessToken string
+	var accessTokenId string
 
-				req, _ := http.NewRequest(http.MethodPatch, ts.URL + "/user/" + userId + "/todo-item/" + todoItemId + "/complete", nil)
-				req.Header.Set("Content-Type", "application/json")
+	// Send request with personal access token.
+	withToken := func(method string, path string, body any) *http.Response {
 
-				res, err := http.DefaultClient.Do(req)
+		var reader io.Reader
 
-				Expect(err).To(BeNil())
-				Expect(res.StatusCode).To(Equal(http.StatusOK))
+		if body != nil {
+			jbody, err := json.Marshal(body)
 
-				defer res.Body.Close()
+			if err != nil {
+				log.Fatalln(err)
+			}
 
-			})
+			reader = bytes.NewReader(jbody)
+		}
 
-			Context("after todo item completed", func() {
+		req, _ := http.NewRequest(method, ts.URL + path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer " + accessToken)
 
-				It("should todo item completed", func() {
+		res, err := http.DefaultClient.Do(req)
 
-					res, err := http.Get(ts.URL + "/user/" + userId + "/todo-items")
+		Expect(err).To(BeNil())
 
-					Expect(err).To(BeNil())
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
+		return res
+	}
 
-					defer res.Body.Close()
+	BeforeAll(func() {
 
-					var payload data.Payload[[]handler.TodoData]
+		jar, _ := cookiejar.New(nil)
 
-					body, _ := io.ReadAll(res.Body)
+		sessionClient = &http.Client{ Jar: jar }
 
-					err = json.Unmarshal(body, &payload)
+		ju, _ := json.Marshal(map[string]any{
+			"username": "token-api-test-user",
+			"email": "token-api@example.com",
+			"password": "token-api-test-pass",
+		})
 
-					Expect(err).To(BeNil())
-					Expect(payload.Status).To(Equal(data.StatusSuccess))
-					Expect(payload.Data).ToNot(BeNil())
-					Expect((*payload.Data)[0].IsDone).To(BeTrue())
-				})
-			})
+		res, err := sessionClient.Post(ts.URL + "/auth/signup", "application/json", bytes.NewBuffer(ju))
 
-			It("should uncomplete todo item", func() {
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
 
-				req, _ := http.NewRequest(http.MethodPatch, ts.URL + "/user/" + userId + "/todo-item/" + todoItemId + "/uncomplete", nil)
-				req.Header.Set("Content-Type", "application/json")
+		res.Body.Close()
 
-				res, err := http.DefaultClient.Do(req)
+		jcred, _ := json.Marshal(map[string]any{
+			"email": "token-api@example.com",
+			"password": "token-api-test-pass",
+		})
 
-				Expect(err).To(BeNil())
-				Expect(res.StatusCode).To(Equal(http.StatusOK))
+		res, err = sessionClient.Post(ts.URL + "/auth/login", "application/json", bytes.NewBuffer(jcred))
 
-				defer res.Body.Close()
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
 
-			})
+		res.Body.Close()
 
-			Context("after todo item uncompleted", func() {
+		u, _ := userRepository.GetByEmail(value.NewEmail("token-api@example.com"))
+		tokenUserId = u.Id().Value()
+	})
 
-				It("should todo item uncompleted", func() {
+	It("should reject request without credentials", func() {
 
-					res, err := http.Get(ts.URL + "/user/" + userId + "/todo-items")
+		res, err := http.Get(ts.URL + "/user/" + tokenUserId + "/todo-items")
 
-					Expect(err).To(BeNil())
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
 
-					defer res.Body.Close()
+		res.Body.Close()
+	})
 
-					var payload data.Payload[[]handler.TodoData]
+	It("should reject request on other user", func() {
 
-					body, _ := io.ReadAll(res.Body)
+		res, err := sessionClient.Get(ts.URL + "/user/00000000-0000-0000-0000-000000000000/todo-items")
 
-					err = json.Unmarshal(body, &payload)
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
 
-					Expect(err).To(BeNil())
-					Expect(payload.Status).To(Equal(data.StatusSuccess))
-					Expect(payload.Data).ToNot(BeNil())
-					Expect((*payload.Data)[0].IsDone).To(BeFalse())
-				})
-			})
+		res.Body.Close()
+	})
 
-			It("should delete todo item", func() {
+	It("should reject unknown scope", func() {
 
-				req, _ := http.NewRequest(http.MethodDelete, ts.URL + "/user/" + userId + "/todo-item/" + todoItemId, nil)
-				req.Header.Set("Content-Type", "application/json")
+		jreq, _ := json.Marshal(map[string]any{
+			"name": "ci",
+			"scopes": []string{"admin"},
+		})
 
-				res, err := http.DefaultClient.Do(req)
+		res, err := sessionClient.Post(ts.URL + "/user/" + tokenUserId + "/tokens", "application/json", bytes.NewBuffer(jreq))
 
-				Expect(err).To(BeNil())
-				Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
 
-				defer res.Body.Close()
-			})
+		res.Body.Close()
+	})
 
-			Context("after todo item deleted", func() {
+	It("should create personal access token", func() {
 
-				It("should todo item deleted", func() {
+		jreq, _ := json.Marshal(map[string]any{
+			"name": "ci",
+			"scopes": []string{"todos:read"},
+		})
 
-					res, err := http.Get(ts.URL + "/user/" + userId + "/todo-items")
+		res, err := sessionClient.Post(ts.URL + "/user/" + tokenUserId + "/tokens", "application/json", bytes.NewBuffer(jreq))
 
-					Expect(err).To(BeNil())
-					Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
 
-					defer res.Body.Close()
+		defer res.Body.Close()
 
-					var payload data.Payload[[]handler.TodoData]
+		body, _ := io.ReadAll(res.Body)
 
-					body, _ := io.ReadAll(res.Body)
+		var payload data.Payload[handler.CreatedAccessTokenData]
 
-					err = json.Unmarshal(body, &payload)
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+		Expect(payload.Data.Token).To(HavePrefix("godo_pat_"))
+		Expect(payload.Data.Scopes).To(Equal([]string{"todos:read"}))
 
-					Expect(err).To(BeNil())
-					Expect(payload.Status).To(Equal(data.StatusSuccess))
-					Expect(payload.Data).ToNot(BeNil())
-					Expect(*payload.Data).To(BeEmpty())
-				})
-			})
+		accessToken = payload.Data.Token
+		accessTokenId = payload.Data.Id
+	})
+
+	It("should allow route within scopes", func() {
+
+		res := withToken(http.MethodGet, "/user/" + tokenUserId + "/todo-items", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+	})
+
+	It("should reject route outside scopes", func() {
+
+		res := withToken(http.MethodPost, "/user/" + tokenUserId + "/todo-item", map[string]any{
+			"title": "token-todo",
+			"description": "token-todo-description",
 		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(res.Header.Get("WWW-Authenticate")).To(ContainSubstring("insufficient_scope"))
+
+		res.Body.Close()
+	})
+
+	It("should not manage tokens with token", func() {
+
+		res := withToken(http.MethodGet, "/user/" + tokenUserId + "/tokens", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should list tokens with last use", func() {
+
+		res, err := sessionClient.Get(ts.URL + "/user/" + tokenUserId + "/tokens")
+
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		var payload data.Payload[[]handler.AccessTokenData]
+
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+		Expect(*payload.Data).To(HaveLen(1))
+		Expect((*payload.Data)[0].Name).To(Equal("ci"))
+		Expect((*payload.Data)[0].LastUsedAt).ToNot(BeNil())
+	})
+
+	It("should revoke token", func() {
+
+		req, _ := http.NewRequest(http.MethodDelete, ts.URL + "/user/" + tokenUserId + "/tokens/" + accessTokenId, nil)
+
+		res, err := sessionClient.Do(req)
+
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		res = withToken(http.MethodGet, "/user/" + tokenUserId + "/todo-items", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		res.Body.Close()
+	})
+})
+
+var _ = Describe("API admin test", Ordered, func() {
+
+	var adminClient *http.Client
+	var targetClient *http.Client
+	var adminId string
+	var targetId string
+
+	BeforeAll(func() {
+
+		adminClient = signUpAndLogin("admin-api-user", "admin-api@example.com", "admin-api-pass")
+		targetClient = signUpAndLogin("admin-api-target", "admin-api-target@example.com", "admin-api-target-pass")
+
+		admin, _ := userRepository.GetByEmail(value.NewEmail("admin-api@example.com"))
+		admin.ChangeRole(value.RoleAdmin)
+		Expect(userRepository.Update(admin)).To(BeNil())
+
+		target, _ := userRepository.GetByEmail(value.NewEmail("admin-api-target@example.com"))
+
+		adminId = admin.Id().Value()
+		targetId = target.Id().Value()
+	})
+
+	It("should reject non-admin", func() {
+
+		res := send(targetClient, http.MethodGet, "/admin/users", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should search users", func() {
+
+		res := send(adminClient, http.MethodGet, "/admin/users?q=ADMIN-API-TARGET", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		var payload data.Payload[handler.PageData[handler.AdminUserData]]
+
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+		Expect(payload.Data.Total).To(Equal(1))
+		Expect(payload.Data.Items[0].Id).To(Equal(targetId))
+		Expect(payload.Data.Items[0].Role).To(Equal("user"))
+	})
+
+	It("should paginate users", func() {
+
+		res := send(adminClient, http.MethodGet, "/admin/users?q=admin-api&page=2&perPage=1", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		var payload data.Payload[handler.PageData[handler.AdminUserData]]
+
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+		Expect(payload.Data.Total).To(Equal(2))
+		Expect(payload.Data.Page).To(Equal(2))
+		Expect(payload.Data.Items).To(HaveLen(1))
+		Expect(payload.Data.Items[0].Email).To(Equal("admin-api@example.com"))
 	})
-})
\ No newline at end of file
+
+	It("should inspect user usage", func() {
+
+		res := send(adminClient, http.MethodGet, "/admin/users/" + targetId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		var payload data.Payload[handler.UserUsageData]
+
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+		Expect(payload.Data.User.Id).To(Equal(targetId))
+		Expect(payload.Data.TodoItems).To(Equal(0))
+	})
+
+	It("should not disable self", func() {
+
+		res := send(adminClient, http.MethodPost, "/admin/users/" + adminId + "/disable", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+	})
+
+	It("should disable user", func() {
+
+		res := send(adminClient, http.MethodPost, "/admin/users/" + targetId + "/disable", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		res = send(targetClient, http.MethodGet, "/user/" + targetId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		res.Body.Close()
+
+		Expect(login(targetClient, "admin-api-target@example.com", "admin-api-target-pass")).To(Equal(http.StatusForbidden))
+	})
+
+	It("should enable user", func() {
+
+		res := send(adminClient, http.MethodPost, "/admin/users/" + targetId + "/enable", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(login(targetClient, "admin-api-target@example.com", "admin-api-target-pass")).To(Equal(http.StatusOK))
+	})
+
+	It("should force password reset", func() {
+
+		res := send(adminClient, http.MethodPost, "/admin/users/" + targetId + "/password-reset", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(memoryMailer.LastMailTo("admin-api-target@example.com").Body).To(MatchRegexp(`Reset token: \S+`))
+
+		res = send(targetClient, http.MethodGet, "/user/" + targetId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		res.Body.Close()
+	})
+
+	It("should change role", func() {
+
+		res := send(adminClient, http.MethodPatch, "/admin/users/" + targetId + "/role", map[string]any{ "role": "root" })
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+
+		res = send(adminClient, http.MethodPatch, "/admin/users/" + targetId + "/role", map[string]any{ "role": "admin" })
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		target, _ := userRepository.GetById(value.NewUserId(targetId))
+
+		Expect(target.IsAdmin()).To(BeTrue())
+	})
+
+	It("should record admin actions in audit log", func() {
+
+		res := send(adminClient, http.MethodGet, "/admin/audit-logs?perPage=100", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		var payload data.Payload[handler.PageData[handler.AuditLogData]]
+
+		Expect(json.Unmarshal(body, &payload)).To(BeNil())
+
+		actions := make([]string, 0)
+
+		for _, log := range payload.Data.Items {
+			Expect(log.ActorId).To(Equal(adminId))
+			Expect(log.TargetUserId).To(Equal(targetId))
+			actions = append(actions, log.Action)
+		}
+
+		Expect(actions).To(Equal([]string{
+			"user.role_changed",
+			"user.password_reset",
+			"user.enabled",
+			"user.disabled",
+			"user.inspected",
+		}))
+	})
+})
+
+// Login with client and return status code.
+func login(c *http.Client, email string, password string) int {
+
+	jcred, _ := json.Marshal(map[string]any{
+		"email": email,
+		"password": password,
+	})
+
+	res, err := c.Post(ts.URL + "/auth/login", "application/json", bytes.NewBuffer(jcred))
+
+	Expect(err).To(BeNil())
+
+	res.Body.Close()
+
+	return res.StatusCode
+}
+
+// Sign up new user and return client logged in as the user.
+func signUpAndLogin(username string, email string, password string) *http.Client {
+
+	jar, _ := cookiejar.New(nil)
+
+	c := &http.Client{ Jar: jar }
+
+	ju, _ := json.Marshal(map[string]any{
+		"username": username,
+		"email": email,
+		"password": password,
+	})
+
+	res, err := c.Post(ts.URL + "/auth/signup", "application/json", bytes.NewBuffer(ju))
+
+	Expect(err).To(BeNil())
+	Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+	res.Body.Close()
+
+	Expect(login(c, email, password)).To(Equal(http.StatusOK))
+
+	return c
+}
+
+// Send JSON request with client.
+func send(c *http.Client, method string, path string, body any) *http.Response {
+
+	var reader io.Reader
+
+	if body != nil {
+		jbody, _ := json.Marshal(body)
+		reader = bytes.NewReader(jbody)
+	}
+
+	req, _ := http.NewRequest(method, ts.URL + path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.Do(req)
+
+	Expect(err).To(BeNil())
+
+	return res
+}
+
+// Decode JSON payload of response and close its body.
+func decode[T any](res *http.Response) data.Payload[T] {
+
+	defer res.Body.Close()
+
+	var payload data.Payload[T]
+
+	body, _ := io.ReadAll(res.Body)
+
+	Expect(json.Unmarshal(body, &payload)).To(BeNil())
+
+	return payload
+}
+
+// Invite user to project as owner and accept invite as the user.
+func shareProject(owner *http.Client, ownerId string, projectId string, c *http.Client, userId string, email string, permission string) {
+
+	res := send(owner, http.MethodPost, "/user/" + ownerId + "/projects/" + projectId + "/invites", map[string]any{
+		"email": email,
+		"permission": permission,
+	})
+
+	Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+
+	res.Body.Close()
+
+	token := regexp.MustCompile(`Invite token: (\S+)`).FindStringSubmatch(memoryMailer.LastMailTo(email).Body)[1]
+
+	res = send(c, http.MethodPost, "/user/" + userId + "/projects/invites/accept", map[string]any{"token": token})
+
+	Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+	project := decode[handler.ProjectData](res)
+
+	Expect(project.Data.Id).To(Equal(projectId))
+	Expect(project.Data.Permission).To(Equal(permission))
+}
+
+var _ = Describe("API project sharing test", Ordered, func() {
+
+	var ownerClient *http.Client
+	var editorClient *http.Client
+	var viewerClient *http.Client
+	var ownerId string
+	var editorId string
+	var viewerId string
+	var projectId string
+
+	listTodos := func(c *http.Client, userId string) []handler.TodoData {
+		return *decode[[]handler.TodoData](send(c, http.MethodGet, "/user/" + userId + "/todo-items", nil)).Data
+	}
+
+	BeforeAll(func() {
+
+		ownerClient = signUpAndLogin("project-owner", "project-owner@example.com", "project-owner-pass")
+		editorClient = signUpAndLogin("project-editor", "project-editor@example.com", "project-editor-pass")
+		viewerClient = signUpAndLogin("project-viewer", "project-viewer@example.com", "project-viewer-pass")
+
+		for email, id := range map[string]*string{
+			"project-owner@example.com": &ownerId,
+			"project-editor@example.com": &editorId,
+			"project-viewer@example.com": &viewerId,
+		} {
+			user, _ := userRepository.GetByEmail(value.NewEmail(email))
+			*id = user.Id().Value()
+		}
+	})
+
+	It("should create project", func() {
+
+		res := send(ownerClient, http.MethodPost, "/user/" + ownerId + "/projects", map[string]any{"name": "shared-project"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		project := decode[handler.ProjectData](res)
+
+		Expect(project.Data.Name).To(Equal("shared-project"))
+		Expect(project.Data.Permission).To(Equal("owner"))
+
+		projectId = project.Data.Id
+	})
+
+	It("should hide project from non-members", func() {
+
+		res := send(editorClient, http.MethodGet, "/user/" + editorId + "/projects/" + projectId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+
+		res.Body.Close()
+	})
+
+	It("should reject invite accepted by another email", func() {
+
+		res := send(ownerClient, http.MethodPost, "/user/" + ownerId + "/projects/" + projectId + "/invites", map[string]any{
+			"email": "project-someone@example.com",
+			"permission": "viewer",
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+
+		res.Body.Close()
+
+		token := regexp.MustCompile(`Invite token: (\S+)`).FindStringSubmatch(memoryMailer.LastMailTo("project-someone@example.com").Body)[1]
+
+		res = send(editorClient, http.MethodPost, "/user/" + editorId + "/projects/invites/accept", map[string]any{"token": token})
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+	})
+
+	It("should invite collaborators", func() {
+
+		shareProject(ownerClient, ownerId, projectId, editorClient, editorId, "project-editor@example.com", "editor")
+		shareProject(ownerClient, ownerId, projectId, viewerClient, viewerId, "project-viewer@example.com", "viewer")
+
+		members := decode[[]handler.ProjectMemberData](send(viewerClient, http.MethodGet, "/user/" + viewerId + "/projects/" + projectId + "/members", nil))
+
+		Expect(*members.Data).To(HaveLen(3))
+	})
+
+	It("should share todo items added by editor", func() {
+
+		res := send(editorClient, http.MethodPost, "/user/" + editorId + "/todo-item", map[string]any{
+			"title": "shared-todo",
+			"description": "shared-todo-description",
+			"projectId": projectId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		for id, c := range map[string]*http.Client{ownerId: ownerClient, viewerId: viewerClient} {
+
+			todos := listTodos(c, id)
+
+			Expect(todos).To(HaveLen(1))
+			Expect(todos[0].Title).To(Equal("shared-todo"))
+			Expect(todos[0].ProjectId).To(Equal(projectId))
+		}
+	})
+
+	It("should forbid viewer to change todo items", func() {
+
+		todoId := listTodos(viewerClient, viewerId)[0].Id
+
+		res := send(viewerClient, http.MethodPatch, "/user/" + viewerId + "/todo-item/" + todoId + "/complete", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+
+		res = send(viewerClient, http.MethodPost, "/user/" + viewerId + "/todo-item", map[string]any{
+			"title": "viewer-todo",
+			"description": "viewer-todo-description",
+			"projectId": projectId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should let owner change todo items of editor", func() {
+
+		todoId := listTodos(ownerClient, ownerId)[0].Id
+
+		res := send(ownerClient, http.MethodPatch, "/user/" + ownerId + "/todo-item/" + todoId + "/complete", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(listTodos(editorClient, editorId)[0].IsDone).To(BeTrue())
+	})
+
+	It("should forbid editor to manage members", func() {
+
+		res := send(editorClient, http.MethodPatch, "/user/" + editorId + "/projects/" + projectId + "/members/" + viewerId, map[string]any{
+			"permission": "editor",
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should keep last owner", func() {
+
+		res := send(ownerClient, http.MethodDelete, "/user/" + ownerId + "/projects/" + projectId + "/members/" + ownerId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+	})
+
+	It("should remove member", func() {
+
+		res := send(ownerClient, http.MethodDelete, "/user/" + ownerId + "/projects/" + projectId + "/members/" + viewerId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(listTodos(viewerClient, viewerId)).To(BeEmpty())
+	})
+
+	It("should delete project", func() {
+
+		res := send(editorClient, http.MethodDelete, "/user/" + editorId + "/projects/" + projectId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+
+		res = send(ownerClient, http.MethodDelete, "/user/" + ownerId + "/projects/" + projectId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(*decode[[]handler.ProjectData](send(editorClient, http.MethodGet, "/user/" + editorId + "/projects", nil)).Data).To(BeEmpty())
+	})
+})
+
+var _ = Describe("API todo assignment test", Ordered, func() {
+
+	var ownerClient *http.Client
+	var assigneeClient *http.Client
+	var outsiderClient *http.Client
+	var ownerId string
+	var assigneeId string
+	var outsiderId string
+	var todoId string
+
+	assigned := func(c *http.Client, userId string) []handler.TodoData {
+		return *decode[[]handler.TodoData](send(c, http.MethodGet, "/user/" + userId + "/assigned", nil)).Data
+	}
+
+	BeforeAll(func() {
+
+		ownerClient = signUpAndLogin("assign-owner", "assign-owner@example.com", "assign-owner-pass")
+		assigneeClient = signUpAndLogin("assign-assignee", "assign-assignee@example.com", "assign-assignee-pass")
+		outsiderClient = signUpAndLogin("assign-outsider", "assign-outsider@example.com", "assign-outsider-pass")
+
+		for email, id := range map[string]*string{
+			"assign-owner@example.com": &ownerId,
+			"assign-assignee@example.com": &assigneeId,
+			"assign-outsider@example.com": &outsiderId,
+		} {
+			user, _ := userRepository.GetByEmail(value.NewEmail(email))
+			*id = user.Id().Value()
+		}
+
+		project := decode[handler.ProjectData](send(ownerClient, http.MethodPost, "/user/" + ownerId + "/projects", map[string]any{"name": "assign-project"}))
+
+		shareProject(ownerClient, ownerId, project.Data.Id, assigneeClient, assigneeId, "assign-assignee@example.com", "viewer")
+
+		res := send(ownerClient, http.MethodPost, "/user/" + ownerId + "/todo-item", map[string]any{
+			"title": "assign-todo",
+			"description": "assign-todo-description",
+			"projectId": project.Data.Id,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		todoId = (*decode[[]handler.TodoData](send(ownerClient, http.MethodGet, "/user/" + ownerId + "/projects/" + project.Data.Id + "/todo-items", nil)).Data)[0].Id
+	})
+
+	It("should reject assignee without access", func() {
+
+		res := send(ownerClient, http.MethodPut, "/user/" + ownerId + "/todo-item/" + todoId + "/assignee", map[string]any{
+			"assigneeId": outsiderId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+
+		Expect(assigned(outsiderClient, outsiderId)).To(BeEmpty())
+	})
+
+	It("should forbid viewer to assign", func() {
+
+		res := send(assigneeClient, http.MethodPut, "/user/" + assigneeId + "/todo-item/" + todoId + "/assignee", map[string]any{
+			"assigneeId": assigneeId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should assign todo item to collaborator", func() {
+
+		res := send(ownerClient, http.MethodPut, "/user/" + ownerId + "/todo-item/" + todoId + "/assignee", map[string]any{
+			"assigneeId": assigneeId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		todos := assigned(assigneeClient, assigneeId)
+
+		Expect(todos).To(HaveLen(1))
+		Expect(todos[0].Id).To(Equal(todoId))
+		Expect(todos[0].AssigneeId).To(Equal(assigneeId))
+	})
+
+	It("should record assignment history", func() {
+
+		res := send(ownerClient, http.MethodDelete, "/user/" + ownerId + "/todo-item/" + todoId + "/assignee", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(assigned(assigneeClient, assigneeId)).To(BeEmpty())
+
+		history := *decode[[]handler.TodoAssignmentData](send(assigneeClient, http.MethodGet, "/user/" + assigneeId + "/todo-item/" + todoId + "/assignments", nil)).Data
+
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].AssigneeId).To(Equal(assigneeId))
+		Expect(history[0].AssignedBy).To(Equal(ownerId))
+		Expect(history[1].AssigneeId).To(BeEmpty())
+	})
+
+	It("should list open items only", func() {
+
+		res := send(ownerClient, http.MethodPut, "/user/" + ownerId + "/todo-item/" + todoId + "/assignee", map[string]any{
+			"assigneeId": ownerId,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(assigned(ownerClient, ownerId)).To(HaveLen(1))
+
+		res = send(ownerClient, http.MethodPatch, "/user/" + ownerId + "/todo-item/" + todoId + "/complete", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(assigned(ownerClient, ownerId)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("API todo comment test", Ordered, func() {
+
+	var ownerClient *http.Client
+	var memberClient *http.Client
+	var outsiderClient *http.Client
+	var ownerId string
+	var memberId string
+	var outsiderId string
+	var todoId string
+	var commentId string
+
+	commentsPath := func(userId string) string {
+		return "/user/" + userId + "/todo-item/" + todoId + "/comments"
+	}
+
+	BeforeAll(func() {
+
+		ownerClient = signUpAndLogin("comment-owner", "comment-owner@example.com", "comment-owner-pass")
+		memberClient = signUpAndLogin("comment-member", "comment-member@example.com", "comment-member-pass")
+		outsiderClient = signUpAndLogin("comment-outsider", "comment-outsider@example.com", "comment-outsider-pass")
+
+		for email, id := range map[string]*string{
+			"comment-owner@example.com": &ownerId,
+			"comment-member@example.com": &memberId,
+			"comment-outsider@example.com": &outsiderId,
+		} {
+			user, _ := userRepository.GetByEmail(value.NewEmail(email))
+			*id = user.Id().Value()
+		}
+
+		project := decode[handler.ProjectData](send(ownerClient, http.MethodPost, "/user/" + ownerId + "/projects", map[string]any{"name": "comment-project"}))
+
+		shareProject(ownerClient, ownerId, project.Data.Id, memberClient, memberId, "comment-member@example.com", "viewer")
+
+		res := send(ownerClient, http.MethodPost, "/user/" + ownerId + "/todo-item", map[string]any{
+			"title": "comment-todo",
+			"description": "comment-todo-description",
+			"projectId": project.Data.Id,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		todoId = (*decode[[]handler.TodoData](send(ownerClient, http.MethodGet, "/user/" + ownerId + "/projects/" + project.Data.Id + "/todo-items", nil)).Data)[0].Id
+	})
+
+	It("should add comment with mentions and sanitized body", func() {
+
+		res := send(memberClient, http.MethodPost, commentsPath(memberId), map[string]any{
+			"body": "@comment-owner **done?** <script>alert(1)</script>",
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		comment := decode[handler.TodoCommentData](res)
+
+		Expect(comment.Data.AuthorName).To(Equal("comment-member"))
+		Expect(comment.Data.Mentions).To(Equal([]string{"comment-owner"}))
+		Expect(comment.Data.BodyHtml).To(ContainSubstring("<strong>done?</strong>"))
+		Expect(comment.Data.BodyHtml).ToNot(ContainSubstring("<script>"))
+		Expect(comment.Data.EditedAt).To(BeNil())
+
+		commentId = comment.Data.Id
+	})
+
+	It("should hide comments from users without access", func() {
+
+		res := send(outsiderClient, http.MethodGet, commentsPath(outsiderId), nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+	})
+
+	It("should let author only edit comment", func() {
+
+		res := send(ownerClient, http.MethodPut, commentsPath(ownerId) + "/" + commentId, map[string]any{"body": "edited by owner"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+
+		res = send(memberClient, http.MethodPut, commentsPath(memberId) + "/" + commentId, map[string]any{"body": "edited"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		comment := decode[handler.TodoCommentData](res)
+
+		Expect(comment.Data.Body).To(Equal("edited"))
+		Expect(comment.Data.Mentions).To(BeEmpty())
+		Expect(comment.Data.EditedAt).ToNot(BeNil())
+	})
+
+	It("should let owner delete comment of others", func() {
+
+		res := send(ownerClient, http.MethodDelete, commentsPath(ownerId) + "/" + commentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(*decode[[]handler.TodoCommentData](send(memberClient, http.MethodGet, commentsPath(memberId), nil)).Data).To(BeEmpty())
+
+		res = send(memberClient, http.MethodDelete, commentsPath(memberId) + "/" + commentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+
+		res.Body.Close()
+	})
+})
+
+var _ = Describe("API todo attachment test", Ordered, func() {
+
+	// Smallest PNG header http.DetectContentType recognizes.
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+	var ownerClient *http.Client
+	var viewerClient *http.Client
+	var ownerId string
+	var viewerId string
+	var todoId string
+	var attachmentId string
+
+	attachmentsPath := func(userId string) string {
+		return "/user/" + userId + "/todo-item/" + todoId + "/attachments"
+	}
+
+	upload := func(c *http.Client, userId string, fileName string, content []byte) *http.Response {
+
+		body := new(bytes.Buffer)
+
+		w := multipart.NewWriter(body)
+
+		part, _ := w.CreateFormFile("file", fileName)
+		part.Write(content)
+
+		w.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL + attachmentsPath(userId), body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		res, err := c.Do(req)
+
+		Expect(err).To(BeNil())
+
+		return res
+	}
+
+	BeforeAll(func() {
+
+		ownerClient = signUpAndLogin("attachment-owner", "attachment-owner@example.com", "attachment-owner-pass")
+		viewerClient = signUpAndLogin("attachment-viewer", "attachment-viewer@example.com", "attachment-viewer-pass")
+
+		owner, _ := userRepository.GetByEmail(value.NewEmail("attachment-owner@example.com"))
+		viewer, _ := userRepository.GetByEmail(value.NewEmail("attachment-viewer@example.com"))
+
+		ownerId = owner.Id().Value()
+		viewerId = viewer.Id().Value()
+
+		project := decode[handler.ProjectData](send(ownerClient, http.MethodPost, "/user/" + ownerId + "/projects", map[string]any{"name": "attachment-project"}))
+
+		shareProject(ownerClient, ownerId, project.Data.Id, viewerClient, viewerId, "attachment-viewer@example.com", "viewer")
+
+		res := send(ownerClient, http.MethodPost, "/user/" + ownerId + "/todo-item", map[string]any{
+			"title": "attachment-todo",
+			"description": "attachment-todo-description",
+			"projectId": project.Data.Id,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		todoId = (*decode[[]handler.TodoData](send(ownerClient, http.MethodGet, "/user/" + ownerId + "/projects/" + project.Data.Id + "/todo-items", nil)).Data)[0].Id
+	})
+
+	It("should upload file with sniffed content type", func() {
+
+		res := upload(ownerClient, ownerId, "../screenshots/shot.png", png)
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		attachment := decode[handler.TodoAttachmentData](res)
+
+		Expect(attachment.Data.FileName).To(Equal("shot.png"))
+		Expect(attachment.Data.ContentType).To(Equal("image/png"))
+		Expect(attachment.Data.Size).To(Equal(int64(len(png))))
+
+		attachmentId = attachment.Data.Id
+	})
+
+	It("should not trust file extension", func() {
+
+		res := upload(ownerClient, ownerId, "fake.png", []byte("<html><script>alert(1)</script></html>"))
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		Expect(decode[handler.TodoAttachmentData](res).Data.ContentType).To(Equal("text/html; charset=utf-8"))
+	})
+
+	It("should reject viewer upload", func() {
+
+		res := upload(viewerClient, viewerId, "viewer.png", png)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+	})
+
+	It("should reject empty and too large file", func() {
+
+		res := upload(ownerClient, ownerId, "empty.txt", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res.Body.Close()
+
+		res = upload(ownerClient, ownerId, "large.bin", make([]byte, 10 << 20 + 1))
+
+		Expect(res.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+
+		res.Body.Close()
+	})
+
+	It("should let viewer list and download file", func() {
+
+		attachments := decode[[]handler.TodoAttachmentData](send(viewerClient, http.MethodGet, attachmentsPath(viewerId), nil))
+
+		Expect(*attachments.Data).To(HaveLen(2))
+
+		res := send(viewerClient, http.MethodGet, attachmentsPath(viewerId) + "/" + attachmentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("Content-Type")).To(Equal("image/png"))
+		Expect(res.Header.Get("Content-Disposition")).To(Equal(`attachment; filename=shot.png`))
+		Expect(res.Header.Get("X-Content-Type-Options")).To(Equal("nosniff"))
+
+		b, _ := io.ReadAll(res.Body)
+
+		res.Body.Close()
+
+		Expect(b).To(Equal(png))
+	})
+
+	It("should let uploader or owner only delete file", func() {
+
+		res := send(viewerClient, http.MethodDelete, attachmentsPath(viewerId) + "/" + attachmentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+
+		res.Body.Close()
+
+		res = send(ownerClient, http.MethodDelete, attachmentsPath(ownerId) + "/" + attachmentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		res = send(viewerClient, http.MethodGet, attachmentsPath(viewerId) + "/" + attachmentId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+
+		res.Body.Close()
+	})
+
+	It("should remove blobs with todo item", func() {
+
+		before := blobStorage.Len()
+
+		res := send(ownerClient, http.MethodDelete, "/user/" + ownerId + "/todo-item/" + todoId, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		Expect(blobStorage.Len()).To(Equal(before - 1))
+	})
+})
+
+var _ = Describe("API todo batch test", Ordered, func() {
+
+	var batchClient *http.Client
+	var sharerClient *http.Client
+	var batchUserId string
+	var sharerId string
+	var ownProjectId string
+	var viewedProjectId string
+
+	todos := func() map[string]handler.TodoData {
+
+		list := decode[[]handler.TodoData](send(batchClient, http.MethodGet, "/user/" + batchUserId + "/todo-items", nil))
+
+		byTitle := make(map[string]handler.TodoData)
+
+		for _, todo := range *list.Data {
+			byTitle[todo.Title] = todo
+		}
+
+		return byTitle
+	}
+
+	batch := func(mode string, operations ...map[string]any) (*http.Response, data.Payload[handler.BatchData]) {
+
+		res := send(batchClient, http.MethodPost, "/user/" + batchUserId + "/todo-items/batch", map[string]any{
+			"mode": mode,
+			"operations": operations,
+		})
+
+		return res, decode[handler.BatchData](res)
+	}
+
+	BeforeAll(func() {
+
+		batchClient = signUpAndLogin("batch-user", "batch-user@example.com", "batch-user-pass")
+		sharerClient = signUpAndLogin("batch-sharer", "batch-sharer@example.com", "batch-sharer-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("batch-user@example.com"))
+		sharer, _ := userRepository.GetByEmail(value.NewEmail("batch-sharer@example.com"))
+
+		batchUserId = user.Id().Value()
+		sharerId = sharer.Id().Value()
+
+		ownProjectId = decode[handler.ProjectData](send(batchClient, http.MethodPost, "/user/" + batchUserId + "/projects", map[string]any{"name": "batch-project"})).Data.Id
+
+		viewedProjectId = decode[handler.ProjectData](send(sharerClient, http.MethodPost, "/user/" + sharerId + "/projects", map[string]any{"name": "batch-viewed-project"})).Data.Id
+
+		shareProject(sharerClient, sharerId, viewedProjectId, batchClient, batchUserId, "batch-user@example.com", "viewer")
+
+		for _, title := range []string{"batch-one", "batch-two", "batch-three"} {
+
+			res := send(batchClient, http.MethodPost, "/user/" + batchUserId + "/todo-item", map[string]any{
+				"title": title,
+				"description": title + "-description",
+			})
+
+			Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+			res.Body.Close()
+		}
+	})
+
+	It("should apply succeeding operations in partial mode", func() {
+
+		before := todos()
+
+		res, payload := batch("partial",
+			map[string]any{"op": "complete", "todoId": before["batch-one"].Id},
+			map[string]any{"op": "update", "todoId": before["batch-two"].Id, "title": "batch-two-renamed"},
+			map[string]any{"op": "delete", "todoId": before["batch-three"].Id},
+			map[string]any{"op": "complete", "todoId": before["batch-three"].Id},
+			map[string]any{"op": "archive", "todoId": before["batch-one"].Id},
+		)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		results := payload.Data.Results
+
+		Expect(results).To(HaveLen(5))
+		Expect(results[0].Ok).To(BeTrue())
+		Expect(results[1].Ok).To(BeTrue())
+		Expect(results[2].Ok).To(BeTrue())
+		Expect(results[3].Ok).To(BeFalse())
+		Expect(results[3].Error).To(Equal("todo not found"))
+		Expect(results[4].Error).To(Equal("unknown batch operation"))
+
+		after := todos()
+
+		Expect(after).To(HaveLen(2))
+		Expect(after["batch-one"].IsDone).To(BeTrue())
+		Expect(after["batch-two-renamed"].Description).To(Equal("batch-two-description"))
+	})
+
+	It("should fail only operations with invalid todo item ID", func() {
+
+		before := todos()
+
+		res, payload := batch("partial",
+			map[string]any{"op": "complete", "todoId": ""},
+			map[string]any{"op": "complete", "todoId": "not-uuid"},
+			map[string]any{"op": "complete", "todoId": before["batch-one"].Id},
+		)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		results := payload.Data.Results
+
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].Error).To(Equal("todo item ID must be UUID"))
+		Expect(results[1].Error).To(Equal("todo item ID must be UUID"))
+		Expect(results[2].Ok).To(BeTrue())
+	})
+
+	It("should apply nothing when atomic batch fails", func() {
+
+		before := todos()
+
+		res, payload := batch("atomic",
+			map[string]any{"op": "uncomplete", "todoId": before["batch-one"].Id},
+			map[string]any{"op": "move", "todoId": before["batch-two-renamed"].Id, "projectId": viewedProjectId},
+		)
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		results := payload.Data.Results
+
+		Expect(payload.Data.UndoToken).To(BeEmpty())
+		Expect(results[0].Ok).To(BeTrue())
+		Expect(results[1].Error).To(Equal("permission denied"))
+
+		Expect(todos()["batch-one"].IsDone).To(BeTrue())
+	})
+
+	It("should move todo items between projects", func() {
+
+		before := todos()
+
+		res, _ := batch("atomic",
+			map[string]any{"op": "uncomplete", "todoId": before["batch-one"].Id},
+			map[string]any{"op": "move", "todoId": before["batch-two-renamed"].Id, "projectId": ownProjectId},
+		)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		after := todos()
+
+		Expect(after["batch-one"].IsDone).To(BeFalse())
+		Expect(after["batch-two-renamed"].ProjectId).To(Equal(ownProjectId))
+
+		res, _ = batch("atomic", map[string]any{"op": "move", "todoId": before["batch-two-renamed"].Id, "projectId": ""})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(todos()["batch-two-renamed"].ProjectId).To(BeEmpty())
+	})
+
+	It("should check updated fields as single update does", func() {
+
+		before := todos()
+
+		res, payload := batch("partial",
+			map[string]any{"op": "update", "todoId": before["batch-one"].Id, "description": " "},
+			map[string]any{"op": "update", "todoId": before["batch-one"].Id, "title": strings.Repeat("a", 256)},
+		)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		results := payload.Data.Results
+
+		Expect(results[0].Error).To(Equal(validation.ErrInvalidTodoDescription.Error()))
+		Expect(results[1].Error).To(Equal(validation.ErrInvalidTodoTitle.Error()))
+
+		res = send(batchClient, http.MethodPut, "/user/" + batchUserId + "/todo-item/" + before["batch-one"].Id, map[string]any{
+			"title": "batch-one",
+			"description": " ",
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(decode[any](res).Message).To(Equal(validation.ErrInvalidTodoDescription.Error()))
+
+		Expect(todos()["batch-one"].Description).To(Equal("batch-one-description"))
+	})
+
+	It("should reject empty batch and unknown mode", func() {
+
+		res, _ := batch("atomic")
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res, _ = batch("best-effort", map[string]any{"op": "complete", "todoId": todos()["batch-one"].Id})
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
+
+var _ = Describe("API todo undo test", Ordered, func() {
+
+	var undoClient *http.Client
+	var otherClient *http.Client
+	var undoUserId string
+	var otherId string
+
+	todos := func() map[string]handler.TodoData {
+
+		list := decode[[]handler.TodoData](send(undoClient, http.MethodGet, "/user/" + undoUserId + "/todo-items", nil))
+
+		byTitle := make(map[string]handler.TodoData)
+
+		for _, todo := range *list.Data {
+			byTitle[todo.Title] = todo
+		}
+
+		return byTitle
+	}
+
+	mutate := func(method string, path string, body any) string {
+
+		res := send(undoClient, method, "/user/" + undoUserId + path, body)
+
+		Expect(res.StatusCode).To(BeNumerically("<", 300))
+
+		payload := decode[handler.UndoData](res)
+
+		Expect(payload.Data).ToNot(BeNil())
+		Expect(payload.Data.UndoToken).ToNot(BeEmpty())
+
+		return payload.Data.UndoToken
+	}
+
+	undo := func(c *http.Client, userId string, token string) int {
+
+		res := send(c, http.MethodPost, "/user/" + userId + "/undo/" + token, nil)
+
+		res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	BeforeAll(func() {
+
+		undoClient = signUpAndLogin("undo-user", "undo-user@example.com", "undo-user-pass")
+		otherClient = signUpAndLogin("undo-other", "undo-other@example.com", "undo-other-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("undo-user@example.com"))
+		other, _ := userRepository.GetByEmail(value.NewEmail("undo-other@example.com"))
+
+		undoUserId = user.Id().Value()
+		otherId = other.Id().Value()
+	})
+
+	It("should undo adding todo item", func() {
+
+		token := mutate(http.MethodPost, "/todo-item", map[string]any{"title": "undo-added", "description": "undo-added-description"})
+
+		Expect(todos()).To(HaveKey("undo-added"))
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusOK))
+
+		Expect(todos()).ToNot(HaveKey("undo-added"))
+	})
+
+	It("should undo completing todo item once", func() {
+
+		mutate(http.MethodPost, "/todo-item", map[string]any{"title": "undo-completed", "description": "undo-completed-description"})
+
+		todoId := todos()["undo-completed"].Id
+
+		token := mutate(http.MethodPatch, "/todo-item/" + todoId + "/complete", nil)
+
+		Expect(todos()["undo-completed"].IsDone).To(BeTrue())
+
+		Expect(undo(otherClient, otherId, token)).To(Equal(http.StatusBadRequest))
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusOK))
+
+		Expect(todos()["undo-completed"].IsDone).To(BeFalse())
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should restore deleted todo item with its ID", func() {
+
+		mutate(http.MethodPost, "/todo-item", map[string]any{"title": "undo-deleted", "description": "undo-deleted-description"})
+
+		todoId := todos()["undo-deleted"].Id
+
+		token := mutate(http.MethodDelete, "/todo-item/" + todoId, nil)
+
+		Expect(todos()).ToNot(HaveKey("undo-deleted"))
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusOK))
+
+		Expect(todos()["undo-deleted"].Id).To(Equal(todoId))
+	})
+
+	It("should refuse to undo when todo item changed since", func() {
+
+		mutate(http.MethodPost, "/todo-item", map[string]any{"title": "undo-updated", "description": "undo-updated-description"})
+
+		todoId := todos()["undo-updated"].Id
+
+		token := mutate(http.MethodPut, "/todo-item/" + todoId, map[string]any{"title": "undo-updated-once", "description": "undo-updated-description"})
+
+		mutate(http.MethodPut, "/todo-item/" + todoId, map[string]any{"title": "undo-updated-twice", "description": "undo-updated-description"})
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusConflict))
+
+		Expect(todos()).To(HaveKey("undo-updated-twice"))
+	})
+
+	It("should undo whole batch", func() {
+
+		for _, title := range []string{"undo-batch-one", "undo-batch-two"} {
+			mutate(http.MethodPost, "/todo-item", map[string]any{"title": title, "description": title + "-description"})
+		}
+
+		before := todos()
+
+		res := send(undoClient, http.MethodPost, "/user/" + undoUserId + "/todo-items/batch", map[string]any{
+			"operations": []map[string]any{
+				{"op": "complete", "todoId": before["undo-batch-one"].Id},
+				{"op": "delete", "todoId": before["undo-batch-two"].Id},
+			},
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		token := decode[handler.BatchData](res).Data.UndoToken
+
+		Expect(undo(undoClient, undoUserId, token)).To(Equal(http.StatusOK))
+
+		after := todos()
+
+		Expect(after["undo-batch-one"].IsDone).To(BeFalse())
+		Expect(after["undo-batch-two"].Id).To(Equal(before["undo-batch-two"].Id))
+	})
+
+	It("should reject unknown token", func() {
+		Expect(undo(undoClient, undoUserId, "unknown-token")).To(Equal(http.StatusBadRequest))
+	})
+})
+
+var _ = Describe("API todo events test", Ordered, func() {
+
+	var eventClient *http.Client
+	var memberClient *http.Client
+	var eventUserId string
+	var memberId string
+
+	// Open event stream and pass its frames, each as field map, until body is closed.
+	stream := func(c *http.Client, userId string, lastEventId string) (<-chan map[string]string, func()) {
+
+		req, _ := http.NewRequest(http.MethodGet, ts.URL + "/user/" + userId + "/events", nil)
+
+		if lastEventId != "" {
+			req.Header.Set("Last-Event-ID", lastEventId)
+		}
+
+		res, err := c.Do(req)
+
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		frames := make(chan map[string]string, 16)
+
+		go func() {
+
+			defer close(frames)
+
+			reader := bufio.NewReader(res.Body)
+
+			frame := make(map[string]string)
+
+			for {
+				line, err := reader.ReadString('\n')
+
+				if err != nil {
+					return
+				}
+
+				line = strings.TrimSuffix(line, "\n")
+
+				if line == "" {
+					if len(frame) > 0 {
+						frames <- frame
+						frame = make(map[string]string)
+					}
+					continue
+				}
+
+				// Heartbeat comment.
+				if strings.HasPrefix(line, ":") {
+					continue
+				}
+
+				field, value, _ := strings.Cut(line, ": ")
+
+				frame[field] = value
+			}
+		}()
+
+		return frames, func() { res.Body.Close() }
+	}
+
+	next := func(frames <-chan map[string]string) (map[string]string, handler.TodoEventData) {
+
+		var frame map[string]string
+
+		Eventually(frames).Should(Receive(&frame))
+
+		var e handler.TodoEventData
+
+		Expect(json.Unmarshal([]byte(frame["data"]), &e)).To(Succeed())
+		Expect(e.Id).To(Equal(frame["id"]))
+		Expect(e.Type).To(Equal(frame["event"]))
+
+		return frame, e
+	}
+
+	BeforeAll(func() {
+
+		eventClient = signUpAndLogin("event-user", "event-user@example.com", "event-user-pass")
+		memberClient = signUpAndLogin("event-member", "event-member@example.com", "event-member-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("event-user@example.com"))
+		member, _ := userRepository.GetByEmail(value.NewEmail("event-member@example.com"))
+
+		eventUserId = user.Id().Value()
+		memberId = member.Id().Value()
+	})
+
+	It("should stream todo changes and resume after last event", func() {
+
+		frames, closeStream := stream(eventClient, eventUserId, "")
+
+		res := send(eventClient, http.MethodPost, "/user/" + eventUserId + "/todo-item", map[string]any{"title": "event-todo", "description": "event-todo-description"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		_, created := next(frames)
+
+		Expect(created.Type).To(Equal("todo.created"))
+		Expect(created.Todo.Title).To(Equal("event-todo"))
+
+		res = send(eventClient, http.MethodPatch, "/user/" + eventUserId + "/todo-item/" + created.Todo.Id + "/complete", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		_, completed := next(frames)
+
+		Expect(completed.Type).To(Equal("todo.completed"))
+		Expect(completed.Todo.IsDone).To(BeTrue())
+
+		closeStream()
+
+		// Changed while disconnected.
+		res = send(eventClient, http.MethodDelete, "/user/" + eventUserId + "/todo-item/" + created.Todo.Id, nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		frames, closeStream = stream(eventClient, eventUserId, completed.Id)
+
+		defer closeStream()
+
+		_, deleted := next(frames)
+
+		Expect(deleted.Type).To(Equal("todo.deleted"))
+		Expect(deleted.Todo.Id).To(Equal(created.Todo.Id))
+	})
+
+	It("should push changes of shared project to members", func() {
+
+		project := decode[handler.ProjectData](send(eventClient, http.MethodPost, "/user/" + eventUserId + "/projects", map[string]any{"name": "event-project"}))
+
+		shareProject(eventClient, eventUserId, project.Data.Id, memberClient, memberId, "event-member@example.com", "viewer")
+
+		frames, closeStream := stream(memberClient, memberId, "")
+
+		defer closeStream()
+
+		res := send(eventClient, http.MethodPost, "/user/" + eventUserId + "/todo-item", map[string]any{
+			"title": "event-shared",
+			"description": "event-shared-description",
+			"projectId": project.Data.Id,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		_, created := next(frames)
+
+		Expect(created.Type).To(Equal("todo.created"))
+		Expect(created.Todo.ProjectId).To(Equal(project.Data.Id))
+	})
+
+	It("should stream todo changes over WebSocket", func() {
+
+		u, _ := url.Parse(ts.URL + "/user/" + eventUserId + "/events/ws")
+
+		config, err := websocket.NewConfig("ws://" + u.Host + u.Path, ts.URL)
+
+		Expect(err).To(BeNil())
+
+		for _, cookie := range eventClient.Jar.Cookies(u) {
+			config.Header.Add("Cookie", cookie.String())
+		}
+
+		ws, err := websocket.DialConfig(config)
+
+		Expect(err).To(BeNil())
+
+		defer ws.Close()
+
+		res := send(eventClient, http.MethodPost, "/user/" + eventUserId + "/todo-item", map[string]any{"title": "event-ws", "description": "event-ws-description"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		var e handler.TodoEventData
+
+		Expect(ws.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+		Expect(websocket.JSON.Receive(ws, &e)).To(Succeed())
+
+		Expect(e.Type).To(Equal("todo.created"))
+		Expect(e.Todo.Title).To(Equal("event-ws"))
+	})
+
+	It("should refuse WebSocket from other origin", func() {
+
+		u, _ := url.Parse(ts.URL + "/user/" + eventUserId + "/events/ws")
+
+		config, _ := websocket.NewConfig("ws://" + u.Host + u.Path, "http://evil.example.com")
+
+		for _, cookie := range eventClient.Jar.Cookies(u) {
+			config.Header.Add("Cookie", cookie.String())
+		}
+
+		_, err := websocket.DialConfig(config)
+
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should refuse invalid last event ID", func() {
+
+		req, _ := http.NewRequest(http.MethodGet, ts.URL + "/user/" + eventUserId + "/events", nil)
+		req.Header.Set("Last-Event-ID", "not-a-number")
+
+		res, err := eventClient.Do(req)
+
+		Expect(err).To(BeNil())
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should refuse events of other user", func() {
+
+		req, _ := http.NewRequest(http.MethodGet, ts.URL + "/user/" + memberId + "/events", nil)
+
+		res, err := eventClient.Do(req)
+
+		Expect(err).To(BeNil())
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+	})
+})
+
+var _ = Describe("API todo sync test", Ordered, func() {
+
+	var syncClient *http.Client
+	var syncUserId string
+
+	sync := func(since string, operations ...map[string]any) handler.SyncData {
+
+		if operations == nil {
+			operations = make([]map[string]any, 0)
+		}
+
+		res := send(syncClient, http.MethodPost, "/user/" + syncUserId + "/sync", map[string]any{
+			"since": since,
+			"operations": operations,
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		return *decode[handler.SyncData](res).Data
+	}
+
+	changeOf := func(data handler.SyncData, todoId string) *handler.SyncChangeData {
+
+		var found *handler.SyncChangeData
+
+		for i, change := range data.Changes {
+			if change.TodoId == todoId {
+				found = &data.Changes[i]
+			}
+		}
+
+		return found
+	}
+
+	BeforeAll(func() {
+
+		syncClient = signUpAndLogin("sync-user", "sync-user@example.com", "sync-user-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("sync-user@example.com"))
+
+		syncUserId = user.Id().Value()
+	})
+
+	It("should apply client changes once and return server changes since token", func() {
+
+		res := send(syncClient, http.MethodPost, "/user/" + syncUserId + "/todo-item", map[string]any{"title": "sync-server", "description": "sync-server-description"})
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		res.Body.Close()
+
+		initial := sync("")
+
+		Expect(initial.Changes).To(HaveLen(1))
+		Expect(initial.Changes[0].Todo.Title).To(Equal("sync-server"))
+		Expect(initial.HasMore).To(BeFalse())
+
+		todoId := uuid.NewString()
+
+		create := map[string]any{
+			"id": "op-create-" + todoId,
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "sync-client",
+			"description": "sync-client-description",
+			"changedAt": time.Now().Add(-time.Minute),
+		}
+
+		first := sync(initial.Token, create)
+
+		Expect(first.Results).To(HaveLen(1))
+		Expect(first.Results[0].Status).To(Equal("applied"))
+		Expect(changeOf(first, todoId).Todo.Title).To(Equal("sync-client"))
+
+		// Retried after lost response.
+		retried := sync(initial.Token, create)
+
+		Expect(retried.Results[0].Status).To(Equal("applied"))
+		Expect(retried.Changes).To(HaveLen(1))
+		Expect(retried.Token).To(Equal(first.Token))
+
+		Expect(sync(first.Token).Changes).To(BeEmpty())
+	})
+
+	It("should merge conflicting changes field by field", func() {
+
+		todoId := uuid.NewString()
+
+		created := sync("", map[string]any{
+			"id": "op-merge-create",
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "merge-title",
+			"description": "merge-description",
+			"changedAt": time.Now().Add(-time.Hour),
+		})
+
+		// Changed on server after client went offline.
+		res := send(syncClient, http.MethodPut, "/user/" + syncUserId + "/todo-item/" + todoId, map[string]any{
+			"title": "merge-title-server",
+			"description": "merge-description",
+		})
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res.Body.Close()
+
+		merged := sync(created.Token, map[string]any{
+			"id": "op-merge-offline",
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "merge-title-client",
+			"description": "merge-description-client",
+			"changedAt": time.Now().Add(-30 * time.Minute),
+		})
+
+		Expect(merged.Results[0].Status).To(Equal("applied"))
+
+		todo := changeOf(merged, todoId).Todo
+
+		Expect(todo.Title).To(Equal("merge-title-server"))
+		Expect(todo.Description).To(Equal("merge-description-client"))
+
+		// Clocks ahead of server count as now.
+		later := sync(merged.Token, map[string]any{
+			"id": "op-merge-later",
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "merge-title-later",
+			"isDone": true,
+			"changedAt": time.Now().Add(time.Hour),
+		})
+
+		todo = changeOf(later, todoId).Todo
+
+		Expect(todo.Title).To(Equal("merge-title-later"))
+		Expect(todo.IsDone).To(BeTrue())
+	})
+
+	It("should keep deleted todo items deleted", func() {
+
+		todoId := uuid.NewString()
+
+		created := sync("", map[string]any{
+			"id": "op-delete-create",
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "delete-title",
+			"description": "delete-description",
+		})
+
+		deleted := sync(created.Token, map[string]any{
+			"id": "op-delete",
+			"op": "delete",
+			"todoId": todoId,
+		})
+
+		Expect(deleted.Results[0].Status).To(Equal("applied"))
+		Expect(changeOf(deleted, todoId).Deleted).To(BeTrue())
+		Expect(changeOf(deleted, todoId).Todo).To(BeNil())
+
+		edited := sync(deleted.Token, map[string]any{
+			"id": "op-delete-edit",
+			"op": "upsert",
+			"todoId": todoId,
+			"title": "delete-title-edited",
+			"changedAt": time.Now(),
+		})
+
+		Expect(edited.Results[0].Status).To(Equal("rejected"))
+		Expect(edited.Results[0].Message).To(Equal(validation.ErrTodoDeleted.Error()))
+
+		// Client syncing from start never hears of it.
+		Expect(changeOf(sync(""), todoId)).To(BeNil())
+	})
+
+	It("should reject invalid operations only", func() {
+
+		data := sync("",
+			map[string]any{"id": "op-invalid-id", "op": "upsert", "todoId": "not-a-uuid", "title": "x", "description": "x"},
+			map[string]any{"id": "op-invalid-op", "op": "rename", "todoId": uuid.NewString()},
+			map[string]any{"id": "op-invalid-input", "op": "upsert", "todoId": uuid.NewString(), "title": "only-title"},
+		)
+
+		Expect(data.Results).To(HaveLen(3))
+
+		for _, result := range data.Results {
+			Expect(result.Status).To(Equal("rejected"))
+		}
+	})
+
+	It("should refuse invalid sync token", func() {
+
+		res := send(syncClient, http.MethodPost, "/user/" + syncUserId + "/sync", map[string]any{"since": "not-a-token"})
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
+
+var _ = Describe("API calendar test", Ordered, func() {
+
+	var calendarClient *http.Client
+	var calendarUserId string
+
+	BeforeAll(func() {
+
+		calendarClient = signUpAndLogin("calendar-user", "calendar-user@example.com", "calendar-user-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("calendar-user@example.com"))
+
+		calendarUserId = user.Id().Value()
+	})
+
+	It("should import VTODO and VEVENT components as todo items", func() {
+
+		source := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Other//Other//EN",
+			"BEGIN:VTODO",
+			"UID:todo-1@other",
+			"SUMMARY:Pay rent\\, today",
+			"DESCRIPTION:Bank transfer",
+			"END:VTODO",
+			"BEGIN:VEVENT",
+			"UID:event-1@other",
+			"SUMMARY:Dentist",
+			"DTSTART:20261020T090000Z",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:todo-2@other",
+			"SUMMARY:Cancelled",
+			"STATUS:CANCELLED",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL + "/user/" + calendarUserId + "/todo-items/import/ical", strings.NewReader(source))
+		req.Header.Set("Content-Type", "text/calendar")
+
+		res, err := calendarClient.Do(req)
+
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		imported := decode[handler.ImportedTodosData](res).Data
+
+		Expect(imported.TodoIds).To(HaveLen(2))
+		Expect(imported.Skipped).To(Equal(1))
+		Expect(imported.Undo).ToNot(BeNil())
+
+		todos := decode[[]handler.TodoData](send(calendarClient, http.MethodGet, "/user/" + calendarUserId + "/todo-items", nil)).Data
+
+		Expect(*todos).To(ContainElement(HaveField("Title", "Pay rent, today")))
+		Expect(*todos).To(ContainElement(And(HaveField("Title", "Dentist"), HaveField("Description", "Dentist"))))
+	})
+
+	It("should refuse malformed calendar", func() {
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL + "/user/" + calendarUserId + "/todo-items/import/ical", strings.NewReader("not a calendar"))
+
+		res, err := calendarClient.Do(req)
+
+		Expect(err).To(BeNil())
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should serve todo items by secret feed URL", func() {
+
+		res := send(calendarClient, http.MethodPost, "/user/" + calendarUserId + "/calendar-feed", nil)
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		feed := decode[handler.CalendarFeedData](res).Data
+
+		Expect(feed.Url).To(HaveSuffix("/calendar/" + feed.Token + ".ics"))
+
+		// Calendar apps fetch feed without login.
+		res, err := http.Get(feed.Url)
+
+		Expect(err).To(BeNil())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("Content-Type")).To(HavePrefix("text/calendar"))
+
+		cal, err := ical.Parse(res.Body)
+
+		res.Body.Close()
+
+		Expect(err).To(BeNil())
+		Expect(cal.Items).To(ContainElement(And(
+			HaveField("Kind", ical.KindTodo),
+			HaveField("Summary", "Pay rent, today"),
+			HaveField("Status", ical.StatusNeedsAction),
+		)))
+
+		// Rotating feed stops old URL.
+		rotated := decode[handler.CalendarFeedData](send(calendarClient, http.MethodPost, "/user/" + calendarUserId + "/calendar-feed", nil)).Data
+
+		res, err = http.Get(feed.Url)
+
+		Expect(err).To(BeNil())
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+
+		res = send(calendarClient, http.MethodDelete, "/user/" + calendarUserId + "/calendar-feed", nil)
+
+		res.Body.Close()
+
+		res, err = http.Get(rotated.Url)
+
+		Expect(err).To(BeNil())
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
+
+var _ = Describe("API todo export and import test", Ordered, func() {
+
+	var fileClient *http.Client
+	var fileUserId string
+
+	importFile := func(query string, body string) *http.Response {
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL + "/user/" + fileUserId + "/todo-items/import?" + query, strings.NewReader(body))
+
+		res, err := fileClient.Do(req)
+
+		Expect(err).To(BeNil())
+
+		return res
+	}
+
+	listTodos := func() []handler.TodoData {
+		return *decode[[]handler.TodoData](send(fileClient, http.MethodGet, "/user/" + fileUserId + "/todo-items", nil)).Data
+	}
+
+	BeforeAll(func() {
+
+		fileClient = signUpAndLogin("file-user", "file-user@example.com", "file-user-pass")
+
+		user, _ := userRepository.GetByEmail(value.NewEmail("file-user@example.com"))
+
+		fileUserId = user.Id().Value()
+	})
+
+	It("should check import on dry run", func() {
+
+		res := importFile("format=md&dryRun=true", "- [ ] file-first\n  file-first-description\n- [x] file-second\n  file-second-description\n")
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		result := decode[handler.ImportedTodosData](res).Data
+
+		Expect(result.Count).To(Equal(2))
+		Expect(result.TodoIds).To(BeEmpty())
+		Expect(listTodos()).To(BeEmpty())
+	})
+
+	It("should import Markdown checklist", func() {
+
+		res := importFile("format=md", "- [ ] file-first\n  file-first-description\n- [x] file-second\n  file-second-description\n")
+
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+
+		result := decode[handler.ImportedTodosData](res).Data
+
+		Expect(result.TodoIds).To(HaveLen(2))
+		Expect(result.Undo).ToNot(BeNil())
+
+		todos := listTodos()
+
+		Expect(todos).To(HaveLen(2))
+		Expect(todos).To(ContainElement(And(HaveField("Title", "file-second"), HaveField("IsDone", true))))
+	})
+
+	It("should report every invalid row and import nothing", func() {
+
+		res := importFile("format=csv", "title,description,isDone\nfile-ok,file-ok-description,false\n,file-no-title,false\nfile-bad-flag,file-bad-flag-description,maybe\n")
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		result := decode[handler.ImportedTodosData](res).Data
+
+		Expect(result.Errors).To(Equal([]handler.ImportErrorData{
+			{Row: 3, Message: validation.ErrInvalidTodoTitle.Error()},
+			{Row: 4, Message: `"maybe" is not done flag`},
+		}))
+		Expect(listTodos()).To(HaveLen(2))
+	})
+
+	It("should export todo items in every format", func() {
+
+		for _, format := range todofile.Formats {
+
+			res := send(fileClient, http.MethodGet, "/user/" + fileUserId + "/todo-items/export?format=" + format, nil)
+
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(res.Header.Get("Content-Disposition")).To(HaveSuffix("." + format + `"`))
+
+			rows, err := todofile.Read(format, res.Body)
+
+			res.Body.Close()
+
+			Expect(err).To(BeNil())
+			Expect(rows).To(HaveLen(2))
+
+			for _, row := range rows {
+				Expect(row.Err).To(BeNil())
+				Expect(row.Todo.IsDone).To(Equal(row.Todo.Title == "file-second"))
+				Expect(row.Todo.Description).To(Equal(row.Todo.Title + "-description"))
+			}
+		}
+
+		res := send(fileClient, http.MethodGet, "/user/" + fileUserId + "/todo-items/export?format=xlsx", nil)
+
+		res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
+
+var _ = Describe("API spec test", Ordered, func() {
+
+	var doc openapi.Document
+
+	BeforeAll(func() {
+
+		res, err := http.Get(ts.URL + "/openapi.json")
+
+		Expect(err).To(BeNil())
+
+		defer res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(json.NewDecoder(res.Body).Decode(&doc)).To(Succeed())
+	})
+
+	It("should describe every route and nothing else", func() {
+
+		e := echo.New()
+
+		web.MapRoutes(e, app.New(), &handler.Readiness{})
+
+		routes := make([]string, 0)
+
+		for _, route := range e.Routes() {
+			routes = append(routes, route.Method + " " + route.Path)
+		}
+
+		sort.Strings(routes)
+
+		Expect(doc.OpenApi).To(Equal("3.1.0"))
+		Expect(doc.Operations()).To(Equal(routes))
+	})
+
+	It("should describe responses of every operation", func() {
+
+		ids := map[string]bool{}
+
+		for path, item := range doc.Paths {
+			for method, op := range item {
+
+				Expect(ids).ToNot(HaveKey(op.OperationId), method + " " + path)
+				ids[op.OperationId] = true
+
+				Expect(op.Responses).To(HaveKey(MatchRegexp(`^[12]\d\d$`)), method + " " + path)
+				Expect(op.Responses).To(HaveKey("500"), method + " " + path)
+
+				for _, param := range op.Parameters {
+					if param.In == "path" {
+						Expect(path).To(ContainSubstring("{" + param.Name + "}"))
+					}
+				}
+			}
+		}
+	})
+
+	It("should refer only to described schemas", func() {
+
+		b, err := json.Marshal(doc)
+
+		Expect(err).To(BeNil())
+
+		for _, ref := range regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(string(b), -1) {
+			Expect(doc.Components.Schemas).To(HaveKey(ref[1]))
+		}
+
+		Expect(doc.Components.Schemas).To(HaveKey("TodoData"))
+		Expect(doc.Components.Schemas["SignUpRequest"].Required).To(Equal([]string{"email", "password", "username"}))
+
+		todos := doc.Paths["/user/{userId}/todo-items"]["get"].Responses["200"].Content["application/json"].Schema
+
+		Expect(todos.Properties["data"].Items.Ref).To(Equal("#/components/schemas/TodoData"))
+		Expect(todos.Properties).To(HaveKey("status"))
+		Expect(todos.Properties).To(HaveKey("errors"))
+	})
+
+	It("should serve docs page", func() {
+
+		res, err := http.Get(ts.URL + "/docs")
+
+		Expect(err).To(BeNil())
+
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get(echo.HeaderContentType)).To(HavePrefix("text/html"))
+		Expect(string(body)).To(ContainSubstring("openapi.json"))
+	})
+})
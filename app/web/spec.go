@@ -0,0 +1,536 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/kkatou7209/godo/app/domain/entity"
+	"github.com/kkatou7209/godo/graph"
+	"github.com/kkatou7209/godo/web/data"
+	"github.com/kkatou7209/godo/web/handler"
+	"github.com/kkatou7209/godo/web/middleware"
+	"github.com/kkatou7209/godo/web/openapi"
+)
+
+// Security schemes of API.
+const (
+	securityCookie = "sessionCookie"
+	securityBearer = "bearer"
+)
+
+var securitySchemes = map[string]*openapi.SecurityScheme{
+	securityCookie: {
+		Type: "apiKey",
+		In: "cookie",
+		Name: middleware.SessionCookieName,
+		Description: "Session set by login.",
+	},
+	securityBearer: {
+		Type: "http",
+		Scheme: "bearer",
+		Description: "Session token, or personal access token where route lists scopes.",
+	},
+}
+
+// Routes listing no scopes refuse personal access tokens.
+var authenticated = []string{securityCookie, securityBearer}
+
+// Response with data of type T in payload envelope.
+func success[T any](status int, description string) *openapi.Reply {
+	return &openapi.Reply{Status: status, Description: description, Body: data.Payload[T]{}}
+}
+
+// Response with payload envelope of no data.
+func failure(status int, description string) *openapi.Reply {
+	return &openapi.Reply{Status: status, Description: description, Body: data.Payload[any]{}}
+}
+
+func query(name string, schemaType string, description string) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, Description: description, Schema: &openapi.Schema{Type: schemaType}}
+}
+
+var (
+	notFound = failure(http.StatusNotFound, "Resource does not exist or is not visible to user.")
+	forbidden = failure(http.StatusForbidden, "Not authorized, or user lacks permission on resource.")
+	tooLarge = failure(http.StatusRequestEntityTooLarge, "Request body is too large.")
+)
+
+var formatQuery = query("format", "string", "csv, json or md.")
+
+var importQueries = []*openapi.Parameter{
+	query("dryRun", "boolean", "Only check rows, importing nothing."),
+	query("projectId", "string", "Add todo items to project."),
+}
+
+var pageQueries = []*openapi.Parameter{
+	query("page", "integer", "Page number starting from 1."),
+	query("perPage", "integer", "Items per page."),
+}
+
+// Routes mapped by MapRoutes. Test of routes fails when they differ.
+var apiRoutes = []*openapi.Route{
+	{
+		Method: http.MethodGet, Path: "/openapi.json", Summary: "Get this document", Tag: "docs",
+		Replies: []*openapi.Reply{{Status: http.StatusOK, Description: "OpenAPI document."}},
+	},
+	{
+		Method: http.MethodGet, Path: "/docs", Summary: "Browse this document", Tag: "docs",
+		Replies: []*openapi.Reply{{Status: http.StatusOK, ContentType: "text/html"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/healthz", Summary: "Check server is alive", Tag: "health",
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Server is alive.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/readyz", Summary: "Check server is ready to take traffic", Tag: "health",
+		Replies: []*openapi.Reply{
+			success[any](http.StatusOK, "Database is reachable and schema migrated."),
+			failure(http.StatusServiceUnavailable, "Server is draining, database is unreachable or schema is not migrated."),
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/signup", Summary: "Sign up", Tag: "auth",
+		Body: handler.SignUpRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusCreated, "User signed up.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/login", Summary: "Log in", Tag: "auth",
+		Body: handler.LoginRequest{},
+		Replies: []*openapi.Reply{
+			success[handler.UserData](http.StatusOK, "Logged in. Session cookie is set."),
+			success[handler.LoginChallengeData](http.StatusAccepted, "Two-factor code required. Verify login with challenge token."),
+			failure(http.StatusForbidden, "User is disabled."),
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/login/verify", Summary: "Verify login with two-factor code", Tag: "auth",
+		Body: handler.VerifyLoginRequest{},
+		Replies: []*openapi.Reply{success[handler.UserData](http.StatusOK, "Logged in. Session cookie is set.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/password/forgot", Summary: "Mail password reset link", Tag: "auth",
+		Body: handler.ForgotPasswordRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Link is mailed if user exists.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/password/reset", Summary: "Reset password", Tag: "auth",
+		Body: handler.ResetPasswordRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Password reset.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/email/confirm", Summary: "Confirm email change", Tag: "auth",
+		Body: handler.TokenRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Email changed.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/email/revert", Summary: "Revert email change", Tag: "auth",
+		Body: handler.TokenRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Email reverted.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId", Summary: "Get user", Tag: "users",
+		Security: authenticated, Scopes: []string{entity.ScopeProfileRead},
+		Replies: []*openapi.Reply{success[handler.UserData](http.StatusOK, "User, or null data if not found.")},
+	},
+	{
+		Method: http.MethodPut, Path: "/user/:userId", Summary: "Update user", Tag: "users",
+		Security: authenticated, Scopes: []string{entity.ScopeProfileWrite},
+		Body: handler.UpdateUserRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "User updated. Changed email is confirmed by mail.")},
+	},
+	{
+		Method: http.MethodPatch, Path: "/user/:userId/password", Summary: "Change password", Tag: "users",
+		Security: authenticated,
+		Body: handler.ChangePasswordRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Password changed.")},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId", Summary: "Request account deletion", Tag: "users",
+		Security: authenticated,
+		Body: handler.PasswordRequest{},
+		Replies: []*openapi.Reply{success[handler.AccountDeletionData](http.StatusAccepted, "Account is deleted after grace period.")},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/deletion", Summary: "Cancel account deletion", Tag: "users",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Deletion cancelled.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/export", Summary: "Export user data", Tag: "users",
+		Security: authenticated, Scopes: []string{entity.ScopeProfileRead, entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[handler.UserExportData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/two-factor", Summary: "Enroll in two-factor authentication", Tag: "two-factor",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[handler.TwoFactorEnrollmentData](http.StatusOK, "Secret to add to authenticator app.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/two-factor/confirm", Summary: "Confirm two-factor enrollment", Tag: "two-factor",
+		Security: authenticated,
+		Body: handler.TwoFactorCodeRequest{},
+		Replies: []*openapi.Reply{success[handler.RecoveryCodesData](http.StatusOK, "Two-factor enabled. Recovery codes are shown only once.")},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/two-factor", Summary: "Disable two-factor authentication", Tag: "two-factor",
+		Security: authenticated,
+		Body: handler.PasswordRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Two-factor disabled.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/calendar-feed", Summary: "Get calendar feed", Tag: "calendar",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[handler.CalendarFeedData](http.StatusOK, ""), notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/calendar-feed", Summary: "Create calendar feed, replacing previous URL", Tag: "calendar",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[handler.CalendarFeedData](http.StatusCreated, "Feed with URL to subscribe to.")},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/calendar-feed", Summary: "Revoke calendar feed", Tag: "calendar",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Feed revoked.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/calendar/:token", Summary: "Subscribe to todo items as iCalendar feed", Tag: "calendar",
+		Replies: []*openapi.Reply{{Status: http.StatusOK, ContentType: "text/calendar"}, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/tokens", Summary: "List personal access tokens", Tag: "tokens",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[[]*handler.AccessTokenData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/tokens", Summary: "Create personal access token", Tag: "tokens",
+		Security: authenticated,
+		Body: handler.CreateAccessTokenRequest{},
+		Replies: []*openapi.Reply{success[handler.CreatedAccessTokenData](http.StatusCreated, "Token is shown only once.")},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/tokens/:tokenId", Summary: "Revoke personal access token", Tag: "tokens",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Token revoked."), notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-items", Summary: "List todo items", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]handler.TodoData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-items/batch", Summary: "Apply operations to todo items at once", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.BatchRequest{},
+		Replies: []*openapi.Reply{
+			success[handler.BatchData](http.StatusOK, "Result of each operation."),
+			success[handler.BatchData](http.StatusBadRequest, "Atomic batch failed and nothing was applied."),
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-items/export", Summary: "Export todo items as file", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Query: []*openapi.Parameter{formatQuery},
+		Replies: []*openapi.Reply{
+			{Status: http.StatusOK, Description: "File of format.", ContentType: "text/csv"},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-items/import", Summary: "Import todo items from file", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Query: append([]*openapi.Parameter{formatQuery}, importQueries...),
+		BodyType: "application/octet-stream",
+		Replies: []*openapi.Reply{
+			success[handler.ImportedTodosData](http.StatusCreated, "Todo items imported."),
+			success[handler.ImportedTodosData](http.StatusOK, "Rows checked on dry run."),
+			success[handler.ImportedTodosData](http.StatusBadRequest, "File or rows are invalid and nothing was imported."),
+			tooLarge,
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-items/import/ical", Summary: "Import todo items from iCalendar file", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Query: importQueries,
+		BodyType: "text/calendar",
+		Replies: []*openapi.Reply{
+			success[handler.ImportedTodosData](http.StatusCreated, "Todo items imported."),
+			success[handler.ImportedTodosData](http.StatusOK, "Items checked on dry run."),
+			success[handler.ImportedTodosData](http.StatusBadRequest, "File or items are invalid and nothing was imported."),
+			tooLarge,
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/undo/:token", Summary: "Undo todo mutation", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{
+			success[any](http.StatusOK, "Mutation undone."),
+			notFound,
+			failure(http.StatusConflict, "Todo items changed since mutation."),
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/sync", Summary: "Sync todo items changed offline", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.SyncRequest{},
+		Replies: []*openapi.Reply{success[handler.SyncData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/events", Summary: "Stream todo changes as server-sent events", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Query: []*openapi.Parameter{query("lastEventId", "string", "Resume after event, if Last-Event-ID header cannot be set.")},
+		Headers: []*openapi.Parameter{query("Last-Event-ID", "string", "Resume after event.")},
+		Replies: []*openapi.Reply{{Status: http.StatusOK, Description: "Stream of TodoEventData.", ContentType: "text/event-stream"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/events/ws", Summary: "Stream todo changes over WebSocket", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Query: []*openapi.Parameter{query("lastEventId", "string", "Resume after event.")},
+		Replies: []*openapi.Reply{{Status: http.StatusSwitchingProtocols, Description: "WebSocket of TodoEventData messages."}},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-item", Summary: "Add todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.AddTodoRequest{},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusCreated, "Todo item added."), forbidden},
+	},
+	{
+		Method: http.MethodPut, Path: "/user/:userId/todo-item/:todoItemId", Summary: "Update todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.UpdateTodoRequest{},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item updated."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/user/:userId/todo-item/:todoItemId/complete", Summary: "Complete todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item completed."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/user/:userId/todo-item/:todoItemId/uncomplete", Summary: "Uncomplete todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item uncompleted."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/todo-item/:todoItemId", Summary: "Delete todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item deleted."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPut, Path: "/user/:userId/todo-item/:todoItemId/assignee", Summary: "Assign todo item to project member", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.AssignTodoRequest{},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item assigned."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/todo-item/:todoItemId/assignee", Summary: "Unassign todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[handler.UndoData](http.StatusOK, "Todo item unassigned."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-item/:todoItemId/assignments", Summary: "List assignment history of todo item", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]handler.TodoAssignmentData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/assigned", Summary: "List todo items assigned to user", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]handler.TodoData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/search", Summary: "Search todo items", Tag: "todos",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Query: []*openapi.Parameter{
+			query("q", "string", "Search terms."),
+			query("limit", "integer", "Maximum number of results."),
+		},
+		Replies: []*openapi.Reply{success[[]handler.TodoSearchResultData](http.StatusOK, "Results, best match first.")},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-item/:todoItemId/comments", Summary: "List comments of todo item", Tag: "comments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]*handler.TodoCommentData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-item/:todoItemId/comments", Summary: "Comment on todo item", Tag: "comments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.CommentRequest{},
+		Replies: []*openapi.Reply{success[handler.TodoCommentData](http.StatusCreated, "Comment added."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPut, Path: "/user/:userId/todo-item/:todoItemId/comments/:commentId", Summary: "Edit comment", Tag: "comments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.CommentRequest{},
+		Replies: []*openapi.Reply{success[handler.TodoCommentData](http.StatusOK, "Comment edited."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/todo-item/:todoItemId/comments/:commentId", Summary: "Delete comment", Tag: "comments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Comment deleted."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-item/:todoItemId/attachments", Summary: "List attachments of todo item", Tag: "attachments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]*handler.TodoAttachmentData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/todo-item/:todoItemId/attachments", Summary: "Attach file to todo item", Tag: "attachments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		BodyType: "multipart/form-data",
+		Replies: []*openapi.Reply{success[handler.TodoAttachmentData](http.StatusCreated, "File attached."), forbidden, notFound, tooLarge},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/todo-item/:todoItemId/attachments/:attachmentId", Summary: "Download attachment", Tag: "attachments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{{Status: http.StatusOK, Description: "File content.", ContentType: "application/octet-stream"}, forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/todo-item/:todoItemId/attachments/:attachmentId", Summary: "Delete attachment", Tag: "attachments",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Attachment deleted."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/projects", Summary: "List projects", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]*handler.ProjectData](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/projects", Summary: "Create project", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.ProjectNameRequest{},
+		Replies: []*openapi.Reply{success[handler.ProjectData](http.StatusCreated, "Project created.")},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/projects/invites/accept", Summary: "Accept project invite", Tag: "projects",
+		Security: authenticated,
+		Body: handler.TokenRequest{},
+		Replies: []*openapi.Reply{success[handler.ProjectData](http.StatusOK, "Joined project."), notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/projects/:projectId", Summary: "Get project", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[handler.ProjectData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/user/:userId/projects/:projectId", Summary: "Rename project", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosWrite},
+		Body: handler.ProjectNameRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Project renamed."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/projects/:projectId", Summary: "Delete project", Tag: "projects",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Project deleted."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/projects/:projectId/todo-items", Summary: "List todo items of project", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]handler.TodoData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/user/:userId/projects/:projectId/members", Summary: "List project members", Tag: "projects",
+		Security: authenticated, Scopes: []string{entity.ScopeTodosRead},
+		Replies: []*openapi.Reply{success[[]*handler.ProjectMemberData](http.StatusOK, ""), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/user/:userId/projects/:projectId/members/:memberId", Summary: "Change permission of project member", Tag: "projects",
+		Security: authenticated,
+		Body: handler.ChangeMemberRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Permission changed."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/user/:userId/projects/:projectId/members/:memberId", Summary: "Remove project member", Tag: "projects",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Member removed."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/user/:userId/projects/:projectId/invites", Summary: "Invite user to project by mail", Tag: "projects",
+		Security: authenticated,
+		Body: handler.InviteMemberRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusAccepted, "Invite mailed."), forbidden, notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/users", Summary: "List users", Tag: "admin",
+		Security: authenticated,
+		Query: append([]*openapi.Parameter{query("q", "string", "Filter by username or email.")}, pageQueries...),
+		Replies: []*openapi.Reply{success[handler.PageData[*handler.AdminUserData]](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/users/:id", Summary: "Inspect user", Tag: "admin",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[handler.UserUsageData](http.StatusOK, ""), notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/users/:id/disable", Summary: "Disable user", Tag: "admin",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "User disabled."), notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/users/:id/enable", Summary: "Enable user", Tag: "admin",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "User enabled."), notFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/users/:id/password-reset", Summary: "Force password reset", Tag: "admin",
+		Security: authenticated,
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Password reset mailed."), notFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/admin/users/:id/role", Summary: "Change role of user", Tag: "admin",
+		Security: authenticated,
+		Body: handler.ChangeRoleRequest{},
+		Replies: []*openapi.Reply{success[any](http.StatusOK, "Role changed."), notFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/audit-logs", Summary: "List audit logs", Tag: "admin",
+		Security: authenticated,
+		Query: pageQueries,
+		Replies: []*openapi.Reply{success[handler.PageData[*handler.AuditLogData]](http.StatusOK, "")},
+	},
+	{
+		Method: http.MethodPost, Path: "/graphql", Summary: "Run GraphQL query or mutation of use cases", Tag: "graphql",
+		Security: authenticated, Optional: true,
+		Body: graph.Request{},
+		Replies: []*openapi.Reply{{Status: http.StatusOK, Description: "GraphQL response. Fields failing tell why in errors."}},
+	},
+}
+
+// Build OpenAPI document of API.
+// Errors every route may respond with are added to replies of routes.
+func Spec() *openapi.Document {
+
+	routes := make([]*openapi.Route, len(apiRoutes))
+
+	for i, apiRoute := range apiRoutes {
+
+		route := *apiRoute
+		route.Replies = slices.Clone(apiRoute.Replies)
+
+		errors := []*openapi.Reply{
+			failure(http.StatusBadRequest, "Request is invalid."),
+			failure(http.StatusInternalServerError, "Unexpected error."),
+		}
+
+		if len(route.Security) > 0 {
+			errors = append(errors,
+				failure(http.StatusUnauthorized, "Authentication required."),
+				failure(http.StatusForbidden, "Authenticated user cannot act on user of path, or lacks scope, session or admin role."),
+			)
+		}
+
+		for _, e := range errors {
+			if !slices.ContainsFunc(route.Replies, func(r *openapi.Reply) bool { return r.Status == e.Status }) {
+				route.Replies = append(route.Replies, e)
+			}
+		}
+
+		routes[i] = &route
+	}
+
+	return openapi.Build(
+		openapi.Info{
+			Title: "GoDo API",
+			Version: "1.0.0",
+			Description: "Responses of JSON are wrapped in payload envelope of status, message, data and errors.",
+		},
+		securitySchemes,
+		routes,
+	)
+}